@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// Sobreposições de IA por comando (--ai-profile, --model, --no-cache, --show-redactions)
+var (
+	aiProfileOverride string
+	aiModelOverride   string
+	aiNoCache         bool
+	aiShowRedactions  bool
+)
+
+func init() {
+	rootCmd.AddCommand(aiCmd)
+}
+
+var aiCmd = &cobra.Command{
+	Use:   "ai",
+	Short: "Configurar e gerenciar a IA",
+	Long: `Comandos para configurar provedores, perfis e recursos de IA.
+
+Exemplos:
+  snip ai config --provider groq --api-key "sua-chave"
+  snip ai profile add fast --provider groq --model llama-3.1-8b-instant --api-key "sua-chave"
+  snip ai profile use fast`,
+}
+
+// addAIFlags adiciona as flags --ai-profile, --model, --no-cache e --show-redactions aos comandos que usam IA
+func addAIFlags(cmds ...*cobra.Command) {
+	for _, c := range cmds {
+		c.Flags().StringVar(&aiProfileOverride, "ai-profile", "", "Perfil de IA a usar neste comando")
+		c.Flags().StringVar(&aiModelOverride, "model", "", "Modelo de IA a usar neste comando")
+		c.Flags().BoolVar(&aiNoCache, "no-cache", false, "Ignorar o cache de respostas da IA")
+		c.Flags().BoolVar(&aiShowRedactions, "show-redactions", false, "Mostrar os dados mascarados antes de cada envio à IA")
+	}
+}
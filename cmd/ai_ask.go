@@ -9,6 +9,7 @@ import (
 )
 
 func init() {
+	addAIFlags(aiAskCmd)
 	rootCmd.AddCommand(aiAskCmd)
 }
 
@@ -10,10 +10,13 @@ import (
 
 var aiCodeLang string
 var aiCodeContext string
+var aiCodeSave bool
 
 func init() {
 	aiCodeCmd.Flags().StringVarP(&aiCodeLang, "lang", "l", "go", "Programming language")
 	aiCodeCmd.Flags().StringVarP(&aiCodeContext, "context", "c", "", "Additional context for code generation")
+	aiCodeCmd.Flags().BoolVarP(&aiCodeSave, "save", "s", false, "Save the answer as a snippet note (see 'snip snippet')")
+	addAIFlags(aiCodeCmd)
 	rootCmd.AddCommand(aiCodeCmd)
 }
 
@@ -27,21 +30,31 @@ The AI will generate clean, well-documented code following best practices.
 Examples:
   snip ai-code "function to reverse a string"
   snip ai-code "REST API endpoint" --lang "python" --context "Use FastAPI"
-  snip ai-code "binary search algorithm" --lang "javascript"`,
+  snip ai-code "binary search algorithm" --lang "javascript"
+  snip ai-code "retry with backoff" --lang go --save   # Keep it as a snippet`,
 	Args: cobra.MinimumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
-		if err := executeWithHandler(func(h handler.Handler) error {
-			description := strings.Join(args, " ")
-			context := ""
-			if aiCodeContext != "" {
-				context = aiCodeContext
-			}
-			lang := "go"
-			if aiCodeLang != "" {
-				lang = aiCodeLang
-			}
-			return h.GenerateCodeWithAI(lang, description, context)
-		}); err != nil {
+		description := strings.Join(args, " ")
+		context := ""
+		if aiCodeContext != "" {
+			context = aiCodeContext
+		}
+		lang := "go"
+		if aiCodeLang != "" {
+			lang = aiCodeLang
+		}
+
+		var err error
+		if aiCodeSave {
+			err = executeWithSnippetHandler(func(h handler.SnippetHandler) error {
+				return h.GenerateAndSave(lang, description, context)
+			})
+		} else {
+			err = executeWithHandler(func(h handler.Handler) error {
+				return h.GenerateCodeWithAI(lang, description, context)
+			})
+		}
+		if err != nil {
 			fmt.Printf("Error: %v\n", err)
 		}
 	},
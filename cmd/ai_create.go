@@ -15,6 +15,7 @@ var aiCreateContext string
 func init() {
 	aiCreateCmd.Flags().StringVarP(&aiCreateTag, "tag", "t", "", "Tag for the note")
 	aiCreateCmd.Flags().StringVarP(&aiCreateContext, "context", "c", "", "Additional context for AI generation")
+	addAIFlags(aiCreateCmd)
 	rootCmd.AddCommand(aiCreateCmd)
 }
 
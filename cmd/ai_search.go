@@ -9,6 +9,7 @@ import (
 )
 
 func init() {
+	addAIFlags(aiSearchCmd)
 	rootCmd.AddCommand(aiSearchCmd)
 }
 
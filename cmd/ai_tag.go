@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/snip/internal/handler"
+	"github.com/spf13/cobra"
+)
+
+var aiTagAll bool
+var aiTagYes bool
+
+func init() {
+	aiTagCmd.Flags().BoolVar(&aiTagAll, "all", false, "Summarize and tag every note")
+	aiTagCmd.Flags().BoolVarP(&aiTagYes, "yes", "y", false, "Apply suggested tags without asking for confirmation")
+	addAIFlags(aiTagCmd)
+	rootCmd.AddCommand(aiTagCmd)
+}
+
+var aiTagCmd = &cobra.Command{
+	Use:   "ai-tag [id]",
+	Short: "Summarize notes and suggest tags with AI",
+	Long: `Ask the AI for a one-line summary and suggested tags for a note.
+
+The summary is stored with the note and shown by 'snip list --verbose'.
+Suggested tags reuse your existing tags whenever possible, and are only
+applied after confirmation (or directly with --yes).
+
+To run this automatically on create/update, enable it with
+'snip ai config --auto-tag' or use the --ai-tag flag on those commands.
+
+Examples:
+  snip ai-tag 12              # Summarize and tag note 12
+  snip ai-tag --all           # Go through every note
+  snip ai-tag --all --yes     # Apply all suggestions without asking`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if aiTagAll && len(args) > 0 {
+			return fmt.Errorf("use either a note id or --all")
+		}
+		if !aiTagAll && len(args) != 1 {
+			return fmt.Errorf("requires a note id or --all")
+		}
+		return nil
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		id := ""
+		if len(args) > 0 {
+			id = args[0]
+		}
+		if err := executeWithHandler(func(h handler.Handler) error {
+			return h.TagNotesWithAI(id, aiTagAll, aiTagYes)
+		}); err != nil {
+			fmt.Printf("Error: %v\n", err)
+		}
+	},
+}
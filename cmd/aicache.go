@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/snip/internal/ai"
+	"github.com/snip/internal/handler"
+	"github.com/spf13/cobra"
+)
+
+var (
+	aiCacheExpiredOnly bool
+	aiCacheTTL         string
+	aiCacheEnable      bool
+	aiCacheDisable     bool
+)
+
+func init() {
+	aiCacheClearCmd.Flags().BoolVar(&aiCacheExpiredOnly, "expired", false, "Remover apenas entradas expiradas")
+
+	aiCacheConfigCmd.Flags().StringVar(&aiCacheTTL, "ttl", "", "Validade das respostas (ex: 24h, 90m, 168h)")
+	aiCacheConfigCmd.Flags().BoolVar(&aiCacheEnable, "enable", false, "Ativar o cache")
+	aiCacheConfigCmd.Flags().BoolVar(&aiCacheDisable, "disable", false, "Desativar o cache")
+
+	aiCmd.AddCommand(aiCacheCmd)
+	aiCacheCmd.AddCommand(aiCacheStatsCmd)
+	aiCacheCmd.AddCommand(aiCacheClearCmd)
+	aiCacheCmd.AddCommand(aiCacheConfigCmd)
+}
+
+var aiCacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Gerenciar o cache de respostas da IA",
+	Long: `Requisições idênticas (provedor, modelo, mensagens e parâmetros) são
+respondidas a partir de um cache local em SQLite até expirarem.
+Use --no-cache em qualquer comando de IA para ignorar o cache.
+
+Exemplos:
+  snip ai cache stats
+  snip ai cache clear
+  snip ai cache clear --expired
+  snip ai cache config --ttl 72h
+  snip db-analysis run 1 --no-cache`,
+}
+
+var aiCacheStatsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Mostrar estatísticas do cache",
+	Run: func(cmd *cobra.Command, args []string) {
+		err := executeWithAICacheHandler(func(h handler.AICacheHandler) error {
+			return h.ShowStats()
+		})
+		if err != nil {
+			fmt.Printf("Erro: %v\n", err)
+		}
+	},
+}
+
+var aiCacheClearCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "Limpar o cache",
+	Run: func(cmd *cobra.Command, args []string) {
+		err := executeWithAICacheHandler(func(h handler.AICacheHandler) error {
+			return h.Clear(aiCacheExpiredOnly)
+		})
+		if err != nil {
+			fmt.Printf("Erro: %v\n", err)
+		}
+	},
+}
+
+var aiCacheConfigCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Configurar validade e ativação do cache",
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := configureAICache(); err != nil {
+			fmt.Printf("Erro: %v\n", err)
+		}
+	},
+}
+
+func configureAICache() error {
+	if aiCacheEnable && aiCacheDisable {
+		return fmt.Errorf("use apenas --enable ou --disable")
+	}
+	if aiCacheTTL != "" {
+		ttl, err := time.ParseDuration(aiCacheTTL)
+		if err != nil || ttl <= 0 {
+			return fmt.Errorf("TTL inválido: %s (use, por exemplo, 24h ou 90m)", aiCacheTTL)
+		}
+	}
+
+	config, err := ai.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("erro ao carregar configuração: %w", err)
+	}
+
+	if config.Cache == nil {
+		config.Cache = &ai.CacheConfig{}
+	}
+	if aiCacheTTL != "" {
+		config.Cache.TTL = aiCacheTTL
+	}
+	if aiCacheEnable {
+		config.Cache.Disabled = false
+	}
+	if aiCacheDisable {
+		config.Cache.Disabled = true
+	}
+
+	if err := ai.SaveConfig(config); err != nil {
+		return fmt.Errorf("erro ao salvar configuração: %w", err)
+	}
+
+	status := "ativo"
+	if config.Cache.Disabled {
+		status = "desativado"
+	}
+	fmt.Printf("✓ Cache %s (TTL: %s)\n", status, config.Cache.GetTTL())
+	return nil
+}
@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/snip/internal/handler"
+	"github.com/spf13/cobra"
+)
+
+var (
+	aiChatResume string
+	aiChatTitle  string
+	aiChatLimit  int
+)
+
+func init() {
+	aiChatCmd.Flags().StringVarP(&aiChatResume, "resume", "r", "", "Retomar uma sessão salva (id ou 'last')")
+	aiChatCmd.Flags().StringVarP(&aiChatTitle, "title", "t", "", "Título da nova sessão (padrão: primeira pergunta)")
+	aiChatListCmd.Flags().IntVarP(&aiChatLimit, "limit", "n", 20, "Número máximo de sessões")
+
+	addAIFlags(aiChatCmd)
+	aiCmd.AddCommand(aiChatCmd)
+	aiChatCmd.AddCommand(aiChatListCmd)
+	aiChatCmd.AddCommand(aiChatShowCmd)
+	aiChatCmd.AddCommand(aiChatDeleteCmd)
+}
+
+var aiChatCmd = &cobra.Command{
+	Use:   "chat",
+	Short: "Chat interativo com a IA, com histórico persistente",
+	Long: `Inicia uma conversa com a IA que mantém o histórico entre as mensagens.
+As sessões ficam salvas no banco e podem ser retomadas com --resume.
+
+Comandos dentro do chat:
+  /note <id>      Fixar uma nota como contexto das próximas respostas
+  /save [título]  Salvar a conversa como uma nova nota
+  /model [nome]   Trocar o modelo (sem nome lista os disponíveis)
+  /help           Mostrar os comandos
+  /exit           Sair (a sessão continua salva)
+
+Exemplos:
+  snip ai chat
+  snip ai chat --title "Estudo de Go"
+  snip ai chat --resume 3
+  snip ai chat --resume last
+  snip ai chat list
+  snip ai chat show 3
+  snip ai chat delete 3`,
+	Run: func(cmd *cobra.Command, args []string) {
+		err := executeWithAIChatHandler(func(h handler.AIChatHandler) error {
+			return h.StartChat(aiChatResume, aiChatTitle, aiModelOverride != "")
+		})
+		if err != nil {
+			fmt.Printf("Erro: %v\n", err)
+		}
+	},
+}
+
+var aiChatListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "Listar as sessões de chat salvas",
+	Run: func(cmd *cobra.Command, args []string) {
+		err := executeWithAIChatHandler(func(h handler.AIChatHandler) error {
+			return h.ListSessions(aiChatLimit)
+		})
+		if err != nil {
+			fmt.Printf("Erro: %v\n", err)
+		}
+	},
+}
+
+var aiChatShowCmd = &cobra.Command{
+	Use:   "show [id]",
+	Short: "Mostrar o histórico de uma sessão",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := executeWithAIChatHandler(func(h handler.AIChatHandler) error {
+			return h.ShowSession(args[0])
+		})
+		if err != nil {
+			fmt.Printf("Erro: %v\n", err)
+		}
+	},
+}
+
+var aiChatDeleteCmd = &cobra.Command{
+	Use:   "delete [id]",
+	Short: "Remover uma sessão e suas mensagens",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := executeWithAIChatHandler(func(h handler.AIChatHandler) error {
+			return h.DeleteSession(args[0])
+		})
+		if err != nil {
+			fmt.Printf("Erro: %v\n", err)
+		}
+	},
+}
@@ -15,19 +15,32 @@ var (
 	aiConfigModel    string
 	aiConfigAPIKey   string
 	aiConfigShow     bool
+	aiConfigLanguage string
+	aiConfigMaxSteps int
+	aiConfigAutoTag  bool
+
+	aiConfigEndpoint   string
+	aiConfigDeployment string
+	aiConfigAPIVersion string
 )
 
 func init() {
-	aiConfigCmd.Flags().StringVarP(&aiConfigProvider, "provider", "p", "", "Provedor de IA (groq, openai, anthropic, deepseek, grok, openrouter)")
+	aiConfigCmd.Flags().StringVarP(&aiConfigProvider, "provider", "p", "", "Provedor de IA (groq, openai, anthropic, deepseek, grok, openrouter, gemini, azure)")
 	aiConfigCmd.Flags().StringVarP(&aiConfigModel, "model", "m", "", "Modelo a ser usado")
 	aiConfigCmd.Flags().StringVarP(&aiConfigAPIKey, "api-key", "k", "", "API Key")
 	aiConfigCmd.Flags().BoolVar(&aiConfigShow, "show", false, "Mostrar configuração atual")
-
-	rootCmd.AddCommand(aiConfigCmd)
+	aiConfigCmd.Flags().StringVarP(&aiConfigLanguage, "language", "l", "", "Idioma dos prompts e respostas (pt-BR, en, es)")
+	aiConfigCmd.Flags().IntVar(&aiConfigMaxSteps, "max-steps", 0, "Limite de chamadas de ferramentas por pergunta no db-chat e db-history chat")
+	aiConfigCmd.Flags().StringVar(&aiConfigEndpoint, "endpoint", "", "Endpoint do recurso (azure: https://<recurso>.openai.azure.com)")
+	aiConfigCmd.Flags().StringVar(&aiConfigDeployment, "deployment", "", "Nome do deployment no Azure OpenAI (padrão: o modelo)")
+	aiConfigCmd.Flags().StringVar(&aiConfigAPIVersion, "api-version", "", "Versão da API do Azure OpenAI (padrão: "+ai.DefaultAzureAPIVersion+")")
+	aiConfigCmd.Flags().BoolVar(&aiConfigAutoTag, "auto-tag", false, "Resumir e sugerir tags ao criar/atualizar notas (--auto-tag=false desativa)")
+
+	aiCmd.AddCommand(aiConfigCmd)
 }
 
 var aiConfigCmd = &cobra.Command{
-	Use:   "ai config",
+	Use:   "config",
 	Short: "Configurar provedor de IA e API key",
 	Long: `Configura o provedor de IA, modelo e API key.
 
@@ -38,10 +51,17 @@ Provedores suportados:
   - deepseek: DeepSeek
   - grok: Grok (xAI)
   - openrouter: OpenRouter
+  - gemini: Google Gemini
+  - azure: Azure OpenAI (requer --endpoint; o modelo é o nome do deployment)
 
 Exemplos:
   snip ai config --provider groq --model "openai/gpt-oss-120b" --api-key "sua-chave"
   snip ai config --provider openai --model "gpt-4o" --api-key "sua-chave"
+  snip ai config --provider gemini --model "gemini-2.0-flash" --api-key "sua-chave"
+  snip ai config --provider azure --endpoint "https://meu-recurso.openai.azure.com" --deployment "gpt4o-prod" --api-key "sua-chave"
+  snip ai config --language en  # Prompts e respostas em inglês
+  snip ai config --max-steps 12 # Investigações mais longas no db-chat
+  snip ai config --auto-tag     # Resumo e tags sugeridas em create/update
   snip ai config --show  # Mostrar configuração atual
   snip ai config         # Modo interativo`,
 	Run: func(cmd *cobra.Command, args []string) {
@@ -57,7 +77,8 @@ Exemplos:
 		}
 
 		// Modo interativo se nenhum parâmetro foi fornecido
-		if aiConfigProvider == "" && aiConfigModel == "" && aiConfigAPIKey == "" {
+		if aiConfigProvider == "" && aiConfigModel == "" && aiConfigAPIKey == "" && aiConfigLanguage == "" && aiConfigMaxSteps == 0 && !cmd.Flags().Changed("auto-tag") &&
+			aiConfigEndpoint == "" && aiConfigDeployment == "" && aiConfigAPIVersion == "" {
 			interactiveConfig(config)
 			return
 		}
@@ -75,6 +96,43 @@ Exemplos:
 			config.APIKey = aiConfigAPIKey
 		}
 
+		if aiConfigEndpoint != "" {
+			config.Endpoint = aiConfigEndpoint
+		}
+
+		if aiConfigDeployment != "" {
+			config.Deployment = aiConfigDeployment
+		}
+
+		if aiConfigAPIVersion != "" {
+			config.APIVersion = aiConfigAPIVersion
+		}
+
+		if config.Provider == ai.ProviderAzure && config.Endpoint == "" {
+			fmt.Println("Endpoint é obrigatório para o Azure OpenAI (use --endpoint)")
+			return
+		}
+
+		if aiConfigLanguage != "" {
+			if !ai.IsValidLanguage(aiConfigLanguage) {
+				fmt.Printf("Idioma inválido: %s (use: %s)\n", aiConfigLanguage, strings.Join(ai.GetLanguages(), ", "))
+				return
+			}
+			config.Language = aiConfigLanguage
+		}
+
+		if aiConfigMaxSteps < 0 {
+			fmt.Println("Limite de passos inválido: use um número positivo")
+			return
+		}
+		if aiConfigMaxSteps > 0 {
+			config.MaxAgentSteps = aiConfigMaxSteps
+		}
+
+		if cmd.Flags().Changed("auto-tag") {
+			config.AutoTag = aiConfigAutoTag
+		}
+
 		// Se o modelo não foi especificado, usar o padrão do provedor
 		if config.Model == "" {
 			models := ai.GetAvailableModels(config.Provider)
@@ -92,6 +150,10 @@ Exemplos:
 		fmt.Printf("  Provedor: %s\n", config.Provider)
 		fmt.Printf("  Modelo: %s\n", config.Model)
 		fmt.Printf("  API Key: %s\n", maskAPIKey(config.APIKey))
+		printEndpointConfig(config.Endpoint, config.Deployment, config.APIVersion)
+		fmt.Printf("  Idioma: %s\n", ai.GetLanguage())
+		fmt.Printf("  Passos do agente: %d\n", ai.GetMaxAgentSteps())
+		fmt.Printf("  Tags automáticas: %s\n", autoTagStatus(config.AutoTag))
 	},
 }
 
@@ -106,6 +168,11 @@ func showConfig() {
 	fmt.Printf("  Provedor: %s\n", config.Provider)
 	fmt.Printf("  Modelo: %s\n", config.Model)
 	fmt.Printf("  API Key: %s\n", maskAPIKey(config.APIKey))
+	printEndpointConfig(config.Endpoint, config.Deployment, config.APIVersion)
+	fmt.Printf("  Idioma: %s\n", ai.GetLanguage())
+	fmt.Printf("  Passos do agente: %d\n", ai.GetMaxAgentSteps())
+	fmt.Printf("  Tags automáticas: %s\n", autoTagStatus(config.AutoTag))
+	fmt.Printf("  Redação: %s\n", config.DescribeRedaction())
 
 	if config.Provider != "" {
 		models := ai.GetAvailableModels(config.Provider)
@@ -137,6 +204,8 @@ func interactiveConfig(currentConfig *ai.AIConfig) {
 		ai.ProviderDeepSeek,
 		ai.ProviderGrok,
 		ai.ProviderOpenRouter,
+		ai.ProviderGemini,
+		ai.ProviderAzure,
 	}
 
 	for i, p := range providers {
@@ -147,7 +216,7 @@ func interactiveConfig(currentConfig *ai.AIConfig) {
 		fmt.Printf("  %s %d. %s\n", marker, i+1, p)
 	}
 
-	fmt.Print("\nEscolha o provedor (1-6) [padrão: groq]: ")
+	fmt.Printf("\nEscolha o provedor (1-%d) [padrão: groq]: ", len(providers))
 	input, _ := reader.ReadString('\n')
 	input = strings.TrimSpace(input)
 
@@ -203,6 +272,20 @@ func interactiveConfig(currentConfig *ai.AIConfig) {
 		currentConfig.APIKey = input
 	}
 
+	// Azure OpenAI: endpoint do recurso, deployment e versão da API
+	if selectedProvider == ai.ProviderAzure {
+		currentConfig.Endpoint = promptWithDefault(reader, "Endpoint (https://<recurso>.openai.azure.com)", currentConfig.Endpoint)
+		currentConfig.Deployment = promptWithDefault(reader, "Deployment (Enter usa o nome do modelo)", currentConfig.Deployment)
+		if currentConfig.APIVersion == "" {
+			currentConfig.APIVersion = ai.DefaultAzureAPIVersion
+		}
+		currentConfig.APIVersion = promptWithDefault(reader, "Versão da API", currentConfig.APIVersion)
+		if currentConfig.Endpoint == "" {
+			fmt.Println("Endpoint é obrigatório para o Azure OpenAI")
+			return
+		}
+	}
+
 	currentConfig.Provider = selectedProvider
 
 	if err := ai.SaveConfig(currentConfig); err != nil {
@@ -214,6 +297,34 @@ func interactiveConfig(currentConfig *ai.AIConfig) {
 	fmt.Printf("  Provedor: %s\n", currentConfig.Provider)
 	fmt.Printf("  Modelo: %s\n", currentConfig.Model)
 	fmt.Printf("  API Key: %s\n", maskAPIKey(currentConfig.APIKey))
+	printEndpointConfig(currentConfig.Endpoint, currentConfig.Deployment, currentConfig.APIVersion)
+}
+
+// promptWithDefault lê uma linha; Enter mantém o valor atual
+func promptWithDefault(reader *bufio.Reader, label, current string) string {
+	if current != "" {
+		fmt.Printf("\n%s [%s]: ", label, current)
+	} else {
+		fmt.Printf("\n%s: ", label)
+	}
+	input, _ := reader.ReadString('\n')
+	if input = strings.TrimSpace(input); input != "" {
+		return input
+	}
+	return current
+}
+
+// printEndpointConfig mostra endpoint/deployment/versão quando configurados (Azure OpenAI)
+func printEndpointConfig(endpoint, deployment, apiVersion string) {
+	if endpoint != "" {
+		fmt.Printf("  Endpoint: %s\n", endpoint)
+	}
+	if deployment != "" {
+		fmt.Printf("  Deployment: %s\n", deployment)
+	}
+	if apiVersion != "" {
+		fmt.Printf("  Versão da API: %s\n", apiVersion)
+	}
 }
 
 func maskAPIKey(key string) string {
@@ -226,3 +337,10 @@ func maskAPIKey(key string) string {
 	return key[:4] + "..." + key[len(key)-4:]
 }
 
+
+func autoTagStatus(enabled bool) string {
+	if enabled {
+		return "ativadas"
+	}
+	return "desativadas"
+}
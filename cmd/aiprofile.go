@@ -0,0 +1,364 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/snip/internal/ai"
+	"github.com/spf13/cobra"
+)
+
+var (
+	aiProfileProvider string
+	aiProfileModel    string
+	aiProfileAPIKey   string
+	aiProfileFeature  string
+	aiProfileClear    bool
+
+	aiProfileEndpoint   string
+	aiProfileDeployment string
+	aiProfileAPIVersion string
+)
+
+func init() {
+	aiProfileAddCmd.Flags().StringVarP(&aiProfileProvider, "provider", "p", "", "Provedor de IA (groq, openai, anthropic, deepseek, grok, openrouter, gemini, azure)")
+	aiProfileAddCmd.Flags().StringVarP(&aiProfileModel, "model", "m", "", "Modelo a ser usado")
+	aiProfileAddCmd.Flags().StringVarP(&aiProfileAPIKey, "api-key", "k", "", "API Key (padrão: a chave principal, se o provedor for o mesmo)")
+	aiProfileAddCmd.Flags().StringVar(&aiProfileEndpoint, "endpoint", "", "Endpoint do recurso (azure: https://<recurso>.openai.azure.com)")
+	aiProfileAddCmd.Flags().StringVar(&aiProfileDeployment, "deployment", "", "Nome do deployment no Azure OpenAI (padrão: o modelo)")
+	aiProfileAddCmd.Flags().StringVar(&aiProfileAPIVersion, "api-version", "", "Versão da API do Azure OpenAI")
+
+	aiProfileUseCmd.Flags().StringVarP(&aiProfileFeature, "feature", "f", "", "Definir o perfil padrão apenas para uma funcionalidade")
+	aiProfileUseCmd.Flags().StringVarP(&aiProfileModel, "model", "m", "", "Modelo padrão para a funcionalidade (requer --feature)")
+
+	aiProfileRemoveCmd.Flags().StringVarP(&aiProfileFeature, "feature", "f", "", "Remover o padrão de uma funcionalidade")
+
+	aiProfileFallbackCmd.Flags().BoolVar(&aiProfileClear, "clear", false, "Remover a cadeia de fallback")
+
+	aiCmd.AddCommand(aiProfileCmd)
+	aiProfileCmd.AddCommand(aiProfileAddCmd)
+	aiProfileCmd.AddCommand(aiProfileListCmd)
+	aiProfileCmd.AddCommand(aiProfileUseCmd)
+	aiProfileCmd.AddCommand(aiProfileRemoveCmd)
+	aiProfileCmd.AddCommand(aiProfileFallbackCmd)
+}
+
+var aiProfileCmd = &cobra.Command{
+	Use:   "profile",
+	Short: "Gerenciar perfis de IA",
+	Long: `Gerencia perfis nomeados de provedor, modelo e API key.
+
+Cada comando de IA aceita --ai-profile e --model para sobrepor o perfil.
+Funcionalidades podem ter um perfil/modelo padrão próprio, e uma cadeia de
+fallback ordenada é usada quando o provedor principal falha.
+
+Funcionalidades: notes, chat, checklist, project, db-chat, db-history-chat, db-analysis,
+db-chart, db-maintenance, db-project, db-dynamic, oracle, backup
+
+Exemplos:
+  snip ai profile add fast --provider groq --model llama-3.1-8b-instant --api-key "chave"
+  snip ai profile add deep --provider anthropic --api-key "chave"
+  snip ai profile add corp --provider azure --endpoint "https://meu-recurso.openai.azure.com" --deployment gpt4o-prod --api-key "chave"
+  snip ai profile use fast
+  snip ai profile use deep --feature db-chat --model claude-3-5-haiku-20241022
+  snip ai profile fallback deep fast
+  snip ai profile list
+  snip ai-ask "pergunta" --ai-profile deep`,
+}
+
+var aiProfileAddCmd = &cobra.Command{
+	Use:   "add [name]",
+	Short: "Adicionar ou atualizar um perfil de IA",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := addAIProfile(args[0]); err != nil {
+			fmt.Printf("Erro: %v\n", err)
+		}
+	},
+}
+
+var aiProfileListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Listar perfis de IA",
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := listAIProfiles(); err != nil {
+			fmt.Printf("Erro: %v\n", err)
+		}
+	},
+}
+
+var aiProfileUseCmd = &cobra.Command{
+	Use:   "use [name]",
+	Short: "Ativar um perfil de IA (globalmente ou para uma funcionalidade)",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := useAIProfile(args[0]); err != nil {
+			fmt.Printf("Erro: %v\n", err)
+		}
+	},
+}
+
+var aiProfileRemoveCmd = &cobra.Command{
+	Use:   "remove [name]",
+	Short: "Remover um perfil de IA ou o padrão de uma funcionalidade",
+	Args:  cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		name := ""
+		if len(args) > 0 {
+			name = args[0]
+		}
+		if err := removeAIProfile(name); err != nil {
+			fmt.Printf("Erro: %v\n", err)
+		}
+	},
+}
+
+var aiProfileFallbackCmd = &cobra.Command{
+	Use:   "fallback [profiles...]",
+	Short: "Definir a cadeia ordenada de perfis de fallback",
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := setAIFallback(args); err != nil {
+			fmt.Printf("Erro: %v\n", err)
+		}
+	},
+}
+
+func addAIProfile(name string) error {
+	if name == ai.DefaultProfileName {
+		return fmt.Errorf("'%s' é reservado para a configuração principal (use: snip ai config)", name)
+	}
+	if aiProfileProvider == "" {
+		return fmt.Errorf("provedor é obrigatório (use --provider)")
+	}
+
+	config, err := ai.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("erro ao carregar configuração: %w", err)
+	}
+
+	profile := &ai.AIProfile{
+		Provider:   ai.Provider(aiProfileProvider),
+		Model:      aiProfileModel,
+		APIKey:     aiProfileAPIKey,
+		Endpoint:   aiProfileEndpoint,
+		Deployment: aiProfileDeployment,
+		APIVersion: aiProfileAPIVersion,
+	}
+
+	if existing, ok := config.Profiles[name]; ok && profile.APIKey == "" && existing.Provider == profile.Provider {
+		profile.APIKey = existing.APIKey
+	}
+	if profile.APIKey == "" && config.Provider == profile.Provider {
+		profile.APIKey = config.APIKey
+	}
+	if profile.Provider == ai.ProviderAzure && profile.Endpoint == "" {
+		return fmt.Errorf("endpoint é obrigatório para o Azure OpenAI (use --endpoint)")
+	}
+	if profile.APIKey == "" {
+		return fmt.Errorf("API key é obrigatória para o provedor %s (use --api-key)", profile.Provider)
+	}
+
+	// Se o modelo não foi especificado, usar o padrão do provedor
+	if profile.Model == "" {
+		models := ai.GetAvailableModels(profile.Provider)
+		if len(models) > 0 {
+			profile.Model = models[0]
+		}
+	}
+
+	if config.Profiles == nil {
+		config.Profiles = make(map[string]*ai.AIProfile)
+	}
+	config.Profiles[name] = profile
+
+	if err := ai.SaveConfig(config); err != nil {
+		return fmt.Errorf("erro ao salvar configuração: %w", err)
+	}
+
+	fmt.Printf("✓ Perfil '%s' salvo com sucesso!\n", name)
+	fmt.Printf("  Provedor: %s\n", profile.Provider)
+	fmt.Printf("  Modelo: %s\n", profile.Model)
+	fmt.Printf("  API Key: %s\n", maskAPIKey(profile.APIKey))
+	printEndpointConfig(profile.Endpoint, profile.Deployment, profile.APIVersion)
+	return nil
+}
+
+func listAIProfiles() error {
+	config, err := ai.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("erro ao carregar configuração: %w", err)
+	}
+
+	fmt.Println("📋 Perfis de IA:")
+	marker := " "
+	if config.ActiveProfile == "" || config.ActiveProfile == ai.DefaultProfileName {
+		marker = "✓"
+	}
+	fmt.Printf("  %s %-12s %s / %s\n", marker, ai.DefaultProfileName, config.Provider, config.Model)
+
+	for _, name := range config.ProfileNames() {
+		p := config.Profiles[name]
+		marker := " "
+		if name == config.ActiveProfile {
+			marker = "✓"
+		}
+		fmt.Printf("  %s %-12s %s / %s (%s)\n", marker, name, p.Provider, p.Model, maskAPIKey(p.APIKey))
+	}
+
+	if len(config.Features) > 0 {
+		fmt.Println("\n🎯 Padrões por funcionalidade:")
+		for _, feature := range ai.GetFeatures() {
+			fd, ok := config.Features[feature]
+			if !ok {
+				continue
+			}
+			profile := fd.Profile
+			if profile == "" {
+				profile = "(ativo)"
+			}
+			if fd.Model != "" {
+				fmt.Printf("  %-16s %s / %s\n", feature, profile, fd.Model)
+			} else {
+				fmt.Printf("  %-16s %s\n", feature, profile)
+			}
+		}
+	}
+
+	if len(config.Fallback) > 0 {
+		fmt.Printf("\n🔁 Fallback: %s\n", strings.Join(config.Fallback, " → "))
+		problems := config.FallbackErrors()
+		for _, name := range config.Fallback {
+			if err, ok := problems[name]; ok {
+				fmt.Printf("  ⚠️  %s é ignorado: %v\n", name, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func useAIProfile(name string) error {
+	config, err := ai.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("erro ao carregar configuração: %w", err)
+	}
+
+	if _, err := config.ResolveProfile(name); err != nil {
+		return err
+	}
+
+	if aiProfileFeature == "" {
+		if aiProfileModel != "" {
+			return fmt.Errorf("--model requer --feature (para mudar o modelo do perfil use: snip ai profile add)")
+		}
+		if name == ai.DefaultProfileName {
+			name = ""
+		}
+		config.ActiveProfile = name
+	} else {
+		feature := ai.Feature(aiProfileFeature)
+		if !ai.IsValidFeature(feature) {
+			return fmt.Errorf("funcionalidade desconhecida: %s", aiProfileFeature)
+		}
+		if config.Features == nil {
+			config.Features = make(map[ai.Feature]ai.FeatureConfig)
+		}
+		config.Features[feature] = ai.FeatureConfig{Profile: name, Model: aiProfileModel}
+	}
+
+	if err := ai.SaveConfig(config); err != nil {
+		return fmt.Errorf("erro ao salvar configuração: %w", err)
+	}
+
+	if aiProfileFeature != "" {
+		fmt.Printf("✓ Perfil '%s' definido como padrão para %s\n", name, aiProfileFeature)
+	} else if name == "" {
+		fmt.Printf("✓ Perfil ativo: %s\n", ai.DefaultProfileName)
+	} else {
+		fmt.Printf("✓ Perfil ativo: %s\n", name)
+	}
+	return nil
+}
+
+func removeAIProfile(name string) error {
+	config, err := ai.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("erro ao carregar configuração: %w", err)
+	}
+
+	if aiProfileFeature != "" {
+		feature := ai.Feature(aiProfileFeature)
+		if _, ok := config.Features[feature]; !ok {
+			return fmt.Errorf("nenhum padrão definido para %s", aiProfileFeature)
+		}
+		delete(config.Features, feature)
+		if err := ai.SaveConfig(config); err != nil {
+			return fmt.Errorf("erro ao salvar configuração: %w", err)
+		}
+		fmt.Printf("✓ Padrão removido para %s\n", aiProfileFeature)
+		return nil
+	}
+
+	if name == "" {
+		return fmt.Errorf("informe o nome do perfil ou --feature")
+	}
+	if _, ok := config.Profiles[name]; !ok {
+		return fmt.Errorf("perfil de IA não encontrado: %s", name)
+	}
+
+	delete(config.Profiles, name)
+	if config.ActiveProfile == name {
+		config.ActiveProfile = ""
+	}
+	for feature, fd := range config.Features {
+		if fd.Profile == name {
+			delete(config.Features, feature)
+		}
+	}
+	var fallback []string
+	for _, p := range config.Fallback {
+		if p != name {
+			fallback = append(fallback, p)
+		}
+	}
+	config.Fallback = fallback
+
+	if err := ai.SaveConfig(config); err != nil {
+		return fmt.Errorf("erro ao salvar configuração: %w", err)
+	}
+
+	fmt.Printf("✓ Perfil '%s' removido\n", name)
+	return nil
+}
+
+func setAIFallback(profiles []string) error {
+	config, err := ai.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("erro ao carregar configuração: %w", err)
+	}
+
+	if aiProfileClear {
+		config.Fallback = nil
+	} else {
+		if len(profiles) == 0 {
+			return fmt.Errorf("informe ao menos um perfil ou use --clear")
+		}
+		for _, name := range profiles {
+			if _, err := config.ResolveProfile(name); err != nil {
+				return err
+			}
+		}
+		config.Fallback = profiles
+	}
+
+	if err := ai.SaveConfig(config); err != nil {
+		return fmt.Errorf("erro ao salvar configuração: %w", err)
+	}
+
+	if len(config.Fallback) == 0 {
+		fmt.Println("✓ Cadeia de fallback removida")
+	} else {
+		fmt.Printf("✓ Fallback: %s\n", strings.Join(config.Fallback, " → "))
+	}
+	return nil
+}
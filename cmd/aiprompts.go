@@ -0,0 +1,165 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/snip/internal/ai"
+	"github.com/snip/internal/handler"
+	"github.com/spf13/cobra"
+)
+
+var aiPromptsLanguage string
+
+func init() {
+	aiPromptsCmd.PersistentFlags().StringVarP(&aiPromptsLanguage, "language", "l", "", "Idioma dos prompts (padrão: o configurado em snip ai config)")
+
+	aiCmd.AddCommand(aiPromptsCmd)
+	aiPromptsCmd.AddCommand(aiPromptsListCmd)
+	aiPromptsCmd.AddCommand(aiPromptsShowCmd)
+	aiPromptsCmd.AddCommand(aiPromptsEditCmd)
+}
+
+var aiPromptsCmd = &cobra.Command{
+	Use:   "prompts",
+	Short: "Inspecionar e personalizar os templates de prompt da IA",
+	Long: `Os prompts usados pela IA são templates versionados embutidos no binário,
+um conjunto por idioma (pt-BR, en, es). Para personalizar um prompt, edite-o:
+uma cópia é criada em ~/.snip/prompts/<idioma>/<nome>.tmpl e passa a ter
+prioridade sobre o padrão. Apague o arquivo para voltar ao padrão.
+
+O idioma é definido com: snip ai config --language en
+
+Exemplos:
+  snip ai prompts list
+  snip ai prompts show dbchat.query
+  snip ai prompts show notes.content --language es
+  snip ai prompts edit dbcharts.extract`,
+}
+
+var aiPromptsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Listar prompts disponíveis",
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := listAIPrompts(); err != nil {
+			fmt.Printf("Erro: %v\n", err)
+		}
+	},
+}
+
+var aiPromptsShowCmd = &cobra.Command{
+	Use:   "show [name]",
+	Short: "Mostrar o template de um prompt",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := showAIPrompt(args[0]); err != nil {
+			fmt.Printf("Erro: %v\n", err)
+		}
+	},
+}
+
+var aiPromptsEditCmd = &cobra.Command{
+	Use:   "edit [name]",
+	Short: "Personalizar um prompt no editor",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := editAIPrompt(args[0]); err != nil {
+			fmt.Printf("Erro: %v\n", err)
+		}
+	},
+}
+
+func promptsLanguage() (string, error) {
+	if aiPromptsLanguage == "" {
+		return ai.GetLanguage(), nil
+	}
+	if !ai.IsValidLanguage(aiPromptsLanguage) {
+		return "", fmt.Errorf("idioma inválido: %s (use: %s)", aiPromptsLanguage, strings.Join(ai.GetLanguages(), ", "))
+	}
+	return aiPromptsLanguage, nil
+}
+
+func listAIPrompts() error {
+	language, err := promptsLanguage()
+	if err != nil {
+		return err
+	}
+
+	prompts, err := ai.ListPrompts(language)
+	if err != nil {
+		return fmt.Errorf("erro ao listar prompts: %w", err)
+	}
+
+	fmt.Printf("📝 Prompts de IA (%s):\n\n", language)
+	for _, p := range prompts {
+		fmt.Printf("  %-24s v%-3d %s\n", p.Name, p.Version, p.Source)
+	}
+	return nil
+}
+
+func showAIPrompt(name string) error {
+	language, err := promptsLanguage()
+	if err != nil {
+		return err
+	}
+
+	source, origin, err := ai.LoadPromptSource(name, language)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("# %s (%s, %s)\n\n", name, language, origin)
+	fmt.Println(strings.TrimSpace(source))
+	return nil
+}
+
+func editAIPrompt(name string) error {
+	language, err := promptsLanguage()
+	if err != nil {
+		return err
+	}
+
+	source, _, err := ai.LoadPromptSource(name, language)
+	if err != nil {
+		return err
+	}
+
+	path, err := ai.GetPromptOverridePath(name, language)
+	if err != nil {
+		return err
+	}
+
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return fmt.Errorf("erro ao criar diretório de prompts: %w", err)
+		}
+		if err := os.WriteFile(path, []byte(source), 0644); err != nil {
+			return fmt.Errorf("erro ao criar prompt personalizado: %w", err)
+		}
+	}
+
+	editor, editorArgs := handler.NewEditorHandler().GetEditor()
+	c := exec.Command(editor, append(editorArgs, path)...)
+	c.Stdin = os.Stdin
+	c.Stdout = os.Stdout
+	c.Stderr = os.Stdout
+	if err := c.Run(); err != nil {
+		return fmt.Errorf("failed to open editor: %w", err)
+	}
+
+	// Validar o template editado para evitar falhas nas próximas chamadas de IA
+	edited, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if _, err := template.New(name).Parse(string(edited)); err != nil {
+		return fmt.Errorf("template inválido (corrija com: snip ai prompts edit %s): %w", name, err)
+	}
+
+	fmt.Printf("✓ Prompt '%s' personalizado em %s\n", name, path)
+	return nil
+}
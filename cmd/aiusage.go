@@ -0,0 +1,132 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/snip/internal/ai"
+	"github.com/snip/internal/handler"
+	"github.com/spf13/cobra"
+)
+
+var (
+	aiUsageSince   string
+	aiUsageBy      string
+	aiBudgetLimit  float64
+	aiBudgetTokens int
+	aiBudgetAction string
+	aiBudgetClear  bool
+)
+
+func init() {
+	aiUsageCmd.Flags().StringVar(&aiUsageSince, "since", "30d", "Período (ex: 7d, 4w, 1m ou 2025-01-01)")
+	aiUsageCmd.Flags().StringVar(&aiUsageBy, "by", "feature", "Agrupar por: feature, model, provider, day")
+
+	aiBudgetCmd.Flags().Float64Var(&aiBudgetLimit, "limit", 0, "Limite mensal de custo em US$")
+	aiBudgetCmd.Flags().IntVar(&aiBudgetTokens, "tokens", 0, "Limite mensal de tokens (entrada + saída)")
+	aiBudgetCmd.Flags().StringVar(&aiBudgetAction, "action", "", "Ação ao exceder o limite: warn ou block")
+	aiBudgetCmd.Flags().BoolVar(&aiBudgetClear, "clear", false, "Remover o orçamento mensal")
+
+	aiCmd.AddCommand(aiUsageCmd)
+	aiCmd.AddCommand(aiBudgetCmd)
+}
+
+var aiUsageCmd = &cobra.Command{
+	Use:   "usage",
+	Short: "Mostrar consumo de tokens e custo estimado da IA",
+	Long: `Mostra o consumo registrado de cada chamada de IA (provedor, modelo,
+funcionalidade, tokens, latência e custo estimado).
+
+Exemplos:
+  snip ai usage
+  snip ai usage --since 7d --by model
+  snip ai usage --since 2025-01-01 --by day`,
+	Run: func(cmd *cobra.Command, args []string) {
+		err := executeWithAIUsageHandler(func(h handler.AIUsageHandler) error {
+			return h.ShowUsage(aiUsageSince, aiUsageBy)
+		})
+		if err != nil {
+			fmt.Printf("Erro: %v\n", err)
+		}
+	},
+}
+
+var aiBudgetCmd = &cobra.Command{
+	Use:   "budget",
+	Short: "Configurar orçamento mensal de IA",
+	Long: `Define limites mensais de custo e/ou tokens. Antes de cada requisição o
+consumo do mês é verificado: com --action warn um aviso é exibido, com
+--action block a requisição é recusada.
+
+Exemplos:
+  snip ai budget --limit 10 --action block
+  snip ai budget --tokens 2000000
+  snip ai budget            # Mostrar orçamento atual
+  snip ai budget --clear`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := setAIBudget(cmd); err != nil {
+			fmt.Printf("Erro: %v\n", err)
+		}
+	},
+}
+
+func setAIBudget(cmd *cobra.Command) error {
+	config, err := ai.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("erro ao carregar configuração: %w", err)
+	}
+
+	changed := cmd.Flags().Changed("limit") || cmd.Flags().Changed("tokens") || cmd.Flags().Changed("action")
+
+	if !aiBudgetClear && !changed {
+		if config.Budget == nil {
+			fmt.Println("Nenhum orçamento mensal configurado.")
+			return nil
+		}
+		printAIBudget(config.Budget)
+		return nil
+	}
+
+	if aiBudgetClear {
+		config.Budget = nil
+		if err := ai.SaveConfig(config); err != nil {
+			return fmt.Errorf("erro ao salvar configuração: %w", err)
+		}
+		fmt.Println("✓ Orçamento mensal removido")
+		return nil
+	}
+
+	if aiBudgetAction != "" && aiBudgetAction != "warn" && aiBudgetAction != "block" {
+		return fmt.Errorf("ação inválida: %s (use warn ou block)", aiBudgetAction)
+	}
+
+	if config.Budget == nil {
+		config.Budget = &ai.BudgetConfig{Action: "warn"}
+	}
+	if cmd.Flags().Changed("limit") {
+		config.Budget.MonthlyLimitUSD = aiBudgetLimit
+	}
+	if cmd.Flags().Changed("tokens") {
+		config.Budget.MonthlyTokenLimit = aiBudgetTokens
+	}
+	if aiBudgetAction != "" {
+		config.Budget.Action = aiBudgetAction
+	}
+
+	if err := ai.SaveConfig(config); err != nil {
+		return fmt.Errorf("erro ao salvar configuração: %w", err)
+	}
+
+	fmt.Println("✓ Orçamento mensal salvo")
+	printAIBudget(config.Budget)
+	return nil
+}
+
+func printAIBudget(budget *ai.BudgetConfig) {
+	if budget.MonthlyLimitUSD > 0 {
+		fmt.Printf("  Limite de custo: US$ %.2f\n", budget.MonthlyLimitUSD)
+	}
+	if budget.MonthlyTokenLimit > 0 {
+		fmt.Printf("  Limite de tokens: %d\n", budget.MonthlyTokenLimit)
+	}
+	fmt.Printf("  Ação: %s\n", budget.Action)
+}
@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/snip/internal/handler"
+	"github.com/spf13/cobra"
+)
+
+var boardInteractive bool
+var boardMoveLeft int
+var boardMoveRight int
+
+func init() {
+	boardCmd.Flags().BoolVarP(&boardInteractive, "interactive", "i", false, "Modo interativo: mover cartões entre colunas com as setas")
+	boardCmd.Flags().IntVar(&boardMoveLeft, "left", 0, "Mover o cartão da tarefa para a coluna à esquerda")
+	boardCmd.Flags().IntVar(&boardMoveRight, "right", 0, "Mover o cartão da tarefa para a coluna à direita")
+
+	rootCmd.AddCommand(boardCmd)
+}
+
+var boardCmd = &cobra.Command{
+	Use:   "board [project-id]",
+	Short: "Quadro kanban das tarefas de um projeto",
+	Long: `Mostra as tarefas do projeto nas colunas A fazer, Em andamento e Concluído.
+Cada cartão traz a prioridade (▲ alta, ■ média, ▼ baixa), o prazo (atrasada,
+vence hoje, em N dias) e marca tarefas recorrentes (↻) e bloqueadas (⊘).
+O quadro se ajusta à largura do terminal; em terminais estreitos as colunas
+são empilhadas. Defina NO_COLOR para desativar as cores.
+
+No modo interativo:
+  ↑/↓ (ou k/j)   escolher o cartão
+  Tab/Shift+Tab  trocar de coluna
+  ←/→            mover o cartão para a coluna vizinha (grava o novo status)
+  q              sair
+
+Sem terminal, --left/--right movem um cartão da mesma forma.
+
+Exemplos:
+  snip board 1
+  snip board 1 --interactive
+  snip board 1 --right 7`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := executeWithTaskHandler(func(h handler.TaskHandler) error {
+			id, err := strconv.Atoi(args[0])
+			if err != nil {
+				return fmt.Errorf("ID inválido: %s", args[0])
+			}
+			switch {
+			case boardMoveRight > 0:
+				return h.MoveCard(id, boardMoveRight, 1)
+			case boardMoveLeft > 0:
+				return h.MoveCard(id, boardMoveLeft, -1)
+			}
+			return h.ShowBoard(id, boardInteractive)
+		}); err != nil {
+			fmt.Printf("Erro: %v\n", err)
+		}
+	},
+}
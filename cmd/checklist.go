@@ -14,11 +14,13 @@ var checklistTaskID int
 var checklistProjectID int
 var checklistNumItems int
 var checklistItemDescription string
+var checklistTemplate bool
 
 func init() {
 	checklistCreateCmd.Flags().StringVarP(&checklistDescription, "description", "d", "", "Descrição da checklist")
 	checklistCreateCmd.Flags().IntVarP(&checklistTaskID, "task", "", 0, "ID da tarefa")
 	checklistCreateCmd.Flags().IntVarP(&checklistProjectID, "project", "", 0, "ID do projeto")
+	checklistCreateCmd.Flags().BoolVar(&checklistTemplate, "template", false, "Criar como template reutilizável (use com run)")
 	
 	checklistAICreateCmd.Flags().StringVarP(&checklistDescription, "description", "d", "", "Contexto para geração")
 	checklistAICreateCmd.Flags().IntVarP(&checklistNumItems, "items", "n", 5, "Número de itens")
@@ -27,6 +29,7 @@ func init() {
 	
 	checklistListCmd.Flags().IntVarP(&checklistTaskID, "task", "", 0, "ID da tarefa")
 	checklistListCmd.Flags().IntVarP(&checklistProjectID, "project", "", 0, "ID do projeto")
+	checklistListCmd.Flags().BoolVar(&checklistTemplate, "templates", false, "Listar apenas templates")
 	
 	checklistItemAddCmd.Flags().StringVarP(&checklistItemDescription, "description", "d", "", "Descrição do item")
 	
@@ -53,7 +56,7 @@ var checklistCreateCmd = &cobra.Command{
 			if checklistProjectID > 0 {
 				projectID = &checklistProjectID
 			}
-			return h.CreateChecklist(title, checklistDescription, taskID, projectID)
+			return h.CreateChecklist(title, checklistDescription, taskID, projectID, checklistTemplate)
 		}); err != nil {
 			fmt.Printf("Erro: %v\n", err)
 		}
@@ -93,7 +96,7 @@ var checklistListCmd = &cobra.Command{
 			if checklistProjectID > 0 {
 				projectID = &checklistProjectID
 			}
-			return h.ListChecklists(taskID, projectID)
+			return h.ListChecklists(taskID, projectID, checklistTemplate)
 		}); err != nil {
 			fmt.Printf("Erro: %v\n", err)
 		}
@@ -189,12 +192,14 @@ var checklistItemDeleteCmd = &cobra.Command{
 func init() {
 	checklistCmd.AddCommand(checklistCreateCmd)
 	checklistCmd.AddCommand(checklistAICreateCmd)
+	addAIFlags(checklistAICreateCmd)
 	checklistCmd.AddCommand(checklistListCmd)
 	checklistCmd.AddCommand(checklistShowCmd)
 	checklistCmd.AddCommand(checklistDeleteCmd)
 	checklistCmd.AddCommand(checklistItemAddCmd)
 	checklistCmd.AddCommand(checklistItemToggleCmd)
 	checklistCmd.AddCommand(checklistItemDeleteCmd)
-	// bulkChecklistCmd é adicionado em checklist_bulk.go
+	// bulkChecklistCmd é adicionado em checklist_bulk.go e os comandos de
+	// template (template, run, history, reorder) em checklist_run.go
 }
 
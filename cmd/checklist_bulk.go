@@ -2,28 +2,44 @@ package cmd
 
 import (
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/snip/internal/checklist"
+	"github.com/snip/internal/handler"
 	"github.com/spf13/cobra"
 )
 
 var (
-	bulkChecklistCSV    string
-	bulkChecklistOutput string
-	bulkChecklistType   string
+	bulkChecklistCSV            string
+	bulkChecklistOutput         string
+	bulkChecklistType           string
 	bulkChecklistTemplateOutput string
+	bulkChecklistImport         bool
+	bulkChecklistProjectID      int
+	bulkChecklistID             int
+	bulkChecklistTitle          string
+	checklistExportCSV          bool
+	checklistExportOutput       string
 )
 
 func init() {
 	bulkChecklistCmd.Flags().StringVarP(&bulkChecklistCSV, "csv", "c", "", "Caminho do arquivo CSV com os itens do checklist")
 	bulkChecklistCmd.Flags().StringVarP(&bulkChecklistOutput, "output", "o", "", "Nome do arquivo markdown de saída (opcional)")
+	bulkChecklistCmd.Flags().BoolVar(&bulkChecklistImport, "import", false, "Gravar os itens do CSV no banco em vez de gerar o relatório")
+	bulkChecklistCmd.Flags().IntVar(&bulkChecklistProjectID, "project", 0, "ID do projeto da checklist importada")
+	bulkChecklistCmd.Flags().IntVar(&bulkChecklistID, "checklist", 0, "Sincronizar uma checklist existente (linhas com id atualizam o item)")
+	bulkChecklistCmd.Flags().StringVar(&bulkChecklistTitle, "title", "", "Título da checklist importada (padrão: nome do arquivo)")
+
+	checklistExportCmd.Flags().BoolVar(&checklistExportCSV, "csv", false, "Exportar no formato CSV do bulk (padrão: relatório markdown)")
+	checklistExportCmd.Flags().StringVarP(&checklistExportOutput, "output", "o", "", "Arquivo de saída (- para a saída padrão no CSV)")
 
 	bulkChecklistTemplateCmd.Flags().StringVarP(&bulkChecklistType, "type", "t", "generic", "Tipo de checklist (generic, daily, weekly, deep, backup, security, performance, maintenance)")
 	bulkChecklistTemplateCmd.Flags().StringVarP(&bulkChecklistTemplateOutput, "output", "o", "", "Caminho do arquivo CSV de saída (opcional)")
 
 	// Adicionar ao checklistCmd (definido em checklist.go)
 	checklistCmd.AddCommand(bulkChecklistCmd)
+	checklistCmd.AddCommand(checklistExportCmd)
 	bulkChecklistCmd.AddCommand(bulkChecklistTemplateCmd)
 }
 
@@ -45,17 +61,42 @@ Exemplo de CSV:
   "Verificar backups","Verificar se backups estão sendo executados","Backup",high,pending,"Verificar logs"
   "Testar restore","Testar procedimento de restore","Backup",medium,completed,"Teste realizado com sucesso"
 
+Com --import os itens são gravados no banco: cria uma checklist nova (no
+projeto de --project) ou, com --checklist, sincroniza uma existente. A coluna
+opcional id, gravada por "snip checklist export --csv", identifica o item a
+atualizar; linhas sem id são associadas pelo título ou viram itens novos.
+Status completed/done/ok marca o item como concluído.
+
 Exemplos:
   snip checklist bulk --csv checklist.csv
-  snip checklist bulk --csv items.csv --output resultado.md`,
+  snip checklist bulk --csv items.csv --output resultado.md
+  snip checklist bulk --import --csv daily.csv --project 1
+  snip checklist bulk --import --csv checklist_4.csv --checklist 4`,
 	Run: func(cmd *cobra.Command, args []string) {
 		if bulkChecklistCSV == "" {
 			fmt.Println("❌ Caminho do arquivo CSV é obrigatório (use --csv)")
 			return
 		}
 
+		if bulkChecklistImport {
+			if bulkChecklistProjectID > 0 && bulkChecklistID > 0 {
+				fmt.Println("❌ Use --project para uma checklist nova ou --checklist para sincronizar, não os dois")
+				return
+			}
+			if err := executeWithChecklistHandler(func(h handler.ChecklistHandler) error {
+				var projectID *int
+				if bulkChecklistProjectID > 0 {
+					projectID = &bulkChecklistProjectID
+				}
+				return h.ImportBulk(bulkChecklistCSV, projectID, bulkChecklistID, bulkChecklistTitle)
+			}); err != nil {
+				fmt.Printf("❌ Erro ao importar CSV: %v\n", err)
+			}
+			return
+		}
+
 		fmt.Printf("📋 Processando checklist em massa de: %s\n", bulkChecklistCSV)
-		fmt.Println("Aguarde...\n")
+		fmt.Print("Aguarde...\n\n")
 
 		result, err := checklist.ProcessBulkChecklistFromCSV(bulkChecklistCSV)
 		if err != nil {
@@ -129,7 +170,7 @@ Exemplos:
 
 		fmt.Printf("📝 Gerando template CSV para checklist tipo: %s\n", bulkChecklistType)
 		fmt.Printf("   Descrição: %s\n", checklist.GetChecklistTypeDescription(checklistType))
-		fmt.Println("Aguarde...\n")
+		fmt.Print("Aguarde...\n\n")
 
 		err := checklist.GenerateCSVTemplate(checklistType, outputPath)
 		if err != nil {
@@ -144,3 +185,27 @@ Exemplos:
 	},
 }
 
+var checklistExportCmd = &cobra.Command{
+	Use:   "export [id]",
+	Short: "Exportar uma checklist para CSV ou markdown",
+	Long: `Exporta os itens da checklist. Com --csv usa o formato do bulk (com a
+coluna id), para preencher numa planilha e sincronizar de volta com
+"snip checklist bulk --import --checklist <id>".
+
+Exemplos:
+  snip checklist export 4 --csv
+  snip checklist export 4 --csv -o daily.csv
+  snip checklist export 4`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := executeWithChecklistHandler(func(h handler.ChecklistHandler) error {
+			id, err := strconv.Atoi(args[0])
+			if err != nil {
+				return fmt.Errorf("ID inválido: %s", args[0])
+			}
+			return h.ExportChecklist(id, checklistExportCSV, checklistExportOutput)
+		}); err != nil {
+			fmt.Printf("Erro: %v\n", err)
+		}
+	},
+}
@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/snip/internal/handler"
+	"github.com/spf13/cobra"
+)
+
+var checklistTemplateOff bool
+var checklistHistoryLimit int
+
+func init() {
+	checklistTemplateCmd.Flags().BoolVar(&checklistTemplateOff, "off", false, "Voltar a ser uma checklist de uso único")
+	checklistHistoryCmd.Flags().IntVarP(&checklistHistoryLimit, "limit", "n", 10, "Número de execuções comparadas (0 = todas)")
+
+	checklistCmd.AddCommand(checklistTemplateCmd)
+	checklistCmd.AddCommand(checklistRunCmd)
+	checklistCmd.AddCommand(checklistHistoryCmd)
+	checklistCmd.AddCommand(checklistReorderCmd)
+}
+
+var checklistTemplateCmd = &cobra.Command{
+	Use:   "template [id]",
+	Short: "Transformar uma checklist em template reutilizável",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := executeWithChecklistHandler(func(h handler.ChecklistHandler) error {
+			id, err := strconv.Atoi(args[0])
+			if err != nil {
+				return fmt.Errorf("ID inválido: %s", args[0])
+			}
+			return h.SetTemplate(id, !checklistTemplateOff)
+		}); err != nil {
+			fmt.Printf("Erro: %v\n", err)
+		}
+	},
+}
+
+var checklistRunCmd = &cobra.Command{
+	Use:   "run [template]",
+	Short: "Executar um template, registrando o resultado de cada item",
+	Long: `Percorre os itens do template (pelo ID ou pelo título) e pede o resultado
+de cada um: o (ok), f (falha) ou s (pular), seguido de uma nota opcional.
+Cada execução fica registrada; q encerra antes do fim e guarda o que já foi respondido.
+
+Exemplos:
+  snip checklist run 3
+  snip checklist run "Checklist diário MySQL"`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := executeWithChecklistHandler(func(h handler.ChecklistHandler) error {
+			return h.RunTemplate(strings.Join(args, " "))
+		}); err != nil {
+			fmt.Printf("Erro: %v\n", err)
+		}
+	},
+}
+
+var checklistHistoryCmd = &cobra.Command{
+	Use:   "history [template]",
+	Short: "Comparar as execuções de um template",
+	Args:  cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := executeWithChecklistHandler(func(h handler.ChecklistHandler) error {
+			return h.ShowHistory(strings.Join(args, " "), checklistHistoryLimit)
+		}); err != nil {
+			fmt.Printf("Erro: %v\n", err)
+		}
+	},
+}
+
+var checklistReorderCmd = &cobra.Command{
+	Use:   "reorder [checklist_id] [item_ids]",
+	Short: "Reordenar os itens de uma checklist",
+	Long: `Define a ordem dos itens. Os IDs informados (separados por vírgula) vêm
+primeiro, na ordem dada; os demais seguem na ordem atual.
+
+Exemplos:
+  snip checklist reorder 3 12,10,11
+  snip checklist reorder 3 14`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := executeWithChecklistHandler(func(h handler.ChecklistHandler) error {
+			id, err := strconv.Atoi(args[0])
+			if err != nil {
+				return fmt.Errorf("ID inválido: %s", args[0])
+			}
+			return h.ReorderItems(id, args[1])
+		}); err != nil {
+			fmt.Printf("Erro: %v\n", err)
+		}
+	},
+}
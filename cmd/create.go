@@ -13,9 +13,18 @@ var message string
 
 var tag string
 
+var createAITag bool
+
+var createProjectID int
+
+var createTaskID int
+
 func init() {
 	createCmd.Flags().StringVarP(&message, "message", "m", "", "Content of the note")
 	createCmd.Flags().StringVarP(&tag, "tag", "t", "", "Tag of the note")
+	createCmd.Flags().BoolVar(&createAITag, "ai-tag", false, "Summarize the note and suggest tags with AI")
+	createCmd.Flags().IntVar(&createProjectID, "project", 0, "Link the note to a project")
+	createCmd.Flags().IntVar(&createTaskID, "task", 0, "Link the note to a task")
 }
 
 var createCmd = &cobra.Command{
@@ -28,16 +37,26 @@ in two ways:
 1. Use the --message flag to provide content directly
 2. If no message is provided, your default editor will open for interactive content editing
 3. Use the --tag flag to provide a tag for the note
+4. Use the --ai-tag flag to get an AI summary and suggested tags (or enable it
+   for every note with 'snip ai config --auto-tag')
+5. Use --project and --task to attach the note to the work it describes
+   (more links later with 'snip link note <id> task <id>')
 
 Examples:
   snip create "My Daily Notes"                    # Opens editor for content
   snip create "Quick Note" --message "Hello!"     # User provided message
   snip create Meeting Notes                       # Opens editor for content
-  snip create TODO --tag "shopping"               # User provided tag`,
+  snip create TODO --tag "shopping"               # User provided tag
+  snip create "Deploy Steps" --ai-tag             # AI summary and tag suggestions
+  snip create "Kickoff minutes" --project 3 --task 12`,
 	Args: cobra.MinimumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		if err := executeWithHandler(func(h handler.Handler) error {
 			validator := validation.NewValidator()
+			if createAITag {
+				h.SetAutoTag(true)
+			}
+			h.SetLinkContext(createProjectID, createTaskID)
 			return h.CreateNote(strings.Join(args, " "), validator.CheckString(message), validator.CheckString(tag))
 		}); err != nil {
 			fmt.Printf("Error: %v\n", err)
@@ -71,6 +71,8 @@ func init() {
 	dbAnalysisRunCmd.Flags().StringVarP(&dbAnalysisTitle, "title", "t", "", "Título da análise (opcional)")
 	dbAnalysisRunCmd.Flags().StringVarP(&dbAnalysisExport, "export", "e", "", "Exportar resultado para markdown (nome do arquivo)")
 
+	addAIFlags(dbAnalysisRunCmd)
+
 	// Adicionar comandos ao root
 	rootCmd.AddCommand(dbAnalysisCmd)
 	dbAnalysisCmd.AddCommand(dbAnalysisCreateCmd)
@@ -20,6 +20,7 @@ func init() {
 	dbChartCmd.Flags().StringVarP(&dbChartChartType, "type", "t", "", "Tipo de gráfico (line, bar, pie, area, table, ascii, html)")
 	dbChartCmd.Flags().StringVarP(&dbChartOutputFile, "output", "o", "", "Arquivo de saída (para HTML)")
 
+	addAIFlags(dbChartCmd)
 	rootCmd.AddCommand(dbChartCmd)
 }
 
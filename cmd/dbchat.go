@@ -6,6 +6,7 @@ import (
 	"os"
 	"strings"
 
+	"github.com/snip/internal/ai"
 	"github.com/snip/internal/dbchat"
 	"github.com/snip/internal/dbconnection"
 	"github.com/snip/internal/dbanalysis"
@@ -13,15 +14,17 @@ import (
 )
 
 var (
-	dbChatDBType      string
-	dbChatHost        string
-	dbChatPort        int
-	dbChatDatabase    string
-	dbChatUsername    string
-	dbChatPassword    string
-	dbChatIsRemote    bool
-	dbChatJDBCURL     string
-	dbChatConnString  string
+	dbChatDBType     string
+	dbChatHost       string
+	dbChatPort       int
+	dbChatDatabase   string
+	dbChatUsername   string
+	dbChatPassword   string
+	dbChatIsRemote   bool
+	dbChatJDBCURL    string
+	dbChatConnString string
+	dbChatMaxSteps   int
+	dbChatNoTools    bool
 )
 
 func init() {
@@ -35,6 +38,8 @@ func init() {
 	dbChatCmd.Flags().StringVar(&dbChatJDBCURL, "jdbc-url", "", "URL JDBC completa")
 	dbChatCmd.Flags().StringVar(&dbChatConnString, "conn-string", "", "String de conexão completa")
 
+	addAgentFlags(dbChatCmd)
+	addAIFlags(dbChatCmd)
 	rootCmd.AddCommand(dbChatCmd)
 }
 
@@ -43,12 +48,17 @@ var dbChatCmd = &cobra.Command{
 	Short: "Chat interativo com banco de dados usando IA",
 	Long: `Inicia uma sessão de chat interativa com um banco de dados usando IA.
 
-A IA pode gerar queries SQL baseadas em suas perguntas e interpretar os resultados.
+A IA investiga o banco em vários passos usando ferramentas (list_tables,
+describe_table, run_readonly_query, explain_query e get_analysis) e mostra
+cada chamada antes da resposta. Apenas queries de leitura são executadas.
+Use --max-steps para limitar as chamadas por pergunta e --no-tools para o
+modo antigo de query única.
 
 Exemplos:
   snip db-chat --db-type postgresql --host localhost --port 5432 --database mydb --username user --password pass
   snip db-chat --db-type mysql --jdbc-url "jdbc:mysql://localhost:3306/db"
   snip db-chat --db-type sqlserver --conn-string "Server=localhost;Database=AdventureWorks;User Id=sa;Password=senha;"
+  snip db-chat --db-type postgresql --database mydb --max-steps 12
 
 Para sair do chat, digite 'exit', 'quit' ou 'sair'.`,
 	Run: func(cmd *cobra.Command, args []string) {
@@ -92,9 +102,13 @@ Para sair do chat, digite 'exit', 'quit' ou 'sair'.`,
 		}
 		defer chat.Close()
 
+		if !dbChatNoTools {
+			chat.UseTools(agentMaxSteps(), lookupAnalysis, printAgentStep)
+		}
+
 		fmt.Println("🤖 Chat com Banco de Dados iniciado!")
 		fmt.Println("Digite suas perguntas ou solicitações. A IA executará queries automaticamente e responderá com os resultados.")
-		fmt.Println("Digite 'exit', 'quit' ou 'sair' para sair.\n")
+		fmt.Print("Digite 'exit', 'quit' ou 'sair' para sair.\n\n")
 
 		scanner := bufio.NewScanner(os.Stdin)
 		for {
@@ -129,3 +143,41 @@ Para sair do chat, digite 'exit', 'quit' ou 'sair'.`,
 	},
 }
 
+// addAgentFlags adiciona as opções do modo agente aos comandos de chat
+func addAgentFlags(cmds ...*cobra.Command) {
+	for _, c := range cmds {
+		c.Flags().IntVar(&dbChatMaxSteps, "max-steps", 0, "Limite de chamadas de ferramentas por pergunta (padrão: snip ai config --max-steps)")
+		c.Flags().BoolVar(&dbChatNoTools, "no-tools", false, "Desativar ferramentas (gera uma única query por pergunta)")
+	}
+}
+
+func agentMaxSteps() int {
+	if dbChatMaxSteps > 0 {
+		return dbChatMaxSteps
+	}
+	return ai.GetMaxAgentSteps()
+}
+
+// printAgentStep mostra o trace de cada ferramenta executada pela IA
+func printAgentStep(step ai.AgentStep) {
+	args := step.Arguments
+	if args == "" || args == "{}" {
+		args = ""
+	}
+	fmt.Printf("\n   🔧 [%d] %s %s\n", step.Step, step.Name, args)
+
+	if step.Err != nil {
+		fmt.Printf("      ↳ ❌ %v\n", step.Err)
+		return
+	}
+
+	lines := strings.Split(strings.TrimSpace(step.Result), "\n")
+	summary := lines[0]
+	if len(summary) > 80 {
+		summary = summary[:77] + "..."
+	}
+	if len(lines) > 1 {
+		summary = fmt.Sprintf("%s (%d linhas)", summary, len(lines))
+	}
+	fmt.Printf("      ↳ %s\n", summary)
+}
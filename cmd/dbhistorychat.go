@@ -12,6 +12,8 @@ import (
 )
 
 func init() {
+	addAgentFlags(dbHistoryChatCmd)
+	addAIFlags(dbHistoryChatCmd)
 	rootCmd.AddCommand(dbHistoryChatCmd)
 }
 
@@ -20,7 +22,11 @@ var dbHistoryChatCmd = &cobra.Command{
 	Short: "Chat interativo com o histórico de análises usando IA",
 	Long: `Inicia uma sessão de chat interativa com o banco SQLite que armazena todas as análises de bancos de dados.
 
-A IA pode gerar queries SQL baseadas em suas perguntas e interpretar os resultados para:
+A IA investiga o histórico em vários passos usando ferramentas (consultas de
+leitura, get_analysis, describe_table) e mostra cada chamada. Use --max-steps
+para limitar as chamadas por pergunta e --no-tools para o modo de query única.
+
+A IA pode:
 - Listar análises por tipo de banco, tipo de análise, data, etc.
 - Comparar análises de diferentes datas para ver evolução
 - Identificar problemas e insights das análises
@@ -54,10 +60,14 @@ Para sair do chat, digite 'exit', 'quit' ou 'sair'.`,
 		}
 		defer chat.Close()
 
+		if !dbChatNoTools {
+			chat.UseTools(agentMaxSteps(), lookupAnalysis, printAgentStep)
+		}
+
 		fmt.Println("🤖 Chat com Histórico de Análises iniciado!")
 		fmt.Println("Digite suas perguntas sobre as análises armazenadas.")
 		fmt.Println("A IA executará queries automaticamente e responderá com os resultados.")
-		fmt.Println("Digite 'exit', 'quit' ou 'sair' para sair.\n")
+		fmt.Print("Digite 'exit', 'quit' ou 'sair' para sair.\n\n")
 
 		scanner := bufio.NewScanner(os.Stdin)
 		for {
@@ -18,6 +18,7 @@ func init() {
 	dbMaintenanceCmd.Flags().IntVarP(&dbMaintenanceAnalysisID, "analysis-id", "a", 0, "ID da análise para gerar plano")
 	dbMaintenanceCmd.Flags().StringVarP(&dbMaintenanceOutputFile, "output", "o", "", "Arquivo de saída (opcional)")
 
+	addAIFlags(dbMaintenanceCmd)
 	rootCmd.AddCommand(dbMaintenanceCmd)
 }
 
@@ -11,12 +11,15 @@ import (
 var (
 	dbProjectAnalysisID int
 	dbProjectIncident   string
+	dbProjectSave       bool
 )
 
 func init() {
 	dbProjectCmd.Flags().IntVarP(&dbProjectAnalysisID, "analysis-id", "a", 0, "ID da análise para transformar em projeto")
 	dbProjectCmd.Flags().StringVarP(&dbProjectIncident, "incident", "i", "", "Descrição do incidente (opcional)")
+	dbProjectCmd.Flags().BoolVar(&dbProjectSave, "save", false, "Criar o projeto e as tarefas (com as estimativas) no sistema")
 
+	addAIFlags(dbProjectCmd)
 	rootCmd.AddCommand(dbProjectCmd)
 }
 
@@ -34,7 +37,8 @@ A IA cria:
 Exemplos:
   snip db-project --analysis-id 1
   snip db-project --analysis-id 1 --incident "Banco de dados lento durante picos"
-  snip db-project --incident "Erro de conexão" --analysis-id 2`,
+  snip db-project --incident "Erro de conexão" --analysis-id 2
+  snip db-project --analysis-id 1 --save    # Cria o projeto e as tarefas`,
 	Run: func(cmd *cobra.Command, args []string) {
 		if dbProjectAnalysisID == 0 && dbProjectIncident == "" {
 			fmt.Println("Erro: --analysis-id ou --incident é obrigatório")
@@ -100,8 +104,16 @@ Exemplos:
 		// Exibir projeto
 		fmt.Println(project.FormatProject())
 
-		// Perguntar se deseja criar no sistema
-		fmt.Println("\n💡 Dica: Use 'snip project create' para criar este projeto no sistema")
+		if dbProjectSave {
+			if err := executeWithProjectHandler(func(h handler.ProjectHandler) error {
+				return h.SaveGeneratedProject(project)
+			}); err != nil {
+				fmt.Printf("Erro: %v\n", err)
+			}
+			return
+		}
+
+		fmt.Println("\n💡 Dica: Use --save para criar este projeto e as tarefas no sistema")
 	},
 }
 
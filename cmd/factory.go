@@ -3,8 +3,11 @@ package cmd
 import (
 	"fmt"
 	"sync"
+	"time"
 
+	"github.com/snip/internal/ai"
 	"github.com/snip/internal/database"
+	"github.com/snip/internal/dbanalysis"
 	"github.com/snip/internal/handler"
 	"github.com/snip/internal/repository"
 )
@@ -16,7 +19,15 @@ var (
 	globalTaskRepo          repository.TaskRepository
 	globalChecklistRepo     repository.ChecklistRepository
 	globalChecklistItemRepo repository.ChecklistItemRepository
+	globalChecklistRunRepo  repository.ChecklistRunRepository
 	globalDBAnalysisRepo    repository.DBAnalysisRepository
+	globalAIUsageRepo       repository.AIUsageRepository
+	globalAICacheRepo       repository.AICacheRepository
+	globalAIChatRepo        repository.AIChatRepository
+	globalSnippetRepo       repository.SnippetRepository
+	globalStudyRepo         repository.StudyRepository
+	globalTimeEntryRepo     repository.TimeEntryRepository
+	globalNoteLinkRepo      repository.NoteLinkRepository
 	repoOnce                sync.Once
 )
 
@@ -52,7 +63,39 @@ func getRepository() (repository.NoteRepository, repository.TagRepository, error
 		if err != nil {
 			return
 		}
+		globalChecklistRunRepo, err = repository.NewChecklistRunRepository(db)
+		if err != nil {
+			return
+		}
 		globalDBAnalysisRepo, err = repository.NewDBAnalysisRepository(db)
+		if err != nil {
+			return
+		}
+		globalAIUsageRepo, err = repository.NewAIUsageRepository(db)
+		if err != nil {
+			return
+		}
+		globalAICacheRepo, err = repository.NewAICacheRepository(db)
+		if err != nil {
+			return
+		}
+		globalAIChatRepo, err = repository.NewAIChatRepository(db)
+		if err != nil {
+			return
+		}
+		globalSnippetRepo, err = repository.NewSnippetRepository(db)
+		if err != nil {
+			return
+		}
+		globalStudyRepo, err = repository.NewStudyRepository(db)
+		if err != nil {
+			return
+		}
+		globalTimeEntryRepo, err = repository.NewTimeEntryRepository(db)
+		if err != nil {
+			return
+		}
+		globalNoteLinkRepo, err = repository.NewNoteLinkRepository(db)
 	})
 	return globalNoteRepo, globalTagRepo, err
 }
@@ -63,7 +106,7 @@ func setupHandler() (handler.Handler, error) {
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
 
-	h := handler.NewHandler(noteRepo, tagRepo)
+	h := handler.NewHandler(noteRepo, tagRepo, globalNoteLinkRepo)
 
 	return h, nil
 }
@@ -74,7 +117,7 @@ func setupProjectHandler() (handler.ProjectHandler, error) {
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
 
-	h := handler.NewProjectHandler(globalProjectRepo, globalTaskRepo)
+	h := handler.NewProjectHandler(globalProjectRepo, globalTaskRepo, globalNoteLinkRepo)
 	return h, nil
 }
 
@@ -84,7 +127,7 @@ func setupTaskHandler() (handler.TaskHandler, error) {
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
 
-	h := handler.NewTaskHandler(globalTaskRepo, globalProjectRepo)
+	h := handler.NewTaskHandler(globalTaskRepo, globalProjectRepo, globalNoteLinkRepo)
 	return h, nil
 }
 
@@ -94,7 +137,7 @@ func setupChecklistHandler() (handler.ChecklistHandler, error) {
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
 
-	h := handler.NewChecklistHandler(globalChecklistRepo, globalChecklistItemRepo)
+	h := handler.NewChecklistHandler(globalChecklistRepo, globalChecklistItemRepo, globalChecklistRunRepo)
 	return h, nil
 }
 
@@ -156,3 +199,186 @@ func executeWithDBAnalysisHandler(fn func(handler.DBAnalysisHandler) error) erro
 
 	return fn(h)
 }
+
+func setupAIUsageHandler() (handler.AIUsageHandler, error) {
+	_, _, err := getRepository()
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+
+	h := handler.NewAIUsageHandler(globalAIUsageRepo)
+	return h, nil
+}
+
+func executeWithAIUsageHandler(fn func(handler.AIUsageHandler) error) error {
+	h, err := setupAIUsageHandler()
+	if err != nil {
+		return fmt.Errorf("failed to setup AI usage handler: %w", err)
+	}
+
+	return fn(h)
+}
+
+func setupAICacheHandler() (handler.AICacheHandler, error) {
+	_, _, err := getRepository()
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+
+	h := handler.NewAICacheHandler(globalAICacheRepo)
+	return h, nil
+}
+
+func executeWithAICacheHandler(fn func(handler.AICacheHandler) error) error {
+	h, err := setupAICacheHandler()
+	if err != nil {
+		return fmt.Errorf("failed to setup AI cache handler: %w", err)
+	}
+
+	return fn(h)
+}
+
+func setupAIChatHandler() (handler.AIChatHandler, error) {
+	noteRepo, _, err := getRepository()
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+
+	h := handler.NewAIChatHandler(globalAIChatRepo, noteRepo)
+	return h, nil
+}
+
+func executeWithAIChatHandler(fn func(handler.AIChatHandler) error) error {
+	h, err := setupAIChatHandler()
+	if err != nil {
+		return fmt.Errorf("failed to setup AI chat handler: %w", err)
+	}
+
+	return fn(h)
+}
+
+func setupSnippetHandler() (handler.SnippetHandler, error) {
+	_, _, err := getRepository()
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+
+	h := handler.NewSnippetHandler(globalSnippetRepo)
+	return h, nil
+}
+
+func executeWithSnippetHandler(fn func(handler.SnippetHandler) error) error {
+	h, err := setupSnippetHandler()
+	if err != nil {
+		return fmt.Errorf("failed to setup snippet handler: %w", err)
+	}
+
+	return fn(h)
+}
+
+func setupStudyHandler() (handler.StudyHandler, error) {
+	noteRepo, tagRepo, err := getRepository()
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+
+	h := handler.NewStudyHandler(globalStudyRepo, noteRepo, tagRepo)
+	return h, nil
+}
+
+func executeWithStudyHandler(fn func(handler.StudyHandler) error) error {
+	h, err := setupStudyHandler()
+	if err != nil {
+		return fmt.Errorf("failed to setup study handler: %w", err)
+	}
+
+	return fn(h)
+}
+
+func setupTimeHandler() (handler.TimeHandler, error) {
+	_, tagRepo, err := getRepository()
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+
+	h := handler.NewTimeHandler(globalTimeEntryRepo, globalTaskRepo, globalProjectRepo, tagRepo)
+	return h, nil
+}
+
+func executeWithTimeHandler(fn func(handler.TimeHandler) error) error {
+	h, err := setupTimeHandler()
+	if err != nil {
+		return fmt.Errorf("failed to setup time handler: %w", err)
+	}
+
+	return fn(h)
+}
+
+func setupBundleHandler() (handler.BundleHandler, error) {
+	_, _, err := getRepository()
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+
+	h := handler.NewBundleHandler(globalProjectRepo, globalTaskRepo, globalChecklistRepo, globalChecklistItemRepo)
+	return h, nil
+}
+
+func executeWithBundleHandler(fn func(handler.BundleHandler) error) error {
+	h, err := setupBundleHandler()
+	if err != nil {
+		return fmt.Errorf("failed to setup bundle handler: %w", err)
+	}
+
+	return fn(h)
+}
+
+// lazyAIStore implementa ai.UsageStore e ai.CacheStore, abrindo o banco
+// apenas quando uma chamada de IA é de fato feita
+type lazyAIStore struct{}
+
+func (lazyAIStore) connect() error {
+	if _, _, err := getRepository(); err != nil {
+		return err
+	}
+	if globalAIUsageRepo == nil || globalAICacheRepo == nil {
+		return fmt.Errorf("repositórios de IA indisponíveis")
+	}
+	return nil
+}
+
+func (s lazyAIStore) Record(record *ai.UsageRecord) error {
+	if err := s.connect(); err != nil {
+		return err
+	}
+	return globalAIUsageRepo.Record(record)
+}
+
+func (s lazyAIStore) Totals(since time.Time) (*ai.UsageSummary, error) {
+	if err := s.connect(); err != nil {
+		return nil, err
+	}
+	return globalAIUsageRepo.Totals(since)
+}
+
+func (s lazyAIStore) Get(key string) (string, bool, error) {
+	if err := s.connect(); err != nil {
+		return "", false, err
+	}
+	return globalAICacheRepo.Get(key)
+}
+
+func (s lazyAIStore) Put(entry *ai.CacheEntry) error {
+	if err := s.connect(); err != nil {
+		return err
+	}
+	return globalAICacheRepo.Put(entry)
+}
+
+// lookupAnalysis busca uma análise salva (ferramenta get_analysis do db-chat)
+func lookupAnalysis(id int) (*dbanalysis.DBAnalysis, error) {
+	if _, _, err := getRepository(); err != nil {
+		return nil, err
+	}
+	return globalDBAnalysisRepo.GetByID(id)
+}
@@ -8,6 +8,14 @@ import (
 	"github.com/spf13/cobra"
 )
 
+var findProjectID int
+var findTaskID int
+
+func init() {
+	findCmd.Flags().IntVar(&findProjectID, "project", 0, "Only notes linked to a project (or to one of its tasks)")
+	findCmd.Flags().IntVar(&findTaskID, "task", 0, "Only notes linked to a task")
+}
+
 var findCmd = &cobra.Command{
 	Use:   "find [text]",
 	Short: "Search for notes containing specific text in title or content",
@@ -22,11 +30,13 @@ Examples:
   snip find "project ideas"    # Find notes with "project ideas"
   snip find TODO urgent        # Find notes containing "TODO urgent"
   snip find golang             # Find notes about golang
+  snip find rollback --project 3  # Only notes linked to project 3
 
 Tip: Use quotes for exact phrases, or separate words for broader matching.`,
 	Args: cobra.MinimumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		if err := executeWithHandler(func(h handler.Handler) error {
+			h.SetLinkContext(findProjectID, findTaskID)
 			return h.FindNotes(strings.Join(args, " "))
 		}); err != nil {
 			fmt.Printf("Error: %v\n", err)
@@ -0,0 +1,229 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/snip/internal/httpclient"
+	"github.com/spf13/cobra"
+)
+
+var (
+	httpIntegration   string
+	httpProxy         string
+	httpNoProxy       string
+	httpCAFiles       []string
+	httpClearCA       bool
+	httpClientCert    string
+	httpClientKey     string
+	httpTLSMinVersion string
+	httpTimeout       string
+	httpShow          bool
+
+	httpCheckIntegration string
+)
+
+func init() {
+	httpConfigCmd.Flags().StringVarP(&httpIntegration, "integration", "i", "", "Aplicar apenas a uma integração (ai, jira, confluence)")
+	httpConfigCmd.Flags().StringVar(&httpProxy, "proxy", "", "URL do proxy (ex: http://proxy.empresa:3128) ou \"direct\" para não usar proxy")
+	httpConfigCmd.Flags().StringVar(&httpNoProxy, "no-proxy", "", "Hosts, domínios e CIDRs sem proxy (ex: localhost,.empresa.com,10.0.0.0/8)")
+	httpConfigCmd.Flags().StringSliceVar(&httpCAFiles, "ca-file", nil, "Bundle PEM de CA adicional (pode repetir)")
+	httpConfigCmd.Flags().BoolVar(&httpClearCA, "clear-ca", false, "Remover as CAs adicionais configuradas")
+	httpConfigCmd.Flags().StringVar(&httpClientCert, "client-cert", "", "Certificado PEM de cliente (mTLS)")
+	httpConfigCmd.Flags().StringVar(&httpClientKey, "client-key", "", "Chave PEM do certificado de cliente (mTLS)")
+	httpConfigCmd.Flags().StringVar(&httpTLSMinVersion, "tls-min-version", "", "Versão mínima de TLS (1.0, 1.1, 1.2, 1.3)")
+	httpConfigCmd.Flags().StringVar(&httpTimeout, "timeout", "", "Timeout das requisições (ex: 30s, 2m)")
+	httpConfigCmd.Flags().BoolVar(&httpShow, "show", false, "Mostrar configuração atual")
+
+	httpCheckCmd.Flags().StringVarP(&httpCheckIntegration, "integration", "i", string(httpclient.IntegrationAI), "Integração cuja configuração será usada (ai, jira, confluence)")
+
+	rootCmd.AddCommand(httpCmd)
+	httpCmd.AddCommand(httpConfigCmd)
+	httpCmd.AddCommand(httpCheckCmd)
+}
+
+var httpCmd = &cobra.Command{
+	Use:   "http",
+	Short: "Configurar a rede das integrações (proxy, CAs, mTLS, timeouts)",
+	Long: `Configura o transporte HTTP usado pela IA, Jira e Confluence.
+
+Sem configuração, HTTPS_PROXY, HTTP_PROXY e NO_PROXY do ambiente são respeitados.
+Ajustes com --integration valem apenas para aquela integração e sobrepõem os globais.
+
+Exemplos:
+  snip http config --proxy http://proxy.empresa:3128 --no-proxy "localhost,.empresa.com"
+  snip http config --ca-file ~/certs/empresa-root.pem --tls-min-version 1.2
+  snip http config --integration jira --client-cert ~/certs/me.pem --client-key ~/certs/me-key.pem
+  snip http config --integration ai --timeout 2m
+  snip http config --show
+  snip http check https://api.openai.com --integration ai`,
+}
+
+var httpConfigCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Configurar proxy, CAs, certificados de cliente, TLS e timeouts",
+	Run: func(cmd *cobra.Command, args []string) {
+		var err error
+		if httpShow {
+			err = showHTTPConfig()
+		} else {
+			err = configureHTTP(cmd)
+		}
+		if err != nil {
+			fmt.Printf("Erro: %v\n", err)
+		}
+	},
+}
+
+var httpCheckCmd = &cobra.Command{
+	Use:   "check [url]",
+	Short: "Testar a conexão com uma URL usando a configuração de rede",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := checkHTTP(args[0]); err != nil {
+			fmt.Printf("Erro: %v\n", err)
+		}
+	},
+}
+
+func configureHTTP(cmd *cobra.Command) error {
+	integration := httpclient.Integration(httpIntegration)
+	if integration != "" && !httpclient.IsValidIntegration(integration) {
+		return fmt.Errorf("integração inválida: %s (use: ai, jira, confluence)", httpIntegration)
+	}
+
+	config, err := httpclient.LoadConfig()
+	if err != nil {
+		return err
+	}
+
+	settings := &config.Settings
+	if integration != "" {
+		if config.Integrations == nil {
+			config.Integrations = make(map[httpclient.Integration]*httpclient.Settings)
+		}
+		if config.Integrations[integration] == nil {
+			config.Integrations[integration] = &httpclient.Settings{}
+		}
+		settings = config.Integrations[integration]
+	}
+
+	changed := false
+	set := func(flag string, target *string, value string) {
+		if cmd.Flags().Changed(flag) {
+			*target = value
+			changed = true
+		}
+	}
+	set("proxy", &settings.Proxy, httpProxy)
+	set("no-proxy", &settings.NoProxy, httpNoProxy)
+	set("client-cert", &settings.ClientCert, httpClientCert)
+	set("client-key", &settings.ClientKey, httpClientKey)
+	set("tls-min-version", &settings.TLSMinVersion, httpTLSMinVersion)
+	set("timeout", &settings.Timeout, httpTimeout)
+	if httpClearCA {
+		settings.CAFiles = nil
+		changed = true
+	}
+	if len(httpCAFiles) > 0 {
+		settings.CAFiles = append(settings.CAFiles, httpCAFiles...)
+		changed = true
+	}
+
+	if !changed {
+		return showHTTPConfig()
+	}
+
+	// Valida todas as integrações antes de salvar (arquivos, timeout, versão TLS)
+	for _, i := range httpclient.GetIntegrations() {
+		if _, err := httpclient.NewWithConfig(config, i); err != nil {
+			return err
+		}
+	}
+
+	if err := httpclient.SaveConfig(config); err != nil {
+		return err
+	}
+
+	fmt.Println("✓ Configuração de rede salva com sucesso!")
+	return showHTTPConfig()
+}
+
+func showHTTPConfig() error {
+	config, err := httpclient.LoadConfig()
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("🌐 Configuração de Rede:")
+	printHTTPSettings("  ", config.Settings, false)
+
+	for _, integration := range httpclient.GetIntegrations() {
+		if config.Integrations[integration] == nil {
+			continue
+		}
+		fmt.Printf("\n  %s:\n", integration)
+		printHTTPSettings("    ", *config.Integrations[integration], true)
+	}
+	return nil
+}
+
+// printHTTPSettings mostra as configurações; em uma integração (inherited)
+// os campos vazios herdam a configuração global
+func printHTTPSettings(indent string, s httpclient.Settings, inherited bool) {
+	value := func(v, empty string) string {
+		if v == "" {
+			if inherited {
+				return "(global)"
+			}
+			return empty
+		}
+		return v
+	}
+	fmt.Printf("%sProxy: %s\n", indent, value(s.Proxy, "(ambiente: HTTPS_PROXY/HTTP_PROXY)"))
+	fmt.Printf("%sSem proxy: %s\n", indent, value(s.NoProxy, "(ambiente: NO_PROXY)"))
+	fmt.Printf("%sCAs adicionais: %s\n", indent, value(strings.Join(s.CAFiles, ", "), "(nenhuma)"))
+	fmt.Printf("%sCertificado de cliente: %s\n", indent, value(s.ClientCert, "(nenhum)"))
+	fmt.Printf("%sTLS mínimo: %s\n", indent, value(s.TLSMinVersion, "1.2"))
+	fmt.Printf("%sTimeout: %s\n", indent, value(s.Timeout, httpclient.DefaultTimeout))
+}
+
+func checkHTTP(url string) error {
+	integration := httpclient.Integration(httpCheckIntegration)
+	if !httpclient.IsValidIntegration(integration) {
+		return fmt.Errorf("integração inválida: %s (use: ai, jira, confluence)", httpCheckIntegration)
+	}
+
+	client, err := httpclient.New(integration)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return fmt.Errorf("URL inválida: %w", err)
+	}
+
+	proxy := "(direto)"
+	if transport, ok := client.Transport.(*http.Transport); ok && transport.Proxy != nil {
+		if proxyURL, err := transport.Proxy(req); err == nil && proxyURL != nil {
+			proxy = proxyURL.Redacted()
+		}
+	}
+	fmt.Printf("🔌 %s via %s (integração: %s)\n", url, proxy, integration)
+
+	start := time.Now()
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("falha na conexão: %w", err)
+	}
+	defer resp.Body.Close()
+
+	fmt.Printf("✓ HTTP %d em %s\n", resp.StatusCode, time.Since(start).Round(time.Millisecond))
+	if resp.TLS != nil && len(resp.TLS.PeerCertificates) > 0 {
+		fmt.Printf("  Certificado: %s (emitido por %s)\n", resp.TLS.PeerCertificates[0].Subject.CommonName, resp.TLS.PeerCertificates[0].Issuer.CommonName)
+	}
+	return nil
+}
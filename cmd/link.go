@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/snip/internal/handler"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	rootCmd.AddCommand(linkCmd)
+	rootCmd.AddCommand(unlinkCmd)
+}
+
+// linkArgs valida "note <id> project|task <id>"
+func linkArgs(cmd *cobra.Command, args []string) error {
+	if err := cobra.ExactArgs(4)(cmd, args); err != nil {
+		return err
+	}
+	if args[0] != "note" {
+		return fmt.Errorf("expected 'note <id> project|task <id>', got %q", args[0])
+	}
+	return nil
+}
+
+var linkCmd = &cobra.Command{
+	Use:   "link note [id] project|task [id]",
+	Short: "Link a note to a project or task",
+	Long: `Attach a note (design notes, meeting minutes, runbooks) to the work it
+describes. A note can be linked to any number of projects and tasks.
+
+Linked notes are shown in 'snip project show' and 'snip task show', and
+'snip list' and 'snip find' accept --project and --task to filter by them.
+
+Examples:
+  snip link note 5 task 12
+  snip link note 5 project 3`,
+	Args: linkArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := executeWithHandler(func(h handler.Handler) error {
+			return h.LinkNote(args[1], args[2], args[3])
+		}); err != nil {
+			fmt.Printf("Error: %v\n", err)
+		}
+	},
+}
+
+var unlinkCmd = &cobra.Command{
+	Use:   "unlink note [id] project|task [id]",
+	Short: "Remove a link between a note and a project or task",
+	Long: `Remove a link created with 'snip link' or 'snip create --project/--task'.
+The note itself is kept.
+
+Examples:
+  snip unlink note 5 task 12`,
+	Args: linkArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := executeWithHandler(func(h handler.Handler) error {
+			return h.UnlinkNote(args[1], args[2], args[3])
+		}); err != nil {
+			fmt.Printf("Error: %v\n", err)
+		}
+	},
+}
@@ -11,11 +11,15 @@ import (
 var isAsc bool
 var verbose bool
 var listTag string
+var listProjectID int
+var listTaskID int
 
 func init() {
 	listCmd.Flags().BoolVarP(&isAsc, "asc", "a", false, "List notes in chronological order (oldest first)")
 	listCmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "Show more information about the notes")
 	listCmd.Flags().StringVarP(&listTag, "tag", "t", "", "List notes by tag")
+	listCmd.Flags().IntVar(&listProjectID, "project", 0, "List notes linked to a project (or to one of its tasks)")
+	listCmd.Flags().IntVar(&listTaskID, "task", 0, "List notes linked to a task")
 }
 
 var listCmd = &cobra.Command{
@@ -27,11 +31,14 @@ var listCmd = &cobra.Command{
 By default, notes are displayed with newest first (descending order by creation date).
 You can control the output format and sorting to match your workflow preferences.
 
-You can also list notes by tag using the --tag flag.
+You can also list notes by tag using the --tag flag, or by the project or task
+they are linked to with --project and --task.
 
 Flags:
   --asc, -a      Sort chronologically (oldest first)
   --verbose, -v  Show detailed information including timestamps and IDs
+  --project      Only notes linked to the project or to one of its tasks
+  --task         Only notes linked to the task
 
 Examples:
   snip list                    # Show newest notes first (default)
@@ -39,10 +46,12 @@ Examples:
   snip list --asc              # Show oldest notes first
   snip list -v                 # Show detailed note information
   snip list --asc --verbose    # Oldest first with full details
-  snip list --tag "tag"        # List notes by tag`,
+  snip list --tag "tag"        # List notes by tag
+  snip list --project 3        # Design notes and minutes of project 3`,
 	Run: func(cmd *cobra.Command, args []string) {
 		validator := validation.NewValidator()
 		if err := executeWithHandler(func(h handler.Handler) error {
+			h.SetLinkContext(listProjectID, listTaskID)
 			return h.ListNotes(isAsc, verbose, validator.CheckString(listTag))
 		}); err != nil {
 			fmt.Printf("Error: %v\n", err)
@@ -117,12 +117,72 @@ var projectAICreateCmd = &cobra.Command{
 	},
 }
 
+var projectCriticalPathCmd = &cobra.Command{
+	Use:   "critical-path [id]",
+	Short: "Mostrar o caminho crítico do projeto",
+	Long: `Calcula a cadeia de dependências mais longa do projeto (snip task depend),
+somando as estimativas das tarefas abertas (snip task create --estimate 2h).
+As datas são projetadas a partir de agora com dias de 8h, e as tarefas que
+terminariam após o prazo (--due) são destacadas.
+
+Exemplo:
+  snip project critical-path 3`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := executeWithProjectHandler(func(h handler.ProjectHandler) error {
+			id, err := strconv.Atoi(args[0])
+			if err != nil {
+				return fmt.Errorf("ID inválido: %s", args[0])
+			}
+			return h.ShowCriticalPath(id)
+		}); err != nil {
+			fmt.Printf("Erro: %v\n", err)
+		}
+	},
+}
+
+var projectGanttOutput string
+
+var projectGanttCmd = &cobra.Command{
+	Use:   "gantt [id]",
+	Short: "Mostrar o gráfico de Gantt do projeto",
+	Long: `Desenha o cronograma do projeto a partir das datas de criação, prazos,
+dependências e estimativas das tarefas (dias de 8h). Tarefas abertas que já
+deveriam ter terminado se estendem até hoje e empurram as dependentes; o
+caminho crítico e as tarefas que terminariam após o prazo são destacados.
+
+Com --output, grava um gráfico HTML autocontido (ou SVG, se o arquivo
+terminar em .svg) para apresentar o plano.
+
+Exemplos:
+  snip project gantt 3
+  snip project gantt 3 --output plano-upgrade.html
+  snip project gantt 3 -o plano-upgrade.svg`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := executeWithProjectHandler(func(h handler.ProjectHandler) error {
+			id, err := strconv.Atoi(args[0])
+			if err != nil {
+				return fmt.Errorf("ID inválido: %s", args[0])
+			}
+			return h.ShowGantt(id, projectGanttOutput)
+		}); err != nil {
+			fmt.Printf("Erro: %v\n", err)
+		}
+	},
+}
+
 func init() {
+	projectGanttCmd.Flags().StringVarP(&projectGanttOutput, "output", "o", "", "Arquivo HTML ou SVG de saída")
+
 	projectCmd.AddCommand(projectCreateCmd)
 	projectCmd.AddCommand(projectListCmd)
 	projectCmd.AddCommand(projectShowCmd)
 	projectCmd.AddCommand(projectUpdateCmd)
 	projectCmd.AddCommand(projectDeleteCmd)
 	projectCmd.AddCommand(projectAICreateCmd)
+	projectCmd.AddCommand(projectCriticalPathCmd)
+	projectCmd.AddCommand(projectGanttCmd)
+	addAIFlags(projectAICreateCmd)
 }
 
@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/snip/internal/bundle"
+	"github.com/snip/internal/handler"
+	"github.com/spf13/cobra"
+)
+
+var bundleFormat string
+var bundleOutput string
+var bundleTemplate bool
+var bundleName string
+var bundleStart string
+var bundleDryRun bool
+
+func init() {
+	projectExportCmd.Flags().StringVarP(&bundleFormat, "format", "f", bundle.FormatYAML, "Formato do pacote (yaml ou json)")
+	projectExportCmd.Flags().StringVarP(&bundleOutput, "output", "o", "", "Arquivo de saída (padrão: ~/.snip/export)")
+	projectExportCmd.Flags().BoolVar(&bundleTemplate, "template", false, "Exportar como modelo (tarefas pendentes, itens desmarcados)")
+
+	projectImportCmd.Flags().StringVarP(&bundleName, "name", "n", "", "Nome do novo projeto (padrão: o do pacote)")
+	projectImportCmd.Flags().StringVar(&bundleStart, "start", "", "Desloca os prazos para que o primeiro caia nesta data (YYYY-MM-DD)")
+	projectImportCmd.Flags().BoolVar(&bundleTemplate, "template", false, "Importar como modelo (zera status e itens concluídos)")
+	projectImportCmd.Flags().BoolVar(&bundleDryRun, "dry-run", false, "Apenas validar e mostrar o que seria criado")
+
+	projectCmd.AddCommand(projectExportCmd)
+	projectCmd.AddCommand(projectImportCmd)
+}
+
+var projectExportCmd = &cobra.Command{
+	Use:   "export [id]",
+	Short: "Exportar um projeto com tarefas e checklists",
+	Long: `Exporta o projeto, as tarefas (com subtarefas, estimativas, recorrência e
+dependências), os checklists e os itens em um pacote YAML ou JSON, para levar
+a outra máquina ou reutilizar como modelo.
+
+Exemplos:
+  snip project export 3
+  snip project export 3 --format json -o upgrade.json
+  snip project export 3 --template -o pg-major-upgrade.yaml`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := executeWithBundleHandler(func(h handler.BundleHandler) error {
+			id, err := strconv.Atoi(args[0])
+			if err != nil {
+				return fmt.Errorf("ID inválido: %s", args[0])
+			}
+			return h.ExportProject(id, bundleFormat, bundleOutput, bundleTemplate)
+		}); err != nil {
+			fmt.Printf("Erro: %v\n", err)
+		}
+	},
+}
+
+var projectImportCmd = &cobra.Command{
+	Use:   "import [arquivo]",
+	Short: "Criar um projeto a partir de um pacote YAML/JSON",
+	Long: `Cria um novo projeto a partir de um pacote gerado por 'snip project export'.
+As tarefas e checklists recebem novos IDs; subtarefas, dependências e
+checklists de tarefas são religados aos novos IDs. O pacote é validado antes
+de qualquer gravação e, se algo falhar no meio, o que foi criado é desfeito.
+
+Exemplos:
+  snip project import upgrade.yaml --dry-run
+  snip project import pg-major-upgrade.yaml --name "Upgrade PG 16 — cliente X" --start 2025-03-01
+  snip project import projeto.json --template`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := executeWithBundleHandler(func(h handler.BundleHandler) error {
+			var start *time.Time
+			if bundleStart != "" {
+				parsed, err := time.Parse("2006-01-02", bundleStart)
+				if err != nil {
+					return fmt.Errorf("data inválida: %w", err)
+				}
+				start = &parsed
+			}
+			return h.ImportProject(args[0], bundleName, start, bundleTemplate, bundleDryRun)
+		}); err != nil {
+			fmt.Printf("Erro: %v\n", err)
+		}
+	},
+}
@@ -1,6 +1,9 @@
 package cmd
 
-import "github.com/spf13/cobra"
+import (
+	"github.com/snip/internal/ai"
+	"github.com/spf13/cobra"
+)
 
 var rootCmd = &cobra.Command{
 	Use:   "snip",
@@ -20,6 +23,13 @@ Exemplos rápidos:
   snip project create "Meu Projeto"
   snip task create "Nova Tarefa" --project 1
   snip checklist ai-create "Preparação" --items 5`,
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		ai.SetOverride(aiProfileOverride, aiModelOverride)
+		ai.SetUsageStore(lazyAIStore{})
+		ai.SetCacheStore(lazyAIStore{})
+		ai.SetCacheDisabled(aiNoCache)
+		ai.SetShowRedactions(aiShowRedactions)
+	},
 }
 
 func Execute() error {
@@ -39,5 +49,5 @@ func init() {
 	rootCmd.AddCommand(backupCmd)
 	rootCmd.AddCommand(exportCmd)
 	rootCmd.AddCommand(importCmd)
-	// ai config é adicionado em aiconfig.go
+	// ai e seus subcomandos são adicionados em ai.go
 }
@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/snip/internal/handler"
+	"github.com/spf13/cobra"
+)
+
+var snippetLang string
+var snippetForce bool
+
+func init() {
+	snippetListCmd.Flags().StringVarP(&snippetLang, "lang", "l", "", "Only show snippets in this language")
+	snippetWriteCmd.Flags().BoolVarP(&snippetForce, "force", "f", false, "Overwrite the file if it exists")
+
+	rootCmd.AddCommand(snippetCmd)
+	snippetCmd.AddCommand(snippetListCmd)
+	snippetCmd.AddCommand(snippetShowCmd)
+	snippetCmd.AddCommand(snippetCopyCmd)
+	snippetCmd.AddCommand(snippetWriteCmd)
+}
+
+var snippetCmd = &cobra.Command{
+	Use:   "snippet",
+	Short: "Manage code snippets saved from AI answers",
+	Long: `Snippets are notes that also keep the language, the code extracted from
+the fenced blocks of the answer and the prompt that produced it.
+Save one with 'snip ai-code "description" --save'. The snippet ID is the note ID,
+so 'snip show <id>' also works and highlights the code.
+
+Examples:
+  snip snippet list
+  snip snippet list --lang go
+  snip snippet show 12
+  snip snippet copy 12                 # Copy the code to the clipboard
+  snip snippet write 12 ./retry.go
+  snip snippet write 12 ./scripts      # Writes ./scripts/snippet-12.<ext>`,
+}
+
+var snippetListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List saved snippets",
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := executeWithSnippetHandler(func(h handler.SnippetHandler) error {
+			return h.ListSnippets(snippetLang)
+		}); err != nil {
+			fmt.Printf("Error: %v\n", err)
+		}
+	},
+}
+
+var snippetShowCmd = &cobra.Command{
+	Use:   "show [id]",
+	Short: "Show only the code of a snippet, highlighted",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := executeWithSnippetHandler(func(h handler.SnippetHandler) error {
+			return h.ShowSnippet(args[0])
+		}); err != nil {
+			fmt.Printf("Error: %v\n", err)
+		}
+	},
+}
+
+var snippetCopyCmd = &cobra.Command{
+	Use:   "copy [id]",
+	Short: "Copy the code of a snippet to the clipboard",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := executeWithSnippetHandler(func(h handler.SnippetHandler) error {
+			return h.CopySnippet(args[0])
+		}); err != nil {
+			fmt.Printf("Error: %v\n", err)
+		}
+	},
+}
+
+var snippetWriteCmd = &cobra.Command{
+	Use:   "write [id] [path]",
+	Short: "Write the code of a snippet to a file",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := executeWithSnippetHandler(func(h handler.SnippetHandler) error {
+			return h.WriteSnippet(args[0], args[1], snippetForce)
+		}); err != nil {
+			fmt.Printf("Error: %v\n", err)
+		}
+	},
+}
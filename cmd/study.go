@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/snip/internal/handler"
+	"github.com/spf13/cobra"
+)
+
+var studyTag string
+var studyNote string
+var studyMaxCards int
+var studyReplace bool
+var studyLimit int
+
+func init() {
+	studyGenerateCmd.Flags().StringVarP(&studyTag, "tag", "t", "", "Generate cards for every note with this tag")
+	studyGenerateCmd.Flags().StringVarP(&studyNote, "note", "n", "", "Generate cards for a single note")
+	studyGenerateCmd.Flags().IntVarP(&studyMaxCards, "max", "m", 5, "Maximum number of cards per note")
+	studyGenerateCmd.Flags().BoolVar(&studyReplace, "replace", false, "Regenerate cards for notes that already have them (resets their progress)")
+	addAIFlags(studyGenerateCmd)
+
+	studyReviewCmd.Flags().StringVarP(&studyTag, "tag", "t", "", "Only review cards from notes with this tag")
+	studyReviewCmd.Flags().IntVarP(&studyLimit, "limit", "l", 0, "Maximum number of cards in this session (0 = all due)")
+
+	studyStatsCmd.Flags().StringVarP(&studyTag, "tag", "t", "", "Only count cards from notes with this tag")
+
+	rootCmd.AddCommand(studyCmd)
+	studyCmd.AddCommand(studyGenerateCmd)
+	studyCmd.AddCommand(studyReviewCmd)
+	studyCmd.AddCommand(studyStatsCmd)
+}
+
+var studyCmd = &cobra.Command{
+	Use:   "study",
+	Short: "Study your notes with AI-generated flashcards",
+	Long: `Turn notes into question/answer flashcards and review them with
+spaced repetition (SM-2). Each card keeps a link to the note it came from,
+its ease factor and the date of the next review.
+
+Examples:
+  snip study generate --tag oracle          # Cards for every note tagged oracle
+  snip study generate --note 12 --max 8
+  snip study generate --tag oracle --replace
+  snip study review                         # Review every due card
+  snip study review --tag postgresql --limit 20
+  snip study stats --tag oracle`,
+}
+
+var studyGenerateCmd = &cobra.Command{
+	Use:   "generate",
+	Short: "Generate flashcards from notes with AI",
+	Args: func(cmd *cobra.Command, args []string) error {
+		if studyTag == "" && studyNote == "" {
+			return fmt.Errorf("requires --tag or --note")
+		}
+		if studyTag != "" && studyNote != "" {
+			return fmt.Errorf("use either --tag or --note")
+		}
+		return nil
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := executeWithStudyHandler(func(h handler.StudyHandler) error {
+			return h.GenerateCards(studyTag, studyNote, studyMaxCards, studyReplace)
+		}); err != nil {
+			fmt.Printf("Error: %v\n", err)
+		}
+	},
+}
+
+var studyReviewCmd = &cobra.Command{
+	Use:   "review",
+	Short: "Review due flashcards (spaced repetition)",
+	Long: `Show each due card, reveal the answer with Enter and rate how well you
+remembered it:
+
+  0 blackout · 1 wrong · 2 wrong, but familiar · 3 hard · 4 good · 5 easy
+
+Answers below 3 bring the card back tomorrow; good answers push the next
+review further away. Type q to stop; progress is saved after every card.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := executeWithStudyHandler(func(h handler.StudyHandler) error {
+			return h.Review(studyTag, studyLimit)
+		}); err != nil {
+			fmt.Printf("Error: %v\n", err)
+		}
+	},
+}
+
+var studyStatsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show how many cards are due",
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := executeWithStudyHandler(func(h handler.StudyHandler) error {
+			return h.ShowStats(studyTag)
+		}); err != nil {
+			fmt.Printf("Error: %v\n", err)
+		}
+	},
+}
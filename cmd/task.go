@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/snip/internal/handler"
+	"github.com/snip/internal/task"
 	"github.com/spf13/cobra"
 )
 
@@ -15,20 +16,48 @@ var taskStatus string
 var taskPriority string
 var taskDueDate string
 var taskProjectID int
+var taskEstimate string
+var taskHideBlocked bool
+var taskDependOn []int
+var taskDependRemove bool
+var taskParentID int
+var taskToggleYes bool
+var taskMoveRoot bool
+var taskRepeat string
+var taskUpcomingDays int
 
 func init() {
 	taskCreateCmd.Flags().StringVarP(&taskDescription, "description", "d", "", "Descrição da tarefa")
 	taskCreateCmd.Flags().StringVarP(&taskPriority, "priority", "p", "medium", "Prioridade (low, medium, high)")
 	taskCreateCmd.Flags().StringVarP(&taskDueDate, "due", "", "", "Data de vencimento (YYYY-MM-DD)")
 	taskCreateCmd.Flags().IntVarP(&taskProjectID, "project", "", 0, "ID do projeto")
+	taskCreateCmd.Flags().StringVarP(&taskEstimate, "estimate", "e", "", "Estimativa (ex: 90m, 2h30m, 3d; 1d = 8h)")
+	taskCreateCmd.Flags().IntVar(&taskParentID, "parent", 0, "ID da tarefa pai (cria uma subtarefa no mesmo projeto)")
+	taskCreateCmd.Flags().StringVar(&taskRepeat, "repeat", "", "Recorrência: daily, weekdays, weekly, biweekly, monthly, quarterly ou RRULE (ex: FREQ=WEEKLY;BYDAY=MO)")
 	
 	taskListCmd.Flags().StringVarP(&taskStatus, "status", "s", "", "Filtrar por status (pending, in_progress, completed)")
 	taskListCmd.Flags().IntVarP(&taskProjectID, "project", "", 0, "ID do projeto")
+	taskListCmd.Flags().BoolVar(&taskHideBlocked, "hide-blocked", false, "Ocultar tarefas bloqueadas por dependências abertas")
 	
 	taskUpdateCmd.Flags().StringVarP(&taskDescription, "description", "d", "", "Descrição da tarefa")
 	taskUpdateCmd.Flags().StringVarP(&taskStatus, "status", "s", "", "Status (pending, in_progress, completed)")
 	taskUpdateCmd.Flags().StringVarP(&taskPriority, "priority", "p", "", "Prioridade (low, medium, high)")
 	taskUpdateCmd.Flags().StringVarP(&taskDueDate, "due", "", "", "Data de vencimento (YYYY-MM-DD)")
+	taskUpdateCmd.Flags().StringVarP(&taskEstimate, "estimate", "e", "", "Estimativa (ex: 90m, 2h30m, 3d; 1d = 8h)")
+	taskUpdateCmd.Flags().StringVar(&taskRepeat, "repeat", "", "Recorrência (RRULE ou atalho; 'none' remove)")
+
+	taskDependCmd.Flags().IntSliceVar(&taskDependOn, "on", nil, "ID(s) das tarefas que devem ser concluídas antes")
+	taskDependCmd.Flags().BoolVar(&taskDependRemove, "remove", false, "Remover as dependências informadas")
+	taskDependCmd.MarkFlagRequired("on")
+
+	taskToggleCmd.Flags().BoolVarP(&taskToggleYes, "yes", "y", false, "Concluir sem confirmar mesmo com subtarefas abertas")
+
+	taskMoveCmd.Flags().IntVar(&taskProjectID, "project", 0, "ID do projeto de destino")
+	taskMoveCmd.Flags().IntVar(&taskParentID, "parent", 0, "ID da nova tarefa pai")
+	taskMoveCmd.Flags().BoolVar(&taskMoveRoot, "root", false, "Tornar a tarefa de primeiro nível")
+
+	taskUpcomingCmd.Flags().IntVar(&taskProjectID, "project", 0, "ID do projeto")
+	taskUpcomingCmd.Flags().IntVarP(&taskUpcomingDays, "days", "d", 30, "Quantos dias à frente mostrar")
 	
 	rootCmd.AddCommand(taskCmd)
 }
@@ -54,7 +83,11 @@ var taskCreateCmd = &cobra.Command{
 				}
 				dueDate = &parsed
 			}
-			return h.CreateTask(taskProjectID, title, taskDescription, taskPriority, dueDate)
+			estimate, err := task.ParseEstimate(taskEstimate)
+			if err != nil {
+				return err
+			}
+			return h.CreateTask(taskProjectID, taskParentID, title, taskDescription, taskPriority, dueDate, estimate, taskRepeat)
 		}); err != nil {
 			fmt.Printf("Erro: %v\n", err)
 		}
@@ -70,7 +103,7 @@ var taskListCmd = &cobra.Command{
 			if taskProjectID > 0 {
 				projectID = taskProjectID
 			}
-			return h.ListTasks(projectID, taskStatus)
+			return h.ListTasks(projectID, taskStatus, taskHideBlocked)
 		}); err != nil {
 			fmt.Printf("Erro: %v\n", err)
 		}
@@ -113,7 +146,19 @@ var taskUpdateCmd = &cobra.Command{
 				}
 				dueDate = &parsed
 			}
-			return h.UpdateTask(id, title, taskDescription, taskStatus, taskPriority, dueDate)
+			var estimate *time.Duration
+			if cmd.Flags().Changed("estimate") {
+				parsed, err := task.ParseEstimate(taskEstimate)
+				if err != nil {
+					return err
+				}
+				estimate = &parsed
+			}
+			var recurrence *string
+			if cmd.Flags().Changed("repeat") {
+				recurrence = &taskRepeat
+			}
+			return h.UpdateTask(id, title, taskDescription, taskStatus, taskPriority, dueDate, estimate, recurrence)
 		}); err != nil {
 			fmt.Printf("Erro: %v\n", err)
 		}
@@ -147,7 +192,87 @@ var taskToggleCmd = &cobra.Command{
 			if err != nil {
 				return fmt.Errorf("ID inválido: %s", args[0])
 			}
-			return h.ToggleTaskComplete(id)
+			return h.ToggleTaskComplete(id, taskToggleYes)
+		}); err != nil {
+			fmt.Printf("Erro: %v\n", err)
+		}
+	},
+}
+
+var taskDependCmd = &cobra.Command{
+	Use:   "depend [id]",
+	Short: "Definir de quais tarefas uma tarefa depende",
+	Long: `Registra que a tarefa só pode começar depois que as tarefas informadas em
+--on forem concluídas. Dependências circulares são recusadas.
+
+Tarefas com dependências abertas aparecem como bloqueadas (⊘) em 'task list'
+e entram no cálculo de 'snip project critical-path'.
+
+Exemplos:
+  snip task depend 12 --on 10
+  snip task depend 12 --on 10,11
+  snip task depend 12 --on 11 --remove`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := executeWithTaskHandler(func(h handler.TaskHandler) error {
+			id, err := strconv.Atoi(args[0])
+			if err != nil {
+				return fmt.Errorf("ID inválido: %s", args[0])
+			}
+			return h.DependTask(id, taskDependOn, taskDependRemove)
+		}); err != nil {
+			fmt.Printf("Erro: %v\n", err)
+		}
+	},
+}
+
+var taskMoveCmd = &cobra.Command{
+	Use:   "move [id]",
+	Short: "Mover uma tarefa e suas subtarefas",
+	Long: `Move a tarefa junto com toda a sua árvore de subtarefas.
+
+Exemplos:
+  snip task move 12 --parent 10       # Vira subtarefa de #10 (e vai para o projeto dela)
+  snip task move 12 --root            # Vira tarefa de primeiro nível
+  snip task move 12 --project 4       # Leva a subárvore para o projeto #4`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := executeWithTaskHandler(func(h handler.TaskHandler) error {
+			id, err := strconv.Atoi(args[0])
+			if err != nil {
+				return fmt.Errorf("ID inválido: %s", args[0])
+			}
+			if taskMoveRoot && taskParentID != 0 {
+				return fmt.Errorf("use --parent ou --root, não os dois")
+			}
+			return h.MoveTask(id, taskProjectID, taskParentID, taskMoveRoot)
+		}); err != nil {
+			fmt.Printf("Erro: %v\n", err)
+		}
+	},
+}
+
+var taskUpcomingCmd = &cobra.Command{
+	Use:   "upcoming",
+	Short: "Listar as próximas ocorrências e prazos",
+	Long: `Mostra, dia a dia, as próximas ocorrências das tarefas recorrentes abertas
+e os prazos das demais tarefas abertas. Ocorrências atrasadas aparecem primeiro.
+
+Ao concluir uma tarefa recorrente com 'snip task toggle', a próxima ocorrência
+é criada automaticamente.
+
+Exemplos:
+  snip task create "Revisar índices" --project 1 --repeat "FREQ=WEEKLY;BYDAY=MO"
+  snip task create "Teste de restore" --project 1 --repeat "FREQ=MONTHLY;BYMONTHDAY=5"
+  snip task create "Aplicar patches" --project 1 --repeat quarterly --due 2025-01-15
+  snip task create "Checar alert log" --project 1 --repeat "FREQ=DAILY;INTERVAL=3"
+  snip task upcoming --days 14`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := executeWithTaskHandler(func(h handler.TaskHandler) error {
+			if taskUpcomingDays < 1 {
+				return fmt.Errorf("--days deve ser maior que zero")
+			}
+			return h.ListUpcoming(taskProjectID, taskUpcomingDays)
 		}); err != nil {
 			fmt.Printf("Erro: %v\n", err)
 		}
@@ -161,5 +286,8 @@ func init() {
 	taskCmd.AddCommand(taskUpdateCmd)
 	taskCmd.AddCommand(taskDeleteCmd)
 	taskCmd.AddCommand(taskToggleCmd)
+	taskCmd.AddCommand(taskDependCmd)
+	taskCmd.AddCommand(taskMoveCmd)
+	taskCmd.AddCommand(taskUpcomingCmd)
 }
 
@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/snip/internal/handler"
+	"github.com/snip/internal/task"
+	"github.com/spf13/cobra"
+)
+
+var timeTags []string
+var timeLogDate string
+var reportSince string
+var reportBy string
+
+func init() {
+	taskStartCmd.Flags().StringSliceVarP(&timeTags, "tag", "t", nil, "Tags do apontamento (ex: cliente-acme,faturavel)")
+	taskLogCmd.Flags().StringSliceVarP(&timeTags, "tag", "t", nil, "Tags do apontamento (ex: cliente-acme,faturavel)")
+	taskLogCmd.Flags().StringVar(&timeLogDate, "date", "", "Dia trabalhado (YYYY-MM-DD); padrão: termina agora")
+
+	reportTimeCmd.Flags().StringVarP(&reportSince, "since", "s", "7d", "Período: 7d, 2w, 12h ou uma data YYYY-MM-DD")
+	reportTimeCmd.Flags().StringVarP(&reportBy, "by", "b", handler.ReportByProject, "Agrupar por project, task ou tag")
+
+	taskCmd.AddCommand(taskStartCmd)
+	taskCmd.AddCommand(taskStopCmd)
+	taskCmd.AddCommand(taskLogCmd)
+
+	reportCmd.AddCommand(reportTimeCmd)
+	rootCmd.AddCommand(reportCmd)
+}
+
+var taskStartCmd = &cobra.Command{
+	Use:   "start [id] [nota]",
+	Short: "Iniciar o cronômetro de uma tarefa",
+	Long: `Inicia o apontamento de horas na tarefa. Só existe um cronômetro ativo por
+vez: pare o atual com 'snip task stop' antes de iniciar outro.
+
+Exemplos:
+  snip task start 12
+  snip task start 12 "upgrade do ambiente de homologação" --tag cliente-acme`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := executeWithTimeHandler(func(h handler.TimeHandler) error {
+			id, err := strconv.Atoi(args[0])
+			if err != nil {
+				return fmt.Errorf("ID inválido: %s", args[0])
+			}
+			return h.StartTimer(id, strings.Join(args[1:], " "), timeTags)
+		}); err != nil {
+			fmt.Printf("Erro: %v\n", err)
+		}
+	},
+}
+
+var taskStopCmd = &cobra.Command{
+	Use:   "stop [nota]",
+	Short: "Parar o cronômetro ativo",
+	Args:  cobra.ArbitraryArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := executeWithTimeHandler(func(h handler.TimeHandler) error {
+			return h.StopTimer(strings.Join(args, " "))
+		}); err != nil {
+			fmt.Printf("Erro: %v\n", err)
+		}
+	},
+}
+
+var taskLogCmd = &cobra.Command{
+	Use:   "log [id] [duração] [nota]",
+	Short: "Lançar horas já trabalhadas em uma tarefa",
+	Long: `Lança um período já trabalhado, sem usar o cronômetro. A duração aceita
+o mesmo formato das estimativas (90m, 1h30m, 1d = 8h).
+
+Exemplos:
+  snip task log 12 1h30m "análise do AWR"
+  snip task log 12 4h "janela de manutenção" --date 2025-01-10 --tag cliente-acme`,
+	Args: cobra.MinimumNArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := executeWithTimeHandler(func(h handler.TimeHandler) error {
+			id, err := strconv.Atoi(args[0])
+			if err != nil {
+				return fmt.Errorf("ID inválido: %s", args[0])
+			}
+			duration, err := task.ParseEstimate(args[1])
+			if err != nil {
+				return err
+			}
+			var date *time.Time
+			if timeLogDate != "" {
+				parsed, err := time.Parse("2006-01-02", timeLogDate)
+				if err != nil {
+					return fmt.Errorf("data inválida: %w", err)
+				}
+				date = &parsed
+			}
+			return h.LogTime(id, duration, strings.Join(args[2:], " "), timeTags, date)
+		}); err != nil {
+			fmt.Printf("Erro: %v\n", err)
+		}
+	},
+}
+
+var reportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Relatórios",
+}
+
+var reportTimeCmd = &cobra.Command{
+	Use:   "time",
+	Short: "Horas apontadas por projeto, tarefa ou tag",
+	Long: `Soma as horas apontadas no período, em horas e em horas decimais (para
+faturamento), e compara a estimativa das tarefas com o total real apontado.
+Tarefas criadas por 'snip db-project --save' já trazem a estimativa da IA.
+
+Exemplos:
+  snip report time
+  snip report time --since 30d --by task
+  snip report time --since 2025-01-01 --by tag`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := executeWithTimeHandler(func(h handler.TimeHandler) error {
+			since, err := task.ParseSince(reportSince, time.Now())
+			if err != nil {
+				return err
+			}
+			return h.ReportTime(since, reportBy)
+		}); err != nil {
+			fmt.Printf("Erro: %v\n", err)
+		}
+	},
+}
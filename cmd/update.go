@@ -9,6 +9,8 @@ import (
 
 var title string
 
+var updateAITag bool
+
 func init() {
 	updateCmd.Flags().StringVarP(
 		&title,
@@ -17,6 +19,7 @@ func init() {
 		"",
 		"If you want to update the title, you can use this flag e.g. --title 'New Title'",
 	)
+	updateCmd.Flags().BoolVar(&updateAITag, "ai-tag", false, "Refresh the AI summary and suggest tags")
 }
 
 var updateCmd = &cobra.Command{
@@ -31,14 +34,19 @@ will be automatically updated.
 
 Flags:
   --title, -t    Update the note's title (optional)
+  --ai-tag       Refresh the AI summary and suggest tags (optional)
 
 Examples:
   snip update 1                           # Edit content of note 1
   snip update 1 --title "New Title"      # Edit content and change title
-  snip update 42 -t "Updated Meeting"    # Edit note 42 with new title`,
+  snip update 42 -t "Updated Meeting"    # Edit note 42 with new title
+  snip update 7 --ai-tag                 # Edit and refresh summary/tags`,
 	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		if err := executeWithHandler(func(h handler.Handler) error {
+			if updateAITag {
+				h.SetAutoTag(true)
+			}
 			return h.UpdateNote(args[0], title)
 		}); err != nil {
 			fmt.Printf("Error: %v\n", err)
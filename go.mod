@@ -4,15 +4,19 @@ go 1.25.1
 
 require (
 	github.com/MichaelMure/go-term-markdown v0.1.4
+	github.com/alecthomas/chroma v0.7.1
 	github.com/lib/pq v1.10.9
+	github.com/mattn/go-runewidth v0.0.12
 	github.com/mattn/go-sqlite3 v1.14.32
 	github.com/mitchellh/go-wordwrap v1.0.1
 	github.com/spf13/cobra v1.10.1
+	golang.org/x/net v0.0.0-20220722155237-a158d28d115b
+	golang.org/x/term v0.0.0-20220722155259-a9ba230a4035
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
 	github.com/MichaelMure/go-term-text v0.3.1 // indirect
-	github.com/alecthomas/chroma v0.7.1 // indirect
 	github.com/danwakefield/fnmatch v0.0.0-20160403171240-cbb64ac3d964 // indirect
 	github.com/disintegration/imaging v1.6.2 // indirect
 	github.com/dlclark/regexp2 v1.1.6 // indirect
@@ -24,10 +28,9 @@ require (
 	github.com/lucasb-eyer/go-colorful v1.0.3 // indirect
 	github.com/mattn/go-colorable v0.1.4 // indirect
 	github.com/mattn/go-isatty v0.0.11 // indirect
-	github.com/mattn/go-runewidth v0.0.12 // indirect
 	github.com/rivo/uniseg v0.1.0 // indirect
 	github.com/spf13/pflag v1.0.9 // indirect
 	golang.org/x/image v0.0.0-20191206065243-da761ea9ff43 // indirect
-	golang.org/x/net v0.0.0-20220722155237-a158d28d115b // indirect
 	golang.org/x/sys v0.0.0-20220722155257-8c9f86f7a55f // indirect
+	golang.org/x/text v0.3.7 // indirect
 )
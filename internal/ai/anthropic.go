@@ -6,14 +6,16 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 )
 
 // AnthropicClient implementa o cliente Anthropic (Claude)
 type AnthropicClient struct {
-	apiKey  string
-	model   string
-	baseURL string
-	client  *http.Client
+	apiKey    string
+	model     string
+	baseURL   string
+	client    *http.Client
+	lastUsage Usage
 }
 
 // NewAnthropicClient cria um novo cliente Anthropic
@@ -27,11 +29,16 @@ func NewAnthropicClient(config *AIConfig) (*AnthropicClient, error) {
 		model = "claude-3-5-sonnet-20241022"
 	}
 
+	httpClient, err := createHTTPClient()
+	if err != nil {
+		return nil, err
+	}
+
 	return &AnthropicClient{
 		apiKey:  config.APIKey,
 		model:   model,
 		baseURL: "https://api.anthropic.com/v1/messages",
-		client:  createHTTPClient(),
+		client:  httpClient,
 	}, nil
 }
 
@@ -47,34 +54,155 @@ func (a *AnthropicClient) SetModel(model string) {
 	a.model = model
 }
 
+// LastUsage retorna o consumo de tokens da última resposta
+func (a *AnthropicClient) LastUsage() Usage {
+	return a.lastUsage
+}
+
 func (a *AnthropicClient) Chat(messages []Message, maxTokens int, temperature float64) (string, error) {
-	// Anthropic usa formato diferente
-	anthropicMessages := make([]map[string]interface{}, len(messages))
-	for i, msg := range messages {
-		anthropicMessages[i] = map[string]interface{}{
-			"role":    msg.Role,
-			"content": msg.Content,
+	content, err := a.send(messages, nil, maxTokens, temperature)
+	if err != nil {
+		return "", err
+	}
+
+	for _, block := range content {
+		if block.Type == "text" {
+			return block.Text, nil
+		}
+	}
+	return "", fmt.Errorf("no content in response")
+}
+
+// ChatWithTools usa os blocos tool_use/tool_result da API Messages
+func (a *AnthropicClient) ChatWithTools(messages []Message, tools []Tool, maxTokens int, temperature float64) (*ToolResponse, error) {
+	content, err := a.send(messages, tools, maxTokens, temperature)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ToolResponse{}
+	var texts []string
+	for _, block := range content {
+		switch block.Type {
+		case "text":
+			texts = append(texts, block.Text)
+		case "tool_use":
+			response.ToolCalls = append(response.ToolCalls, ToolCall{
+				ID:   block.ID,
+				Type: "function",
+				Function: ToolCallFunction{
+					Name:      block.Name,
+					Arguments: string(block.Input),
+				},
+			})
+		}
+	}
+	response.Content = strings.Join(texts, "\n")
+
+	return response, nil
+}
+
+// anthropicBlock é um bloco de conteúdo da resposta
+type anthropicBlock struct {
+	Type  string          `json:"type"`
+	Text  string          `json:"text"`
+	ID    string          `json:"id"`
+	Name  string          `json:"name"`
+	Input json.RawMessage `json:"input"`
+}
+
+// anthropicMessages converte as mensagens para o formato da API:
+// o prompt de sistema vai em campo próprio e ferramentas viram blocos
+func anthropicMessages(messages []Message) (string, []map[string]interface{}) {
+	var system []string
+	var result []map[string]interface{}
+
+	for _, msg := range messages {
+		switch {
+		case msg.Role == "system":
+			system = append(system, msg.Content)
+		case msg.Role == "tool":
+			block := map[string]interface{}{
+				"type":        "tool_result",
+				"tool_use_id": msg.ToolCallID,
+				"content":     msg.Content,
+			}
+			// Resultados consecutivos vão na mesma mensagem do usuário
+			if n := len(result); n > 0 && result[n-1]["role"] == "user" {
+				if blocks, ok := result[n-1]["content"].([]map[string]interface{}); ok {
+					result[n-1]["content"] = append(blocks, block)
+					continue
+				}
+			}
+			result = append(result, map[string]interface{}{
+				"role":    "user",
+				"content": []map[string]interface{}{block},
+			})
+		case len(msg.ToolCalls) > 0:
+			var blocks []map[string]interface{}
+			if msg.Content != "" {
+				blocks = append(blocks, map[string]interface{}{"type": "text", "text": msg.Content})
+			}
+			for _, call := range msg.ToolCalls {
+				blocks = append(blocks, map[string]interface{}{
+					"type":  "tool_use",
+					"id":    call.ID,
+					"name":  call.Function.Name,
+					"input": json.RawMessage(argumentsOrEmpty(call.Function.Arguments)),
+				})
+			}
+			result = append(result, map[string]interface{}{
+				"role":    "assistant",
+				"content": blocks,
+			})
+		default:
+			result = append(result, map[string]interface{}{
+				"role":    msg.Role,
+				"content": msg.Content,
+			})
 		}
 	}
 
+	return strings.Join(system, "\n\n"), result
+}
+
+func (a *AnthropicClient) send(messages []Message, tools []Tool, maxTokens int, temperature float64) ([]anthropicBlock, error) {
+	system, anthropicMsgs := anthropicMessages(messages)
+
 	reqBody := map[string]interface{}{
-		"model":     a.model,
-		"messages":  anthropicMessages,
+		"model":      a.model,
+		"messages":   anthropicMsgs,
 		"max_tokens": maxTokens,
 	}
 
+	if system != "" {
+		reqBody["system"] = system
+	}
+
 	if temperature > 0 {
 		reqBody["temperature"] = temperature
 	}
 
+	if len(tools) > 0 {
+		var anthropicTools []map[string]interface{}
+		for _, tool := range tools {
+			anthropicTools = append(anthropicTools, map[string]interface{}{
+				"name":         tool.Name,
+				"description":  tool.Description,
+				"input_schema": toolParameters(tool),
+			})
+		}
+		reqBody["tools"] = anthropicTools
+	}
+
 	jsonData, err := json.Marshal(reqBody)
 	if err != nil {
-		return "", fmt.Errorf("failed to marshal request: %w", err)
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
 	req, err := http.NewRequest("POST", a.baseURL, bytes.NewBuffer(jsonData))
 	if err != nil {
-		return "", fmt.Errorf("failed to create request: %w", err)
+		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	req.Header.Set("Content-Type", "application/json")
@@ -83,35 +211,42 @@ func (a *AnthropicClient) Chat(messages []Message, maxTokens int, temperature fl
 
 	resp, err := a.client.Do(req)
 	if err != nil {
-		return "", fmt.Errorf("failed to send request: %w", err)
+		return nil, fmt.Errorf("failed to send request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return "", fmt.Errorf("failed to read response: %w", err)
+		return nil, fmt.Errorf("failed to read response: %w", err)
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
 	}
 
 	var response struct {
-		Content []struct {
-			Type string `json:"type"`
-			Text string `json:"text"`
-		} `json:"content"`
+		Content []anthropicBlock `json:"content"`
+		Usage   struct {
+			InputTokens  int `json:"input_tokens"`
+			OutputTokens int `json:"output_tokens"`
+		} `json:"usage"`
 	}
 
 	if err := json.Unmarshal(body, &response); err != nil {
-		return "", fmt.Errorf("failed to parse response: %w", err)
+		return nil, fmt.Errorf("failed to parse response: %w", err)
 	}
 
+	a.lastUsage = Usage{InputTokens: response.Usage.InputTokens, OutputTokens: response.Usage.OutputTokens}
+
 	if len(response.Content) == 0 {
-		return "", fmt.Errorf("no content in response")
+		return nil, fmt.Errorf("no content in response")
 	}
 
-	return response.Content[0].Text, nil
+	return response.Content, nil
+}
+
+func (a *AnthropicClient) ChatJSON(messages []Message, schema interface{}, maxTokens int, temperature float64) error {
+	return chatJSONGeneric(a, messages, schema, maxTokens, temperature)
 }
 
 func (a *AnthropicClient) GenerateContent(prompt string, maxTokens int) (string, error) {
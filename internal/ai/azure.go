@@ -0,0 +1,201 @@
+package ai
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// DefaultAzureAPIVersion é a versão da API usada quando api_version não é configurada
+const DefaultAzureAPIVersion = "2024-06-01"
+
+// AzureOpenAIClient implementa o cliente Azure OpenAI: a URL usa o nome do
+// deployment e a versão da API, e a chave vai no cabeçalho api-key
+type AzureOpenAIClient struct {
+	apiKey     string
+	model      string
+	endpoint   string
+	deployment string
+	apiVersion string
+	client     *http.Client
+	lastUsage  Usage
+}
+
+// NewAzureOpenAIClient cria um novo cliente Azure OpenAI
+func NewAzureOpenAIClient(config *AIConfig) (*AzureOpenAIClient, error) {
+	if config.APIKey == "" {
+		return nil, fmt.Errorf("API key não configurada")
+	}
+	if config.Endpoint == "" {
+		return nil, fmt.Errorf("endpoint do Azure OpenAI não configurado (ex: https://meu-recurso.openai.azure.com). Execute: snip ai config --endpoint <url>")
+	}
+
+	model := config.Model
+	if model == "" {
+		model = "gpt-4o"
+	}
+
+	// O deployment costuma ter o nome do modelo; use --deployment quando for diferente
+	deployment := config.Deployment
+	if deployment == "" {
+		deployment = model
+	}
+
+	apiVersion := config.APIVersion
+	if apiVersion == "" {
+		apiVersion = DefaultAzureAPIVersion
+	}
+
+	httpClient, err := createHTTPClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return &AzureOpenAIClient{
+		apiKey:     config.APIKey,
+		model:      model,
+		endpoint:   strings.TrimRight(config.Endpoint, "/"),
+		deployment: deployment,
+		apiVersion: apiVersion,
+		client:     httpClient,
+	}, nil
+}
+
+func (a *AzureOpenAIClient) GetProvider() Provider {
+	return ProviderAzure
+}
+
+func (a *AzureOpenAIClient) GetModel() string {
+	return a.model
+}
+
+// SetModel troca o modelo e, com ele, o deployment (--model e /model usam o nome do deployment)
+func (a *AzureOpenAIClient) SetModel(model string) {
+	a.model = model
+	a.deployment = model
+}
+
+// LastUsage retorna o consumo de tokens da última resposta
+func (a *AzureOpenAIClient) LastUsage() Usage {
+	return a.lastUsage
+}
+
+func (a *AzureOpenAIClient) url() string {
+	return fmt.Sprintf("%s/openai/deployments/%s/chat/completions?api-version=%s",
+		a.endpoint, url.PathEscape(a.deployment), url.QueryEscape(a.apiVersion))
+}
+
+func (a *AzureOpenAIClient) Chat(messages []Message, maxTokens int, temperature float64) (string, error) {
+	message, err := a.chat(newChatRequest(a.model, messages, maxTokens, temperature))
+	if err != nil {
+		return "", err
+	}
+	return message.Content, nil
+}
+
+// ChatJSONMode usa o response_format json_object da API
+func (a *AzureOpenAIClient) ChatJSONMode(messages []Message, maxTokens int, temperature float64) (string, error) {
+	reqBody := newChatRequest(a.model, messages, maxTokens, temperature)
+	reqBody.ResponseFormat = &ResponseFormat{Type: "json_object"}
+
+	message, err := a.chat(reqBody)
+	if err != nil {
+		return "", err
+	}
+	return message.Content, nil
+}
+
+// ChatWithTools usa o campo tools (function calling) da API
+func (a *AzureOpenAIClient) ChatWithTools(messages []Message, tools []Tool, maxTokens int, temperature float64) (*ToolResponse, error) {
+	reqBody := newChatRequest(a.model, messages, maxTokens, temperature)
+	reqBody.Tools = toOpenAITools(tools)
+
+	message, err := a.chat(reqBody)
+	if err != nil {
+		return nil, err
+	}
+	return &ToolResponse{Content: message.Content, ToolCalls: message.ToolCalls}, nil
+}
+
+func (a *AzureOpenAIClient) chat(reqBody ChatRequest) (*Message, error) {
+	jsonData, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequest("POST", a.url(), bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("api-key", a.apiKey)
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var chatResp ChatResponse
+	if err := json.Unmarshal(body, &chatResp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	a.lastUsage = Usage{InputTokens: chatResp.Usage.PromptTokens, OutputTokens: chatResp.Usage.CompletionTokens}
+
+	if len(chatResp.Choices) == 0 {
+		return nil, fmt.Errorf("no choices in response")
+	}
+
+	return &chatResp.Choices[0].Message, nil
+}
+
+func (a *AzureOpenAIClient) ChatJSON(messages []Message, schema interface{}, maxTokens int, temperature float64) error {
+	return chatJSONGeneric(a, messages, schema, maxTokens, temperature)
+}
+
+func (a *AzureOpenAIClient) GenerateContent(prompt string, maxTokens int) (string, error) {
+	return generateContentGeneric(a, prompt, maxTokens)
+}
+
+func (a *AzureOpenAIClient) GenerateNoteContent(topic string, context string) (string, error) {
+	return generateNoteContentGeneric(a, topic, context)
+}
+
+func (a *AzureOpenAIClient) ImproveSearchQuery(query string, notesContext []string) (string, error) {
+	return improveSearchQueryGeneric(a, query, notesContext)
+}
+
+func (a *AzureOpenAIClient) AnswerQuestion(question string, notesContext []string) (string, error) {
+	return answerQuestionGeneric(a, question, notesContext)
+}
+
+func (a *AzureOpenAIClient) GenerateCode(language string, description string, context string) (string, error) {
+	return generateCodeGeneric(a, language, description, context)
+}
+
+func (a *AzureOpenAIClient) GenerateTips(topic string) (string, error) {
+	return generateTipsGeneric(a, topic)
+}
+
+func (a *AzureOpenAIClient) GenerateChecklist(topic string, context string, numItems int) ([]string, error) {
+	return generateChecklistGeneric(a, topic, context, numItems)
+}
+
+func (a *AzureOpenAIClient) GenerateProjectPlan(projectName string, description string) (string, error) {
+	return generateProjectPlanGeneric(a, projectName, description)
+}
@@ -0,0 +1,205 @@
+package ai
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+)
+
+// DefaultCacheTTL é a validade padrão de uma resposta em cache
+const DefaultCacheTTL = 24 * time.Hour
+
+// CacheConfig define o comportamento do cache de respostas
+type CacheConfig struct {
+	Disabled bool `json:"disabled,omitempty"`
+	// TTL é uma duração Go (ex: "24h", "90m")
+	TTL string `json:"ttl,omitempty"`
+}
+
+// GetTTL retorna a validade configurada ou o padrão
+func (c *CacheConfig) GetTTL() time.Duration {
+	if c == nil || c.TTL == "" {
+		return DefaultCacheTTL
+	}
+	ttl, err := time.ParseDuration(c.TTL)
+	if err != nil || ttl <= 0 {
+		return DefaultCacheTTL
+	}
+	return ttl
+}
+
+// CacheEntry representa uma resposta armazenada na tabela ai_cache. A chave
+// usa o provedor/modelo pedido; Provider e Model são os de quem respondeu,
+// que com fallback podem ser outros.
+type CacheEntry struct {
+	Key       string    `json:"key"`
+	Provider  Provider  `json:"provider"`
+	Model     string    `json:"model"`
+	Response  string    `json:"response"`
+	Hits      int       `json:"hits"`
+	CreatedAt time.Time `json:"created_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// CacheStats resume o conteúdo do cache
+type CacheStats struct {
+	Entries     int            `json:"entries"`
+	Expired     int            `json:"expired"`
+	Hits        int            `json:"hits"`
+	SizeBytes   int64          `json:"size_bytes"`
+	OldestEntry time.Time      `json:"oldest_entry"`
+	NewestEntry time.Time      `json:"newest_entry"`
+	ByModel     map[string]int `json:"by_model"`
+}
+
+// CacheStore persiste respostas indexadas pela chave de conteúdo
+type CacheStore interface {
+	Get(key string) (string, bool, error)
+	Put(entry *CacheEntry) error
+}
+
+var (
+	cacheStore    CacheStore
+	cacheDisabled bool
+)
+
+// SetCacheStore define onde as respostas são armazenadas
+func SetCacheStore(store CacheStore) {
+	cacheStore = store
+}
+
+// SetCacheDisabled desativa o cache nesta execução (--no-cache)
+func SetCacheDisabled(disabled bool) {
+	cacheDisabled = disabled
+}
+
+// CacheKey calcula a chave de conteúdo de uma requisição
+func CacheKey(provider Provider, model string, messages []Message, maxTokens int, temperature float64, jsonMode bool) string {
+	payload, _ := json.Marshal(struct {
+		Provider    Provider  `json:"provider"`
+		Model       string    `json:"model"`
+		Messages    []Message `json:"messages"`
+		MaxTokens   int       `json:"max_tokens"`
+		Temperature float64   `json:"temperature"`
+		JSONMode    bool      `json:"json_mode,omitempty"`
+	}{provider, model, messages, maxTokens, temperature, jsonMode})
+
+	sum := sha256.Sum256(payload)
+	return hex.EncodeToString(sum[:])
+}
+
+// CachedClient responde requisições repetidas a partir do cache
+type CachedClient struct {
+	client AIClient
+	ttl    time.Duration
+}
+
+// NewCachedClient envolve um cliente com o cache de respostas
+func NewCachedClient(client AIClient, ttl time.Duration) *CachedClient {
+	return &CachedClient{client: client, ttl: ttl}
+}
+
+func (c *CachedClient) GetProvider() Provider {
+	return c.client.GetProvider()
+}
+
+func (c *CachedClient) GetModel() string {
+	return c.client.GetModel()
+}
+
+func (c *CachedClient) SetModel(model string) {
+	c.client.SetModel(model)
+}
+
+func (c *CachedClient) Chat(messages []Message, maxTokens int, temperature float64) (string, error) {
+	return c.cached(messages, maxTokens, temperature, false, func(client AIClient) (string, error) {
+		return client.Chat(messages, maxTokens, temperature)
+	})
+}
+
+func (c *CachedClient) ChatJSONMode(messages []Message, maxTokens int, temperature float64) (string, error) {
+	return c.cached(messages, maxTokens, temperature, true, func(client AIClient) (string, error) {
+		return chatJSONMode(client, messages, maxTokens, temperature)
+	})
+}
+
+// ChatWithTools não usa cache: os resultados das ferramentas mudam a cada execução
+func (c *CachedClient) ChatWithTools(messages []Message, tools []Tool, maxTokens int, temperature float64) (*ToolResponse, error) {
+	return c.client.ChatWithTools(messages, tools, maxTokens, temperature)
+}
+
+func (c *CachedClient) cached(messages []Message, maxTokens int, temperature float64, jsonMode bool, call func(client AIClient) (string, error)) (string, error) {
+	if cacheStore == nil || cacheDisabled {
+		return call(c.client)
+	}
+
+	key := CacheKey(c.client.GetProvider(), c.client.GetModel(), messages, maxTokens, temperature, jsonMode)
+	if response, ok, err := cacheStore.Get(key); err == nil && ok {
+		return response, nil
+	}
+
+	response, answered, err := respond(c.client, call)
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	// Falha ao gravar no cache não deve interromper a resposta
+	_ = cacheStore.Put(&CacheEntry{
+		Key:       key,
+		Provider:  answered.GetProvider(),
+		Model:     answered.GetModel(),
+		Response:  response,
+		CreatedAt: now,
+		ExpiresAt: now.Add(c.ttl),
+	})
+
+	return response, nil
+}
+
+// respond faz a chamada e retorna também o cliente que respondeu: com
+// fallback, é o primeiro da cadeia que não falhou
+func respond(client AIClient, call func(client AIClient) (string, error)) (string, AIClient, error) {
+	if fallback, ok := client.(*FallbackClient); ok {
+		return fallback.tryWith(call)
+	}
+	response, err := call(client)
+	return response, client, err
+}
+
+func (c *CachedClient) ChatJSON(messages []Message, schema interface{}, maxTokens int, temperature float64) error {
+	return chatJSONGeneric(c, messages, schema, maxTokens, temperature)
+}
+
+func (c *CachedClient) GenerateContent(prompt string, maxTokens int) (string, error) {
+	return generateContentGeneric(c, prompt, maxTokens)
+}
+
+func (c *CachedClient) GenerateNoteContent(topic string, context string) (string, error) {
+	return generateNoteContentGeneric(c, topic, context)
+}
+
+func (c *CachedClient) ImproveSearchQuery(query string, notesContext []string) (string, error) {
+	return improveSearchQueryGeneric(c, query, notesContext)
+}
+
+func (c *CachedClient) AnswerQuestion(question string, notesContext []string) (string, error) {
+	return answerQuestionGeneric(c, question, notesContext)
+}
+
+func (c *CachedClient) GenerateCode(language string, description string, context string) (string, error) {
+	return generateCodeGeneric(c, language, description, context)
+}
+
+func (c *CachedClient) GenerateTips(topic string) (string, error) {
+	return generateTipsGeneric(c, topic)
+}
+
+func (c *CachedClient) GenerateChecklist(topic string, context string, numItems int) ([]string, error) {
+	return generateChecklistGeneric(c, topic, context, numItems)
+}
+
+func (c *CachedClient) GenerateProjectPlan(projectName string, description string) (string, error) {
+	return generateProjectPlanGeneric(c, projectName, description)
+}
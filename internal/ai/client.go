@@ -3,14 +3,14 @@ package ai
 import (
 	"fmt"
 	"net/http"
-	"time"
+
+	"github.com/snip/internal/httpclient"
 )
 
-// createHTTPClient cria um cliente HTTP padrão
-func createHTTPClient() *http.Client {
-	return &http.Client{
-		Timeout: 30 * time.Second,
-	}
+// createHTTPClient cria o cliente HTTP com proxy, CAs, mTLS e timeout de
+// ~/.snip/http_config.json (integração "ai")
+func createHTTPClient() (*http.Client, error) {
+	return httpclient.New(httpclient.IntegrationAI)
 }
 
 // Provider representa um provedor de IA
@@ -23,11 +23,19 @@ const (
 	ProviderDeepSeek   Provider = "deepseek"
 	ProviderGrok       Provider = "grok"
 	ProviderOpenRouter Provider = "openrouter"
+	ProviderGemini     Provider = "gemini"
+	ProviderAzure      Provider = "azure"
+	// ProviderReplay responde a partir de cassetes gravados (testes, sem rede)
+	ProviderReplay Provider = "replay"
 )
 
 // AIClient é a interface genérica para clientes de IA
 type AIClient interface {
 	Chat(messages []Message, maxTokens int, temperature float64) (string, error)
+	// ChatJSON preenche schema (ponteiro para struct) com a resposta JSON validada
+	ChatJSON(messages []Message, schema interface{}, maxTokens int, temperature float64) error
+	// ChatWithTools oferece ferramentas ao modelo usando a API nativa do provedor
+	ChatWithTools(messages []Message, tools []Tool, maxTokens int, temperature float64) (*ToolResponse, error)
 	GenerateContent(prompt string, maxTokens int) (string, error)
 	GenerateNoteContent(topic string, context string) (string, error)
 	ImproveSearchQuery(query string, notesContext []string) (string, error)
@@ -45,40 +53,96 @@ type AIClient interface {
 type Message struct {
 	Role    string `json:"role"`
 	Content string `json:"content"`
+	// ToolCalls são as ferramentas pedidas pelo assistente
+	ToolCalls []ToolCall `json:"tool_calls,omitempty"`
+	// ToolCallID liga uma mensagem "tool" à chamada que ela responde
+	ToolCallID string `json:"tool_call_id,omitempty"`
 }
 
 // NewAIClient cria um novo cliente de IA baseado na configuração
 func NewAIClient() (AIClient, error) {
+	return NewAIClientFor(FeatureDefault)
+}
+
+// NewAIClientFor cria um cliente de IA para uma funcionalidade, aplicando
+// sobreposições de linha de comando, padrões por funcionalidade e a cadeia de fallback
+func NewAIClientFor(feature Feature) (AIClient, error) {
 	config, err := LoadConfig()
 	if err != nil {
 		return nil, fmt.Errorf("erro ao carregar configuração: %w", err)
 	}
 
-	if config.Provider == "" {
-		// Fallback para Groq se não configurado
-		config.Provider = ProviderGroq
+	profileName, model := config.selectProfile(feature)
+	profile, err := config.ResolveProfile(profileName)
+	if err != nil {
+		return nil, err
 	}
 
-	if config.APIKey == "" {
-		return nil, fmt.Errorf("API key não configurada. Execute: snip ai config")
+	replayMode, cassetteDir, err := config.replaySettings()
+	if err != nil {
+		return nil, err
+	}
+
+	var redactor *Redactor
+	if rules := config.redactionRules(); !rules.Disabled {
+		if redactor, err = NewRedactor(rules); err != nil {
+			return nil, err
+		}
+	}
+
+	// Replay dispensa API key, cache e medição: tudo vem dos cassetes
+	if replayMode == ReplayModeReplay || profile.Provider == ProviderReplay {
+		client := NewReplayClient(cassetteDir)
+		if model != "" {
+			client.SetModel(model)
+		}
+		return withRedaction(client, redactor), nil
+	}
+
+	primary, err := newClientFromProfile(profile, model)
+	if err != nil {
+		return nil, err
 	}
 
-	switch config.Provider {
-	case ProviderGroq:
-		return NewGroqClientWithConfig(config)
-	case ProviderOpenAI:
-		return NewOpenAIClient(config)
-	case ProviderAnthropic:
-		return NewAnthropicClient(config)
-	case ProviderDeepSeek:
-		return NewDeepSeekClient(config)
-	case ProviderGrok:
-		return NewGrokClient(config)
-	case ProviderOpenRouter:
-		return NewOpenRouterClient(config)
-	default:
-		return nil, fmt.Errorf("provedor não suportado: %s", config.Provider)
+	clients := []AIClient{NewMeteredClient(primary, feature, config.Budget)}
+	fallback := &FallbackClient{}
+	for _, name := range config.Fallback {
+		if name == profileName || (name == DefaultProfileName && profileName == "") {
+			continue
+		}
+		client, err := config.fallbackClient(name)
+		if err != nil {
+			// Avisado só se o principal falhar: comandos sem IA também montam o cliente
+			fallback.skip(name, err)
+			continue
+		}
+		clients = append(clients, NewMeteredClient(client, feature, config.Budget))
 	}
+
+	var client AIClient = clients[0]
+	if len(clients) > 1 || len(fallback.skipped) > 0 {
+		fallback.clients = clients
+		client = fallback
+	}
+
+	// O cache fica por fora: respostas em cache não consomem orçamento
+	if config.Cache == nil || !config.Cache.Disabled {
+		client = NewCachedClient(client, config.Cache.GetTTL())
+	}
+
+	if replayMode == ReplayModeRecord {
+		client = NewRecordingClient(client, cassetteDir)
+	}
+
+	return withRedaction(client, redactor), nil
+}
+
+// withRedaction aplica a redação por fora de tudo: cache, cassetes e provedor só veem placeholders
+func withRedaction(client AIClient, redactor *Redactor) AIClient {
+	if redactor == nil {
+		return client
+	}
+	return NewRedactingClient(client, redactor)
 }
 
 // NewGroqClientWithConfig cria um cliente Groq com configuração
@@ -92,11 +156,16 @@ func NewGroqClientWithConfig(config *AIConfig) (*GroqClient, error) {
 		model = "openai/gpt-oss-120b" // Default Groq
 	}
 
+	httpClient, err := createHTTPClient()
+	if err != nil {
+		return nil, err
+	}
+
 	return &GroqClient{
 		apiKey:  config.APIKey,
 		model:   model,
 		baseURL: "https://api.groq.com/openai/v1/chat/completions",
-		client:  createHTTPClient(),
+		client:  httpClient,
 	}, nil
 }
 
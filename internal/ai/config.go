@@ -12,6 +12,49 @@ type AIConfig struct {
 	Provider Provider `json:"provider"`
 	Model    string   `json:"model"`
 	APIKey   string   `json:"api_key"`
+	// Endpoint, Deployment e APIVersion são usados pelo Azure OpenAI
+	Endpoint   string `json:"endpoint,omitempty"`
+	Deployment string `json:"deployment,omitempty"`
+	APIVersion string `json:"api_version,omitempty"`
+
+	// Perfis nomeados (ex: "fast" = Groq, "deep" = Anthropic)
+	Profiles      map[string]*AIProfile     `json:"profiles,omitempty"`
+	ActiveProfile string                    `json:"active_profile,omitempty"`
+	Features      map[Feature]FeatureConfig `json:"features,omitempty"`
+	// Fallback é a lista ordenada de perfis usados quando o provedor principal falha
+	Fallback []string `json:"fallback,omitempty"`
+	// Budget define limites mensais opcionais de custo/tokens
+	Budget *BudgetConfig `json:"budget,omitempty"`
+	// Cache configura o cache de respostas (ativo por padrão)
+	Cache *CacheConfig `json:"cache,omitempty"`
+	// Language define o conjunto de prompts e o idioma das respostas (pt-BR, en, es)
+	Language string `json:"language,omitempty"`
+	// MaxAgentSteps limita as chamadas de ferramentas por pergunta (db-chat, db-history chat)
+	MaxAgentSteps int `json:"max_agent_steps,omitempty"`
+	// AutoTag resume e sugere tags automaticamente ao criar/atualizar notas
+	AutoTag bool `json:"auto_tag,omitempty"`
+	// Replay grava ou reproduz respostas em cassetes (também via SNIP_AI_REPLAY)
+	Replay *ReplayConfig `json:"replay,omitempty"`
+	// Redaction mascara senhas, tokens e dados pessoais antes de enviar à IA (ativa por padrão)
+	Redaction *RedactionConfig `json:"redaction,omitempty"`
+	// ContextWindows sobrepõe a janela de contexto (tokens) estimada por modelo
+	ContextWindows map[string]int `json:"context_windows,omitempty"`
+}
+
+// AIProfile representa um perfil nomeado de provedor, modelo e API key
+type AIProfile struct {
+	Provider   Provider `json:"provider"`
+	Model      string   `json:"model"`
+	APIKey     string   `json:"api_key"`
+	Endpoint   string   `json:"endpoint,omitempty"`
+	Deployment string   `json:"deployment,omitempty"`
+	APIVersion string   `json:"api_version,omitempty"`
+}
+
+// FeatureConfig define o perfil e/ou modelo padrão de uma funcionalidade
+type FeatureConfig struct {
+	Profile string `json:"profile,omitempty"`
+	Model   string `json:"model,omitempty"`
 }
 
 // GetConfigPath retorna o caminho do arquivo de configuração
@@ -139,6 +182,21 @@ func GetAvailableModels(provider Provider) []string {
 			"meta-llama/llama-3.1-70b-instruct",
 			"mistralai/mixtral-8x7b-instruct",
 		}
+	case ProviderGemini:
+		return []string{
+			"gemini-2.0-flash",
+			"gemini-1.5-pro",
+			"gemini-1.5-flash",
+			"gemini-1.5-flash-8b",
+		}
+	case ProviderAzure:
+		// No Azure o modelo é o nome do deployment; estes são os nomes mais comuns
+		return []string{
+			"gpt-4o",
+			"gpt-4o-mini",
+			"gpt-4-turbo",
+			"gpt-35-turbo",
+		}
 	default:
 		return []string{}
 	}
@@ -0,0 +1,131 @@
+package ai
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+)
+
+// FallbackClient tenta uma cadeia ordenada de clientes até que um responda
+type FallbackClient struct {
+	clients []AIClient
+	// skipped são os perfis da cadeia que não puderam ser montados; só são
+	// avisados quando o fallback é de fato necessário
+	skipped     []string
+	warnSkipped sync.Once
+}
+
+// NewFallbackClient cria um cliente com fallback; o primeiro cliente é o principal
+func NewFallbackClient(clients ...AIClient) *FallbackClient {
+	return &FallbackClient{clients: clients}
+}
+
+// skip registra um perfil da cadeia que ficou de fora
+func (f *FallbackClient) skip(name string, err error) {
+	f.skipped = append(f.skipped, fmt.Sprintf("⚠️  Perfil de fallback '%s' ignorado: %v", name, err))
+}
+
+func (f *FallbackClient) GetProvider() Provider {
+	return f.clients[0].GetProvider()
+}
+
+func (f *FallbackClient) GetModel() string {
+	return f.clients[0].GetModel()
+}
+
+func (f *FallbackClient) SetModel(model string) {
+	f.clients[0].SetModel(model)
+}
+
+func (f *FallbackClient) Chat(messages []Message, maxTokens int, temperature float64) (string, error) {
+	return f.try(func(client AIClient) (string, error) {
+		return client.Chat(messages, maxTokens, temperature)
+	})
+}
+
+func (f *FallbackClient) ChatJSONMode(messages []Message, maxTokens int, temperature float64) (string, error) {
+	return f.try(func(client AIClient) (string, error) {
+		return chatJSONMode(client, messages, maxTokens, temperature)
+	})
+}
+
+func (f *FallbackClient) ChatWithTools(messages []Message, tools []Tool, maxTokens int, temperature float64) (*ToolResponse, error) {
+	var response *ToolResponse
+	_, err := f.try(func(client AIClient) (string, error) {
+		var err error
+		response, err = client.ChatWithTools(messages, tools, maxTokens, temperature)
+		return "", err
+	})
+	return response, err
+}
+
+func (f *FallbackClient) try(call func(client AIClient) (string, error)) (string, error) {
+	response, _, err := f.tryWith(call)
+	return response, err
+}
+
+// tryWith percorre a cadeia e retorna também o cliente que respondeu
+func (f *FallbackClient) tryWith(call func(client AIClient) (string, error)) (string, AIClient, error) {
+	var errs []string
+	for i, client := range f.clients {
+		response, err := call(client)
+		if err == nil {
+			return response, client, nil
+		}
+		// O orçamento é global: tentar outro provedor não resolveria
+		if errors.Is(err, ErrBudgetExceeded) {
+			return "", nil, err
+		}
+
+		errs = append(errs, fmt.Sprintf("%s/%s: %v", client.GetProvider(), client.GetModel(), err))
+		f.warnSkipped.Do(func() {
+			for _, warning := range f.skipped {
+				fmt.Fprintln(os.Stderr, warning)
+			}
+		})
+		if i < len(f.clients)-1 {
+			next := f.clients[i+1]
+			fmt.Fprintf(os.Stderr, "⚠️  %s falhou, tentando %s/%s...\n", client.GetProvider(), next.GetProvider(), next.GetModel())
+		}
+	}
+
+	return "", nil, fmt.Errorf("todos os provedores de IA falharam: %s", strings.Join(errs, "; "))
+}
+
+func (f *FallbackClient) ChatJSON(messages []Message, schema interface{}, maxTokens int, temperature float64) error {
+	return chatJSONGeneric(f, messages, schema, maxTokens, temperature)
+}
+
+func (f *FallbackClient) GenerateContent(prompt string, maxTokens int) (string, error) {
+	return generateContentGeneric(f, prompt, maxTokens)
+}
+
+func (f *FallbackClient) GenerateNoteContent(topic string, context string) (string, error) {
+	return generateNoteContentGeneric(f, topic, context)
+}
+
+func (f *FallbackClient) ImproveSearchQuery(query string, notesContext []string) (string, error) {
+	return improveSearchQueryGeneric(f, query, notesContext)
+}
+
+func (f *FallbackClient) AnswerQuestion(question string, notesContext []string) (string, error) {
+	return answerQuestionGeneric(f, question, notesContext)
+}
+
+func (f *FallbackClient) GenerateCode(language string, description string, context string) (string, error) {
+	return generateCodeGeneric(f, language, description, context)
+}
+
+func (f *FallbackClient) GenerateTips(topic string) (string, error) {
+	return generateTipsGeneric(f, topic)
+}
+
+func (f *FallbackClient) GenerateChecklist(topic string, context string, numItems int) ([]string, error) {
+	return generateChecklistGeneric(f, topic, context, numItems)
+}
+
+func (f *FallbackClient) GenerateProjectPlan(projectName string, description string) (string, error) {
+	return generateProjectPlanGeneric(f, projectName, description)
+}
@@ -0,0 +1,73 @@
+package ai
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MaxFlashcardsPerNote limita quantos cartões são gerados por nota
+const MaxFlashcardsPerNote = 10
+
+// maxFlashcardContent limita o conteúdo da nota enviado ao modelo
+const maxFlashcardContent = 8000
+
+// Flashcard é um par pergunta/resposta gerado a partir de uma nota
+type Flashcard struct {
+	Question string `json:"question" ai:"required"`
+	Answer   string `json:"answer" ai:"required"`
+}
+
+// FlashcardSet é a resposta JSON esperada do prompt study.flashcards
+type FlashcardSet struct {
+	Cards []Flashcard `json:"cards"`
+}
+
+// Validate exige ao menos um cartão
+func (s *FlashcardSet) Validate() error {
+	if len(s.Cards) == 0 {
+		return fmt.Errorf("nenhum cartão gerado")
+	}
+	return nil
+}
+
+// GenerateFlashcards pede ao modelo até maxCards cartões de pergunta/resposta
+// sobre o conteúdo da nota. Cartões repetidos são descartados.
+func GenerateFlashcards(client AIClient, title, content string, maxCards int) ([]Flashcard, error) {
+	if maxCards <= 0 || maxCards > MaxFlashcardsPerNote {
+		maxCards = MaxFlashcardsPerNote
+	}
+	if runes := []rune(content); len(runes) > maxFlashcardContent {
+		content = string(runes[:maxFlashcardContent]) + "..."
+	}
+
+	messages, err := PromptMessages("study.flashcards", PromptData{
+		"Title":    title,
+		"Content":  content,
+		"MaxCards": maxCards,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var result FlashcardSet
+	if err := client.ChatJSON(messages, &result, 2000, 0.3); err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	var cards []Flashcard
+	for _, c := range result.Cards {
+		c.Question = strings.TrimSpace(c.Question)
+		c.Answer = strings.TrimSpace(c.Answer)
+		key := strings.ToLower(c.Question)
+		if c.Question == "" || c.Answer == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		cards = append(cards, c)
+		if len(cards) == maxCards {
+			break
+		}
+	}
+	return cards, nil
+}
@@ -0,0 +1,329 @@
+package ai
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// GeminiClient implementa o cliente Google Gemini (API generateContent)
+type GeminiClient struct {
+	apiKey    string
+	model     string
+	baseURL   string
+	client    *http.Client
+	lastUsage Usage
+}
+
+// NewGeminiClient cria um novo cliente Gemini
+func NewGeminiClient(config *AIConfig) (*GeminiClient, error) {
+	if config.APIKey == "" {
+		return nil, fmt.Errorf("API key não configurada")
+	}
+
+	model := config.Model
+	if model == "" {
+		model = "gemini-2.0-flash"
+	}
+
+	// endpoint permite apontar para um proxy ou gateway compatível
+	baseURL := "https://generativelanguage.googleapis.com/v1beta"
+	if config.Endpoint != "" {
+		baseURL = strings.TrimRight(config.Endpoint, "/")
+	}
+
+	httpClient, err := createHTTPClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return &GeminiClient{
+		apiKey:  config.APIKey,
+		model:   model,
+		baseURL: baseURL,
+		client:  httpClient,
+	}, nil
+}
+
+func (g *GeminiClient) GetProvider() Provider {
+	return ProviderGemini
+}
+
+func (g *GeminiClient) GetModel() string {
+	return g.model
+}
+
+func (g *GeminiClient) SetModel(model string) {
+	g.model = model
+}
+
+// LastUsage retorna o consumo de tokens da última resposta
+func (g *GeminiClient) LastUsage() Usage {
+	return g.lastUsage
+}
+
+func (g *GeminiClient) Chat(messages []Message, maxTokens int, temperature float64) (string, error) {
+	parts, err := g.send(messages, nil, maxTokens, temperature, false)
+	if err != nil {
+		return "", err
+	}
+	return geminiText(parts), nil
+}
+
+// ChatJSONMode usa responseMimeType application/json da API
+func (g *GeminiClient) ChatJSONMode(messages []Message, maxTokens int, temperature float64) (string, error) {
+	parts, err := g.send(messages, nil, maxTokens, temperature, true)
+	if err != nil {
+		return "", err
+	}
+	return geminiText(parts), nil
+}
+
+// ChatWithTools usa functionDeclarations e as partes functionCall/functionResponse
+func (g *GeminiClient) ChatWithTools(messages []Message, tools []Tool, maxTokens int, temperature float64) (*ToolResponse, error) {
+	parts, err := g.send(messages, tools, maxTokens, temperature, false)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ToolResponse{Content: geminiText(parts)}
+	for _, part := range parts {
+		if part.FunctionCall == nil {
+			continue
+		}
+		// A API não identifica as chamadas; o ID liga a resposta da ferramenta à chamada
+		response.ToolCalls = append(response.ToolCalls, ToolCall{
+			ID:   fmt.Sprintf("call_%d", len(response.ToolCalls)+1),
+			Type: "function",
+			Function: ToolCallFunction{
+				Name:      part.FunctionCall.Name,
+				Arguments: argumentsOrEmpty(string(part.FunctionCall.Args)),
+			},
+		})
+	}
+
+	return response, nil
+}
+
+// geminiPart é uma parte do conteúdo (texto, chamada ou resposta de função)
+type geminiPart struct {
+	Text             string                  `json:"text,omitempty"`
+	FunctionCall     *geminiFunctionCall     `json:"functionCall,omitempty"`
+	FunctionResponse *geminiFunctionResponse `json:"functionResponse,omitempty"`
+}
+
+type geminiFunctionCall struct {
+	Name string          `json:"name"`
+	Args json.RawMessage `json:"args,omitempty"`
+}
+
+type geminiFunctionResponse struct {
+	Name     string                 `json:"name"`
+	Response map[string]interface{} `json:"response"`
+}
+
+type geminiContent struct {
+	Role  string       `json:"role,omitempty"`
+	Parts []geminiPart `json:"parts"`
+}
+
+func geminiText(parts []geminiPart) string {
+	var texts []string
+	for _, part := range parts {
+		if part.Text != "" {
+			texts = append(texts, part.Text)
+		}
+	}
+	return strings.Join(texts, "")
+}
+
+// geminiContents converte as mensagens para o formato da API: o prompt de
+// sistema vai em systemInstruction, o assistente usa o papel "model" e os
+// resultados de ferramentas viram functionResponse com o nome da função
+func geminiContents(messages []Message) (*geminiContent, []geminiContent) {
+	var system []string
+	var contents []geminiContent
+	toolNames := make(map[string]string)
+
+	appendParts := func(role string, parts ...geminiPart) {
+		// A API exige papéis alternados: partes consecutivas do mesmo papel são unidas
+		if n := len(contents); n > 0 && contents[n-1].Role == role {
+			contents[n-1].Parts = append(contents[n-1].Parts, parts...)
+			return
+		}
+		contents = append(contents, geminiContent{Role: role, Parts: parts})
+	}
+
+	for _, msg := range messages {
+		switch {
+		case msg.Role == "system":
+			system = append(system, msg.Content)
+		case msg.Role == "tool":
+			appendParts("user", geminiPart{FunctionResponse: &geminiFunctionResponse{
+				Name:     toolNames[msg.ToolCallID],
+				Response: map[string]interface{}{"content": msg.Content},
+			}})
+		case msg.Role == "assistant":
+			var parts []geminiPart
+			if msg.Content != "" {
+				parts = append(parts, geminiPart{Text: msg.Content})
+			}
+			for _, call := range msg.ToolCalls {
+				toolNames[call.ID] = call.Function.Name
+				parts = append(parts, geminiPart{FunctionCall: &geminiFunctionCall{
+					Name: call.Function.Name,
+					Args: json.RawMessage(argumentsOrEmpty(call.Function.Arguments)),
+				}})
+			}
+			if len(parts) > 0 {
+				appendParts("model", parts...)
+			}
+		default:
+			appendParts("user", geminiPart{Text: msg.Content})
+		}
+	}
+
+	if len(system) == 0 {
+		return nil, contents
+	}
+	return &geminiContent{Parts: []geminiPart{{Text: strings.Join(system, "\n\n")}}}, contents
+}
+
+// geminiFunctionDeclarations converte as ferramentas; a API rejeita objetos
+// sem propriedades, então ferramentas sem argumentos vão sem parameters
+func geminiFunctionDeclarations(tools []Tool) []map[string]interface{} {
+	var declarations []map[string]interface{}
+	for _, tool := range tools {
+		declaration := map[string]interface{}{
+			"name":        tool.Name,
+			"description": tool.Description,
+		}
+		if props, ok := tool.Parameters["properties"].(map[string]interface{}); ok && len(props) > 0 {
+			declaration["parameters"] = tool.Parameters
+		}
+		declarations = append(declarations, declaration)
+	}
+	return declarations
+}
+
+func (g *GeminiClient) send(messages []Message, tools []Tool, maxTokens int, temperature float64, jsonMode bool) ([]geminiPart, error) {
+	system, contents := geminiContents(messages)
+
+	generationConfig := map[string]interface{}{
+		"maxOutputTokens": maxTokens,
+		"temperature":     temperature,
+	}
+	if jsonMode {
+		generationConfig["responseMimeType"] = "application/json"
+	}
+
+	reqBody := map[string]interface{}{
+		"contents":         contents,
+		"generationConfig": generationConfig,
+	}
+	if system != nil {
+		reqBody["systemInstruction"] = system
+	}
+	if len(tools) > 0 {
+		reqBody["tools"] = []map[string]interface{}{
+			{"functionDeclarations": geminiFunctionDeclarations(tools)},
+		}
+	}
+
+	jsonData, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/models/%s:generateContent", g.baseURL, g.model)
+	req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("x-goog-api-key", g.apiKey)
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var response struct {
+		Candidates []struct {
+			Content      geminiContent `json:"content"`
+			FinishReason string        `json:"finishReason"`
+		} `json:"candidates"`
+		UsageMetadata struct {
+			PromptTokenCount     int `json:"promptTokenCount"`
+			CandidatesTokenCount int `json:"candidatesTokenCount"`
+		} `json:"usageMetadata"`
+		PromptFeedback struct {
+			BlockReason string `json:"blockReason"`
+		} `json:"promptFeedback"`
+	}
+
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	g.lastUsage = Usage{InputTokens: response.UsageMetadata.PromptTokenCount, OutputTokens: response.UsageMetadata.CandidatesTokenCount}
+
+	if response.PromptFeedback.BlockReason != "" {
+		return nil, fmt.Errorf("prompt blocked: %s", response.PromptFeedback.BlockReason)
+	}
+	if len(response.Candidates) == 0 || len(response.Candidates[0].Content.Parts) == 0 {
+		return nil, fmt.Errorf("no content in response")
+	}
+
+	return response.Candidates[0].Content.Parts, nil
+}
+
+func (g *GeminiClient) ChatJSON(messages []Message, schema interface{}, maxTokens int, temperature float64) error {
+	return chatJSONGeneric(g, messages, schema, maxTokens, temperature)
+}
+
+func (g *GeminiClient) GenerateContent(prompt string, maxTokens int) (string, error) {
+	return generateContentGeneric(g, prompt, maxTokens)
+}
+
+func (g *GeminiClient) GenerateNoteContent(topic string, context string) (string, error) {
+	return generateNoteContentGeneric(g, topic, context)
+}
+
+func (g *GeminiClient) ImproveSearchQuery(query string, notesContext []string) (string, error) {
+	return improveSearchQueryGeneric(g, query, notesContext)
+}
+
+func (g *GeminiClient) AnswerQuestion(question string, notesContext []string) (string, error) {
+	return answerQuestionGeneric(g, question, notesContext)
+}
+
+func (g *GeminiClient) GenerateCode(language string, description string, context string) (string, error) {
+	return generateCodeGeneric(g, language, description, context)
+}
+
+func (g *GeminiClient) GenerateTips(topic string) (string, error) {
+	return generateTipsGeneric(g, topic)
+}
+
+func (g *GeminiClient) GenerateChecklist(topic string, context string, numItems int) ([]string, error) {
+	return generateChecklistGeneric(g, topic, context, numItems)
+}
+
+func (g *GeminiClient) GenerateProjectPlan(projectName string, description string) (string, error) {
+	return generateProjectPlanGeneric(g, projectName, description)
+}
@@ -10,10 +10,11 @@ import (
 
 // GrokClient implementa o cliente Grok (xAI)
 type GrokClient struct {
-	apiKey  string
-	model   string
-	baseURL string
-	client  *http.Client
+	apiKey    string
+	model     string
+	baseURL   string
+	client    *http.Client
+	lastUsage Usage
 }
 
 // NewGrokClient cria um novo cliente Grok
@@ -27,11 +28,16 @@ func NewGrokClient(config *AIConfig) (*GrokClient, error) {
 		model = "grok-beta"
 	}
 
+	httpClient, err := createHTTPClient()
+	if err != nil {
+		return nil, err
+	}
+
 	return &GrokClient{
 		apiKey:  config.APIKey,
 		model:   model,
 		baseURL: "https://api.x.ai/v1/chat/completions",
-		client:  createHTTPClient(),
+		client:  httpClient,
 	}, nil
 }
 
@@ -47,28 +53,52 @@ func (g *GrokClient) SetModel(model string) {
 	g.model = model
 }
 
+// LastUsage retorna o consumo de tokens da última resposta
+func (g *GrokClient) LastUsage() Usage {
+	return g.lastUsage
+}
+
 func (g *GrokClient) Chat(messages []Message, maxTokens int, temperature float64) (string, error) {
-	reqBody := ChatRequest{
-		Model:    g.model,
-		Messages: messages,
+	message, err := g.chat(newChatRequest(g.model, messages, maxTokens, temperature))
+	if err != nil {
+		return "", err
 	}
+	return message.Content, nil
+}
+
+// ChatJSONMode usa o response_format json_object da API
+func (g *GrokClient) ChatJSONMode(messages []Message, maxTokens int, temperature float64) (string, error) {
+	reqBody := newChatRequest(g.model, messages, maxTokens, temperature)
+	reqBody.ResponseFormat = &ResponseFormat{Type: "json_object"}
 
-	if maxTokens > 0 {
-		reqBody.MaxTokens = maxTokens
+	message, err := g.chat(reqBody)
+	if err != nil {
+		return "", err
 	}
+	return message.Content, nil
+}
 
-	if temperature > 0 {
-		reqBody.Temperature = temperature
+// ChatWithTools usa o campo tools (function calling) da API
+func (g *GrokClient) ChatWithTools(messages []Message, tools []Tool, maxTokens int, temperature float64) (*ToolResponse, error) {
+	reqBody := newChatRequest(g.model, messages, maxTokens, temperature)
+	reqBody.Tools = toOpenAITools(tools)
+
+	message, err := g.chat(reqBody)
+	if err != nil {
+		return nil, err
 	}
+	return &ToolResponse{Content: message.Content, ToolCalls: message.ToolCalls}, nil
+}
 
+func (g *GrokClient) chat(reqBody ChatRequest) (*Message, error) {
 	jsonData, err := json.Marshal(reqBody)
 	if err != nil {
-		return "", fmt.Errorf("failed to marshal request: %w", err)
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
 	req, err := http.NewRequest("POST", g.baseURL, bytes.NewBuffer(jsonData))
 	if err != nil {
-		return "", fmt.Errorf("failed to create request: %w", err)
+		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	req.Header.Set("Content-Type", "application/json")
@@ -76,29 +106,35 @@ func (g *GrokClient) Chat(messages []Message, maxTokens int, temperature float64
 
 	resp, err := g.client.Do(req)
 	if err != nil {
-		return "", fmt.Errorf("failed to send request: %w", err)
+		return nil, fmt.Errorf("failed to send request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return "", fmt.Errorf("failed to read response: %w", err)
+		return nil, fmt.Errorf("failed to read response: %w", err)
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
 	}
 
 	var chatResp ChatResponse
 	if err := json.Unmarshal(body, &chatResp); err != nil {
-		return "", fmt.Errorf("failed to parse response: %w", err)
+		return nil, fmt.Errorf("failed to parse response: %w", err)
 	}
 
+	g.lastUsage = Usage{InputTokens: chatResp.Usage.PromptTokens, OutputTokens: chatResp.Usage.CompletionTokens}
+
 	if len(chatResp.Choices) == 0 {
-		return "", fmt.Errorf("no choices in response")
+		return nil, fmt.Errorf("no choices in response")
 	}
 
-	return chatResp.Choices[0].Message.Content, nil
+	return &chatResp.Choices[0].Message, nil
+}
+
+func (g *GrokClient) ChatJSON(messages []Message, schema interface{}, maxTokens int, temperature float64) error {
+	return chatJSONGeneric(g, messages, schema, maxTokens, temperature)
 }
 
 func (g *GrokClient) GenerateContent(prompt string, maxTokens int) (string, error) {
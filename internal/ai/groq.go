@@ -6,7 +6,6 @@ import (
 	"fmt"
 	"io"
 	"net/http"
-	"time"
 )
 
 const (
@@ -15,10 +14,11 @@ const (
 )
 
 type GroqClient struct {
-	apiKey  string
-	model   string
-	baseURL string
-	client  *http.Client
+	apiKey    string
+	model     string
+	baseURL   string
+	client    *http.Client
+	lastUsage Usage
 }
 
 // GetProvider retorna o provedor
@@ -32,10 +32,30 @@ func (g *GroqClient) GetModel() string {
 }
 
 type ChatRequest struct {
-	Model       string    `json:"model"`
-	Messages    []Message `json:"messages"`
-	MaxTokens   int       `json:"max_tokens,omitempty"`
-	Temperature float64   `json:"temperature,omitempty"`
+	Model          string          `json:"model"`
+	Messages       []Message       `json:"messages"`
+	MaxTokens      int             `json:"max_tokens,omitempty"`
+	Temperature    float64         `json:"temperature,omitempty"`
+	ResponseFormat *ResponseFormat `json:"response_format,omitempty"`
+	Tools          []openAITool    `json:"tools,omitempty"`
+}
+
+// newChatRequest monta a requisição das APIs compatíveis com OpenAI
+func newChatRequest(model string, messages []Message, maxTokens int, temperature float64) ChatRequest {
+	reqBody := ChatRequest{
+		Model:    model,
+		Messages: messages,
+	}
+
+	if maxTokens > 0 {
+		reqBody.MaxTokens = maxTokens
+	}
+
+	if temperature > 0 {
+		reqBody.Temperature = temperature
+	}
+
+	return reqBody
 }
 
 type ChatResponse struct {
@@ -69,13 +89,16 @@ func NewGroqClient() (*GroqClient, error) {
 		return nil, fmt.Errorf("GROQ_API_KEY environment variable is not set")
 	}
 
+	httpClient, err := createHTTPClient()
+	if err != nil {
+		return nil, err
+	}
+
 	return &GroqClient{
 		apiKey:  apiKey,
 		model:   DefaultModel,
 		baseURL: GroqAPIURL,
-		client: &http.Client{
-			Timeout: 30 * time.Second,
-		},
+		client:  httpClient,
 	}, nil
 }
 
@@ -83,28 +106,52 @@ func (g *GroqClient) SetModel(model string) {
 	g.model = model
 }
 
+// LastUsage retorna o consumo de tokens da última resposta
+func (g *GroqClient) LastUsage() Usage {
+	return g.lastUsage
+}
+
 func (g *GroqClient) Chat(messages []Message, maxTokens int, temperature float64) (string, error) {
-	reqBody := ChatRequest{
-		Model:    g.model,
-		Messages: messages,
+	message, err := g.chat(newChatRequest(g.model, messages, maxTokens, temperature))
+	if err != nil {
+		return "", err
 	}
+	return message.Content, nil
+}
 
-	if maxTokens > 0 {
-		reqBody.MaxTokens = maxTokens
+// ChatJSONMode usa o response_format json_object da API
+func (g *GroqClient) ChatJSONMode(messages []Message, maxTokens int, temperature float64) (string, error) {
+	reqBody := newChatRequest(g.model, messages, maxTokens, temperature)
+	reqBody.ResponseFormat = &ResponseFormat{Type: "json_object"}
+
+	message, err := g.chat(reqBody)
+	if err != nil {
+		return "", err
 	}
+	return message.Content, nil
+}
 
-	if temperature > 0 {
-		reqBody.Temperature = temperature
+// ChatWithTools usa o campo tools (function calling) da API
+func (g *GroqClient) ChatWithTools(messages []Message, tools []Tool, maxTokens int, temperature float64) (*ToolResponse, error) {
+	reqBody := newChatRequest(g.model, messages, maxTokens, temperature)
+	reqBody.Tools = toOpenAITools(tools)
+
+	message, err := g.chat(reqBody)
+	if err != nil {
+		return nil, err
 	}
+	return &ToolResponse{Content: message.Content, ToolCalls: message.ToolCalls}, nil
+}
 
+func (g *GroqClient) chat(reqBody ChatRequest) (*Message, error) {
 	jsonData, err := json.Marshal(reqBody)
 	if err != nil {
-		return "", fmt.Errorf("failed to marshal request: %w", err)
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
 	req, err := http.NewRequest("POST", g.baseURL, bytes.NewBuffer(jsonData))
 	if err != nil {
-		return "", fmt.Errorf("failed to create request: %w", err)
+		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	req.Header.Set("Content-Type", "application/json")
@@ -112,34 +159,40 @@ func (g *GroqClient) Chat(messages []Message, maxTokens int, temperature float64
 
 	resp, err := g.client.Do(req)
 	if err != nil {
-		return "", fmt.Errorf("failed to send request: %w", err)
+		return nil, fmt.Errorf("failed to send request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return "", fmt.Errorf("failed to read response: %w", err)
+		return nil, fmt.Errorf("failed to read response: %w", err)
 	}
 
 	if resp.StatusCode != http.StatusOK {
 		var groqErr GroqError
 		if err := json.Unmarshal(body, &groqErr); err == nil {
-			return "", fmt.Errorf("groq API error: %s (type: %s, code: %s)",
+			return nil, fmt.Errorf("groq API error: %s (type: %s, code: %s)",
 				groqErr.Error.Message, groqErr.Error.Type, groqErr.Error.Code)
 		}
-		return "", fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
 	}
 
 	var chatResp ChatResponse
 	if err := json.Unmarshal(body, &chatResp); err != nil {
-		return "", fmt.Errorf("failed to parse response: %w", err)
+		return nil, fmt.Errorf("failed to parse response: %w", err)
 	}
 
+	g.lastUsage = Usage{InputTokens: chatResp.Usage.PromptTokens, OutputTokens: chatResp.Usage.CompletionTokens}
+
 	if len(chatResp.Choices) == 0 {
-		return "", fmt.Errorf("no choices in response")
+		return nil, fmt.Errorf("no choices in response")
 	}
 
-	return chatResp.Choices[0].Message.Content, nil
+	return &chatResp.Choices[0].Message, nil
+}
+
+func (g *GroqClient) ChatJSON(messages []Message, schema interface{}, maxTokens int, temperature float64) error {
+	return chatJSONGeneric(g, messages, schema, maxTokens, temperature)
 }
 
 func (g *GroqClient) GenerateContent(prompt string, maxTokens int) (string, error) {
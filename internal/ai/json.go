@@ -0,0 +1,316 @@
+package ai
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// JSONModeClient é implementado pelos clientes cuja API tem modo JSON nativo
+type JSONModeClient interface {
+	ChatJSONMode(messages []Message, maxTokens int, temperature float64) (string, error)
+}
+
+// Validator pode ser implementado pelo schema para validações adicionais
+type Validator interface {
+	Validate() error
+}
+
+// ErrInvalidJSON indica que a resposta continuou inválida após a nova tentativa
+var ErrInvalidJSON = errors.New("resposta JSON inválida da IA")
+
+// ResponseFormat ativa o modo JSON nas APIs compatíveis com OpenAI
+type ResponseFormat struct {
+	Type string `json:"type"`
+}
+
+var (
+	trailingCommaPattern = regexp.MustCompile(`,\s*([}\]])`)
+	smartQuotesReplacer  = strings.NewReplacer("“", `"`, "”", `"`)
+)
+
+// chatJSONMode usa o modo JSON nativo quando o cliente oferece, senão o Chat comum
+func chatJSONMode(client AIClient, messages []Message, maxTokens int, temperature float64) (string, error) {
+	if jc, ok := client.(JSONModeClient); ok {
+		return jc.ChatJSONMode(messages, maxTokens, temperature)
+	}
+	return client.Chat(messages, maxTokens, temperature)
+}
+
+// chatJSONGeneric pede uma resposta JSON, extrai, repara e valida contra o
+// schema (ponteiro para struct/slice). Em caso de erro tenta mais uma vez,
+// informando ao modelo o erro de validação.
+func chatJSONGeneric(client AIClient, messages []Message, schema interface{}, maxTokens int, temperature float64) error {
+	if reflect.ValueOf(schema).Kind() != reflect.Ptr {
+		return fmt.Errorf("schema deve ser um ponteiro")
+	}
+
+	response, err := chatJSONMode(client, messages, maxTokens, temperature)
+	if err != nil {
+		return err
+	}
+
+	validationErr := decodeJSONResponse(response, schema)
+	if validationErr == nil {
+		return nil
+	}
+
+	_, repair, err := RenderPrompt("json.repair", PromptData{
+		"Error":    validationErr.Error(),
+		"Skeleton": jsonSkeleton(reflect.TypeOf(schema)),
+	})
+	if err != nil {
+		return err
+	}
+
+	retry := append(append([]Message{}, messages...),
+		Message{Role: "assistant", Content: response},
+		Message{Role: "user", Content: repair},
+	)
+
+	response, err = chatJSONMode(client, retry, maxTokens, temperature)
+	if err != nil {
+		return err
+	}
+
+	if err := decodeJSONResponse(response, schema); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidJSON, err)
+	}
+	return nil
+}
+
+// decodeJSONResponse extrai o JSON da resposta, aplica reparos simples e valida
+func decodeJSONResponse(response string, schema interface{}) error {
+	raw := ExtractJSON(response)
+	if raw == "" {
+		return errors.New("nenhum JSON encontrado na resposta")
+	}
+
+	// Zerar o destino antes de cada tentativa
+	target := reflect.ValueOf(schema).Elem()
+	target.Set(reflect.Zero(target.Type()))
+
+	if err := json.Unmarshal([]byte(raw), schema); err != nil {
+		repaired := RepairJSON(raw)
+		target.Set(reflect.Zero(target.Type()))
+		if err2 := json.Unmarshal([]byte(repaired), schema); err2 != nil {
+			return fmt.Errorf("JSON inválido: %w", err)
+		}
+	}
+
+	return ValidateJSONSchema(schema)
+}
+
+// ExtractJSON remove blocos markdown e texto ao redor, retornando o primeiro
+// objeto ou array JSON da resposta
+func ExtractJSON(response string) string {
+	response = strings.TrimSpace(response)
+
+	if idx := strings.Index(response, "```"); idx >= 0 {
+		rest := response[idx+3:]
+		rest = strings.TrimPrefix(rest, "json")
+		if end := strings.Index(rest, "```"); end >= 0 {
+			response = strings.TrimSpace(rest[:end])
+		} else {
+			response = strings.TrimSpace(rest)
+		}
+	}
+
+	start := strings.IndexAny(response, "{[")
+	if start < 0 {
+		return ""
+	}
+
+	open := response[start]
+	close := byte('}')
+	if open == '[' {
+		close = ']'
+	}
+
+	// Encontrar o fechamento correspondente, respeitando strings
+	depth := 0
+	inString := false
+	escaped := false
+	for i := start; i < len(response); i++ {
+		ch := response[i]
+		if escaped {
+			escaped = false
+			continue
+		}
+		switch {
+		case ch == '\\' && inString:
+			escaped = true
+		case ch == '"':
+			inString = !inString
+		case inString:
+		case ch == open:
+			depth++
+		case ch == close:
+			depth--
+			if depth == 0 {
+				return response[start : i+1]
+			}
+		}
+	}
+
+	// JSON truncado: devolver o que houver para tentativa de reparo
+	return response[start:]
+}
+
+// RepairJSON corrige problemas comuns: vírgulas finais, aspas tipográficas e
+// estruturas não fechadas (respostas truncadas)
+func RepairJSON(raw string) string {
+	raw = smartQuotesReplacer.Replace(raw)
+	raw = trailingCommaPattern.ReplaceAllString(raw, "$1")
+
+	var stack []byte
+	inString := false
+	escaped := false
+	for i := 0; i < len(raw); i++ {
+		ch := raw[i]
+		if escaped {
+			escaped = false
+			continue
+		}
+		switch {
+		case ch == '\\' && inString:
+			escaped = true
+		case ch == '"':
+			inString = !inString
+		case inString:
+		case ch == '{':
+			stack = append(stack, '}')
+		case ch == '[':
+			stack = append(stack, ']')
+		case ch == '}' || ch == ']':
+			if len(stack) > 0 {
+				stack = stack[:len(stack)-1]
+			}
+		}
+	}
+
+	if inString {
+		raw += `"`
+	}
+	raw = strings.TrimRight(strings.TrimSpace(raw), ",")
+	for i := len(stack) - 1; i >= 0; i-- {
+		raw += string(stack[i])
+	}
+	return trailingCommaPattern.ReplaceAllString(raw, "$1")
+}
+
+// ValidateJSONSchema verifica os campos marcados com `ai:"required"` e chama
+// Validate() quando o schema o implementa
+func ValidateJSONSchema(schema interface{}) error {
+	if err := validateRequired(reflect.ValueOf(schema), ""); err != nil {
+		return err
+	}
+	if v, ok := schema.(Validator); ok {
+		return v.Validate()
+	}
+	return nil
+}
+
+func validateRequired(v reflect.Value, path string) error {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			name := jsonFieldName(field)
+			fieldPath := name
+			if path != "" {
+				fieldPath = path + "." + name
+			}
+			value := v.Field(i)
+			if field.Tag.Get("ai") == "required" && isMissing(value) {
+				return fmt.Errorf("campo obrigatório ausente: %s", fieldPath)
+			}
+			if err := validateRequired(value, fieldPath); err != nil {
+				return err
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if err := validateRequired(v.Index(i), fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// isMissing trata slices e maps vazios como ausentes: "[]" não preenche um
+// campo obrigatório
+func isMissing(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Slice, reflect.Map:
+		return v.Len() == 0
+	}
+	return v.IsZero()
+}
+
+func jsonFieldName(field reflect.StructField) string {
+	tag := strings.Split(field.Tag.Get("json"), ",")[0]
+	if tag == "" {
+		return field.Name
+	}
+	return tag
+}
+
+// jsonSkeleton descreve a estrutura esperada a partir do tipo Go do schema
+func jsonSkeleton(t reflect.Type) string {
+	data, err := json.MarshalIndent(skeletonValue(t, 0), "", "  ")
+	if err != nil {
+		return "{}"
+	}
+	return string(data)
+}
+
+func skeletonValue(t reflect.Type, depth int) interface{} {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if depth > 5 {
+		return nil
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		obj := make(map[string]interface{})
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() || field.Tag.Get("json") == "-" {
+				continue
+			}
+			obj[jsonFieldName(field)] = skeletonValue(field.Type, depth+1)
+		}
+		return obj
+	case reflect.Slice, reflect.Array:
+		return []interface{}{skeletonValue(t.Elem(), depth+1)}
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return false
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return 0
+	case reflect.Float32, reflect.Float64:
+		return 0.0
+	default:
+		return nil
+	}
+}
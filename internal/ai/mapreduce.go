@@ -0,0 +1,258 @@
+package ai
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Estratégias usadas por ChatLongText (gravadas nos metadados do resultado)
+const (
+	StrategySingle    = "single"
+	StrategyMapReduce = "map-reduce"
+)
+
+const (
+	// maxChunkTokens limita cada parte mesmo em modelos de janela grande
+	maxChunkTokens = 16000
+	// mapPromptTokens reserva espaço para as instruções do passo de resumo
+	mapPromptTokens = 600
+	// mapSummaryTokens é o tamanho máximo do resumo de cada parte
+	mapSummaryTokens = 800
+	// maxReduceLevels limita quantas vezes os resumos são resumidos de novo
+	maxReduceLevels = 3
+)
+
+// LongTextRequest é uma requisição cujo conteúdo pode não caber na janela do modelo
+type LongTextRequest struct {
+	// Text é o conteúdo potencialmente grande (ex: resultado de uma análise)
+	Text string
+	// Subject identifica o conteúdo no passo de resumo (ex: "oracle / logs")
+	Subject string
+	// Build monta as mensagens finais com o texto original ou, quando summarized
+	// é verdadeiro, com a junção dos resumos das partes
+	Build       func(text string, summarized bool) ([]Message, error)
+	MaxTokens   int
+	Temperature float64
+}
+
+// LongTextResult é a resposta e a estratégia usada para obtê-la
+type LongTextResult struct {
+	Content         string
+	Strategy        string
+	Chunks          int
+	EstimatedTokens int
+	ContextWindow   int
+}
+
+// Metadata retorna a estratégia em formato chave/valor para gravar junto ao resultado
+func (r *LongTextResult) Metadata() map[string]string {
+	return map[string]string{
+		"strategy":         r.Strategy,
+		"chunks":           strconv.Itoa(r.Chunks),
+		"estimated_tokens": strconv.Itoa(r.EstimatedTokens),
+		"context_window":   strconv.Itoa(r.ContextWindow),
+	}
+}
+
+// ChatLongText envia o texto em uma única requisição quando ele cabe na janela
+// do modelo. Caso contrário divide o texto em partes, resume cada uma (map) e
+// faz a síntese final sobre os resumos (reduce), preservando os cabeçalhos de seção.
+func ChatLongText(client AIClient, req LongTextRequest) (*LongTextResult, error) {
+	model := client.GetModel()
+	window := ContextWindow(model)
+	result := &LongTextResult{
+		Strategy:        StrategySingle,
+		Chunks:          1,
+		EstimatedTokens: EstimateTokens(model, req.Text),
+		ContextWindow:   window,
+	}
+
+	messages, err := req.Build(req.Text, false)
+	if err != nil {
+		return nil, err
+	}
+
+	promptTokens := EstimateMessagesTokens(model, messages)
+	if promptTokens+req.MaxTokens <= window {
+		if result.Content, err = client.Chat(messages, req.MaxTokens, req.Temperature); err != nil {
+			return nil, err
+		}
+		return result, nil
+	}
+
+	// Espaço que sobra para o texto depois das instruções e da resposta
+	budget := window - (promptTokens - result.EstimatedTokens) - req.MaxTokens
+	if budget <= 0 {
+		return nil, fmt.Errorf("o prompt não cabe na janela de contexto de %s (%d tokens)", model, window)
+	}
+
+	chunkTokens := window - mapPromptTokens - mapSummaryTokens
+	if chunkTokens > maxChunkTokens {
+		chunkTokens = maxChunkTokens
+	}
+	if chunkTokens <= 0 {
+		return nil, fmt.Errorf("janela de contexto de %s (%d tokens) pequena demais para resumir por partes", model, window)
+	}
+
+	text := req.Text
+	for level := 1; ; level++ {
+		chunks := SplitIntoChunks(model, text, chunkTokens)
+		if level == 1 {
+			result.Chunks = len(chunks)
+		}
+
+		summaries := make([]string, 0, len(chunks))
+		for i, chunk := range chunks {
+			summary, err := summarizeChunk(client, req.Subject, chunk, i+1, len(chunks))
+			if err != nil {
+				return nil, fmt.Errorf("erro ao resumir a parte %d/%d: %w", i+1, len(chunks), err)
+			}
+			summaries = append(summaries, summary)
+		}
+
+		text = strings.Join(summaries, "\n\n---\n\n")
+		if EstimateTokens(model, text) <= budget {
+			break
+		}
+		if level == maxReduceLevels {
+			return nil, fmt.Errorf("conteúdo grande demais para %s mesmo após %d níveis de resumo", model, maxReduceLevels)
+		}
+	}
+
+	if messages, err = req.Build(text, true); err != nil {
+		return nil, err
+	}
+	if result.Content, err = client.Chat(messages, req.MaxTokens, req.Temperature); err != nil {
+		return nil, err
+	}
+
+	result.Strategy = StrategyMapReduce
+	return result, nil
+}
+
+func summarizeChunk(client AIClient, subject, chunk string, part, total int) (string, error) {
+	messages, err := PromptMessages("longtext.map", PromptData{
+		"Subject": subject,
+		"Part":    part,
+		"Total":   total,
+		"Content": chunk,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	summary, err := client.Chat(messages, mapSummaryTokens, 0.2)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(summary), nil
+}
+
+// SplitIntoChunks divide o texto em partes de até maxTokens, cortando de
+// preferência entre seções markdown (linhas iniciadas por #). Uma seção maior
+// que o limite é cortada por linhas, repetindo o cabeçalho em cada parte.
+func SplitIntoChunks(model, text string, maxTokens int) []string {
+	var chunks []string
+	var current strings.Builder
+
+	flush := func() {
+		if strings.TrimSpace(current.String()) != "" {
+			chunks = append(chunks, strings.TrimRight(current.String(), "\n"))
+		}
+		current.Reset()
+	}
+
+	for _, section := range splitSections(text) {
+		if EstimateTokens(model, current.String()+section) <= maxTokens {
+			current.WriteString(section)
+			continue
+		}
+
+		flush()
+		if EstimateTokens(model, section) <= maxTokens {
+			current.WriteString(section)
+			continue
+		}
+
+		chunks = append(chunks, splitSection(model, section, maxTokens)...)
+	}
+	flush()
+
+	return chunks
+}
+
+// splitSections separa o texto antes de cada cabeçalho markdown
+func splitSections(text string) []string {
+	var sections []string
+	var current strings.Builder
+
+	for _, line := range strings.SplitAfter(text, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "#") && current.Len() > 0 {
+			sections = append(sections, current.String())
+			current.Reset()
+		}
+		current.WriteString(line)
+	}
+	if current.Len() > 0 {
+		sections = append(sections, current.String())
+	}
+	return sections
+}
+
+// splitSection corta uma seção grande por linhas, repetindo o cabeçalho
+func splitSection(model, section string, maxTokens int) []string {
+	lines := strings.SplitAfter(section, "\n")
+
+	header := ""
+	if strings.HasPrefix(strings.TrimSpace(lines[0]), "#") {
+		header = strings.TrimRight(lines[0], "\n") + "\n"
+		lines = lines[1:]
+	}
+
+	var pieces []string
+	var current strings.Builder
+	current.WriteString(header)
+
+	for _, line := range lines {
+		if EstimateTokens(model, current.String()+line) > maxTokens && current.Len() > len(header) {
+			pieces = append(pieces, strings.TrimRight(current.String(), "\n"))
+			current.Reset()
+			current.WriteString(header)
+		}
+
+		// Linha isolada maior que o limite: corta por caracteres
+		for line != "" && EstimateTokens(model, header+line) > maxTokens {
+			cut := cutPoint(model, line, maxTokens-EstimateTokens(model, header))
+			current.WriteString(line[:cut])
+			pieces = append(pieces, strings.TrimRight(current.String(), "\n"))
+			current.Reset()
+			current.WriteString(header)
+			line = line[cut:]
+		}
+		current.WriteString(line)
+	}
+
+	if strings.TrimSpace(current.String()) != strings.TrimSpace(header) {
+		pieces = append(pieces, strings.TrimRight(current.String(), "\n"))
+	}
+	return pieces
+}
+
+// cutPoint retorna o maior prefixo (em bytes, respeitando runas) que cabe em maxTokens
+func cutPoint(model, line string, maxTokens int) int {
+	limits, _ := limitsFor(model)
+	maxRunes := int(float64(maxTokens-1) * limits.CharsPerToken)
+	if maxRunes < 1 {
+		maxRunes = 1
+	}
+
+	count := 0
+	for i := range line {
+		if count == maxRunes {
+			return i
+		}
+		count++
+	}
+	return len(line)
+}
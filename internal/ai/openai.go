@@ -10,10 +10,11 @@ import (
 
 // OpenAIClient implementa o cliente OpenAI
 type OpenAIClient struct {
-	apiKey  string
-	model   string
-	baseURL string
-	client  *http.Client
+	apiKey    string
+	model     string
+	baseURL   string
+	client    *http.Client
+	lastUsage Usage
 }
 
 // NewOpenAIClient cria um novo cliente OpenAI
@@ -27,11 +28,16 @@ func NewOpenAIClient(config *AIConfig) (*OpenAIClient, error) {
 		model = "gpt-4o"
 	}
 
+	httpClient, err := createHTTPClient()
+	if err != nil {
+		return nil, err
+	}
+
 	return &OpenAIClient{
 		apiKey:  config.APIKey,
 		model:   model,
 		baseURL: "https://api.openai.com/v1/chat/completions",
-		client:  createHTTPClient(),
+		client:  httpClient,
 	}, nil
 }
 
@@ -47,28 +53,52 @@ func (o *OpenAIClient) SetModel(model string) {
 	o.model = model
 }
 
+// LastUsage retorna o consumo de tokens da última resposta
+func (o *OpenAIClient) LastUsage() Usage {
+	return o.lastUsage
+}
+
 func (o *OpenAIClient) Chat(messages []Message, maxTokens int, temperature float64) (string, error) {
-	reqBody := ChatRequest{
-		Model:    o.model,
-		Messages: messages,
+	message, err := o.chat(newChatRequest(o.model, messages, maxTokens, temperature))
+	if err != nil {
+		return "", err
 	}
+	return message.Content, nil
+}
+
+// ChatJSONMode usa o response_format json_object da API
+func (o *OpenAIClient) ChatJSONMode(messages []Message, maxTokens int, temperature float64) (string, error) {
+	reqBody := newChatRequest(o.model, messages, maxTokens, temperature)
+	reqBody.ResponseFormat = &ResponseFormat{Type: "json_object"}
 
-	if maxTokens > 0 {
-		reqBody.MaxTokens = maxTokens
+	message, err := o.chat(reqBody)
+	if err != nil {
+		return "", err
 	}
+	return message.Content, nil
+}
 
-	if temperature > 0 {
-		reqBody.Temperature = temperature
+// ChatWithTools usa o campo tools (function calling) da API
+func (o *OpenAIClient) ChatWithTools(messages []Message, tools []Tool, maxTokens int, temperature float64) (*ToolResponse, error) {
+	reqBody := newChatRequest(o.model, messages, maxTokens, temperature)
+	reqBody.Tools = toOpenAITools(tools)
+
+	message, err := o.chat(reqBody)
+	if err != nil {
+		return nil, err
 	}
+	return &ToolResponse{Content: message.Content, ToolCalls: message.ToolCalls}, nil
+}
 
+func (o *OpenAIClient) chat(reqBody ChatRequest) (*Message, error) {
 	jsonData, err := json.Marshal(reqBody)
 	if err != nil {
-		return "", fmt.Errorf("failed to marshal request: %w", err)
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
 	req, err := http.NewRequest("POST", o.baseURL, bytes.NewBuffer(jsonData))
 	if err != nil {
-		return "", fmt.Errorf("failed to create request: %w", err)
+		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	req.Header.Set("Content-Type", "application/json")
@@ -76,29 +106,35 @@ func (o *OpenAIClient) Chat(messages []Message, maxTokens int, temperature float
 
 	resp, err := o.client.Do(req)
 	if err != nil {
-		return "", fmt.Errorf("failed to send request: %w", err)
+		return nil, fmt.Errorf("failed to send request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return "", fmt.Errorf("failed to read response: %w", err)
+		return nil, fmt.Errorf("failed to read response: %w", err)
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
 	}
 
 	var chatResp ChatResponse
 	if err := json.Unmarshal(body, &chatResp); err != nil {
-		return "", fmt.Errorf("failed to parse response: %w", err)
+		return nil, fmt.Errorf("failed to parse response: %w", err)
 	}
 
+	o.lastUsage = Usage{InputTokens: chatResp.Usage.PromptTokens, OutputTokens: chatResp.Usage.CompletionTokens}
+
 	if len(chatResp.Choices) == 0 {
-		return "", fmt.Errorf("no choices in response")
+		return nil, fmt.Errorf("no choices in response")
 	}
 
-	return chatResp.Choices[0].Message.Content, nil
+	return &chatResp.Choices[0].Message, nil
+}
+
+func (o *OpenAIClient) ChatJSON(messages []Message, schema interface{}, maxTokens int, temperature float64) error {
+	return chatJSONGeneric(o, messages, schema, maxTokens, temperature)
 }
 
 func (o *OpenAIClient) GenerateContent(prompt string, maxTokens int) (string, error) {
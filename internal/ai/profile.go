@@ -0,0 +1,189 @@
+package ai
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Feature identifica uma funcionalidade que usa IA, permitindo padrões por funcionalidade
+type Feature string
+
+const (
+	FeatureDefault       Feature = ""
+	FeatureNotes         Feature = "notes"
+	FeatureChat          Feature = "chat"
+	FeatureChecklist     Feature = "checklist"
+	FeatureProject       Feature = "project"
+	FeatureDBChat        Feature = "db-chat"
+	FeatureDBHistoryChat Feature = "db-history-chat"
+	FeatureDBAnalysis    Feature = "db-analysis"
+	FeatureDBChart       Feature = "db-chart"
+	FeatureDBMaintenance Feature = "db-maintenance"
+	FeatureDBProject     Feature = "db-project"
+	FeatureDBDynamic     Feature = "db-dynamic"
+	FeatureOracle        Feature = "oracle"
+	FeatureBackup        Feature = "backup"
+)
+
+// DefaultProfileName é o nome reservado para a configuração principal (provider/model/api_key)
+const DefaultProfileName = "default"
+
+// GetFeatures retorna todas as funcionalidades que aceitam padrões próprios
+func GetFeatures() []Feature {
+	return []Feature{
+		FeatureNotes,
+		FeatureChat,
+		FeatureChecklist,
+		FeatureProject,
+		FeatureDBChat,
+		FeatureDBHistoryChat,
+		FeatureDBAnalysis,
+		FeatureDBChart,
+		FeatureDBMaintenance,
+		FeatureDBProject,
+		FeatureDBDynamic,
+		FeatureOracle,
+		FeatureBackup,
+	}
+}
+
+// IsValidFeature verifica se a funcionalidade é conhecida
+func IsValidFeature(feature Feature) bool {
+	for _, f := range GetFeatures() {
+		if f == feature {
+			return true
+		}
+	}
+	return false
+}
+
+// runtimeOverride guarda as sobreposições de perfil/modelo passadas na linha de comando
+var runtimeOverride struct {
+	profile string
+	model   string
+}
+
+// SetOverride define o perfil e/ou modelo a usar nesta execução (--ai-profile, --model)
+func SetOverride(profile, model string) {
+	runtimeOverride.profile = profile
+	runtimeOverride.model = model
+}
+
+// ResolveProfile retorna o perfil pelo nome; "" ou "default" retornam a configuração principal
+func (c *AIConfig) ResolveProfile(name string) (*AIProfile, error) {
+	if p, ok := c.Profiles[name]; ok && name != "" {
+		return p, nil
+	}
+
+	if name == "" || name == DefaultProfileName {
+		return &AIProfile{
+			Provider:   c.Provider,
+			Model:      c.Model,
+			APIKey:     c.APIKey,
+			Endpoint:   c.Endpoint,
+			Deployment: c.Deployment,
+			APIVersion: c.APIVersion,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("perfil de IA não encontrado: %s", name)
+}
+
+// fallbackClient monta o cliente de um perfil da cadeia de fallback
+func (c *AIConfig) fallbackClient(name string) (AIClient, error) {
+	profile, err := c.ResolveProfile(name)
+	if err != nil {
+		return nil, err
+	}
+	return newClientFromProfile(profile, "")
+}
+
+// FallbackErrors retorna, por nome, os perfis da cadeia de fallback que não
+// podem ser usados (perfil inexistente ou sem API key, por exemplo)
+func (c *AIConfig) FallbackErrors() map[string]error {
+	problems := make(map[string]error)
+	for _, name := range c.Fallback {
+		if _, err := c.fallbackClient(name); err != nil {
+			problems[name] = err
+		}
+	}
+	return problems
+}
+
+// ProfileNames retorna os nomes dos perfis configurados em ordem alfabética
+func (c *AIConfig) ProfileNames() []string {
+	names := make([]string, 0, len(c.Profiles))
+	for name := range c.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// selectProfile determina o perfil e o modelo para uma funcionalidade.
+// Ordem de precedência: --ai-profile/--model, padrão da funcionalidade, perfil ativo, configuração principal.
+func (c *AIConfig) selectProfile(feature Feature) (string, string) {
+	profileName := runtimeOverride.profile
+	model := runtimeOverride.model
+
+	if fd, ok := c.Features[feature]; ok && feature != FeatureDefault && profileName == "" {
+		profileName = fd.Profile
+		if model == "" {
+			model = fd.Model
+		}
+	}
+
+	if profileName == "" {
+		profileName = c.ActiveProfile
+	}
+
+	return profileName, model
+}
+
+// newClientFromProfile cria o cliente concreto para um perfil
+func newClientFromProfile(profile *AIProfile, model string) (AIClient, error) {
+	config := &AIConfig{
+		Provider:   profile.Provider,
+		Model:      profile.Model,
+		APIKey:     profile.APIKey,
+		Endpoint:   profile.Endpoint,
+		Deployment: profile.Deployment,
+		APIVersion: profile.APIVersion,
+	}
+
+	if model != "" {
+		config.Model = model
+		// No Azure o --model escolhe o deployment
+		config.Deployment = model
+	}
+
+	if config.Provider == "" {
+		// Fallback para Groq se não configurado
+		config.Provider = ProviderGroq
+	}
+
+	if config.APIKey == "" {
+		return nil, fmt.Errorf("API key não configurada. Execute: snip ai config")
+	}
+
+	switch config.Provider {
+	case ProviderGroq:
+		return NewGroqClientWithConfig(config)
+	case ProviderOpenAI:
+		return NewOpenAIClient(config)
+	case ProviderAnthropic:
+		return NewAnthropicClient(config)
+	case ProviderDeepSeek:
+		return NewDeepSeekClient(config)
+	case ProviderGrok:
+		return NewGrokClient(config)
+	case ProviderOpenRouter:
+		return NewOpenRouterClient(config)
+	case ProviderGemini:
+		return NewGeminiClient(config)
+	case ProviderAzure:
+		return NewAzureOpenAIClient(config)
+	default:
+		return nil, fmt.Errorf("provedor não suportado: %s", config.Provider)
+	}
+}
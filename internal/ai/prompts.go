@@ -0,0 +1,191 @@
+package ai
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// Idiomas suportados para prompts e respostas
+const (
+	LanguagePortuguese = "pt-BR"
+	LanguageEnglish    = "en"
+	LanguageSpanish    = "es"
+
+	DefaultLanguage = LanguagePortuguese
+)
+
+//go:embed prompts
+var embeddedPrompts embed.FS
+
+// PromptData são as variáveis disponíveis em um template de prompt
+type PromptData map[string]interface{}
+
+// PromptInfo descreve um template de prompt disponível
+type PromptInfo struct {
+	Name     string
+	Language string
+	Version  int
+	// Source é "embedded" ou o caminho do arquivo que sobrepõe o padrão
+	Source string
+}
+
+var promptVersionPattern = regexp.MustCompile(`\{\{/\*\s*version:\s*(\d+)`)
+
+// GetLanguages retorna os idiomas suportados
+func GetLanguages() []string {
+	return []string{LanguagePortuguese, LanguageEnglish, LanguageSpanish}
+}
+
+// IsValidLanguage verifica se um idioma é suportado
+func IsValidLanguage(language string) bool {
+	for _, l := range GetLanguages() {
+		if l == language {
+			return true
+		}
+	}
+	return false
+}
+
+// GetLanguage retorna o idioma configurado (padrão: pt-BR)
+func GetLanguage() string {
+	config, err := LoadConfig()
+	if err != nil || !IsValidLanguage(config.Language) {
+		return DefaultLanguage
+	}
+	return config.Language
+}
+
+// GetPromptsDir retorna o diretório de templates do usuário (~/.snip/prompts)
+func GetPromptsDir() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(homeDir, ".snip", "prompts"), nil
+}
+
+// GetPromptOverridePath retorna o caminho do template do usuário para um prompt
+func GetPromptOverridePath(name, language string) (string, error) {
+	dir, err := GetPromptsDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, language, name+".tmpl"), nil
+}
+
+// LoadPromptSource retorna o texto do template, preferindo o arquivo do usuário,
+// depois o padrão embutido do idioma e, por fim, o padrão em pt-BR
+func LoadPromptSource(name, language string) (string, string, error) {
+	if path, err := GetPromptOverridePath(name, language); err == nil {
+		if data, err := os.ReadFile(path); err == nil {
+			return string(data), path, nil
+		}
+	}
+
+	if data, err := embeddedPrompts.ReadFile("prompts/" + language + "/" + name + ".tmpl"); err == nil {
+		return string(data), "embedded", nil
+	}
+
+	if language != DefaultLanguage {
+		if data, err := embeddedPrompts.ReadFile("prompts/" + DefaultLanguage + "/" + name + ".tmpl"); err == nil {
+			return string(data), "embedded (" + DefaultLanguage + ")", nil
+		}
+	}
+
+	return "", "", fmt.Errorf("prompt não encontrado: %s", name)
+}
+
+// ListPrompts lista os prompts disponíveis para um idioma
+func ListPrompts(language string) ([]PromptInfo, error) {
+	entries, err := embeddedPrompts.ReadDir("prompts/" + DefaultLanguage)
+	if err != nil {
+		return nil, err
+	}
+
+	var prompts []PromptInfo
+	for _, entry := range entries {
+		name := strings.TrimSuffix(entry.Name(), ".tmpl")
+		source, origin, err := LoadPromptSource(name, language)
+		if err != nil {
+			continue
+		}
+		prompts = append(prompts, PromptInfo{
+			Name:     name,
+			Language: language,
+			Version:  promptVersion(source),
+			Source:   origin,
+		})
+	}
+
+	sort.Slice(prompts, func(i, j int) bool {
+		return prompts[i].Name < prompts[j].Name
+	})
+	return prompts, nil
+}
+
+func promptVersion(source string) int {
+	match := promptVersionPattern.FindStringSubmatch(source)
+	if match == nil {
+		return 0
+	}
+	version, _ := strconv.Atoi(match[1])
+	return version
+}
+
+// RenderPrompt renderiza as partes "system" e "user" de um template no idioma configurado
+func RenderPrompt(name string, data PromptData) (string, string, error) {
+	source, _, err := LoadPromptSource(name, GetLanguage())
+	if err != nil {
+		return "", "", err
+	}
+
+	tmpl, err := template.New(name).Parse(source)
+	if err != nil {
+		return "", "", fmt.Errorf("erro no template %s: %w", name, err)
+	}
+
+	render := func(block string) (string, error) {
+		if tmpl.Lookup(block) == nil {
+			return "", nil
+		}
+		var buf bytes.Buffer
+		if err := tmpl.ExecuteTemplate(&buf, block, data); err != nil {
+			return "", fmt.Errorf("erro ao renderizar %s/%s: %w", name, block, err)
+		}
+		return strings.TrimSpace(buf.String()), nil
+	}
+
+	system, err := render("system")
+	if err != nil {
+		return "", "", err
+	}
+	user, err := render("user")
+	if err != nil {
+		return "", "", err
+	}
+
+	return system, user, nil
+}
+
+// PromptMessages renderiza um template como mensagens de chat
+func PromptMessages(name string, data PromptData) ([]Message, error) {
+	system, user, err := RenderPrompt(name, data)
+	if err != nil {
+		return nil, err
+	}
+
+	var messages []Message
+	if system != "" {
+		messages = append(messages, Message{Role: "system", Content: system})
+	}
+	messages = append(messages, Message{Role: "user", Content: user})
+	return messages, nil
+}
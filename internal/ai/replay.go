@@ -0,0 +1,359 @@
+package ai
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	// ReplayModeRecord grava em cassetes as respostas do provedor real
+	ReplayModeRecord = "record"
+	// ReplayModeReplay responde apenas a partir dos cassetes, sem rede nem API key
+	ReplayModeReplay = "replay"
+
+	// ReplayModeEnv seleciona o modo (record, replay ou off) e tem prioridade sobre o AIConfig
+	ReplayModeEnv = "SNIP_AI_REPLAY"
+	// ReplayDirEnv define o diretório dos cassetes
+	ReplayDirEnv = "SNIP_AI_CASSETTES"
+)
+
+// Tipos de requisição gravados no cassete
+const (
+	cassetteModeChat  = "chat"
+	cassetteModeJSON  = "json"
+	cassetteModeTools = "tools"
+)
+
+// ErrCassetteNotFound indica que não há resposta gravada para a requisição
+var ErrCassetteNotFound = errors.New("cassete não encontrado")
+
+// ReplayConfig configura a gravação/reprodução de respostas da IA
+type ReplayConfig struct {
+	// Mode é "record", "replay" ou vazio (desativado)
+	Mode string `json:"mode,omitempty"`
+	// Dir é o diretório dos cassetes (padrão: ~/.snip/cassettes)
+	Dir string `json:"dir,omitempty"`
+}
+
+// Cassette é uma requisição gravada e sua resposta
+type Cassette struct {
+	Key         string           `json:"key"`
+	Mode        string           `json:"mode"`
+	Provider    Provider         `json:"provider,omitempty"`
+	Model       string           `json:"model,omitempty"`
+	Messages    []Message        `json:"messages"`
+	Tools       []string         `json:"tools,omitempty"`
+	MaxTokens   int              `json:"max_tokens"`
+	Temperature float64          `json:"temperature"`
+	Response    CassetteResponse `json:"response"`
+	RecordedAt  time.Time        `json:"recorded_at"`
+}
+
+// CassetteResponse é a resposta gravada: texto e, no modo tools, as chamadas de ferramentas
+type CassetteResponse struct {
+	Content   string     `json:"content"`
+	ToolCalls []ToolCall `json:"tool_calls,omitempty"`
+}
+
+// replaySettings resolve modo e diretório: variáveis de ambiente, depois AIConfig
+func (c *AIConfig) replaySettings() (string, string, error) {
+	mode, dir := "", ""
+	if c.Replay != nil {
+		mode, dir = c.Replay.Mode, c.Replay.Dir
+	}
+	if env, ok := os.LookupEnv(ReplayModeEnv); ok {
+		mode = env
+	}
+	if env := os.Getenv(ReplayDirEnv); env != "" {
+		dir = env
+	}
+
+	mode = strings.ToLower(strings.TrimSpace(mode))
+	switch mode {
+	case "off":
+		mode = ""
+	case "", ReplayModeRecord, ReplayModeReplay:
+	default:
+		return "", "", fmt.Errorf("modo de replay inválido: %s (use record, replay ou off)", mode)
+	}
+
+	if dir == "" {
+		var err error
+		if dir, err = defaultCassetteDir(); err != nil {
+			return "", "", err
+		}
+	}
+	return mode, dir, nil
+}
+
+func defaultCassetteDir() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(homeDir, ".snip", "cassettes"), nil
+}
+
+// CassetteKey calcula o hash da requisição. Provedor e modelo ficam de fora
+// para que um cassete gravado com um provedor sirva para qualquer outro.
+func CassetteKey(mode string, messages []Message, tools []Tool, maxTokens int, temperature float64) string {
+	payload, _ := json.Marshal(struct {
+		Mode        string       `json:"mode"`
+		Messages    []Message    `json:"messages"`
+		Tools       []openAITool `json:"tools,omitempty"`
+		MaxTokens   int          `json:"max_tokens"`
+		Temperature float64      `json:"temperature"`
+	}{mode, messages, toOpenAITools(tools), maxTokens, temperature})
+
+	sum := sha256.Sum256(payload)
+	return hex.EncodeToString(sum[:])
+}
+
+func cassettePath(dir, key string) string {
+	return filepath.Join(dir, key+".json")
+}
+
+// LoadCassette lê o cassete gravado para a chave
+func LoadCassette(dir, key string) (*Cassette, error) {
+	data, err := os.ReadFile(cassettePath(dir, key))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("%w: %s (grave com %s=%s)", ErrCassetteNotFound, cassettePath(dir, key), ReplayModeEnv, ReplayModeRecord)
+		}
+		return nil, err
+	}
+
+	var cassette Cassette
+	if err := json.Unmarshal(data, &cassette); err != nil {
+		return nil, fmt.Errorf("cassete inválido %s: %w", cassettePath(dir, key), err)
+	}
+	return &cassette, nil
+}
+
+// SaveCassette grava o cassete no diretório, nomeado pela chave
+func SaveCassette(dir string, cassette *Cassette) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(cassette, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(cassettePath(dir, cassette.Key), append(data, '\n'), 0644)
+}
+
+func toolNames(tools []Tool) []string {
+	var names []string
+	for _, tool := range tools {
+		names = append(names, tool.Name)
+	}
+	return names
+}
+
+// ReplayClient responde a partir dos cassetes, sem acessar a rede
+type ReplayClient struct {
+	dir   string
+	model string
+}
+
+// NewReplayClient cria um cliente que lê os cassetes de dir
+func NewReplayClient(dir string) *ReplayClient {
+	return &ReplayClient{dir: dir, model: "replay"}
+}
+
+func (r *ReplayClient) GetProvider() Provider {
+	return ProviderReplay
+}
+
+func (r *ReplayClient) GetModel() string {
+	return r.model
+}
+
+func (r *ReplayClient) SetModel(model string) {
+	r.model = model
+}
+
+func (r *ReplayClient) replay(mode string, messages []Message, tools []Tool, maxTokens int, temperature float64) (*CassetteResponse, error) {
+	cassette, err := LoadCassette(r.dir, CassetteKey(mode, messages, tools, maxTokens, temperature))
+	if err != nil {
+		return nil, err
+	}
+	return &cassette.Response, nil
+}
+
+func (r *ReplayClient) Chat(messages []Message, maxTokens int, temperature float64) (string, error) {
+	response, err := r.replay(cassetteModeChat, messages, nil, maxTokens, temperature)
+	if err != nil {
+		return "", err
+	}
+	return response.Content, nil
+}
+
+func (r *ReplayClient) ChatJSONMode(messages []Message, maxTokens int, temperature float64) (string, error) {
+	response, err := r.replay(cassetteModeJSON, messages, nil, maxTokens, temperature)
+	if err != nil {
+		return "", err
+	}
+	return response.Content, nil
+}
+
+func (r *ReplayClient) ChatWithTools(messages []Message, tools []Tool, maxTokens int, temperature float64) (*ToolResponse, error) {
+	response, err := r.replay(cassetteModeTools, messages, tools, maxTokens, temperature)
+	if err != nil {
+		return nil, err
+	}
+	return &ToolResponse{Content: response.Content, ToolCalls: response.ToolCalls}, nil
+}
+
+func (r *ReplayClient) ChatJSON(messages []Message, schema interface{}, maxTokens int, temperature float64) error {
+	return chatJSONGeneric(r, messages, schema, maxTokens, temperature)
+}
+
+func (r *ReplayClient) GenerateContent(prompt string, maxTokens int) (string, error) {
+	return generateContentGeneric(r, prompt, maxTokens)
+}
+
+func (r *ReplayClient) GenerateNoteContent(topic string, context string) (string, error) {
+	return generateNoteContentGeneric(r, topic, context)
+}
+
+func (r *ReplayClient) ImproveSearchQuery(query string, notesContext []string) (string, error) {
+	return improveSearchQueryGeneric(r, query, notesContext)
+}
+
+func (r *ReplayClient) AnswerQuestion(question string, notesContext []string) (string, error) {
+	return answerQuestionGeneric(r, question, notesContext)
+}
+
+func (r *ReplayClient) GenerateCode(language string, description string, context string) (string, error) {
+	return generateCodeGeneric(r, language, description, context)
+}
+
+func (r *ReplayClient) GenerateTips(topic string) (string, error) {
+	return generateTipsGeneric(r, topic)
+}
+
+func (r *ReplayClient) GenerateChecklist(topic string, context string, numItems int) ([]string, error) {
+	return generateChecklistGeneric(r, topic, context, numItems)
+}
+
+func (r *ReplayClient) GenerateProjectPlan(projectName string, description string) (string, error) {
+	return generateProjectPlanGeneric(r, projectName, description)
+}
+
+// RecordingClient repassa as requisições ao cliente real e grava cada resposta
+type RecordingClient struct {
+	client AIClient
+	dir    string
+}
+
+// NewRecordingClient envolve um cliente real gravando cassetes em dir
+func NewRecordingClient(client AIClient, dir string) *RecordingClient {
+	return &RecordingClient{client: client, dir: dir}
+}
+
+func (r *RecordingClient) GetProvider() Provider {
+	return r.client.GetProvider()
+}
+
+func (r *RecordingClient) GetModel() string {
+	return r.client.GetModel()
+}
+
+func (r *RecordingClient) SetModel(model string) {
+	r.client.SetModel(model)
+}
+
+func (r *RecordingClient) record(mode string, messages []Message, tools []Tool, maxTokens int, temperature float64, response CassetteResponse) {
+	cassette := &Cassette{
+		Key:         CassetteKey(mode, messages, tools, maxTokens, temperature),
+		Mode:        mode,
+		Provider:    r.client.GetProvider(),
+		Model:       r.client.GetModel(),
+		Messages:    messages,
+		Tools:       toolNames(tools),
+		MaxTokens:   maxTokens,
+		Temperature: temperature,
+		Response:    response,
+		RecordedAt:  time.Now(),
+	}
+
+	// Falha ao gravar não deve interromper a resposta
+	if err := SaveCassette(r.dir, cassette); err != nil {
+		fmt.Fprintf(os.Stderr, "Aviso: não foi possível gravar o cassete: %v\n", err)
+	}
+}
+
+func (r *RecordingClient) Chat(messages []Message, maxTokens int, temperature float64) (string, error) {
+	response, err := r.client.Chat(messages, maxTokens, temperature)
+	if err != nil {
+		return "", err
+	}
+	r.record(cassetteModeChat, messages, nil, maxTokens, temperature, CassetteResponse{Content: response})
+	return response, nil
+}
+
+func (r *RecordingClient) ChatJSONMode(messages []Message, maxTokens int, temperature float64) (string, error) {
+	response, err := chatJSONMode(r.client, messages, maxTokens, temperature)
+	if err != nil {
+		return "", err
+	}
+	r.record(cassetteModeJSON, messages, nil, maxTokens, temperature, CassetteResponse{Content: response})
+	return response, nil
+}
+
+func (r *RecordingClient) ChatWithTools(messages []Message, tools []Tool, maxTokens int, temperature float64) (*ToolResponse, error) {
+	response, err := r.client.ChatWithTools(messages, tools, maxTokens, temperature)
+	if err != nil {
+		return nil, err
+	}
+	r.record(cassetteModeTools, messages, tools, maxTokens, temperature, CassetteResponse{
+		Content:   response.Content,
+		ToolCalls: response.ToolCalls,
+	})
+	return response, nil
+}
+
+func (r *RecordingClient) ChatJSON(messages []Message, schema interface{}, maxTokens int, temperature float64) error {
+	return chatJSONGeneric(r, messages, schema, maxTokens, temperature)
+}
+
+func (r *RecordingClient) GenerateContent(prompt string, maxTokens int) (string, error) {
+	return generateContentGeneric(r, prompt, maxTokens)
+}
+
+func (r *RecordingClient) GenerateNoteContent(topic string, context string) (string, error) {
+	return generateNoteContentGeneric(r, topic, context)
+}
+
+func (r *RecordingClient) ImproveSearchQuery(query string, notesContext []string) (string, error) {
+	return improveSearchQueryGeneric(r, query, notesContext)
+}
+
+func (r *RecordingClient) AnswerQuestion(question string, notesContext []string) (string, error) {
+	return answerQuestionGeneric(r, question, notesContext)
+}
+
+func (r *RecordingClient) GenerateCode(language string, description string, context string) (string, error) {
+	return generateCodeGeneric(r, language, description, context)
+}
+
+func (r *RecordingClient) GenerateTips(topic string) (string, error) {
+	return generateTipsGeneric(r, topic)
+}
+
+func (r *RecordingClient) GenerateChecklist(topic string, context string, numItems int) ([]string, error) {
+	return generateChecklistGeneric(r, topic, context, numItems)
+}
+
+func (r *RecordingClient) GenerateProjectPlan(projectName string, description string) (string, error) {
+	return generateProjectPlanGeneric(r, projectName, description)
+}
@@ -0,0 +1,90 @@
+package ai
+
+import (
+	"strings"
+)
+
+// MaxSuggestedTags limita quantas tags são sugeridas por nota
+const MaxSuggestedTags = 5
+
+// maxTaggingContent limita o conteúdo da nota enviado ao modelo
+const maxTaggingContent = 6000
+
+// NoteTagging é o resumo de uma linha e as tags sugeridas para uma nota
+type NoteTagging struct {
+	Summary string   `json:"summary" ai:"required"`
+	Tags    []string `json:"tags"`
+}
+
+// IsAutoTagEnabled indica se o resumo/tags automáticos estão ativos em create/update
+func IsAutoTagEnabled() bool {
+	config, err := LoadConfig()
+	if err != nil {
+		return false
+	}
+	return config.AutoTag
+}
+
+// SuggestNoteTags pede ao modelo um resumo e tags para a nota, preferindo as
+// tags já existentes. As tags retornadas são normalizadas e, quando equivalem
+// a uma tag existente, usam a grafia dela.
+func SuggestNoteTags(client AIClient, title, content string, existingTags []string) (*NoteTagging, error) {
+	if runes := []rune(content); len(runes) > maxTaggingContent {
+		content = string(runes[:maxTaggingContent]) + "..."
+	}
+
+	messages, err := PromptMessages("notes.tag", PromptData{
+		"Title":        title,
+		"Content":      content,
+		"ExistingTags": existingTags,
+		"MaxTags":      MaxSuggestedTags,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var result NoteTagging
+	if err := client.ChatJSON(messages, &result, 500, 0.2); err != nil {
+		return nil, err
+	}
+
+	result.Summary = strings.Join(strings.Fields(result.Summary), " ")
+	result.Tags = normalizeSuggestedTags(result.Tags, existingTags)
+	return &result, nil
+}
+
+// normalizeSuggestedTags remove duplicatas, troca espaços por hífen (as tags
+// são separadas por espaço na CLI) e reaproveita tags existentes
+func normalizeSuggestedTags(tags []string, existingTags []string) []string {
+	existing := make(map[string]string, len(existingTags))
+	for _, tag := range existingTags {
+		existing[strings.ToLower(tag)] = tag
+	}
+
+	seen := make(map[string]bool)
+	var result []string
+	for _, tag := range tags {
+		tag = strings.Join(strings.Fields(strings.TrimPrefix(strings.TrimSpace(tag), "#")), "-")
+		if tag == "" {
+			continue
+		}
+
+		key := strings.ToLower(tag)
+		if name, ok := existing[key]; ok {
+			tag = name
+		} else {
+			tag = key
+		}
+
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		result = append(result, tag)
+
+		if len(result) == MaxSuggestedTags {
+			break
+		}
+	}
+	return result
+}
@@ -0,0 +1,93 @@
+package ai
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// DefaultContextWindow é usado para modelos sem janela conhecida
+const DefaultContextWindow = 8192
+
+// modelLimits descreve a janela de contexto (tokens) e a média de caracteres por token
+type modelLimits struct {
+	ContextWindow int
+	CharsPerToken float64
+}
+
+// modelContextWindows contém valores aproximados por modelo; o estimador é
+// conservador de propósito, já que texto em português gera mais tokens
+var modelContextWindows = map[string]modelLimits{
+	"openai/gpt-oss-120b":        {ContextWindow: 131072, CharsPerToken: 3.5},
+	"llama-3.1-70b-versatile":    {ContextWindow: 131072, CharsPerToken: 3.2},
+	"llama-3.1-8b-instant":       {ContextWindow: 131072, CharsPerToken: 3.2},
+	"mixtral-8x7b-32768":         {ContextWindow: 32768, CharsPerToken: 3.0},
+	"gemma-7b-it":                {ContextWindow: 8192, CharsPerToken: 3.2},
+	"gpt-4o":                     {ContextWindow: 128000, CharsPerToken: 3.5},
+	"gpt-4o-mini":                {ContextWindow: 128000, CharsPerToken: 3.5},
+	"gpt-4-turbo":                {ContextWindow: 128000, CharsPerToken: 3.2},
+	"gpt-4":                      {ContextWindow: 8192, CharsPerToken: 3.2},
+	"gpt-3.5-turbo":              {ContextWindow: 16385, CharsPerToken: 3.2},
+	"gpt-35-turbo":               {ContextWindow: 16385, CharsPerToken: 3.2},
+	"claude-3-5-sonnet-20241022": {ContextWindow: 200000, CharsPerToken: 3.0},
+	"claude-3-5-haiku-20241022":  {ContextWindow: 200000, CharsPerToken: 3.0},
+	"claude-3-opus-20240229":     {ContextWindow: 200000, CharsPerToken: 3.0},
+	"claude-3-sonnet-20240229":   {ContextWindow: 200000, CharsPerToken: 3.0},
+	"claude-3-haiku-20240307":    {ContextWindow: 200000, CharsPerToken: 3.0},
+	"deepseek-chat":              {ContextWindow: 64000, CharsPerToken: 3.2},
+	"deepseek-coder":             {ContextWindow: 64000, CharsPerToken: 3.2},
+	"grok-beta":                  {ContextWindow: 131072, CharsPerToken: 3.2},
+	"grok-2":                     {ContextWindow: 131072, CharsPerToken: 3.2},
+	"gemini-2.0-flash":           {ContextWindow: 1048576, CharsPerToken: 3.5},
+	"gemini-1.5-pro":             {ContextWindow: 2097152, CharsPerToken: 3.5},
+	"gemini-1.5-flash":           {ContextWindow: 1048576, CharsPerToken: 3.5},
+	"gemini-1.5-flash-8b":        {ContextWindow: 1048576, CharsPerToken: 3.5},
+}
+
+// defaultCharsPerToken é a média usada para modelos desconhecidos
+const defaultCharsPerToken = 3.0
+
+// messageOverheadTokens cobre papel e separadores de cada mensagem
+const messageOverheadTokens = 4
+
+func limitsFor(model string) (modelLimits, bool) {
+	if limits, ok := modelContextWindows[model]; ok {
+		return limits, true
+	}
+	// OpenRouter usa o prefixo do provedor (ex: "openai/gpt-4o")
+	if idx := strings.Index(model, "/"); idx >= 0 {
+		if limits, ok := modelContextWindows[model[idx+1:]]; ok {
+			return limits, true
+		}
+	}
+	return modelLimits{ContextWindow: DefaultContextWindow, CharsPerToken: defaultCharsPerToken}, false
+}
+
+// ContextWindow retorna a janela de contexto do modelo em tokens.
+// context_windows em ai_config.json sobrepõe a tabela embutida.
+func ContextWindow(model string) int {
+	if config, err := LoadConfig(); err == nil {
+		if window, ok := config.ContextWindows[model]; ok && window > 0 {
+			return window
+		}
+	}
+	limits, _ := limitsFor(model)
+	return limits.ContextWindow
+}
+
+// EstimateTokens estima quantos tokens o texto ocupa no modelo
+func EstimateTokens(model, text string) int {
+	if text == "" {
+		return 0
+	}
+	limits, _ := limitsFor(model)
+	return int(float64(utf8.RuneCountInString(text))/limits.CharsPerToken) + 1
+}
+
+// EstimateMessagesTokens estima os tokens de entrada de uma conversa
+func EstimateMessagesTokens(model string, messages []Message) int {
+	total := 0
+	for _, msg := range messages {
+		total += EstimateTokens(model, msg.Content) + messageOverheadTokens
+	}
+	return total
+}
@@ -0,0 +1,177 @@
+package ai
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// DefaultMaxAgentSteps é o limite padrão de chamadas de ferramentas por pergunta
+const DefaultMaxAgentSteps = 8
+
+// ErrAgentStepLimit indica que o agente atingiu o limite de passos sem concluir
+var ErrAgentStepLimit = errors.New("limite de passos do agente atingido")
+
+// Tool descreve uma função que o modelo pode chamar
+type Tool struct {
+	Name        string
+	Description string
+	// Parameters é o JSON Schema dos argumentos
+	Parameters map[string]interface{}
+}
+
+// ToolCall é uma chamada de ferramenta pedida pelo modelo (formato OpenAI)
+type ToolCall struct {
+	ID       string           `json:"id"`
+	Type     string           `json:"type"`
+	Function ToolCallFunction `json:"function"`
+}
+
+// ToolCallFunction contém o nome e os argumentos (JSON) da chamada
+type ToolCallFunction struct {
+	Name      string `json:"name"`
+	Arguments string `json:"arguments"`
+}
+
+// ToolResponse é a resposta de ChatWithTools: texto final ou chamadas de ferramentas
+type ToolResponse struct {
+	Content   string
+	ToolCalls []ToolCall
+}
+
+// ToolExecutor executa uma ferramenta e devolve o resultado como texto
+type ToolExecutor func(name string, arguments json.RawMessage) (string, error)
+
+// AgentStep registra uma chamada de ferramenta feita pelo agente
+type AgentStep struct {
+	Step      int
+	Name      string
+	Arguments string
+	Result    string
+	Err       error
+}
+
+// Agent conduz uma conversa em vários passos, executando as ferramentas
+// pedidas pelo modelo até obter uma resposta final
+type Agent struct {
+	Client   AIClient
+	Tools    []Tool
+	Execute  ToolExecutor
+	MaxSteps int
+	// OnStep é chamado após cada ferramenta executada (trace)
+	OnStep func(AgentStep)
+}
+
+// GetMaxAgentSteps retorna o limite de passos configurado
+func GetMaxAgentSteps() int {
+	config, err := LoadConfig()
+	if err != nil || config.MaxAgentSteps <= 0 {
+		return DefaultMaxAgentSteps
+	}
+	return config.MaxAgentSteps
+}
+
+// Run executa o agente e retorna a resposta final e os passos executados
+func (a *Agent) Run(messages []Message, maxTokens int, temperature float64) (string, []AgentStep, error) {
+	maxSteps := a.MaxSteps
+	if maxSteps <= 0 {
+		maxSteps = DefaultMaxAgentSteps
+	}
+
+	conversation := append([]Message{}, messages...)
+	var steps []AgentStep
+
+	for {
+		limitReached := len(steps) >= maxSteps
+		if limitReached {
+			// As ferramentas continuam declaradas (exigido com histórico de
+			// chamadas), mas o modelo é instruído a responder com o que já obteve
+			_, final, err := RenderPrompt("agent.limit", PromptData{"MaxSteps": maxSteps})
+			if err != nil {
+				return "", steps, err
+			}
+			conversation = append(conversation, Message{Role: "user", Content: final})
+		}
+
+		response, err := a.Client.ChatWithTools(conversation, a.Tools, maxTokens, temperature)
+		if err != nil {
+			return "", steps, err
+		}
+
+		if len(response.ToolCalls) == 0 {
+			return response.Content, steps, nil
+		}
+		if limitReached {
+			return "", steps, fmt.Errorf("%w (%d)", ErrAgentStepLimit, maxSteps)
+		}
+
+		conversation = append(conversation, Message{
+			Role:      "assistant",
+			Content:   response.Content,
+			ToolCalls: response.ToolCalls,
+		})
+
+		for _, call := range response.ToolCalls {
+			step := AgentStep{
+				Step:      len(steps) + 1,
+				Name:      call.Function.Name,
+				Arguments: call.Function.Arguments,
+			}
+
+			result, err := a.Execute(call.Function.Name, json.RawMessage(argumentsOrEmpty(call.Function.Arguments)))
+			step.Result = result
+			step.Err = err
+			if err != nil {
+				// O erro volta para o modelo, que pode corrigir a chamada
+				result = fmt.Sprintf("ERRO: %v", err)
+			}
+
+			steps = append(steps, step)
+			if a.OnStep != nil {
+				a.OnStep(step)
+			}
+
+			conversation = append(conversation, Message{
+				Role:       "tool",
+				Content:    result,
+				ToolCallID: call.ID,
+			})
+		}
+	}
+}
+
+func argumentsOrEmpty(arguments string) string {
+	if arguments == "" {
+		return "{}"
+	}
+	return arguments
+}
+
+// openAITool é o formato de ferramenta das APIs compatíveis com OpenAI
+type openAITool struct {
+	Type     string `json:"type"`
+	Function struct {
+		Name        string                 `json:"name"`
+		Description string                 `json:"description"`
+		Parameters  map[string]interface{} `json:"parameters"`
+	} `json:"function"`
+}
+
+func toOpenAITools(tools []Tool) []openAITool {
+	var result []openAITool
+	for _, tool := range tools {
+		t := openAITool{Type: "function"}
+		t.Function.Name = tool.Name
+		t.Function.Description = tool.Description
+		t.Function.Parameters = toolParameters(tool)
+		result = append(result, t)
+	}
+	return result
+}
+
+func toolParameters(tool Tool) map[string]interface{} {
+	if tool.Parameters == nil {
+		return map[string]interface{}{"type": "object", "properties": map[string]interface{}{}}
+	}
+	return tool.Parameters
+}
@@ -0,0 +1,279 @@
+package ai
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// Usage representa o consumo de tokens de uma resposta
+type Usage struct {
+	InputTokens  int
+	OutputTokens int
+}
+
+// UsageReporter é implementado pelos clientes que extraem o consumo das respostas
+type UsageReporter interface {
+	LastUsage() Usage
+}
+
+// UsageRecord representa uma chamada registrada na tabela ai_usage
+type UsageRecord struct {
+	ID           int       `json:"id"`
+	Provider     Provider  `json:"provider"`
+	Model        string    `json:"model"`
+	Feature      Feature   `json:"feature"`
+	InputTokens  int       `json:"input_tokens"`
+	OutputTokens int       `json:"output_tokens"`
+	LatencyMs    int64     `json:"latency_ms"`
+	CostUSD      float64   `json:"cost_usd"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// UsageSummary agrega o consumo de um grupo (funcionalidade, modelo, etc.)
+type UsageSummary struct {
+	Key          string  `json:"key"`
+	Requests     int     `json:"requests"`
+	InputTokens  int     `json:"input_tokens"`
+	OutputTokens int     `json:"output_tokens"`
+	AvgLatencyMs float64 `json:"avg_latency_ms"`
+	CostUSD      float64 `json:"cost_usd"`
+}
+
+// UsageStore persiste e consulta registros de consumo
+type UsageStore interface {
+	Record(record *UsageRecord) error
+	Totals(since time.Time) (*UsageSummary, error)
+}
+
+var usageStore UsageStore
+
+// ErrBudgetExceeded indica que o orçamento mensal bloqueou a requisição
+var ErrBudgetExceeded = errors.New("orçamento mensal de IA excedido")
+
+// SetUsageStore define onde o consumo de IA é registrado
+func SetUsageStore(store UsageStore) {
+	usageStore = store
+}
+
+// BudgetConfig define limites mensais de consumo
+type BudgetConfig struct {
+	MonthlyLimitUSD   float64 `json:"monthly_limit_usd,omitempty"`
+	MonthlyTokenLimit int     `json:"monthly_token_limit,omitempty"`
+	// Action é "warn" (avisa e continua) ou "block" (recusa a requisição)
+	Action string `json:"action,omitempty"`
+}
+
+// ModelPrice é o preço em USD por 1 milhão de tokens
+type ModelPrice struct {
+	Input  float64
+	Output float64
+}
+
+// modelPrices contém preços aproximados; modelos ausentes têm custo estimado zero
+var modelPrices = map[string]ModelPrice{
+	"openai/gpt-oss-120b":        {Input: 0.15, Output: 0.75},
+	"llama-3.1-70b-versatile":    {Input: 0.59, Output: 0.79},
+	"llama-3.1-8b-instant":       {Input: 0.05, Output: 0.08},
+	"mixtral-8x7b-32768":         {Input: 0.24, Output: 0.24},
+	"gemma-7b-it":                {Input: 0.07, Output: 0.07},
+	"gpt-4o":                     {Input: 2.50, Output: 10.00},
+	"gpt-4o-mini":                {Input: 0.15, Output: 0.60},
+	"gpt-4-turbo":                {Input: 10.00, Output: 30.00},
+	"gpt-4":                      {Input: 30.00, Output: 60.00},
+	"gpt-3.5-turbo":              {Input: 0.50, Output: 1.50},
+	"gpt-35-turbo":               {Input: 0.50, Output: 1.50},
+	"claude-3-5-sonnet-20241022": {Input: 3.00, Output: 15.00},
+	"claude-3-5-haiku-20241022":  {Input: 0.80, Output: 4.00},
+	"claude-3-opus-20240229":     {Input: 15.00, Output: 75.00},
+	"claude-3-sonnet-20240229":   {Input: 3.00, Output: 15.00},
+	"claude-3-haiku-20240307":    {Input: 0.25, Output: 1.25},
+	"deepseek-chat":              {Input: 0.27, Output: 1.10},
+	"deepseek-coder":             {Input: 0.27, Output: 1.10},
+	"grok-beta":                  {Input: 5.00, Output: 15.00},
+	"grok-2":                     {Input: 2.00, Output: 10.00},
+	"gemini-2.0-flash":           {Input: 0.10, Output: 0.40},
+	"gemini-1.5-pro":             {Input: 1.25, Output: 5.00},
+	"gemini-1.5-flash":           {Input: 0.075, Output: 0.30},
+	"gemini-1.5-flash-8b":        {Input: 0.0375, Output: 0.15},
+}
+
+// EstimateCost estima o custo em USD de uma chamada
+func EstimateCost(model string, usage Usage) float64 {
+	price, ok := modelPrices[model]
+	if !ok {
+		// OpenRouter usa o prefixo do provedor (ex: "openai/gpt-4o")
+		if idx := strings.Index(model, "/"); idx >= 0 {
+			price, ok = modelPrices[model[idx+1:]]
+		}
+		if !ok {
+			return 0
+		}
+	}
+	return (float64(usage.InputTokens)*price.Input + float64(usage.OutputTokens)*price.Output) / 1_000_000
+}
+
+// StartOfMonth retorna o início do mês corrente
+func StartOfMonth(now time.Time) time.Time {
+	return time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+}
+
+// MeteredClient registra consumo e aplica o orçamento mensal antes de cada requisição
+type MeteredClient struct {
+	client  AIClient
+	feature Feature
+	budget  *BudgetConfig
+}
+
+// NewMeteredClient envolve um cliente com contabilização de consumo
+func NewMeteredClient(client AIClient, feature Feature, budget *BudgetConfig) *MeteredClient {
+	return &MeteredClient{client: client, feature: feature, budget: budget}
+}
+
+var budgetWarned bool
+
+// checkBudget verifica o orçamento mensal antes de enviar uma requisição
+func (m *MeteredClient) checkBudget() error {
+	if m.budget == nil || usageStore == nil {
+		return nil
+	}
+	if m.budget.MonthlyLimitUSD <= 0 && m.budget.MonthlyTokenLimit <= 0 {
+		return nil
+	}
+
+	totals, err := usageStore.Totals(StartOfMonth(time.Now()))
+	if err != nil {
+		return nil
+	}
+
+	var exceeded string
+	if m.budget.MonthlyLimitUSD > 0 && totals.CostUSD >= m.budget.MonthlyLimitUSD {
+		exceeded = fmt.Sprintf("custo de US$ %.4f atingiu o limite de US$ %.2f", totals.CostUSD, m.budget.MonthlyLimitUSD)
+	} else if m.budget.MonthlyTokenLimit > 0 && totals.InputTokens+totals.OutputTokens >= m.budget.MonthlyTokenLimit {
+		exceeded = fmt.Sprintf("%d tokens atingiram o limite de %d", totals.InputTokens+totals.OutputTokens, m.budget.MonthlyTokenLimit)
+	}
+
+	if exceeded == "" {
+		return nil
+	}
+
+	if m.budget.Action == "block" {
+		return fmt.Errorf("%w: %s (ajuste com: snip ai budget)", ErrBudgetExceeded, exceeded)
+	}
+
+	if !budgetWarned {
+		budgetWarned = true
+		fmt.Fprintf(os.Stderr, "⚠️  Orçamento mensal de IA excedido: %s\n", exceeded)
+	}
+	return nil
+}
+
+func (m *MeteredClient) GetProvider() Provider {
+	return m.client.GetProvider()
+}
+
+func (m *MeteredClient) GetModel() string {
+	return m.client.GetModel()
+}
+
+func (m *MeteredClient) SetModel(model string) {
+	m.client.SetModel(model)
+}
+
+// LastUsage retorna o consumo da última resposta do cliente envolvido
+func (m *MeteredClient) LastUsage() Usage {
+	if reporter, ok := m.client.(UsageReporter); ok {
+		return reporter.LastUsage()
+	}
+	return Usage{}
+}
+
+func (m *MeteredClient) Chat(messages []Message, maxTokens int, temperature float64) (string, error) {
+	return m.metered(func() (string, error) {
+		return m.client.Chat(messages, maxTokens, temperature)
+	})
+}
+
+func (m *MeteredClient) ChatJSONMode(messages []Message, maxTokens int, temperature float64) (string, error) {
+	return m.metered(func() (string, error) {
+		return chatJSONMode(m.client, messages, maxTokens, temperature)
+	})
+}
+
+func (m *MeteredClient) ChatWithTools(messages []Message, tools []Tool, maxTokens int, temperature float64) (*ToolResponse, error) {
+	var response *ToolResponse
+	_, err := m.metered(func() (string, error) {
+		var err error
+		response, err = m.client.ChatWithTools(messages, tools, maxTokens, temperature)
+		return "", err
+	})
+	return response, err
+}
+
+func (m *MeteredClient) metered(call func() (string, error)) (string, error) {
+	if err := m.checkBudget(); err != nil {
+		return "", err
+	}
+
+	start := time.Now()
+	response, err := call()
+	if err != nil {
+		return "", err
+	}
+
+	if usageStore != nil {
+		usage := m.LastUsage()
+		record := &UsageRecord{
+			Provider:     m.client.GetProvider(),
+			Model:        m.client.GetModel(),
+			Feature:      m.feature,
+			InputTokens:  usage.InputTokens,
+			OutputTokens: usage.OutputTokens,
+			LatencyMs:    time.Since(start).Milliseconds(),
+			CostUSD:      EstimateCost(m.client.GetModel(), usage),
+			CreatedAt:    time.Now(),
+		}
+		// Falha ao registrar consumo não deve interromper a resposta
+		_ = usageStore.Record(record)
+	}
+
+	return response, nil
+}
+
+func (m *MeteredClient) ChatJSON(messages []Message, schema interface{}, maxTokens int, temperature float64) error {
+	return chatJSONGeneric(m, messages, schema, maxTokens, temperature)
+}
+
+func (m *MeteredClient) GenerateContent(prompt string, maxTokens int) (string, error) {
+	return generateContentGeneric(m, prompt, maxTokens)
+}
+
+func (m *MeteredClient) GenerateNoteContent(topic string, context string) (string, error) {
+	return generateNoteContentGeneric(m, topic, context)
+}
+
+func (m *MeteredClient) ImproveSearchQuery(query string, notesContext []string) (string, error) {
+	return improveSearchQueryGeneric(m, query, notesContext)
+}
+
+func (m *MeteredClient) AnswerQuestion(question string, notesContext []string) (string, error) {
+	return answerQuestionGeneric(m, question, notesContext)
+}
+
+func (m *MeteredClient) GenerateCode(language string, description string, context string) (string, error) {
+	return generateCodeGeneric(m, language, description, context)
+}
+
+func (m *MeteredClient) GenerateTips(topic string) (string, error) {
+	return generateTipsGeneric(m, topic)
+}
+
+func (m *MeteredClient) GenerateChecklist(topic string, context string, numItems int) ([]string, error) {
+	return generateChecklistGeneric(m, topic, context, numItems)
+}
+
+func (m *MeteredClient) GenerateProjectPlan(projectName string, description string) (string, error) {
+	return generateProjectPlanGeneric(m, projectName, description)
+}
@@ -0,0 +1,264 @@
+package aichat
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/snip/internal/ai"
+	"github.com/snip/internal/note"
+)
+
+// maxHistoryMessages limita quantas mensagens anteriores vão para o modelo
+const maxHistoryMessages = 30
+
+// maxTitleLength limita o título gerado a partir da primeira pergunta
+const maxTitleLength = 60
+
+// defaultTitle é usado quando a sessão é gravada antes da primeira pergunta (/note)
+const defaultTitle = "Nova conversa"
+
+// ChatSession representa uma conversa persistida nas tabelas ai_sessions e ai_messages
+type ChatSession struct {
+	ID           int
+	Title        string
+	Provider     ai.Provider
+	Model        string
+	PinnedNotes  []int
+	Messages     []ChatMessage
+	MessageCount int
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// ChatMessage representa uma mensagem da conversa
+type ChatMessage struct {
+	ID        int
+	SessionID int
+	Role      string // "user" ou "assistant"
+	Content   string
+	Timestamp time.Time
+}
+
+// SessionStore persiste sessões e mensagens
+type SessionStore interface {
+	CreateSession(session *ChatSession) error
+	UpdateSession(session *ChatSession) error
+	AddMessage(message *ChatMessage) error
+	PinNote(sessionID, noteID int) error
+}
+
+// NoteLookup busca uma nota fixada como contexto (/note <id>)
+type NoteLookup func(id int) (*note.NoteWithTags, error)
+
+// Chat é o gerenciador de uma conversa com a IA sobre as notas
+type Chat struct {
+	aiClient ai.AIClient
+	store    SessionStore
+	lookup   NoteLookup
+	session  *ChatSession
+}
+
+// NewChat cria uma nova sessão; ela só é gravada na primeira mensagem ou nota fixada
+func NewChat(store SessionStore, lookup NoteLookup, title string) (*Chat, error) {
+	aiClient, err := ai.NewAIClientFor(ai.FeatureChat)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao criar cliente IA: %w", err)
+	}
+
+	now := time.Now()
+	session := &ChatSession{
+		Title:     title,
+		Provider:  aiClient.GetProvider(),
+		Model:     aiClient.GetModel(),
+		Messages:  []ChatMessage{},
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	return &Chat{aiClient: aiClient, store: store, lookup: lookup, session: session}, nil
+}
+
+// ResumeChat continua uma sessão salva. O modelo da sessão é restaurado quando
+// o provedor atual é o mesmo e keepModel é falso (sem --model explícito)
+func ResumeChat(session *ChatSession, store SessionStore, lookup NoteLookup, keepModel bool) (*Chat, error) {
+	aiClient, err := ai.NewAIClientFor(ai.FeatureChat)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao criar cliente IA: %w", err)
+	}
+
+	if !keepModel && session.Model != "" && session.Provider == aiClient.GetProvider() {
+		aiClient.SetModel(session.Model)
+	}
+	session.Provider = aiClient.GetProvider()
+	session.Model = aiClient.GetModel()
+
+	return &Chat{aiClient: aiClient, store: store, lookup: lookup, session: session}, nil
+}
+
+// SendMessage envia a mensagem com o histórico e as notas fixadas e grava a troca
+func (c *Chat) SendMessage(userMessage string) (string, error) {
+	messages, err := c.buildMessages(userMessage)
+	if err != nil {
+		return "", err
+	}
+
+	response, err := c.aiClient.Chat(messages, 2000, 0.7)
+	if err != nil {
+		return "", err
+	}
+
+	if len(c.session.Messages) == 0 && (c.session.Title == "" || c.session.Title == defaultTitle) {
+		c.session.Title = titleFrom(userMessage)
+	}
+	if err := c.ensureSaved(); err != nil {
+		return "", err
+	}
+
+	if err := c.addMessage("user", userMessage); err != nil {
+		return "", err
+	}
+	if err := c.addMessage("assistant", response); err != nil {
+		return "", err
+	}
+
+	return response, nil
+}
+
+// buildMessages monta o prompt: sistema com as notas fixadas, histórico recente e a nova pergunta
+func (c *Chat) buildMessages(userMessage string) ([]ai.Message, error) {
+	var notes []*note.NoteWithTags
+	for _, id := range c.session.PinnedNotes {
+		n, err := c.lookup(id)
+		if err != nil {
+			// Nota apagada depois de fixada: segue sem ela
+			continue
+		}
+		notes = append(notes, n)
+	}
+
+	system, _, err := ai.RenderPrompt("aichat.system", ai.PromptData{"Notes": notes})
+	if err != nil {
+		return nil, err
+	}
+
+	messages := []ai.Message{{Role: "system", Content: system}}
+
+	history := c.session.Messages
+	if len(history) > maxHistoryMessages {
+		history = history[len(history)-maxHistoryMessages:]
+	}
+	for _, msg := range history {
+		messages = append(messages, ai.Message{Role: msg.Role, Content: msg.Content})
+	}
+
+	return append(messages, ai.Message{Role: "user", Content: userMessage}), nil
+}
+
+// PinNote fixa uma nota como contexto das próximas mensagens
+func (c *Chat) PinNote(noteID int) (*note.NoteWithTags, error) {
+	n, err := c.lookup(noteID)
+	if err != nil {
+		return nil, fmt.Errorf("nota #%d não encontrada", noteID)
+	}
+
+	for _, id := range c.session.PinnedNotes {
+		if id == noteID {
+			return n, nil
+		}
+	}
+
+	if err := c.ensureSaved(); err != nil {
+		return nil, err
+	}
+	if err := c.store.PinNote(c.session.ID, noteID); err != nil {
+		return nil, fmt.Errorf("erro ao fixar nota: %w", err)
+	}
+
+	c.session.PinnedNotes = append(c.session.PinnedNotes, noteID)
+	return n, nil
+}
+
+// SetModel troca o modelo usado nas próximas mensagens
+func (c *Chat) SetModel(model string) error {
+	c.aiClient.SetModel(model)
+	c.session.Model = c.aiClient.GetModel()
+
+	if c.session.ID == 0 {
+		return nil
+	}
+	c.session.UpdatedAt = time.Now()
+	return c.store.UpdateSession(c.session)
+}
+
+// GetProvider retorna o provedor usado na sessão
+func (c *Chat) GetProvider() ai.Provider {
+	return c.aiClient.GetProvider()
+}
+
+// GetSession retorna a sessão atual
+func (c *Chat) GetSession() *ChatSession {
+	return c.session
+}
+
+// Transcript retorna a conversa em markdown (usado por /save)
+func (c *Chat) Transcript() string {
+	var sb strings.Builder
+
+	if len(c.session.PinnedNotes) > 0 {
+		ids := make([]string, 0, len(c.session.PinnedNotes))
+		for _, id := range c.session.PinnedNotes {
+			ids = append(ids, fmt.Sprintf("#%d", id))
+		}
+		sb.WriteString(fmt.Sprintf("> Notas de contexto: %s\n\n", strings.Join(ids, ", ")))
+	}
+
+	for _, msg := range c.session.Messages {
+		if msg.Role == "user" {
+			sb.WriteString("**Você:** ")
+		} else {
+			sb.WriteString("**Assistente:** ")
+		}
+		sb.WriteString(strings.TrimSpace(msg.Content))
+		sb.WriteString("\n\n")
+	}
+
+	return strings.TrimSpace(sb.String())
+}
+
+func (c *Chat) ensureSaved() error {
+	if c.session.ID != 0 {
+		return nil
+	}
+	if c.session.Title == "" {
+		c.session.Title = defaultTitle
+	}
+	if err := c.store.CreateSession(c.session); err != nil {
+		return fmt.Errorf("erro ao salvar sessão: %w", err)
+	}
+	return nil
+}
+
+func (c *Chat) addMessage(role, content string) error {
+	msg := ChatMessage{
+		SessionID: c.session.ID,
+		Role:      role,
+		Content:   content,
+		Timestamp: time.Now(),
+	}
+	if err := c.store.AddMessage(&msg); err != nil {
+		return fmt.Errorf("erro ao salvar mensagem: %w", err)
+	}
+
+	c.session.Messages = append(c.session.Messages, msg)
+	c.session.UpdatedAt = msg.Timestamp
+	return c.store.UpdateSession(c.session)
+}
+
+func titleFrom(message string) string {
+	title := strings.Join(strings.Fields(message), " ")
+	if runes := []rune(title); len(runes) > maxTitleLength {
+		title = string(runes[:maxTitleLength-3]) + "..."
+	}
+	return title
+}
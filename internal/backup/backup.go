@@ -17,7 +17,7 @@ type BackupAnalyzer struct {
 
 // NewBackupAnalyzer cria um novo analisador de backups
 func NewBackupAnalyzer() (*BackupAnalyzer, error) {
-	aiClient, err := ai.NewAIClient()
+	aiClient, err := ai.NewAIClientFor(ai.FeatureBackup)
 	if err != nil {
 		return nil, fmt.Errorf("erro ao criar cliente IA: %w", err)
 	}
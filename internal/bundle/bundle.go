@@ -0,0 +1,379 @@
+package bundle
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/snip/internal/checklist"
+	"github.com/snip/internal/project"
+	"github.com/snip/internal/task"
+	"gopkg.in/yaml.v3"
+)
+
+// Version é a versão do formato do pacote
+const Version = 1
+
+// Formatos aceitos em export/import
+const (
+	FormatYAML = "yaml"
+	FormatJSON = "json"
+)
+
+// Bundle é um projeto com tarefas, dependências, checklists e itens em um arquivo
+// portátil. As tarefas são referenciadas pelo campo ref (o ID de origem), que é
+// remapeado para novos IDs na importação.
+type Bundle struct {
+	Version    int         `json:"version" yaml:"version"`
+	ExportedAt time.Time   `json:"exported_at" yaml:"exported_at"`
+	Template   bool        `json:"template,omitempty" yaml:"template,omitempty"`
+	Project    Project     `json:"project" yaml:"project"`
+	Tasks      []Task      `json:"tasks,omitempty" yaml:"tasks,omitempty"`
+	Checklists []Checklist `json:"checklists,omitempty" yaml:"checklists,omitempty"`
+}
+
+// Project são os dados exportados de project.Project
+type Project struct {
+	Name        string `json:"name" yaml:"name"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	Status      string `json:"status,omitempty" yaml:"status,omitempty"`
+}
+
+// Task são os dados exportados de task.Task
+type Task struct {
+	Ref         int    `json:"ref" yaml:"ref"`
+	Parent      int    `json:"parent,omitempty" yaml:"parent,omitempty"`
+	Title       string `json:"title" yaml:"title"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	Status      string `json:"status,omitempty" yaml:"status,omitempty"`
+	Priority    string `json:"priority,omitempty" yaml:"priority,omitempty"`
+	DueDate     string `json:"due_date,omitempty" yaml:"due_date,omitempty"` // YYYY-MM-DD
+	Estimate    string `json:"estimate,omitempty" yaml:"estimate,omitempty"` // ex: 2h30m, 3d
+	Recurrence  string `json:"recurrence,omitempty" yaml:"recurrence,omitempty"`
+	DependsOn   []int  `json:"depends_on,omitempty" yaml:"depends_on,omitempty"`
+}
+
+// Checklist são os dados exportados de checklist.Checklist; Task é o ref da
+// tarefa dona (0 = checklist do projeto)
+type Checklist struct {
+	Task        int    `json:"task,omitempty" yaml:"task,omitempty"`
+	Title       string `json:"title" yaml:"title"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	Items       []Item `json:"items,omitempty" yaml:"items,omitempty"`
+}
+
+// Item são os dados exportados de checklist.ChecklistItem, na ordem do checklist
+type Item struct {
+	Title       string `json:"title" yaml:"title"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	Completed   bool   `json:"completed,omitempty" yaml:"completed,omitempty"`
+	Category    string `json:"category,omitempty" yaml:"category,omitempty"`
+	Priority    string `json:"priority,omitempty" yaml:"priority,omitempty"`
+	Notes       string `json:"notes,omitempty" yaml:"notes,omitempty"`
+}
+
+// ValidationError reúne todos os problemas encontrados no pacote
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("pacote inválido:\n  - %s", strings.Join(e.Problems, "\n  - "))
+}
+
+var (
+	projectStatuses = map[string]bool{"active": true, "completed": true, "archived": true}
+	taskStatuses    = map[string]bool{"pending": true, "in_progress": true, "completed": true}
+	taskPriorities  = map[string]bool{"low": true, "medium": true, "high": true}
+)
+
+// New monta o pacote de um projeto. items mapeia o ID do checklist para seus itens.
+func New(p *project.Project, tasks []*task.Task, deps map[int][]int, checklists []*checklist.Checklist, items map[int][]*checklist.ChecklistItem) *Bundle {
+	b := &Bundle{
+		Version:    Version,
+		ExportedAt: time.Now().UTC().Truncate(time.Second),
+		Project:    Project{Name: p.Name, Description: p.Description, Status: p.Status},
+	}
+
+	inProject := make(map[int]bool, len(tasks))
+	for _, t := range tasks {
+		inProject[t.ID] = true
+	}
+
+	sorted := append([]*task.Task(nil), tasks...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].ID < sorted[j].ID })
+	for _, t := range sorted {
+		bt := Task{
+			Ref:         t.ID,
+			Title:       t.Title,
+			Description: t.Description,
+			Status:      t.Status,
+			Priority:    t.Priority,
+			Recurrence:  t.Recurrence,
+		}
+		if inProject[t.ParentTaskID] {
+			bt.Parent = t.ParentTaskID
+		}
+		if t.DueDate != nil {
+			bt.DueDate = t.DueDate.Format("2006-01-02")
+		}
+		if t.EstimatedTime > 0 {
+			bt.Estimate = task.FormatDuration(t.EstimatedTime)
+		}
+		// Dependências para tarefas de outros projetos não viajam no pacote
+		for _, depID := range deps[t.ID] {
+			if inProject[depID] {
+				bt.DependsOn = append(bt.DependsOn, depID)
+			}
+		}
+		sort.Ints(bt.DependsOn)
+		b.Tasks = append(b.Tasks, bt)
+	}
+
+	for _, c := range checklists {
+		bc := Checklist{Title: c.Title, Description: c.Description}
+		if c.TaskID != nil && inProject[*c.TaskID] {
+			bc.Task = *c.TaskID
+		}
+		list := append([]*checklist.ChecklistItem(nil), items[c.ID]...)
+		sort.SliceStable(list, func(i, j int) bool { return list[i].Order < list[j].Order })
+		for _, item := range list {
+			bc.Items = append(bc.Items, Item{
+				Title:       item.Title,
+				Description: item.Description,
+				Completed:   item.Completed,
+				Category:    item.Category,
+				Priority:    item.Priority,
+				Notes:       item.Notes,
+			})
+		}
+		b.Checklists = append(b.Checklists, bc)
+	}
+	return b
+}
+
+// AsTemplate zera o andamento (status, itens concluídos) para reutilizar o pacote
+// como modelo de projeto
+func (b *Bundle) AsTemplate() {
+	b.Template = true
+	b.Project.Status = "active"
+	for i := range b.Tasks {
+		b.Tasks[i].Status = "pending"
+	}
+	for i := range b.Checklists {
+		for j := range b.Checklists[i].Items {
+			b.Checklists[i].Items[j].Completed = false
+		}
+	}
+}
+
+// ShiftDueDates move todos os prazos para que o mais cedo caia em start,
+// mantendo os intervalos entre eles
+func (b *Bundle) ShiftDueDates(start time.Time) error {
+	var earliest time.Time
+	for _, t := range b.Tasks {
+		if t.DueDate == "" {
+			continue
+		}
+		due, err := time.Parse("2006-01-02", t.DueDate)
+		if err != nil {
+			return fmt.Errorf("tarefa %d: data inválida %q", t.Ref, t.DueDate)
+		}
+		if earliest.IsZero() || due.Before(earliest) {
+			earliest = due
+		}
+	}
+	if earliest.IsZero() {
+		return nil
+	}
+
+	offset := daysBetween(earliest, start)
+	for i, t := range b.Tasks {
+		if t.DueDate == "" {
+			continue
+		}
+		due, _ := time.Parse("2006-01-02", t.DueDate)
+		b.Tasks[i].DueDate = due.AddDate(0, 0, offset).Format("2006-01-02")
+	}
+	return nil
+}
+
+// Validate confere o pacote contra as regras de project, task e checklist e
+// retorna um *ValidationError com todos os problemas encontrados
+func (b *Bundle) Validate() error {
+	var problems []string
+	add := func(format string, args ...interface{}) {
+		problems = append(problems, fmt.Sprintf(format, args...))
+	}
+
+	if b.Version != Version {
+		add("versão %d não suportada (esperado %d)", b.Version, Version)
+	}
+	if strings.TrimSpace(b.Project.Name) == "" {
+		add("project.name é obrigatório")
+	}
+	if b.Project.Status != "" && !projectStatuses[b.Project.Status] {
+		add("project.status inválido: %s (use active, completed ou archived)", b.Project.Status)
+	}
+
+	refs := make(map[int]bool, len(b.Tasks))
+	for _, t := range b.Tasks {
+		if t.Ref <= 0 {
+			add("tarefa %q: ref deve ser um número positivo", t.Title)
+			continue
+		}
+		if refs[t.Ref] {
+			add("tarefa %d: ref duplicado", t.Ref)
+		}
+		refs[t.Ref] = true
+	}
+
+	parents := make(map[int]int)
+	deps := make(map[int][]int)
+	for _, t := range b.Tasks {
+		label := fmt.Sprintf("tarefa %d", t.Ref)
+		if strings.TrimSpace(t.Title) == "" {
+			add("%s: title é obrigatório", label)
+		}
+		if t.Status != "" && !taskStatuses[t.Status] {
+			add("%s: status inválido: %s", label, t.Status)
+		}
+		if t.Priority != "" && !taskPriorities[t.Priority] {
+			add("%s: priority inválida: %s", label, t.Priority)
+		}
+		if t.DueDate != "" {
+			if _, err := time.Parse("2006-01-02", t.DueDate); err != nil {
+				add("%s: due_date inválida: %s (use YYYY-MM-DD)", label, t.DueDate)
+			}
+		}
+		if _, err := task.ParseEstimate(t.Estimate); err != nil {
+			add("%s: %v", label, err)
+		}
+		if t.Recurrence != "" {
+			if _, err := task.ParseRecurrence(t.Recurrence); err != nil {
+				add("%s: %v", label, err)
+			}
+		}
+		if t.Parent != 0 {
+			if !refs[t.Parent] {
+				add("%s: parent %d não existe no pacote", label, t.Parent)
+			} else {
+				parents[t.Ref] = t.Parent
+			}
+		}
+		for _, dep := range t.DependsOn {
+			if !refs[dep] {
+				add("%s: depends_on %d não existe no pacote", label, dep)
+				continue
+			}
+			if cycle := task.FindCycle(deps, t.Ref, dep); cycle != nil {
+				add("%s: dependência circular %v", label, cycle)
+				continue
+			}
+			deps[t.Ref] = append(deps[t.Ref], dep)
+		}
+	}
+
+	for ref := range parents {
+		seen := map[int]bool{ref: true}
+		for p := parents[ref]; p != 0; p = parents[p] {
+			if seen[p] {
+				add("tarefa %d: hierarquia de subtarefas circular", ref)
+				break
+			}
+			seen[p] = true
+		}
+	}
+
+	for i, c := range b.Checklists {
+		label := fmt.Sprintf("checklist %d", i+1)
+		if strings.TrimSpace(c.Title) == "" {
+			add("%s: title é obrigatório", label)
+		}
+		if c.Task != 0 && !refs[c.Task] {
+			add("%s: task %d não existe no pacote", label, c.Task)
+		}
+		for j, item := range c.Items {
+			if strings.TrimSpace(item.Title) == "" {
+				add("%s, item %d: title é obrigatório", label, j+1)
+			}
+		}
+	}
+
+	if len(problems) > 0 {
+		return &ValidationError{Problems: problems}
+	}
+	return nil
+}
+
+// TaskOrder retorna as tarefas com cada pai antes das subtarefas, para que os
+// novos IDs dos pais existam ao criar os filhos
+func (b *Bundle) TaskOrder() []Task {
+	created := make(map[int]bool, len(b.Tasks))
+	var ordered []Task
+	for len(ordered) < len(b.Tasks) {
+		progress := false
+		for _, t := range b.Tasks {
+			if created[t.Ref] || (t.Parent != 0 && !created[t.Parent]) {
+				continue
+			}
+			created[t.Ref] = true
+			ordered = append(ordered, t)
+			progress = true
+		}
+		if !progress {
+			break
+		}
+	}
+	return ordered
+}
+
+// Marshal serializa o pacote em YAML ou JSON
+func (b *Bundle) Marshal(format string) ([]byte, error) {
+	switch format {
+	case FormatYAML:
+		var buf bytes.Buffer
+		encoder := yaml.NewEncoder(&buf)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(b); err != nil {
+			return nil, err
+		}
+		if err := encoder.Close(); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	case FormatJSON:
+		data, err := json.MarshalIndent(b, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		return append(data, '\n'), nil
+	}
+	return nil, fmt.Errorf("formato não suportado: %s (use yaml ou json)", format)
+}
+
+// Unmarshal lê um pacote; o formato vem da extensão do arquivo (.json ou .yaml/.yml)
+func Unmarshal(path string, data []byte) (*Bundle, error) {
+	var b Bundle
+	var err error
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		err = json.Unmarshal(data, &b)
+	} else {
+		err = yaml.Unmarshal(data, &b)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler pacote %s: %w", filepath.Base(path), err)
+	}
+	return &b, nil
+}
+
+// daysBetween conta dias de calendário entre duas datas
+func daysBetween(from, to time.Time) int {
+	a := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	b := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+	return int(b.Sub(a).Hours() / 24)
+}
@@ -3,8 +3,10 @@ package checklist
 import (
 	"encoding/csv"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -25,6 +27,8 @@ const (
 
 // BulkChecklistItem representa um item de checklist para processamento em massa
 type BulkChecklistItem struct {
+	// ID é o item de origem quando o CSV veio de checklist export (0 = item novo)
+	ID          int    `json:"id,omitempty"`
 	Title       string `json:"title"`
 	Description string `json:"description"`
 	Category    string `json:"category"`
@@ -52,7 +56,13 @@ func ProcessBulkChecklistFromCSV(csvPath string) (*BulkChecklistResult, error) {
 	}
 	defer file.Close()
 
-	reader := csv.NewReader(file)
+	return ParseBulkChecklist(file)
+}
+
+// ParseBulkChecklist lê os itens de um CSV em massa. A coluna id é opcional e
+// identifica o item de origem em CSVs gerados por checklist export.
+func ParseBulkChecklist(r io.Reader) (*BulkChecklistResult, error) {
+	reader := csv.NewReader(r)
 	reader.Comma = ','
 	reader.Comment = '#'
 	reader.TrimLeadingSpace = true
@@ -93,7 +103,17 @@ func ProcessBulkChecklistFromCSV(csvPath string) (*BulkChecklistResult, error) {
 			continue // Pular linhas incompletas
 		}
 
+		id := 0
+		if value := getField(record, headerMap, "id"); value != "" {
+			parsed, err := strconv.Atoi(value)
+			if err != nil {
+				return nil, fmt.Errorf("linha %d: id inválido: %s", i+2, value)
+			}
+			id = parsed
+		}
+
 		item := BulkChecklistItem{
+			ID:          id,
 			Title:       getField(record, headerMap, "title"),
 			Description: getField(record, headerMap, "description"),
 			Category:    getField(record, headerMap, "category"),
@@ -111,19 +131,7 @@ func ProcessBulkChecklistFromCSV(csvPath string) (*BulkChecklistResult, error) {
 		}
 
 		// Processar item (aqui você pode adicionar lógica de validação/execução)
-		result.Items = append(result.Items, item)
-		result.TotalItems++
-
-		switch strings.ToLower(item.Status) {
-		case "completed", "done", "ok":
-			result.Completed++
-		case "failed", "error":
-			result.Failed++
-		default:
-			result.Pending++
-		}
-
-		_ = i // Evitar erro de variável não usada
+		result.AddItem(item)
 	}
 
 	result.ExecutionTime = time.Since(startTime)
@@ -131,6 +139,112 @@ func ProcessBulkChecklistFromCSV(csvPath string) (*BulkChecklistResult, error) {
 	return result, nil
 }
 
+// AddItem acrescenta o item ao resultado e atualiza os totais pelo status
+func (r *BulkChecklistResult) AddItem(item BulkChecklistItem) {
+	r.Items = append(r.Items, item)
+	r.TotalItems++
+
+	switch {
+	case IsCompletedStatus(item.Status):
+		r.Completed++
+	case isFailedStatus(item.Status):
+		r.Failed++
+	default:
+		r.Pending++
+	}
+}
+
+// IsCompletedStatus indica se o status do CSV marca o item como concluído
+func IsCompletedStatus(status string) bool {
+	switch strings.ToLower(strings.TrimSpace(status)) {
+	case "completed", "done", "ok":
+		return true
+	}
+	return false
+}
+
+func isFailedStatus(status string) bool {
+	switch strings.ToLower(strings.TrimSpace(status)) {
+	case "failed", "error":
+		return true
+	}
+	return false
+}
+
+// bulkCSVHeader é o formato gravado por WriteBulkChecklistCSV: o do CSV em
+// massa com a coluna id na frente, para que a planilha possa ser reimportada
+var bulkCSVHeader = []string{"id", "title", "description", "category", "priority", "status", "notes"}
+
+// NewItemFromBulk converte a linha do CSV em item da checklist. O status é
+// guardado como veio e também define Completed; falhas e pendências ficam
+// como não concluídas.
+func NewItemFromBulk(checklistID int, b BulkChecklistItem, order int) *ChecklistItem {
+	item := NewChecklistItem(checklistID, b.Title, b.Description, order)
+	item.Status = strings.ToLower(strings.TrimSpace(b.Status))
+	item.Completed = IsCompletedStatus(b.Status)
+	item.Category = b.Category
+	item.Priority = strings.ToLower(b.Priority)
+	item.Notes = b.Notes
+	return item
+}
+
+// ApplyBulk atualiza o item com os valores da linha do CSV
+func (item *ChecklistItem) ApplyBulk(b BulkChecklistItem) {
+	item.Title = b.Title
+	item.Description = b.Description
+	item.Status = strings.ToLower(strings.TrimSpace(b.Status))
+	item.Completed = IsCompletedStatus(b.Status)
+	item.Category = b.Category
+	item.Priority = strings.ToLower(b.Priority)
+	item.Notes = b.Notes
+}
+
+// ToBulk converte o item para a linha do CSV em massa, com os mesmos padrões
+// da importação (prioridade medium). O status importado é mantido enquanto
+// concordar com Completed; itens sem status ou marcados pelo toggle depois da
+// importação saem como pending ou completed.
+func (item *ChecklistItem) ToBulk() BulkChecklistItem {
+	status := item.Status
+	if status == "" || IsCompletedStatus(status) != item.Completed {
+		status = "pending"
+		if item.Completed {
+			status = "completed"
+		}
+	}
+	priority := item.Priority
+	if priority == "" {
+		priority = "medium"
+	}
+	return BulkChecklistItem{
+		ID:          item.ID,
+		Title:       item.Title,
+		Description: item.Description,
+		Category:    item.Category,
+		Priority:    priority,
+		Status:      status,
+		Notes:       item.Notes,
+	}
+}
+
+// WriteBulkChecklistCSV grava os itens no formato do CSV em massa
+func WriteBulkChecklistCSV(w io.Writer, items []*ChecklistItem) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(bulkCSVHeader); err != nil {
+		return fmt.Errorf("erro ao escrever cabeçalho: %w", err)
+	}
+
+	for _, item := range items {
+		b := item.ToBulk()
+		row := []string{strconv.Itoa(b.ID), b.Title, b.Description, b.Category, b.Priority, b.Status, b.Notes}
+		if err := writer.Write(row); err != nil {
+			return fmt.Errorf("erro ao escrever linha: %w", err)
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
 // getField obtém um campo do record baseado no headerMap
 func getField(record []string, headerMap map[string]int, fieldName string) string {
 	if idx, ok := headerMap[strings.ToLower(fieldName)]; ok && idx < len(record) {
@@ -9,8 +9,11 @@ type Checklist struct {
 	ProjectID   *int
 	Title       string
 	Description string
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
+	// IsTemplate marca a checklist como reutilizável: em vez de marcar os
+	// itens, cada uso vira uma execução (Run) com os resultados próprios
+	IsTemplate bool
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
 }
 
 // ChecklistItem representa um item de checklist
@@ -21,8 +24,14 @@ type ChecklistItem struct {
 	Description string
 	Completed   bool
 	Order       int
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
+	// Category, Priority, Status e Notes vêm das colunas do CSV em massa;
+	// Status guarda o valor original (failed, error...) para a exportação
+	Category  string
+	Priority  string
+	Status    string
+	Notes     string
+	CreatedAt time.Time
+	UpdatedAt time.Time
 }
 
 // NewChecklist cria um novo checklist
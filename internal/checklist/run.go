@@ -0,0 +1,179 @@
+package checklist
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// RunResult é o resultado de um item em uma execução do template
+type RunResult string
+
+const (
+	ResultOK      RunResult = "ok"
+	ResultFailed  RunResult = "failed"
+	ResultSkipped RunResult = "skipped"
+)
+
+// ParseRunResult aceita o resultado por extenso ou pela inicial (o/f/s),
+// em inglês ou português
+func ParseRunResult(value string) (RunResult, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "o", "ok":
+		return ResultOK, nil
+	case "f", "failed", "fail", "falha":
+		return ResultFailed, nil
+	case "s", "skipped", "skip", "pular":
+		return ResultSkipped, nil
+	}
+	return "", fmt.Errorf("resultado inválido: %s (use ok, failed ou skipped)", value)
+}
+
+// Icon retorna o símbolo do resultado usado em run e history
+func (r RunResult) Icon() string {
+	switch r {
+	case ResultOK:
+		return "✓"
+	case ResultFailed:
+		return "✗"
+	case ResultSkipped:
+		return "–"
+	default:
+		return "·"
+	}
+}
+
+// Run é uma execução de um template de checklist
+type Run struct {
+	ID          int
+	ChecklistID int
+	StartedAt   time.Time
+	// FinishedAt fica nil quando a execução foi interrompida antes do último item
+	FinishedAt *time.Time
+	Results    []*RunItem
+}
+
+// RunItem é o resultado registrado para um item em uma execução. O título é
+// copiado do item para que o histórico sobreviva a edições do template.
+type RunItem struct {
+	ID         int
+	RunID      int
+	ItemID     int
+	Title      string
+	Result     RunResult
+	Notes      string
+	RecordedAt time.Time
+}
+
+// NewRun inicia uma execução do template
+func NewRun(checklistID int) *Run {
+	return &Run{
+		ChecklistID: checklistID,
+		StartedAt:   time.Now(),
+	}
+}
+
+// Count retorna quantos itens da execução tiveram o resultado informado
+func (r *Run) Count(result RunResult) int {
+	count := 0
+	for _, item := range r.Results {
+		if item.Result == result {
+			count++
+		}
+	}
+	return count
+}
+
+// Result retorna o resultado registrado para o item, ou "" se não houver
+func (r *Run) Result(itemID int) RunResult {
+	for _, item := range r.Results {
+		if item.ItemID == itemID {
+			return item.Result
+		}
+	}
+	return ""
+}
+
+// HistoryRow é a linha de um item no histórico: o resultado em cada execução,
+// na ordem das execuções ("" quando o item não foi registrado nela)
+type HistoryRow struct {
+	ItemID  int
+	Title   string
+	Removed bool
+	Results []RunResult
+}
+
+// Regressed indica que o item falhou na última execução em que foi registrado
+// depois de ter passado na anterior
+func (h HistoryRow) Regressed() bool {
+	var recorded []RunResult
+	for _, result := range h.Results {
+		if result != "" && result != ResultSkipped {
+			recorded = append(recorded, result)
+		}
+	}
+	n := len(recorded)
+	return n >= 2 && recorded[n-1] == ResultFailed && recorded[n-2] == ResultOK
+}
+
+// History monta a comparação das execuções (em ordem cronológica) item a item:
+// primeiro os itens atuais do template, na ordem dele, depois os que foram
+// removidos mas aparecem em alguma execução
+func History(items []*ChecklistItem, runs []*Run) []HistoryRow {
+	var rows []HistoryRow
+	index := make(map[int]int)
+	for _, item := range items {
+		index[item.ID] = len(rows)
+		rows = append(rows, HistoryRow{ItemID: item.ID, Title: item.Title})
+	}
+	for _, run := range runs {
+		for _, result := range run.Results {
+			if _, ok := index[result.ItemID]; !ok {
+				index[result.ItemID] = len(rows)
+				rows = append(rows, HistoryRow{ItemID: result.ItemID, Title: result.Title, Removed: true})
+			}
+		}
+	}
+
+	for i := range rows {
+		rows[i].Results = make([]RunResult, len(runs))
+		for j, run := range runs {
+			rows[i].Results[j] = run.Result(rows[i].ItemID)
+		}
+	}
+	return rows
+}
+
+// Reorder aplica a nova ordem aos itens: os IDs informados vêm primeiro, na
+// ordem dada, e os demais mantêm a ordem relativa logo depois. O campo Order
+// é renumerado a partir de 1.
+func Reorder(items []*ChecklistItem, ids []int) ([]*ChecklistItem, error) {
+	byID := make(map[int]*ChecklistItem, len(items))
+	for _, item := range items {
+		byID[item.ID] = item
+	}
+
+	seen := make(map[int]bool, len(ids))
+	var ordered []*ChecklistItem
+	for _, id := range ids {
+		item, ok := byID[id]
+		if !ok {
+			return nil, fmt.Errorf("item #%d não pertence à checklist", id)
+		}
+		if seen[id] {
+			return nil, fmt.Errorf("item #%d informado mais de uma vez", id)
+		}
+		seen[id] = true
+		ordered = append(ordered, item)
+	}
+	for _, item := range items {
+		if !seen[item.ID] {
+			ordered = append(ordered, item)
+		}
+	}
+
+	for i, item := range ordered {
+		item.Order = i + 1
+	}
+	return ordered, nil
+}
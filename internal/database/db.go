@@ -186,8 +186,242 @@ func ensureDatabase(db *sql.DB) error {
     CREATE INDEX IF NOT EXISTS idx_db_analyses_created_at ON db_analyses(created_at);
     CREATE INDEX IF NOT EXISTS idx_error_kb_database_type ON error_knowledge_base(database_type);
     CREATE INDEX IF NOT EXISTS idx_error_kb_error_code ON error_knowledge_base(error_code);
+
+    -- AI Usage Table
+    CREATE TABLE IF NOT EXISTS ai_usage (
+        id INTEGER PRIMARY KEY AUTOINCREMENT,
+        provider TEXT NOT NULL,
+        model TEXT NOT NULL,
+        feature TEXT,
+        input_tokens INTEGER DEFAULT 0,
+        output_tokens INTEGER DEFAULT 0,
+        latency_ms INTEGER DEFAULT 0,
+        cost_usd REAL DEFAULT 0,
+        created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+    );
+
+    CREATE INDEX IF NOT EXISTS idx_ai_usage_created_at ON ai_usage(created_at);
+    CREATE INDEX IF NOT EXISTS idx_ai_usage_feature ON ai_usage(feature);
+    CREATE INDEX IF NOT EXISTS idx_ai_usage_model ON ai_usage(model);
+
+    -- AI Response Cache Table
+    CREATE TABLE IF NOT EXISTS ai_cache (
+        cache_key TEXT PRIMARY KEY,
+        provider TEXT NOT NULL,
+        model TEXT NOT NULL,
+        response TEXT NOT NULL,
+        hits INTEGER DEFAULT 0,
+        created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+        expires_at DATETIME NOT NULL
+    );
+
+    CREATE INDEX IF NOT EXISTS idx_ai_cache_expires_at ON ai_cache(expires_at);
+
+    -- AI Chat Sessions Tables
+    CREATE TABLE IF NOT EXISTS ai_sessions (
+        id INTEGER PRIMARY KEY AUTOINCREMENT,
+        title TEXT NOT NULL,
+        provider TEXT,
+        model TEXT,
+        created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+        updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+    );
+
+    CREATE TABLE IF NOT EXISTS ai_messages (
+        id INTEGER PRIMARY KEY AUTOINCREMENT,
+        session_id INTEGER NOT NULL,
+        role TEXT NOT NULL,
+        content TEXT NOT NULL,
+        created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+        FOREIGN KEY (session_id) REFERENCES ai_sessions(id) ON DELETE CASCADE
+    );
+
+    CREATE TABLE IF NOT EXISTS ai_session_notes (
+        session_id INTEGER NOT NULL,
+        note_id INTEGER NOT NULL,
+        PRIMARY KEY (session_id, note_id),
+        FOREIGN KEY (session_id) REFERENCES ai_sessions(id) ON DELETE CASCADE,
+        FOREIGN KEY (note_id) REFERENCES notes(id) ON DELETE CASCADE
+    );
+
+    CREATE INDEX IF NOT EXISTS idx_ai_sessions_updated_at ON ai_sessions(updated_at);
+    CREATE INDEX IF NOT EXISTS idx_ai_messages_session_id ON ai_messages(session_id);
+
+    -- Snippets Table (código extraído de notas, ex: snip ai-code --save)
+    CREATE TABLE IF NOT EXISTS note_snippets (
+        note_id INTEGER PRIMARY KEY,
+        language TEXT NOT NULL,
+        code TEXT NOT NULL,
+        prompt TEXT,
+        created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+        FOREIGN KEY (note_id) REFERENCES notes(id) ON DELETE CASCADE
+    );
+
+    CREATE INDEX IF NOT EXISTS idx_note_snippets_language ON note_snippets(language);
+
+    -- Study Cards Table (flashcards gerados de notas, revisados com SM-2)
+    CREATE TABLE IF NOT EXISTS study_cards (
+        id INTEGER PRIMARY KEY AUTOINCREMENT,
+        note_id INTEGER NOT NULL,
+        question TEXT NOT NULL,
+        answer TEXT NOT NULL,
+        ease_factor REAL NOT NULL DEFAULT 2.5,
+        interval_days INTEGER NOT NULL DEFAULT 0,
+        repetitions INTEGER NOT NULL DEFAULT 0,
+        due_at DATETIME NOT NULL,
+        last_reviewed_at DATETIME,
+        created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+        FOREIGN KEY (note_id) REFERENCES notes(id) ON DELETE CASCADE
+    );
+
+    -- Study Reviews Table (histórico de respostas de cada cartão)
+    CREATE TABLE IF NOT EXISTS study_reviews (
+        id INTEGER PRIMARY KEY AUTOINCREMENT,
+        card_id INTEGER NOT NULL,
+        quality INTEGER NOT NULL,
+        ease_factor REAL NOT NULL,
+        interval_days INTEGER NOT NULL,
+        reviewed_at DATETIME NOT NULL,
+        FOREIGN KEY (card_id) REFERENCES study_cards(id) ON DELETE CASCADE
+    );
+
+    CREATE INDEX IF NOT EXISTS idx_study_cards_note_id ON study_cards(note_id);
+    CREATE INDEX IF NOT EXISTS idx_study_cards_due_at ON study_cards(due_at);
+    CREATE INDEX IF NOT EXISTS idx_study_reviews_card_id ON study_reviews(card_id);
+
+    -- Task Dependencies Table (task_id só pode começar após depends_on_id)
+    CREATE TABLE IF NOT EXISTS task_dependencies (
+        task_id INTEGER NOT NULL,
+        depends_on_id INTEGER NOT NULL,
+        created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+        PRIMARY KEY (task_id, depends_on_id),
+        FOREIGN KEY (task_id) REFERENCES tasks(id) ON DELETE CASCADE,
+        FOREIGN KEY (depends_on_id) REFERENCES tasks(id) ON DELETE CASCADE
+    );
+
+    CREATE INDEX IF NOT EXISTS idx_task_dependencies_depends_on ON task_dependencies(depends_on_id);
+
+    -- Time Entries Table (ended_at NULL = cronômetro ativo)
+    CREATE TABLE IF NOT EXISTS time_entries (
+        id INTEGER PRIMARY KEY AUTOINCREMENT,
+        task_id INTEGER NOT NULL,
+        started_at DATETIME NOT NULL,
+        ended_at DATETIME,
+        duration_seconds INTEGER NOT NULL DEFAULT 0,
+        note TEXT,
+        created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+        FOREIGN KEY (task_id) REFERENCES tasks(id) ON DELETE CASCADE
+    );
+
+    CREATE TABLE IF NOT EXISTS time_entry_tags (
+        entry_id INTEGER NOT NULL,
+        tag_id INTEGER NOT NULL,
+        PRIMARY KEY (entry_id, tag_id),
+        FOREIGN KEY (entry_id) REFERENCES time_entries(id) ON DELETE CASCADE,
+        FOREIGN KEY (tag_id) REFERENCES tags(id) ON DELETE CASCADE
+    );
+
+    CREATE INDEX IF NOT EXISTS idx_time_entries_task_id ON time_entries(task_id);
+    CREATE INDEX IF NOT EXISTS idx_time_entries_started_at ON time_entries(started_at);
+    -- Apenas um cronômetro ativo por vez
+    CREATE UNIQUE INDEX IF NOT EXISTS idx_time_entries_active ON time_entries((ended_at IS NULL)) WHERE ended_at IS NULL;
+
+    -- Note Links Tables (notas vinculadas a projetos e tarefas)
+    CREATE TABLE IF NOT EXISTS note_projects (
+        note_id INTEGER NOT NULL,
+        project_id INTEGER NOT NULL,
+        created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+        PRIMARY KEY (note_id, project_id),
+        FOREIGN KEY (note_id) REFERENCES notes(id) ON DELETE CASCADE,
+        FOREIGN KEY (project_id) REFERENCES projects(id) ON DELETE CASCADE
+    );
+
+    CREATE TABLE IF NOT EXISTS note_tasks (
+        note_id INTEGER NOT NULL,
+        task_id INTEGER NOT NULL,
+        created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+        PRIMARY KEY (note_id, task_id),
+        FOREIGN KEY (note_id) REFERENCES notes(id) ON DELETE CASCADE,
+        FOREIGN KEY (task_id) REFERENCES tasks(id) ON DELETE CASCADE
+    );
+
+    CREATE INDEX IF NOT EXISTS idx_note_projects_project_id ON note_projects(project_id);
+    CREATE INDEX IF NOT EXISTS idx_note_tasks_task_id ON note_tasks(task_id);
+
+    -- Checklist Runs Table (execuções de um template de checklist)
+    CREATE TABLE IF NOT EXISTS checklist_runs (
+        id INTEGER PRIMARY KEY AUTOINCREMENT,
+        checklist_id INTEGER NOT NULL,
+        started_at DATETIME NOT NULL,
+        finished_at DATETIME,
+        FOREIGN KEY (checklist_id) REFERENCES checklists(id) ON DELETE CASCADE
+    );
+
+    -- Checklist Run Items Table (resultado de cada item em uma execução)
+    CREATE TABLE IF NOT EXISTS checklist_run_items (
+        id INTEGER PRIMARY KEY AUTOINCREMENT,
+        run_id INTEGER NOT NULL,
+        item_id INTEGER NOT NULL,
+        title TEXT NOT NULL,
+        result TEXT NOT NULL CHECK (result IN ('ok', 'failed', 'skipped')),
+        notes TEXT,
+        recorded_at DATETIME NOT NULL,
+        FOREIGN KEY (run_id) REFERENCES checklist_runs(id) ON DELETE CASCADE
+    );
+
+    CREATE INDEX IF NOT EXISTS idx_checklist_runs_checklist_id ON checklist_runs(checklist_id);
+    CREATE INDEX IF NOT EXISTS idx_checklist_run_items_run_id ON checklist_run_items(run_id);
     `
 
-	_, err := db.Exec(query)
+	if _, err := db.Exec(query); err != nil {
+		return err
+	}
+
+	// Colunas adicionadas após a criação das tabelas originais
+	if err := ensureColumn(db, "notes", "summary", "TEXT"); err != nil {
+		return err
+	}
+	if err := ensureColumn(db, "tasks", "estimated_minutes", "INTEGER DEFAULT 0"); err != nil {
+		return err
+	}
+	if err := ensureColumn(db, "tasks", "parent_task_id", "INTEGER REFERENCES tasks(id)"); err != nil {
+		return err
+	}
+	if err := ensureColumn(db, "tasks", "recurrence", "TEXT"); err != nil {
+		return err
+	}
+	if err := ensureColumn(db, "checklists", "is_template", "INTEGER DEFAULT 0"); err != nil {
+		return err
+	}
+	if err := ensureColumn(db, "checklist_items", "category", "TEXT"); err != nil {
+		return err
+	}
+	if err := ensureColumn(db, "checklist_items", "priority", "TEXT"); err != nil {
+		return err
+	}
+	if err := ensureColumn(db, "checklist_items", "status", "TEXT"); err != nil {
+		return err
+	}
+	if err := ensureColumn(db, "checklist_items", "notes", "TEXT"); err != nil {
+		return err
+	}
+	if _, err := db.Exec(`CREATE INDEX IF NOT EXISTS idx_tasks_parent_task_id ON tasks(parent_task_id)`); err != nil {
+		return err
+	}
+	return ensureColumn(db, "db_analyses", "metadata", "TEXT")
+}
+
+// ensureColumn adiciona a coluna em bancos criados antes dela existir
+func ensureColumn(db *sql.DB, table, column, definition string) error {
+	var count int
+	query := `SELECT COUNT(*) FROM pragma_table_info(?) WHERE name = ?`
+	if err := db.QueryRow(query, table, column).Scan(&count); err != nil {
+		return err
+	}
+	if count > 0 {
+		return nil
+	}
+
+	_, err := db.Exec("ALTER TABLE " + table + " ADD COLUMN " + column + " " + definition)
 	return err
 }
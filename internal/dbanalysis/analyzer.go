@@ -29,7 +29,7 @@ type Analyzer struct {
 // NewAnalyzer cria um novo analisador
 func NewAnalyzer() (*Analyzer, error) {
 	// Tentar criar cliente IA, mas não falhar se não estiver configurado
-	aiClient, err := ai.NewAIClient()
+	aiClient, err := ai.NewAIClientFor(ai.FeatureDBAnalysis)
 	if err != nil {
 		// IA é opcional - continuar sem ela
 		aiClient = nil
@@ -436,21 +436,33 @@ func (a *Analyzer) analyzeErrorKnowledge(analysis *DBAnalysis, config *Connectio
 
 // generateAIInsights gera insights usando IA
 func (a *Analyzer) generateAIInsights(analysis *DBAnalysis) (string, error) {
-	prompt := fmt.Sprintf(`Você é um especialista em banco de dados %s. Analise os seguintes resultados de análise e forneça insights, recomendações e possíveis problemas.
-
-Tipo de Análise: %s
-Resultado:
-%s
-
-Forneça:
-1. Resumo executivo
-2. Principais problemas identificados
-3. Recomendações de ação
-4. Próximos passos sugeridos
+	// Resultados grandes (ex: running_queries, logs) são resumidos por partes antes da síntese
+	result, err := ai.ChatLongText(a.aiClient, ai.LongTextRequest{
+		Text:    analysis.Result,
+		Subject: fmt.Sprintf("%s / %s", analysis.DatabaseType, analysis.AnalysisType),
+		Build: func(text string, summarized bool) ([]ai.Message, error) {
+			return ai.PromptMessages("dbanalysis.insights", ai.PromptData{
+				"DBType":       analysis.DatabaseType,
+				"AnalysisType": analysis.AnalysisType,
+				"Result":       text,
+				"Summarized":   summarized,
+			})
+		},
+		MaxTokens:   2000,
+		Temperature: 0.7,
+	})
+	if err != nil {
+		return "", err
+	}
 
-Formate a resposta em markdown.`, analysis.DatabaseType, analysis.AnalysisType, analysis.Result)
+	if analysis.Metadata == nil {
+		analysis.Metadata = map[string]string{}
+	}
+	for key, value := range result.Metadata() {
+		analysis.Metadata["insights_"+key] = value
+	}
 
-	return a.aiClient.GenerateContent(prompt, 2000)
+	return result.Content, nil
 }
 
 // Funções auxiliares de diagnóstico específicas por banco
@@ -88,19 +88,21 @@ type ConnectionConfig = dbtypes.ConnectionConfig
 
 // DBAnalysis representa uma análise de banco de dados
 type DBAnalysis struct {
-	ID              int          `json:"id"`
-	Title           string       `json:"title"`
-	DatabaseType    DatabaseType `json:"database_type"`
-	AnalysisType    AnalysisType `json:"analysis_type"`
-	ConnectionConfig string      `json:"connection_config"` // JSON string
-	LogFilePath     string       `json:"log_file_path,omitempty"`
-	OutputType      OutputType   `json:"output_type"`
-	Result          string       `json:"result"` // Resultado da análise
-	AIInsights      string       `json:"ai_insights,omitempty"` // Insights gerados pela IA
-	Status          string       `json:"status"` // pending, completed, error
-	ErrorMessage    string       `json:"error_message,omitempty"`
-	CreatedAt       time.Time    `json:"created_at"`
-	UpdatedAt       time.Time    `json:"updated_at"`
+	ID               int          `json:"id"`
+	Title            string       `json:"title"`
+	DatabaseType     DatabaseType `json:"database_type"`
+	AnalysisType     AnalysisType `json:"analysis_type"`
+	ConnectionConfig string       `json:"connection_config"` // JSON string
+	LogFilePath      string       `json:"log_file_path,omitempty"`
+	OutputType       OutputType   `json:"output_type"`
+	Result           string       `json:"result"`                // Resultado da análise
+	AIInsights       string       `json:"ai_insights,omitempty"` // Insights gerados pela IA
+	Status           string       `json:"status"`                // pending, completed, error
+	ErrorMessage     string       `json:"error_message,omitempty"`
+	// Metadata guarda detalhes de execução (ex: estratégia usada nos insights)
+	Metadata  map[string]string `json:"metadata,omitempty"`
+	CreatedAt time.Time         `json:"created_at"`
+	UpdatedAt time.Time         `json:"updated_at"`
 }
 
 // NewDBAnalysis cria uma nova análise de banco de dados
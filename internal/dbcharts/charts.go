@@ -3,6 +3,7 @@ package dbcharts
 import (
 	"encoding/json"
 	"fmt"
+	"html"
 	"math"
 	"strings"
 
@@ -20,6 +21,7 @@ const (
 	ChartTypeTable     ChartType = "table"
 	ChartTypeASCII     ChartType = "ascii"
 	ChartTypeHTML      ChartType = "html"
+	ChartTypeSVG       ChartType = "svg"
 )
 
 // ChartData representa dados para um gráfico
@@ -46,7 +48,7 @@ type ChartGenerator struct {
 
 // NewChartGenerator cria um novo gerador de gráficos
 func NewChartGenerator() (*ChartGenerator, error) {
-	aiClient, err := ai.NewAIClient()
+	aiClient, err := ai.NewAIClientFor(ai.FeatureDBChart)
 	if err != nil {
 		return nil, fmt.Errorf("erro ao criar cliente IA: %w", err)
 	}
@@ -79,57 +81,29 @@ func (c *ChartGenerator) GenerateChartFromAnalysis(analysisResult string, chartT
 	return c.GenerateChartFromData(extractedData)
 }
 
-// extractDataWithAI usa IA para extrair dados estruturados do resultado da análise
-func (c *ChartGenerator) extractDataWithAI(analysisResult string, chartType ChartType) (ChartData, error) {
-	prompt := fmt.Sprintf(`Analise o seguinte resultado de análise de banco de dados e extraia dados numéricos que possam ser visualizados em um gráfico do tipo %s.
-
-Resultado da análise:
-%s
-
-Retorne APENAS um JSON válido com a seguinte estrutura:
-{
-  "labels": ["label1", "label2", ...],
-  "series": [
-    {
-      "name": "Nome da série",
-      "values": [valor1, valor2, ...]
-    }
-  ],
-  "title": "Título do gráfico",
-  "x_axis": "Rótulo do eixo X",
-  "y_axis": "Rótulo do eixo Y",
-  "chart_type": "%s"
-}
-
-Se não houver dados numéricos suficientes, retorne um JSON com arrays vazios.`, chartType, analysisResult, chartType)
-
-	messages := []ai.Message{
-		{
-			Role:    "system",
-			Content: "Você é um especialista em análise de dados que extrai informações estruturadas de textos para visualização em gráficos.",
-		},
-		{
-			Role:    "user",
-			Content: prompt,
-		},
+// Validate garante que cada série tenha um valor por rótulo
+func (d *ChartData) Validate() error {
+	for _, series := range d.Series {
+		if len(series.Values) != len(d.Labels) {
+			return fmt.Errorf("a série %q tem %d valores para %d rótulos", series.Name, len(series.Values), len(d.Labels))
+		}
 	}
+	return nil
+}
 
-	response, err := c.aiClient.Chat(messages, 2000, 0.3)
+// extractDataWithAI usa IA para extrair dados estruturados do resultado da análise
+func (c *ChartGenerator) extractDataWithAI(analysisResult string, chartType ChartType) (ChartData, error) {
+	messages, err := ai.PromptMessages("dbcharts.extract", ai.PromptData{
+		"ChartType": chartType,
+		"Result":    analysisResult,
+	})
 	if err != nil {
 		return ChartData{}, err
 	}
 
-	// Limpar resposta (remover markdown code blocks se houver)
-	response = strings.TrimSpace(response)
-	response = strings.TrimPrefix(response, "```json")
-	response = strings.TrimPrefix(response, "```")
-	response = strings.TrimSuffix(response, "```")
-	response = strings.TrimSpace(response)
-
 	var data ChartData
-	err = json.Unmarshal([]byte(response), &data)
-	if err != nil {
-		return ChartData{}, fmt.Errorf("erro ao parsear JSON: %w", err)
+	if err := c.aiClient.ChatJSON(messages, &data, 2000, 0.3); err != nil {
+		return ChartData{}, fmt.Errorf("erro ao extrair dados: %w", err)
 	}
 
 	data.ChartType = chartType
@@ -141,7 +115,7 @@ func (c *ChartGenerator) generateASCIIChart(data ChartData) (string, error) {
 	var result strings.Builder
 
 	// Título formatado
-	result.WriteString(fmt.Sprintf("\n### 📊 %s\n\n", data.Title))
+	writeTitle(&result, "📊", data.Title)
 
 	if len(data.Series) == 0 || len(data.Labels) == 0 {
 		return "```\n⚠️ Dados insuficientes para gerar gráfico\n```", nil
@@ -287,18 +261,7 @@ func (c *ChartGenerator) generateASCIIChart(data ChartData) (string, error) {
 func (c *ChartGenerator) generateHTMLChart(data ChartData) (string, error) {
 	var result strings.Builder
 
-	result.WriteString(`<!DOCTYPE html>
-<html>
-<head>
-    <title>` + data.Title + `</title>
-    <script src="https://cdn.jsdelivr.net/npm/chart.js"></script>
-    <style>
-        body { font-family: Arial, sans-serif; margin: 20px; }
-        .chart-container { width: 800px; height: 400px; margin: 20px 0; }
-    </style>
-</head>
-<body>
-    <h1>` + data.Title + `</h1>
+	result.WriteString(`
     <div class="chart-container">
         <canvas id="chart"></canvas>
     </div>
@@ -355,18 +318,49 @@ func (c *ChartGenerator) generateHTMLChart(data ChartData) (string, error) {
                 }
             }
         });
-    </script>
+    </script>`)
+
+	head := `
+    <script src="https://cdn.jsdelivr.net/npm/chart.js"></script>
+    <style>
+        .chart-container { width: 800px; height: 400px; margin: 20px 0; }
+    </style>`
+	return htmlPage(data.Title, head, result.String()), nil
+}
+
+// writeTitle escreve o título dos gráficos em texto
+func writeTitle(result *strings.Builder, icon, title string) {
+	result.WriteString(fmt.Sprintf("\n### %s %s\n\n", icon, title))
+}
+
+// htmlPage monta a página HTML dos gráficos; head e body já vêm prontos
+func htmlPage(title, head, body string) string {
+	var result strings.Builder
+	result.WriteString(`<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="utf-8">
+    <title>` + html.EscapeString(title) + `</title>
+    <style>
+        body { font-family: Arial, sans-serif; margin: 20px; }
+    </style>`)
+	result.WriteString(head)
+	result.WriteString(`
+</head>
+<body>
+    <h1>` + html.EscapeString(title) + `</h1>`)
+	result.WriteString(body)
+	result.WriteString(`
 </body>
 </html>`)
-
-	return result.String(), nil
+	return result.String()
 }
 
 // generateTable gera tabela formatada melhorada
 func (c *ChartGenerator) generateTable(data ChartData) (string, error) {
 	var result strings.Builder
 
-	result.WriteString(fmt.Sprintf("\n### 📋 %s\n\n", data.Title))
+	writeTitle(&result, "📋", data.Title)
 
 	if len(data.Labels) == 0 {
 		return "```\n⚠️ Sem dados para exibir\n```", nil
@@ -415,47 +409,19 @@ func (c *ChartGenerator) generateTable(data ChartData) (string, error) {
 
 // SuggestChartType sugere o tipo de gráfico mais apropriado usando IA
 func (c *ChartGenerator) SuggestChartType(analysisResult string) (ChartType, string, error) {
-	prompt := fmt.Sprintf(`Analise o seguinte resultado de análise de banco de dados e sugira o tipo de gráfico mais apropriado para visualizar os dados.
-
-Resultado:
-%s
-
-Retorne APENAS um JSON com:
-{
-  "chart_type": "line" ou "bar" ou "pie" ou "area" ou "table",
-  "reason": "explicação breve do porquê"
-}`, analysisResult)
-
-	messages := []ai.Message{
-		{
-			Role:    "system",
-			Content: "Você é um especialista em visualização de dados que sugere o melhor tipo de gráfico para diferentes tipos de dados.",
-		},
-		{
-			Role:    "user",
-			Content: prompt,
-		},
-	}
-
-	response, err := c.aiClient.Chat(messages, 500, 0.3)
+	messages, err := ai.PromptMessages("dbcharts.suggest", ai.PromptData{
+		"Result": analysisResult,
+	})
 	if err != nil {
 		return ChartTypeTable, "", err
 	}
 
-	// Limpar resposta
-	response = strings.TrimSpace(response)
-	response = strings.TrimPrefix(response, "```json")
-	response = strings.TrimPrefix(response, "```")
-	response = strings.TrimSuffix(response, "```")
-	response = strings.TrimSpace(response)
-
 	var suggestion struct {
-		ChartType string `json:"chart_type"`
+		ChartType string `json:"chart_type" ai:"required"`
 		Reason    string `json:"reason"`
 	}
 
-	err = json.Unmarshal([]byte(response), &suggestion)
-	if err != nil {
+	if err := c.aiClient.ChatJSON(messages, &suggestion, 500, 0.3); err != nil {
 		return ChartTypeTable, "", err
 	}
 
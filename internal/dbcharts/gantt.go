@@ -0,0 +1,393 @@
+package dbcharts
+
+import (
+	"fmt"
+	"html"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/mattn/go-runewidth"
+)
+
+// GanttBar é uma linha do gráfico de Gantt
+type GanttBar struct {
+	Label    string
+	Start    time.Time
+	End      time.Time
+	Progress float64    // parte concluída da barra, de 0 a 1
+	Due      *time.Time // prazo, marcado com ◆
+	Indent   int        // nível de recuo do rótulo (subtarefas)
+	Critical bool
+	Late     bool   // termina depois do prazo
+	Note     string // detalhe mostrado no tooltip do SVG (ex: estimativa)
+	// DependsOn são os índices (em GanttData.Bars) das barras das quais esta depende
+	DependsOn []int
+}
+
+// GanttData representa um cronograma para o gráfico de Gantt
+type GanttData struct {
+	Title string
+	Today time.Time // linha de "hoje" (zero = sem linha)
+	Bars  []GanttBar
+}
+
+// Validate garante barras com início antes do fim e dependências existentes
+func (d *GanttData) Validate() error {
+	for i, bar := range d.Bars {
+		if bar.End.Before(bar.Start) {
+			return fmt.Errorf("a barra %q termina antes de começar", bar.Label)
+		}
+		for _, dep := range bar.DependsOn {
+			if dep < 0 || dep >= len(d.Bars) || dep == i {
+				return fmt.Errorf("a barra %q depende de um índice inválido: %d", bar.Label, dep)
+			}
+		}
+	}
+	return nil
+}
+
+// GenerateGantt gera o gráfico de Gantt em ASCII (largura em colunas do
+// terminal), HTML ou SVG autocontidos. Não usa IA.
+func GenerateGantt(data GanttData, chartType ChartType, width int) (string, error) {
+	if err := data.Validate(); err != nil {
+		return "", err
+	}
+	switch chartType {
+	case ChartTypeHTML:
+		return generateGanttHTML(data), nil
+	case ChartTypeSVG:
+		return generateGanttSVG(data), nil
+	default:
+		return generateGanttASCII(data, width), nil
+	}
+}
+
+// ganttRange retorna o primeiro dia e o número de dias cobertos pelas barras e prazos
+func ganttRange(data GanttData) (time.Time, int) {
+	var first, last time.Time
+	extend := func(t time.Time) {
+		if first.IsZero() || t.Before(first) {
+			first = t
+		}
+		if last.IsZero() || t.After(last) {
+			last = t
+		}
+	}
+	for _, bar := range data.Bars {
+		extend(bar.Start)
+		extend(bar.End)
+		if bar.Due != nil {
+			extend(*bar.Due)
+		}
+	}
+	first = startOfDay(first)
+	days := int(math.Ceil(last.Sub(first).Hours() / 24))
+	if days < 1 {
+		days = 1
+	}
+	// O dia do prazo é desenhado inteiro
+	for _, bar := range data.Bars {
+		if bar.Due != nil && daysFrom(first, *bar.Due) >= float64(days) {
+			days++
+			break
+		}
+	}
+	return first, days
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// daysFrom conta dias (com fração) de first até t
+func daysFrom(first, t time.Time) float64 {
+	return t.Sub(first).Hours() / 24
+}
+
+// tickStep escolhe o intervalo em dias entre as datas do eixo
+func tickStep(perDay float64, minGap float64) int {
+	for _, step := range []int{1, 2, 7, 14, 28, 56} {
+		if float64(step)*perDay >= minGap {
+			return step
+		}
+	}
+	return int(math.Ceil(minGap / perDay))
+}
+
+// generateGanttASCII desenha uma linha por barra, com as datas no eixo superior
+func generateGanttASCII(data GanttData, width int) string {
+	var result strings.Builder
+	writeTitle(&result, "📅", data.Title)
+
+	if len(data.Bars) == 0 {
+		result.WriteString("⚠️ Sem tarefas para exibir\n")
+		return result.String()
+	}
+
+	labels := make([]string, len(data.Bars))
+	labelWidth := 10
+	for i, bar := range data.Bars {
+		labels[i] = strings.Repeat("  ", bar.Indent) + bar.Label
+		if w := runewidth.StringWidth(labels[i]); w > labelWidth {
+			labelWidth = w
+		}
+	}
+	if labelWidth > 30 {
+		labelWidth = 30
+	}
+
+	// rótulo, marcador do caminho crítico, barra e alerta de atraso
+	first, days := ganttRange(data)
+	cols := width - labelWidth - 5
+	if cols < 20 {
+		cols = 20
+	}
+	perDay := float64(cols) / float64(days)
+	if perDay > 4 {
+		perDay = 4
+		cols = days * 4
+	}
+	column := func(t time.Time) int {
+		return int(daysFrom(first, t) * perDay)
+	}
+
+	// Eixo: datas e marcas
+	step := tickStep(perDay, 7)
+	dates := []rune(strings.Repeat(" ", cols+5))
+	axis := []rune(strings.Repeat("─", cols))
+	for day := 0; day < days; day += step {
+		col := column(first.AddDate(0, 0, day))
+		if col >= cols {
+			break
+		}
+		axis[col] = '┬'
+		copy(dates[col:], []rune(first.AddDate(0, 0, day).Format("02/01")))
+	}
+	indent := strings.Repeat(" ", labelWidth+3)
+	result.WriteString(indent + strings.TrimRight(string(dates), " ") + "\n")
+	result.WriteString(indent + string(axis) + "\n")
+
+	todayCol := -1
+	if !data.Today.IsZero() {
+		todayCol = column(data.Today)
+	}
+
+	for i, bar := range data.Bars {
+		start := column(bar.Start)
+		end := int(math.Ceil(daysFrom(first, bar.End)*perDay)) - 1
+		if end < start {
+			end = start
+		}
+		done := start + int(math.Round(bar.Progress*float64(end-start+1)))
+
+		row := make([]rune, cols)
+		for c := range row {
+			switch {
+			case c >= start && c <= end && c < done:
+				row[c] = '█'
+			case c >= start && c <= end:
+				row[c] = '░'
+			case c == todayCol:
+				row[c] = '┆'
+			default:
+				row[c] = ' '
+			}
+		}
+		if bar.Due != nil {
+			// O marco fica no meio do dia do prazo
+			if col := column(startOfDay(*bar.Due).Add(12 * time.Hour)); col >= 0 && col < cols {
+				row[col] = '◆'
+			}
+		}
+
+		marker := " "
+		if bar.Critical {
+			marker = "*"
+		}
+		label := runewidth.FillRight(runewidth.Truncate(labels[i], labelWidth, "…"), labelWidth)
+		line := fmt.Sprintf("%s %s %s", label, marker, string(row))
+		if bar.Late {
+			line += " ⚠"
+		}
+		result.WriteString(strings.TrimRight(line, " ") + "\n")
+	}
+
+	result.WriteString("\n  Legenda: █ concluído  ░ restante  ◆ prazo  ┆ hoje  * caminho crítico  ⚠ após o prazo\n")
+
+	var dependencies []string
+	for _, bar := range data.Bars {
+		if len(bar.DependsOn) == 0 {
+			continue
+		}
+		var names []string
+		for _, dep := range bar.DependsOn {
+			names = append(names, data.Bars[dep].Label)
+		}
+		dependencies = append(dependencies, fmt.Sprintf("    %s ← %s", bar.Label, strings.Join(names, ", ")))
+	}
+	if len(dependencies) > 0 {
+		result.WriteString("\n  Dependências:\n")
+		result.WriteString(strings.Join(dependencies, "\n") + "\n")
+	}
+	return result.String()
+}
+
+// Medidas e cores do SVG
+const (
+	ganttRowHeight = 28
+	ganttHeader    = 44
+	ganttBarHeight = 16
+)
+
+var ganttColors = map[string]string{
+	"planned":  "#90caf9",
+	"done":     "#1e88e5",
+	"critical": "#ef9a9a",
+	"critDone": "#e53935",
+	"due":      "#fb8c00",
+	"late":     "#d32f2f",
+	"today":    "#d32f2f",
+	"grid":     "#e0e0e0",
+	"weekend":  "#f5f5f5",
+	"arrow":    "#757575",
+}
+
+// generateGanttSVG gera o gráfico como SVG autocontido
+func generateGanttSVG(data GanttData) string {
+	var result strings.Builder
+
+	maxLabel := 10
+	for _, bar := range data.Bars {
+		if n := len([]rune(bar.Label)) + 2*bar.Indent; n > maxLabel {
+			maxLabel = n
+		}
+	}
+	if maxLabel > 40 {
+		maxLabel = 40
+	}
+	labelWidth := maxLabel*7 + 16
+
+	first, days := ganttRange(data)
+	dayWidth := math.Max(12, math.Min(40, 900/float64(days)))
+	chartWidth := float64(days) * dayWidth
+	width := float64(labelWidth) + chartWidth + 20
+	height := ganttHeader + len(data.Bars)*ganttRowHeight + 10
+
+	x := func(t time.Time) float64 {
+		return float64(labelWidth) + daysFrom(first, t)*dayWidth
+	}
+	rowY := func(i int) float64 {
+		return float64(ganttHeader + i*ganttRowHeight)
+	}
+
+	result.WriteString(fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" width="%.0f" height="%d" viewBox="0 0 %.0f %d" font-family="Arial, sans-serif" font-size="12">
+  <defs>
+    <marker id="arrow" viewBox="0 0 10 10" refX="9" refY="5" markerWidth="6" markerHeight="6" orient="auto">
+      <path d="M0,0 L10,5 L0,10 z" fill="%s"/>
+    </marker>
+  </defs>
+  <rect width="100%%" height="100%%" fill="white"/>
+`, width, height, width, height, ganttColors["arrow"]))
+
+	// Fins de semana e grade com as datas
+	step := tickStep(dayWidth, 48)
+	for day := 0; day < days; day++ {
+		date := first.AddDate(0, 0, day)
+		if weekday := date.Weekday(); weekday == time.Saturday || weekday == time.Sunday {
+			result.WriteString(fmt.Sprintf(`  <rect x="%.1f" y="%d" width="%.1f" height="%d" fill="%s"/>`+"\n",
+				x(date), ganttHeader-8, dayWidth, height-ganttHeader, ganttColors["weekend"]))
+		}
+		if day%step == 0 {
+			result.WriteString(fmt.Sprintf(`  <line x1="%.1f" y1="%d" x2="%.1f" y2="%d" stroke="%s"/>`+"\n",
+				x(date), ganttHeader-14, x(date), height-10, ganttColors["grid"]))
+			result.WriteString(fmt.Sprintf(`  <text x="%.1f" y="%d" fill="#616161">%s</text>`+"\n",
+				x(date)+3, ganttHeader-18, date.Format("02/01")))
+		}
+	}
+
+	// Dependências: do fim da barra anterior ao início da dependente
+	for i, bar := range data.Bars {
+		for _, dep := range bar.DependsOn {
+			fromX, fromY := x(data.Bars[dep].End), rowY(dep)+ganttRowHeight/2
+			toX, toY := x(bar.Start), rowY(i)+ganttRowHeight/2
+			result.WriteString(fmt.Sprintf(`  <path d="M%.1f,%.1f h6 V%.1f H%.1f" fill="none" stroke="%s" marker-end="url(#arrow)"/>`+"\n",
+				fromX, fromY, toY, math.Max(toX, fromX+6)+1, ganttColors["arrow"]))
+		}
+	}
+
+	for i, bar := range data.Bars {
+		y := rowY(i)
+		label := []rune(bar.Label)
+		if limit := maxLabel - 2*bar.Indent; len(label) > limit && limit > 1 {
+			label = append(label[:limit-1], '…')
+		}
+		weight := "normal"
+		if bar.Critical {
+			weight = "bold"
+		}
+		result.WriteString(fmt.Sprintf(`  <text x="%d" y="%.1f" font-weight="%s">%s</text>`+"\n",
+			8+bar.Indent*14, y+ganttRowHeight/2+4, weight, html.EscapeString(string(label))))
+
+		planned, done := ganttColors["planned"], ganttColors["done"]
+		if bar.Critical {
+			planned, done = ganttColors["critical"], ganttColors["critDone"]
+		}
+		barX := x(bar.Start)
+		barWidth := math.Max(x(bar.End)-barX, 3)
+		barY := y + (ganttRowHeight-ganttBarHeight)/2
+
+		tooltip := fmt.Sprintf("%s\n%s → %s", bar.Label, bar.Start.Format("02/01/2006 15:04"), bar.End.Format("02/01/2006 15:04"))
+		if bar.Note != "" {
+			tooltip += "\n" + bar.Note
+		}
+		result.WriteString(fmt.Sprintf(`  <g><title>%s</title>`+"\n", html.EscapeString(tooltip)))
+		result.WriteString(fmt.Sprintf(`    <rect x="%.1f" y="%.1f" width="%.1f" height="%d" rx="3" fill="%s"/>`+"\n",
+			barX, barY, barWidth, ganttBarHeight, planned))
+		if bar.Progress > 0 {
+			result.WriteString(fmt.Sprintf(`    <rect x="%.1f" y="%.1f" width="%.1f" height="%d" rx="3" fill="%s"/>`+"\n",
+				barX, barY, barWidth*math.Min(bar.Progress, 1), ganttBarHeight, done))
+		}
+		result.WriteString("  </g>\n")
+
+		if bar.Due != nil {
+			color := ganttColors["due"]
+			if bar.Late {
+				color = ganttColors["late"]
+			}
+			cx, cy := x(startOfDay(*bar.Due).Add(12*time.Hour)), y+ganttRowHeight/2
+			result.WriteString(fmt.Sprintf(`  <polygon points="%.1f,%.1f %.1f,%.1f %.1f,%.1f %.1f,%.1f" fill="%s"><title>prazo %s</title></polygon>`+"\n",
+				cx, cy-7, cx+7, cy, cx, cy+7, cx-7, cy, color, bar.Due.Format("02/01/2006")))
+		}
+	}
+
+	if !data.Today.IsZero() && !data.Today.Before(first) && data.Today.Before(first.AddDate(0, 0, days)) {
+		result.WriteString(fmt.Sprintf(`  <line x1="%.1f" y1="%d" x2="%.1f" y2="%d" stroke="%s" stroke-dasharray="4 3"><title>hoje</title></line>`+"\n",
+			x(data.Today), ganttHeader-10, x(data.Today), height-10, ganttColors["today"]))
+	}
+
+	result.WriteString("</svg>\n")
+	return result.String()
+}
+
+// generateGanttHTML embute o SVG em uma página sem dependências externas
+func generateGanttHTML(data GanttData) string {
+	head := `
+    <style>
+        .legend span { display: inline-block; margin-right: 16px; }
+        .swatch { display: inline-block; width: 12px; height: 12px; margin-right: 4px; vertical-align: middle; }
+    </style>`
+
+	body := fmt.Sprintf(`
+    <div>
+%s    </div>
+    <p class="legend">
+        <span><i class="swatch" style="background:%s"></i>concluído</span>
+        <span><i class="swatch" style="background:%s"></i>restante</span>
+        <span><i class="swatch" style="background:%s"></i>caminho crítico</span>
+        <span><i class="swatch" style="background:%s"></i>prazo</span>
+        <span><i class="swatch" style="background:%s"></i>após o prazo</span>
+    </p>`, generateGanttSVG(data), ganttColors["done"], ganttColors["planned"], ganttColors["critDone"], ganttColors["due"], ganttColors["late"])
+
+	return htmlPage(data.Title, head, body)
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/snip/internal/ai"
+	"github.com/snip/internal/dbtools"
 	"github.com/snip/internal/dbtypes"
 )
 
@@ -36,11 +37,15 @@ type DBChat struct {
 	dbType   dbtypes.DatabaseType
 	config   *dbtypes.ConnectionConfig
 	session  *ChatSession
+	// Modo agente (ferramentas); nil usa o fluxo de query única
+	tools    *dbtools.Toolset
+	maxSteps int
+	trace    func(ai.AgentStep)
 }
 
 // NewDBChat cria uma nova sessão de chat
 func NewDBChat(dbType dbtypes.DatabaseType, config *dbtypes.ConnectionConfig, db *sql.DB) (*DBChat, error) {
-	aiClient, err := ai.NewAIClient()
+	aiClient, err := ai.NewAIClientFor(ai.FeatureDBChat)
 	if err != nil {
 		return nil, fmt.Errorf("erro ao criar cliente IA: %w", err)
 	}
@@ -62,6 +67,15 @@ func NewDBChat(dbType dbtypes.DatabaseType, config *dbtypes.ConnectionConfig, db
 	}, nil
 }
 
+// UseTools ativa o modo agente: a IA investiga o banco com ferramentas
+// (list_tables, describe_table, run_readonly_query, explain_query, get_analysis)
+// em até maxSteps chamadas, reportando cada uma em trace
+func (c *DBChat) UseTools(maxSteps int, lookup dbtools.AnalysisLookup, trace func(ai.AgentStep)) {
+	c.tools = dbtools.NewToolset(c.db, c.dbType, lookup)
+	c.maxSteps = maxSteps
+	c.trace = trace
+}
+
 // SendMessage envia uma mensagem e recebe resposta
 func (c *DBChat) SendMessage(userMessage string) (string, error) {
 	// Adicionar mensagem do usuário
@@ -71,11 +85,21 @@ func (c *DBChat) SendMessage(userMessage string) (string, error) {
 		Timestamp: time.Now(),
 	})
 
-	// Construir contexto do chat
-	context := c.buildContext()
+	var response, query, result string
+	var err error
+	if c.tools != nil {
+		response, query, result, err = c.runAgent()
+	} else {
+		// Construir contexto do chat
+		var context string
+		context, err = c.buildContext()
+		if err != nil {
+			return "", err
+		}
 
-	// Gerar resposta com IA
-	response, query, result, err := c.generateResponse(userMessage, context)
+		// Gerar resposta com IA
+		response, query, result, err = c.generateResponse(userMessage, context)
+	}
 	if err != nil {
 		return "", fmt.Errorf("erro ao gerar resposta: %w", err)
 	}
@@ -94,42 +118,62 @@ func (c *DBChat) SendMessage(userMessage string) (string, error) {
 	return response, nil
 }
 
+// runAgent responde usando ferramentas, com o histórico como mensagens de chat
+func (c *DBChat) runAgent() (response, query, result string, err error) {
+	system, _, err := ai.RenderPrompt("dbchat.agent", ai.PromptData{
+		"DBType":   c.dbType,
+		"Host":     c.config.Host,
+		"Database": c.config.Database,
+		"MaxSteps": c.maxSteps,
+	})
+	if err != nil {
+		return "", "", "", err
+	}
+
+	messages := []ai.Message{{Role: "system", Content: system}}
+	for _, msg := range c.session.Messages {
+		messages = append(messages, ai.Message{Role: msg.Role, Content: msg.Content})
+	}
+
+	agent := &ai.Agent{
+		Client:   c.aiClient,
+		Tools:    c.tools.Tools(),
+		Execute:  c.tools.Execute,
+		MaxSteps: c.maxSteps,
+		OnStep:   c.trace,
+	}
+
+	response, steps, err := agent.Run(messages, 2000, 0.3)
+	query, result = dbtools.LastQuery(steps)
+	return response, query, result, err
+}
+
 // buildContext constrói o contexto para a IA
-func (c *DBChat) buildContext() string {
-	var context strings.Builder
-
-	context.WriteString(fmt.Sprintf("Você é um assistente especializado em %s.\n\n", c.dbType))
-	context.WriteString("Contexto do banco de dados:\n")
-	context.WriteString(fmt.Sprintf("- Tipo: %s\n", c.dbType))
-	context.WriteString(fmt.Sprintf("- Host: %s\n", c.config.Host))
-	if c.config.Database != "" {
-		context.WriteString(fmt.Sprintf("- Database: %s\n", c.config.Database))
-	}
-
-	// Adicionar TODO o histórico da conversa (mantém contexto completo)
-	if len(c.session.Messages) > 0 {
-		context.WriteString("\nHistórico completo da conversa:\n")
-		for _, msg := range c.session.Messages {
-			if msg.Role == "user" {
-				context.WriteString(fmt.Sprintf("\n[Usuário]: %s\n", msg.Content))
-			} else {
-				context.WriteString(fmt.Sprintf("[Assistente]: %s\n", msg.Content))
-				if msg.Query != "" {
-					context.WriteString(fmt.Sprintf("  [Query executada]: %s\n", msg.Query))
-				}
-				if msg.Result != "" && msg.Result != "Nenhum resultado encontrado." {
-					// Incluir resultado resumido para contexto
-					resultLines := strings.Split(msg.Result, "\n")
-					if len(resultLines) > 0 {
-						context.WriteString(fmt.Sprintf("  [Resultado]: %s...\n", resultLines[0]))
-					}
-				}
-			}
+func (c *DBChat) buildContext() (string, error) {
+	// Adicionar TODO o histórico da conversa (mantém contexto completo),
+	// com apenas a primeira linha de cada resultado
+	history := make([]ChatMessage, 0, len(c.session.Messages))
+	for _, msg := range c.session.Messages {
+		if msg.Result == "Nenhum resultado encontrado." {
+			msg.Result = ""
 		}
-		context.WriteString("\n")
+		if msg.Result != "" {
+			msg.Result = strings.Split(msg.Result, "\n")[0]
+		}
+		history = append(history, msg)
+	}
+
+	_, context, err := ai.RenderPrompt("dbchat.context", ai.PromptData{
+		"DBType":   c.dbType,
+		"Host":     c.config.Host,
+		"Database": c.config.Database,
+		"History":  history,
+	})
+	if err != nil {
+		return "", err
 	}
 
-	return context.String()
+	return context + "\n\n", nil
 }
 
 // generateResponse gera resposta da IA e executa queries se necessário
@@ -233,32 +277,14 @@ func (c *DBChat) generateQuery(userMessage, context string) (string, error) {
 		limitClause = "ROWNUM"
 	}
 
-	prompt := fmt.Sprintf(`%s
-
-O usuário solicitou: "%s"
-
-Gere uma query SQL apropriada para %s que atenda EXATAMENTE à solicitação do usuário.
-
-IMPORTANTE:
-- Retorne APENAS a query SQL, sem explicações, sem markdown, sem código de bloco
-- Use sintaxe correta para %s
-- Seja específico e preciso
-- Inclua apenas colunas necessárias
-- Use %s quando apropriado para evitar resultados muito grandes (máximo 100 linhas)
-- Se o usuário não especificar limite, use %s 100
-- Use nomes de tabelas e colunas corretos baseados no contexto da conversa anterior
-
-Query SQL:`, context, userMessage, c.dbType, c.dbType, limitClause, limitClause)
-
-	messages := []ai.Message{
-		{
-			Role:    "system",
-			Content: fmt.Sprintf("Você é um especialista em SQL para %s. Gere queries SQL precisas e otimizadas.", c.dbType),
-		},
-		{
-			Role:    "user",
-			Content: prompt,
-		},
+	messages, err := ai.PromptMessages("dbchat.query", ai.PromptData{
+		"Context":     context,
+		"Message":     userMessage,
+		"DBType":      c.dbType,
+		"LimitClause": limitClause,
+	})
+	if err != nil {
+		return "", err
 	}
 
 	query, err := c.aiClient.Chat(messages, 500, 0.3)
@@ -389,17 +415,15 @@ func (c *DBChat) executeQuery(query string) (string, error) {
 
 // interpretQueryResult interpreta o resultado da query usando IA
 func (c *DBChat) interpretQueryResult(userMessage, context, query, result string) (string, error) {
-	prompt := fmt.Sprintf("%s\n\nO usuário perguntou: \"%s\"\n\nA seguinte query foi executada automaticamente:\n```sql\n%s\n```\n\nResultado obtido:\n```\n%s\n```\n\nIMPORTANTE:\n- Você DEVE responder baseado nos resultados REAIS obtidos da query\n- Formate a resposta de forma clara, natural e bem estruturada\n- Use os dados reais para responder a pergunta do usuário\n- Se houver tabelas ou listas, formate-as de forma legível\n- Forneça insights relevantes baseados nos dados obtidos\n- Seja direto e objetivo, mas completo\n- Use formatação markdown para melhorar a legibilidade (tabelas, listas, etc.)\n\nResponda de forma natural e bem formatada:", context, userMessage, query, result)
-
-	messages := []ai.Message{
-		{
-			Role:    "system",
-			Content: fmt.Sprintf("Você é um analista de dados especializado em %s que interpreta resultados de queries SQL de forma clara, natural e bem formatada. Você SEMPRE responde baseado nos dados reais obtidos, não em sugestões ou exemplos.", c.dbType),
-		},
-		{
-			Role:    "user",
-			Content: prompt,
-		},
+	messages, err := ai.PromptMessages("dbchat.interpret", ai.PromptData{
+		"Context": context,
+		"Message": userMessage,
+		"DBType":  c.dbType,
+		"Query":   query,
+		"Result":  result,
+	})
+	if err != nil {
+		return "", err
 	}
 
 	response, err := c.aiClient.Chat(messages, 2000, 0.7)
@@ -412,17 +436,14 @@ func (c *DBChat) interpretQueryResult(userMessage, context, query, result string
 
 // generateErrorExplanation gera explicação de erro usando IA
 func (c *DBChat) generateErrorExplanation(userMessage, context, query, errorMsg string) (string, error) {
-	prompt := fmt.Sprintf("%s\n\nO usuário tentou executar a seguinte query:\n```sql\n%s\n```\n\nMas ocorreu um erro: %s\n\nExplique o erro de forma clara e sugira como corrigir.", context, query, errorMsg)
-
-	messages := []ai.Message{
-		{
-			Role:    "system",
-			Content: "Você é um especialista em SQL que ajuda a entender e corrigir erros em queries.",
-		},
-		{
-			Role:    "user",
-			Content: prompt,
-		},
+	messages, err := ai.PromptMessages("dbchat.error", ai.PromptData{
+		"Context": context,
+		"Message": userMessage,
+		"Query":   query,
+		"Error":   errorMsg,
+	})
+	if err != nil {
+		return "", err
 	}
 
 	response, err := c.aiClient.Chat(messages, 1000, 0.7)
@@ -435,21 +456,13 @@ func (c *DBChat) generateErrorExplanation(userMessage, context, query, errorMsg
 
 // generateConversationalResponse gera resposta conversacional sem query
 func (c *DBChat) generateConversationalResponse(userMessage, context string) (string, error) {
-	prompt := fmt.Sprintf(`%s
-
-O usuário disse: "%s"
-
-Responda de forma útil e conversacional sobre o banco de dados %s.`, context, userMessage, c.dbType)
-
-	messages := []ai.Message{
-		{
-			Role:    "system",
-			Content: fmt.Sprintf("Você é um assistente especializado em %s. Responda perguntas de forma clara e útil.", c.dbType),
-		},
-		{
-			Role:    "user",
-			Content: prompt,
-		},
+	messages, err := ai.PromptMessages("dbchat.conversation", ai.PromptData{
+		"Context": context,
+		"Message": userMessage,
+		"DBType":  c.dbType,
+	})
+	if err != nil {
+		return "", err
 	}
 
 	response, err := c.aiClient.Chat(messages, 1500, 0.7)
@@ -19,7 +19,7 @@ type DynamicAnalyzer struct {
 
 // NewDynamicAnalyzer cria um novo analisador dinâmico
 func NewDynamicAnalyzer(dbType dbtypes.DatabaseType, config *dbtypes.ConnectionConfig, db *sql.DB) (*DynamicAnalyzer, error) {
-	aiClient, err := ai.NewAIClient()
+	aiClient, err := ai.NewAIClientFor(ai.FeatureDBDynamic)
 	if err != nil {
 		return nil, fmt.Errorf("erro ao criar cliente IA: %w", err)
 	}
@@ -174,33 +174,13 @@ func (d *DynamicAnalyzer) getSchemaInfo() (string, error) {
 
 // generateAnalysisQuery gera query SQL baseada na solicitação
 func (d *DynamicAnalyzer) generateAnalysisQuery(request, schemaInfo string) (string, error) {
-	prompt := fmt.Sprintf(`Você é um especialista em SQL para %s.
-
-Schema disponível:
-%s
-
-O usuário solicitou: "%s"
-
-Gere uma query SQL apropriada que atenda à solicitação. 
-
-IMPORTANTE:
-- Retorne APENAS a query SQL, sem explicações
-- Use sintaxe correta para %s
-- Seja específico e preciso
-- Use LIMIT/TOP/ROWNUM quando apropriado
-- Se não souber a estrutura exata, use nomes genéricos comuns
-
-Query SQL:`, d.dbType, schemaInfo, request, d.dbType)
-
-	messages := []ai.Message{
-		{
-			Role:    "system",
-			Content: fmt.Sprintf("Você é um especialista em SQL para %s. Gere queries SQL precisas e otimizadas.", d.dbType),
-		},
-		{
-			Role:    "user",
-			Content: prompt,
-		},
+	messages, err := ai.PromptMessages("dbdynamic.query", ai.PromptData{
+		"DBType":  d.dbType,
+		"Schema":  schemaInfo,
+		"Request": request,
+	})
+	if err != nil {
+		return "", err
 	}
 
 	query, err := d.aiClient.Chat(messages, 1000, 0.3)
@@ -285,17 +265,13 @@ func (d *DynamicAnalyzer) executeQuery(query string) (string, error) {
 
 // interpretResults interpreta os resultados com IA
 func (d *DynamicAnalyzer) interpretResults(request, query, result string) (string, error) {
-	prompt := fmt.Sprintf("O usuário solicitou: \"%s\"\n\nA seguinte query foi executada:\n```sql\n%s\n```\n\nResultados:\n```\n%s\n```\n\nInterprete os resultados de forma clara e útil. Explique o que os dados significam, identifique padrões, anomalias ou insights relevantes.", request, query, result)
-
-	messages := []ai.Message{
-		{
-			Role:    "system",
-			Content: "Você é um analista de dados que interpreta resultados de queries SQL de forma clara e útil.",
-		},
-		{
-			Role:    "user",
-			Content: prompt,
-		},
+	messages, err := ai.PromptMessages("dbdynamic.interpret", ai.PromptData{
+		"Request": request,
+		"Query":   query,
+		"Result":  result,
+	})
+	if err != nil {
+		return "", err
 	}
 
 	interpretation, err := d.aiClient.Chat(messages, 2000, 0.7)
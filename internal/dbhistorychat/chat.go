@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/snip/internal/ai"
+	"github.com/snip/internal/dbtools"
 )
 
 // ChatSession representa uma sessão de chat com o histórico de análises
@@ -31,11 +32,15 @@ type DBHistoryChat struct {
 	aiClient ai.AIClient
 	db       *sql.DB
 	session  *ChatSession
+	// Modo agente (ferramentas); nil usa o fluxo de query única
+	tools    *dbtools.Toolset
+	maxSteps int
+	trace    func(ai.AgentStep)
 }
 
 // NewDBHistoryChat cria uma nova sessão de chat com o histórico
 func NewDBHistoryChat(db *sql.DB) (*DBHistoryChat, error) {
-	aiClient, err := ai.NewAIClient()
+	aiClient, err := ai.NewAIClientFor(ai.FeatureDBHistoryChat)
 	if err != nil {
 		return nil, fmt.Errorf("erro ao criar cliente IA: %w", err)
 	}
@@ -53,6 +58,14 @@ func NewDBHistoryChat(db *sql.DB) (*DBHistoryChat, error) {
 	}, nil
 }
 
+// UseTools ativa o modo agente sobre o banco de análises, com até maxSteps
+// chamadas de ferramentas por pergunta, reportadas em trace
+func (c *DBHistoryChat) UseTools(maxSteps int, lookup dbtools.AnalysisLookup, trace func(ai.AgentStep)) {
+	c.tools = dbtools.NewToolset(c.db, dbtools.DatabaseTypeSQLite, lookup)
+	c.maxSteps = maxSteps
+	c.trace = trace
+}
+
 // SendMessage envia uma mensagem e recebe resposta
 func (c *DBHistoryChat) SendMessage(userMessage string) (string, error) {
 	// Adicionar mensagem do usuário
@@ -62,11 +75,21 @@ func (c *DBHistoryChat) SendMessage(userMessage string) (string, error) {
 		Timestamp: time.Now(),
 	})
 
-	// Construir contexto do chat
-	context := c.buildContext()
+	var response, query, result string
+	var err error
+	if c.tools != nil {
+		response, query, result, err = c.runAgent()
+	} else {
+		// Construir contexto do chat
+		var context string
+		context, err = c.buildContext()
+		if err != nil {
+			return "", err
+		}
 
-	// Gerar resposta com IA
-	response, query, result, err := c.generateResponse(userMessage, context)
+		// Gerar resposta com IA
+		response, query, result, err = c.generateResponse(userMessage, context)
+	}
 	if err != nil {
 		return "", fmt.Errorf("erro ao gerar resposta: %w", err)
 	}
@@ -85,60 +108,56 @@ func (c *DBHistoryChat) SendMessage(userMessage string) (string, error) {
 	return response, nil
 }
 
-// buildContext constrói o contexto para a IA
-func (c *DBHistoryChat) buildContext() string {
-	var context strings.Builder
+// runAgent responde usando ferramentas, com o histórico como mensagens de chat
+func (c *DBHistoryChat) runAgent() (response, query, result string, err error) {
+	system, _, err := ai.RenderPrompt("dbhistorychat.agent", ai.PromptData{
+		"MaxSteps": c.maxSteps,
+	})
+	if err != nil {
+		return "", "", "", err
+	}
 
-	context.WriteString("Você é um assistente especializado em análise de bancos de dados.\n\n")
-	context.WriteString("Você está conversando com um banco de dados SQLite que armazena todas as análises de bancos de dados realizadas.\n\n")
-	
-	context.WriteString("Schema da tabela db_analyses:\n")
-	context.WriteString("- id: INTEGER PRIMARY KEY\n")
-	context.WriteString("- title: TEXT (título da análise)\n")
-	context.WriteString("- database_type: TEXT (oracle, sqlserver, mysql, postgresql, mongodb)\n")
-	context.WriteString("- analysis_type: TEXT (diagnostic, tuning, query, awr, ash, locks, etc.)\n")
-	context.WriteString("- connection_config: TEXT (JSON com configuração de conexão)\n")
-	context.WriteString("- log_file_path: TEXT (caminho do arquivo de log, se aplicável)\n")
-	context.WriteString("- output_type: TEXT (text, json, markdown)\n")
-	context.WriteString("- result: TEXT (resultado completo da análise)\n")
-	context.WriteString("- ai_insights: TEXT (insights gerados pela IA)\n")
-	context.WriteString("- status: TEXT (pending, completed, error)\n")
-	context.WriteString("- error_message: TEXT (mensagem de erro, se houver)\n")
-	context.WriteString("- created_at: DATETIME (data de criação)\n")
-	context.WriteString("- updated_at: DATETIME (data de atualização)\n\n")
-
-	context.WriteString("Você pode ajudar o usuário a:\n")
-	context.WriteString("- Listar análises por tipo de banco, tipo de análise, data, etc.\n")
-	context.WriteString("- Comparar análises de diferentes datas para ver evolução\n")
-	context.WriteString("- Identificar problemas e insights das análises\n")
-	context.WriteString("- Rastrear a evolução ou degradação dos bancos ao longo do tempo\n")
-	context.WriteString("- Analisar tendências e padrões nas análises\n")
-	context.WriteString("- Responder perguntas sobre resultados específicos\n\n")
-
-	// Adicionar TODO o histórico da conversa (mantém contexto completo)
-	if len(c.session.Messages) > 0 {
-		context.WriteString("Histórico completo da conversa:\n")
-		for _, msg := range c.session.Messages {
-			if msg.Role == "user" {
-				context.WriteString(fmt.Sprintf("\n[Usuário]: %s\n", msg.Content))
-			} else {
-				context.WriteString(fmt.Sprintf("[Assistente]: %s\n", msg.Content))
-				if msg.Query != "" {
-					context.WriteString(fmt.Sprintf("  [Query executada]: %s\n", msg.Query))
-				}
-				if msg.Result != "" && msg.Result != "Nenhum resultado encontrado." {
-					// Incluir resultado resumido para contexto
-					resultLines := strings.Split(msg.Result, "\n")
-					if len(resultLines) > 0 {
-						context.WriteString(fmt.Sprintf("  [Resultado]: %s...\n", resultLines[0]))
-					}
-				}
-			}
+	messages := []ai.Message{{Role: "system", Content: system}}
+	for _, msg := range c.session.Messages {
+		messages = append(messages, ai.Message{Role: msg.Role, Content: msg.Content})
+	}
+
+	agent := &ai.Agent{
+		Client:   c.aiClient,
+		Tools:    c.tools.Tools(),
+		Execute:  c.tools.Execute,
+		MaxSteps: c.maxSteps,
+		OnStep:   c.trace,
+	}
+
+	response, steps, err := agent.Run(messages, 2000, 0.3)
+	query, result = dbtools.LastQuery(steps)
+	return response, query, result, err
+}
+
+// buildContext constrói o contexto para a IA
+func (c *DBHistoryChat) buildContext() (string, error) {
+	// Adicionar TODO o histórico da conversa (mantém contexto completo),
+	// com apenas a primeira linha de cada resultado
+	history := make([]ChatMessage, 0, len(c.session.Messages))
+	for _, msg := range c.session.Messages {
+		if msg.Result == "Nenhum resultado encontrado." {
+			msg.Result = ""
+		}
+		if msg.Result != "" {
+			msg.Result = strings.Split(msg.Result, "\n")[0]
 		}
-		context.WriteString("\n")
+		history = append(history, msg)
 	}
 
-	return context.String()
+	_, context, err := ai.RenderPrompt("dbhistorychat.context", ai.PromptData{
+		"History": history,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return context + "\n\n", nil
 }
 
 // generateResponse gera resposta da IA e executa queries se necessário
@@ -235,36 +254,12 @@ func (c *DBHistoryChat) needsQueryExecution(message string) bool {
 
 // generateQuery gera uma query SQL baseada na mensagem do usuário
 func (c *DBHistoryChat) generateQuery(userMessage, context string) (string, error) {
-	prompt := fmt.Sprintf(`%s
-
-O usuário solicitou: "%s"
-
-Gere uma query SQL apropriada para SQLite que atenda EXATAMENTE à solicitação do usuário.
-
-IMPORTANTE:
-- Retorne APENAS a query SQL, sem explicações, sem markdown, sem código de bloco
-- Use sintaxe SQLite correta
-- A tabela se chama 'db_analyses'
-- Seja específico e preciso
-- Inclua apenas colunas necessárias
-- Use LIMIT quando apropriado para evitar resultados muito grandes (máximo 100 linhas)
-- Se o usuário não especificar limite, use LIMIT 100
-- Para datas, use funções SQLite como date(), datetime(), strftime()
-- Para comparações de texto, use LIKE ou = conforme apropriado
-- Para análises de evolução, compare created_at entre diferentes períodos
-- Para insights e problemas, busque em ai_insights e error_message
-
-Query SQL:`, context, userMessage)
-
-	messages := []ai.Message{
-		{
-			Role:    "system",
-			Content: "Você é um especialista em SQL para SQLite. Gere queries SQL precisas e otimizadas para consultar análises de bancos de dados armazenadas na tabela db_analyses.",
-		},
-		{
-			Role:    "user",
-			Content: prompt,
-		},
+	messages, err := ai.PromptMessages("dbhistorychat.query", ai.PromptData{
+		"Context": context,
+		"Message": userMessage,
+	})
+	if err != nil {
+		return "", err
 	}
 
 	query, err := c.aiClient.Chat(messages, 500, 0.3)
@@ -400,17 +395,14 @@ func (c *DBHistoryChat) executeQuery(query string) (string, error) {
 
 // interpretQueryResult interpreta o resultado da query usando IA
 func (c *DBHistoryChat) interpretQueryResult(userMessage, context, query, result string) (string, error) {
-	prompt := fmt.Sprintf("%s\n\nO usuário perguntou: \"%s\"\n\nA seguinte query foi executada automaticamente:\n```sql\n%s\n```\n\nResultado obtido:\n```\n%s\n```\n\nIMPORTANTE:\n- Você DEVE responder baseado nos resultados REAIS obtidos da query\n- Formate a resposta de forma clara, natural e bem estruturada\n- Use os dados reais para responder a pergunta do usuário\n- Se houver tabelas ou listas, formate-as de forma legível\n- Forneça insights relevantes baseados nos dados obtidos\n- Seja direto e objetivo, mas completo\n- Use formatação markdown para melhorar a legibilidade (tabelas, listas, etc.)\n- Para comparações de datas, destaque tendências e evoluções\n- Para problemas e erros, destaque-os claramente\n- Para insights, apresente-os de forma organizada\n\nResponda de forma natural e bem formatada:", context, userMessage, query, result)
-
-	messages := []ai.Message{
-		{
-			Role:    "system",
-			Content: "Você é um analista especializado em interpretar resultados de análises de bancos de dados. Você interpreta resultados de queries SQL de forma clara, natural e bem formatada. Você SEMPRE responde baseado nos dados reais obtidos, não em sugestões ou exemplos. Você ajuda a identificar tendências, problemas, evoluções e insights importantes.",
-		},
-		{
-			Role:    "user",
-			Content: prompt,
-		},
+	messages, err := ai.PromptMessages("dbhistorychat.interpret", ai.PromptData{
+		"Context": context,
+		"Message": userMessage,
+		"Query":   query,
+		"Result":  result,
+	})
+	if err != nil {
+		return "", err
 	}
 
 	response, err := c.aiClient.Chat(messages, 2000, 0.7)
@@ -423,17 +415,14 @@ func (c *DBHistoryChat) interpretQueryResult(userMessage, context, query, result
 
 // generateErrorExplanation gera explicação de erro usando IA
 func (c *DBHistoryChat) generateErrorExplanation(userMessage, context, query, errorMsg string) (string, error) {
-	prompt := fmt.Sprintf("%s\n\nO usuário tentou executar a seguinte query:\n```sql\n%s\n```\n\nMas ocorreu um erro: %s\n\nExplique o erro de forma clara e sugira como corrigir.", context, query, errorMsg)
-
-	messages := []ai.Message{
-		{
-			Role:    "system",
-			Content: "Você é um especialista em SQL que ajuda a entender e corrigir erros em queries SQLite.",
-		},
-		{
-			Role:    "user",
-			Content: prompt,
-		},
+	messages, err := ai.PromptMessages("dbhistorychat.error", ai.PromptData{
+		"Context": context,
+		"Message": userMessage,
+		"Query":   query,
+		"Error":   errorMsg,
+	})
+	if err != nil {
+		return "", err
 	}
 
 	response, err := c.aiClient.Chat(messages, 1000, 0.7)
@@ -446,21 +435,12 @@ func (c *DBHistoryChat) generateErrorExplanation(userMessage, context, query, er
 
 // generateConversationalResponse gera resposta conversacional sem query
 func (c *DBHistoryChat) generateConversationalResponse(userMessage, context string) (string, error) {
-	prompt := fmt.Sprintf(`%s
-
-O usuário disse: "%s"
-
-Responda de forma útil e conversacional sobre o histórico de análises de bancos de dados.`, context, userMessage)
-
-	messages := []ai.Message{
-		{
-			Role:    "system",
-			Content: "Você é um assistente especializado em análise de bancos de dados. Responda perguntas sobre o histórico de análises de forma clara e útil.",
-		},
-		{
-			Role:    "user",
-			Content: prompt,
-		},
+	messages, err := ai.PromptMessages("dbhistorychat.conversation", ai.PromptData{
+		"Context": context,
+		"Message": userMessage,
+	})
+	if err != nil {
+		return "", err
 	}
 
 	response, err := c.aiClient.Chat(messages, 1500, 0.7)
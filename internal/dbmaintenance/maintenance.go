@@ -1,7 +1,6 @@
 package dbmaintenance
 
 import (
-	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
@@ -41,128 +40,88 @@ type MaintenancePlanner struct {
 
 // NewMaintenancePlanner cria um novo planejador de manutenção
 func NewMaintenancePlanner() (*MaintenancePlanner, error) {
-	aiClient, err := ai.NewAIClient()
+	aiClient, err := ai.NewAIClientFor(ai.FeatureDBMaintenance)
 	if err != nil {
 		return nil, fmt.Errorf("erro ao criar cliente IA: %w", err)
 	}
 	return &MaintenancePlanner{aiClient: aiClient}, nil
 }
 
+// planResponse é o formato JSON pedido à IA
+type planResponse struct {
+	Title       string `json:"title" ai:"required"`
+	Description string `json:"description"`
+	Priority    string `json:"priority"`
+	Tasks       []struct {
+		Title                string   `json:"title" ai:"required"`
+		Description          string   `json:"description"`
+		Steps                []string `json:"steps"`
+		Priority             string   `json:"priority"`
+		EstimatedTimeMinutes int      `json:"estimated_time_minutes"`
+		Dependencies         []int    `json:"dependencies"`
+	} `json:"tasks" ai:"required"`
+}
+
 // GenerateMaintenancePlan gera um plano de manutenção baseado em análise
 func (m *MaintenancePlanner) GenerateMaintenancePlan(analysisResult string, analysisType string, dbType string) (*MaintenancePlan, error) {
-	// Usar IA para gerar plano
-	planJSON, err := m.generatePlanWithAI(analysisResult, analysisType, dbType)
+	messages, err := ai.PromptMessages("dbmaintenance.plan", ai.PromptData{
+		"DBType":       dbType,
+		"AnalysisType": analysisType,
+		"Result":       analysisResult,
+	})
 	if err != nil {
-		return nil, fmt.Errorf("erro ao gerar plano: %w", err)
+		return nil, err
 	}
 
-	// Parsear JSON
-	var plan MaintenancePlan
-	err = json.Unmarshal([]byte(planJSON), &plan)
-	if err != nil {
-		return nil, fmt.Errorf("erro ao parsear plano: %w", err)
+	var response planResponse
+	if err := m.aiClient.ChatJSON(messages, &response, 3000, 0.5); err != nil {
+		return nil, fmt.Errorf("erro ao gerar plano: %w", err)
 	}
 
-	plan.CreatedAt = time.Now()
-	plan.UpdatedAt = time.Now()
-	plan.Status = "pending"
-
-	return &plan, nil
-}
-
-// generatePlanWithAI usa IA para gerar plano de manutenção
-func (m *MaintenancePlanner) generatePlanWithAI(analysisResult, analysisType, dbType string) (string, error) {
-	prompt := fmt.Sprintf(`Você é um DBA experiente especializado em %s.
-
-Analise o seguinte resultado de análise (%s) e crie um plano de manutenção detalhado:
-
-%s
-
-Crie um plano de manutenção completo com:
-1. Título e descrição do plano
-2. Prioridade (high, medium, low)
-3. Lista de tarefas com:
-   - Título da tarefa
-   - Descrição detalhada
-   - Passo a passo para execução
-   - Prioridade individual
-   - Tempo estimado (em minutos)
-   - Dependências (IDs de outras tarefas)
-
-Retorne APENAS um JSON válido com a seguinte estrutura:
-{
-  "title": "Título do Plano",
-  "description": "Descrição detalhada",
-  "priority": "high|medium|low",
-  "tasks": [
-    {
-      "title": "Título da Tarefa",
-      "description": "Descrição",
-      "steps": ["passo 1", "passo 2", ...],
-      "priority": "high|medium|low",
-      "estimated_time_minutes": 30,
-      "dependencies": []
-    }
-  ]
-}`, dbType, analysisType, analysisResult)
-
-	messages := []ai.Message{
-		{
-			Role:    "system",
-			Content: "Você é um DBA experiente que cria planos de manutenção detalhados e práticos para bancos de dados.",
-		},
-		{
-			Role:    "user",
-			Content: prompt,
-		},
+	plan := &MaintenancePlan{
+		Title:       response.Title,
+		Description: response.Description,
+		Priority:    response.Priority,
+		Status:      "pending",
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
 	}
 
-	response, err := m.aiClient.Chat(messages, 3000, 0.5)
-	if err != nil {
-		return "", err
+	for i, t := range response.Tasks {
+		task := MaintenanceTask{
+			ID:            i + 1,
+			Title:         t.Title,
+			Description:   t.Description,
+			Steps:         t.Steps,
+			Priority:      t.Priority,
+			Status:        "pending",
+			EstimatedTime: time.Duration(t.EstimatedTimeMinutes) * time.Minute,
+			Dependencies:  t.Dependencies,
+		}
+		plan.EstimatedDuration += task.EstimatedTime
+		plan.Tasks = append(plan.Tasks, task)
 	}
 
-	// Limpar resposta
-	response = strings.TrimSpace(response)
-	response = strings.TrimPrefix(response, "```json")
-	response = strings.TrimPrefix(response, "```")
-	response = strings.TrimSuffix(response, "```")
-	response = strings.TrimSpace(response)
-
-	return response, nil
+	return plan, nil
 }
 
-// SuggestMaintenanceActions sugere ações de manutenção baseadas em análise
-func (m *MaintenancePlanner) SuggestMaintenanceActions(analysisResult string, dbType string) (string, error) {
-	prompt := fmt.Sprintf(`Analise o seguinte resultado de análise de banco de dados %s e sugira ações de manutenção prioritárias:
-
-%s
-
-Forneça:
-1. Problemas identificados
-2. Ações recomendadas (priorizadas)
-3. Impacto esperado de cada ação
-4. Tempo estimado para implementação
-
-Formate a resposta em markdown de forma clara e organizada.`, dbType, analysisResult)
-
-	messages := []ai.Message{
-		{
-			Role:    "system",
-			Content: "Você é um DBA experiente que identifica problemas e sugere ações de manutenção prioritárias.",
-		},
-		{
-			Role:    "user",
-			Content: prompt,
+// SuggestMaintenanceActions sugere ações de manutenção baseadas em análise.
+// Resultados maiores que a janela do modelo são resumidos por partes; a
+// estratégia usada fica em Strategy/Metadata() do retorno.
+func (m *MaintenancePlanner) SuggestMaintenanceActions(analysisResult string, dbType string) (*ai.LongTextResult, error) {
+	return ai.ChatLongText(m.aiClient, ai.LongTextRequest{
+		Text:    analysisResult,
+		Subject: dbType + " / maintenance",
+		Build: func(text string, summarized bool) ([]ai.Message, error) {
+			return ai.PromptMessages("dbmaintenance.actions", ai.PromptData{
+				"DBType":     dbType,
+				"Result":     text,
+				"Summarized": summarized,
+			})
 		},
-	}
-
-	response, err := m.aiClient.Chat(messages, 2000, 0.7)
-	if err != nil {
-		return "", err
-	}
-
-	return response, nil
+		MaxTokens:   2000,
+		Temperature: 0.7,
+	})
 }
 
 // FormatPlan formata o plano para exibição
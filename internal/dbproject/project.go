@@ -1,7 +1,6 @@
 package dbproject
 
 import (
-	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
@@ -35,152 +34,91 @@ type ProjectGenerator struct {
 
 // NewProjectGenerator cria um novo gerador de projetos
 func NewProjectGenerator() (*ProjectGenerator, error) {
-	aiClient, err := ai.NewAIClient()
+	aiClient, err := ai.NewAIClientFor(ai.FeatureDBProject)
 	if err != nil {
 		return nil, fmt.Errorf("erro ao criar cliente IA: %w", err)
 	}
 	return &ProjectGenerator{aiClient: aiClient}, nil
 }
 
-// GenerateProjectFromAnalysis transforma uma análise em projeto
-func (p *ProjectGenerator) GenerateProjectFromAnalysis(analysisTitle string, analysisResult string, analysisType string, dbType string) (*ProjectFromAnalysis, error) {
-	// Usar IA para transformar análise em projeto
-	projectJSON, err := p.generateProjectWithAI(analysisTitle, analysisResult, analysisType, dbType)
-	if err != nil {
-		return nil, fmt.Errorf("erro ao gerar projeto: %w", err)
-	}
+// projectResponse é o formato JSON pedido à IA
+type projectResponse struct {
+	ProjectName        string `json:"project_name" ai:"required"`
+	ProjectDescription string `json:"project_description"`
+	Priority           string `json:"priority"`
+	Tasks              []struct {
+		Title                string   `json:"title" ai:"required"`
+		Description          string   `json:"description"`
+		Steps                []string `json:"steps"`
+		Priority             string   `json:"priority"`
+		EstimatedTimeMinutes int      `json:"estimated_time_minutes"`
+		DueDate              string   `json:"due_date"`
+	} `json:"tasks" ai:"required"`
+}
 
-	// Parsear JSON
-	var project ProjectFromAnalysis
-	err = json.Unmarshal([]byte(projectJSON), &project)
-	if err != nil {
-		return nil, fmt.Errorf("erro ao parsear projeto: %w", err)
+// toProject converte a resposta da IA no projeto
+func (r *projectResponse) toProject(createdFrom string) *ProjectFromAnalysis {
+	project := &ProjectFromAnalysis{
+		ProjectName:        r.ProjectName,
+		ProjectDescription: r.ProjectDescription,
+		Priority:           r.Priority,
+		CreatedFrom:        createdFrom,
 	}
 
-	project.CreatedFrom = analysisTitle
+	for _, t := range r.Tasks {
+		task := ProjectTask{
+			Title:         t.Title,
+			Description:   t.Description,
+			Steps:         t.Steps,
+			Priority:      t.Priority,
+			EstimatedTime: time.Duration(t.EstimatedTimeMinutes) * time.Minute,
+		}
+		if dueDate, err := time.Parse("2006-01-02", t.DueDate); err == nil {
+			task.DueDate = &dueDate
+		}
+		project.Tasks = append(project.Tasks, task)
+	}
 
-	return &project, nil
+	return project
 }
 
-// generateProjectWithAI usa IA para transformar análise em projeto
-func (p *ProjectGenerator) generateProjectWithAI(analysisTitle, analysisResult, analysisType, dbType string) (string, error) {
-	prompt := fmt.Sprintf(`Você é um gerente de projetos especializado em bancos de dados.
-
-Com base na seguinte análise de banco de dados %s (%s), transforme os problemas e recomendações identificados em um projeto estruturado com tarefas e passo a passo.
-
-Análise:
-Título: %s
-Tipo: %s
-Resultado:
-%s
-
-Crie um projeto completo com:
-1. Nome do projeto (descritivo e claro)
-2. Descrição do projeto
-3. Prioridade geral (high, medium, low)
-4. Lista de tarefas com:
-   - Título da tarefa
-   - Descrição detalhada
-   - Passo a passo para execução
-   - Prioridade individual
-   - Tempo estimado (em minutos)
-   - Data de vencimento sugerida (se aplicável)
-
-Retorne APENAS um JSON válido com a seguinte estrutura:
-{
-  "project_name": "Nome do Projeto",
-  "project_description": "Descrição detalhada",
-  "priority": "high|medium|low",
-  "tasks": [
-    {
-      "title": "Título da Tarefa",
-      "description": "Descrição",
-      "steps": ["passo 1", "passo 2", ...],
-      "priority": "high|medium|low",
-      "estimated_time_minutes": 60,
-      "due_date": "2024-12-31" ou null
-    }
-  ]
-}`, dbType, analysisType, analysisTitle, analysisType, analysisResult)
-
-	messages := []ai.Message{
-		{
-			Role:    "system",
-			Content: "Você é um gerente de projetos experiente que transforma análises técnicas em projetos estruturados com tarefas e passo a passo.",
-		},
-		{
-			Role:    "user",
-			Content: prompt,
-		},
-	}
-
-	response, err := p.aiClient.Chat(messages, 3000, 0.5)
+// GenerateProjectFromAnalysis transforma uma análise em projeto
+func (p *ProjectGenerator) GenerateProjectFromAnalysis(analysisTitle string, analysisResult string, analysisType string, dbType string) (*ProjectFromAnalysis, error) {
+	messages, err := ai.PromptMessages("dbproject.analysis", ai.PromptData{
+		"DBType":       dbType,
+		"AnalysisType": analysisType,
+		"Title":        analysisTitle,
+		"Result":       analysisResult,
+	})
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	// Limpar resposta
-	response = strings.TrimSpace(response)
-	response = strings.TrimPrefix(response, "```json")
-	response = strings.TrimPrefix(response, "```")
-	response = strings.TrimSuffix(response, "```")
-	response = strings.TrimSpace(response)
+	var response projectResponse
+	if err := p.aiClient.ChatJSON(messages, &response, 3000, 0.5); err != nil {
+		return nil, fmt.Errorf("erro ao gerar projeto: %w", err)
+	}
 
-	return response, nil
+	return response.toProject(analysisTitle), nil
 }
 
 // GenerateIncidentProject transforma um incidente em projeto
 func (p *ProjectGenerator) GenerateIncidentProject(incidentDescription string, analysisResult string, dbType string) (*ProjectFromAnalysis, error) {
-	prompt := fmt.Sprintf(`Você é um gerente de projetos especializado em resolução de incidentes de banco de dados.
-
-Com base no seguinte incidente e análise de banco de dados %s, crie um projeto de resolução:
-
-Incidente:
-%s
-
-Análise relacionada:
-%s
-
-Crie um projeto de resolução com:
-1. Nome do projeto (focado na resolução)
-2. Descrição do problema e objetivo
-3. Prioridade (geralmente high para incidentes)
-4. Tarefas de resolução com passo a passo detalhado
-5. Tarefas de prevenção para evitar recorrência
-
-Retorne APENAS um JSON válido com a mesma estrutura do GenerateProjectFromAnalysis.`, dbType, incidentDescription, analysisResult)
-
-	messages := []ai.Message{
-		{
-			Role:    "system",
-			Content: "Você é um especialista em resolução de incidentes que cria projetos estruturados para resolver problemas de banco de dados.",
-		},
-		{
-			Role:    "user",
-			Content: prompt,
-		},
-	}
-
-	response, err := p.aiClient.Chat(messages, 3000, 0.5)
+	messages, err := ai.PromptMessages("dbproject.incident", ai.PromptData{
+		"DBType":   dbType,
+		"Incident": incidentDescription,
+		"Result":   analysisResult,
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	// Limpar resposta
-	response = strings.TrimSpace(response)
-	response = strings.TrimPrefix(response, "```json")
-	response = strings.TrimPrefix(response, "```")
-	response = strings.TrimSuffix(response, "```")
-	response = strings.TrimSpace(response)
-
-	var project ProjectFromAnalysis
-	err = json.Unmarshal([]byte(response), &project)
-	if err != nil {
-		return nil, fmt.Errorf("erro ao parsear projeto: %w", err)
+	var response projectResponse
+	if err := p.aiClient.ChatJSON(messages, &response, 3000, 0.5); err != nil {
+		return nil, fmt.Errorf("erro ao gerar projeto: %w", err)
 	}
 
-	project.CreatedFrom = "incident: " + incidentDescription
-	return &project, nil
+	return response.toProject("incident: " + incidentDescription), nil
 }
 
 // FormatProject formata o projeto para exibição
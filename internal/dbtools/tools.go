@@ -0,0 +1,445 @@
+package dbtools
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/snip/internal/ai"
+	"github.com/snip/internal/dbanalysis"
+	"github.com/snip/internal/dbtypes"
+)
+
+// DatabaseTypeSQLite é o banco interno do snip (histórico de análises)
+const DatabaseTypeSQLite dbtypes.DatabaseType = "sqlite"
+
+const (
+	maxRows      = 100
+	maxCellWidth = 200
+	queryTimeout = 60 * time.Second
+)
+
+// AnalysisLookup busca uma análise salva pelo ID
+type AnalysisLookup func(id int) (*dbanalysis.DBAnalysis, error)
+
+// Toolset expõe um banco de dados como ferramentas para o agente de IA
+type Toolset struct {
+	db     *sql.DB
+	dbType dbtypes.DatabaseType
+	lookup AnalysisLookup
+}
+
+// NewToolset cria as ferramentas para um banco; lookup pode ser nil
+func NewToolset(db *sql.DB, dbType dbtypes.DatabaseType, lookup AnalysisLookup) *Toolset {
+	return &Toolset{db: db, dbType: dbType, lookup: lookup}
+}
+
+var (
+	identifierPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_$#]*(\.[A-Za-z_][A-Za-z0-9_$#]*)?$`)
+	lineComment       = regexp.MustCompile(`--[^\n]*`)
+	blockComment      = regexp.MustCompile(`(?s)/\*.*?\*/`)
+	writeKeywords     = regexp.MustCompile(`(?i)\b(insert|update|delete|merge|upsert|drop|alter|create|truncate|rename|grant|revoke|exec|execute|call|attach|detach|pragma|vacuum|reindex|into|lock|commit|rollback|savepoint)\b`)
+	readOnlyPrefixes  = []string{"select", "with", "show", "describe", "desc", "explain", "values"}
+	// Funções que alteram estado ou acessam o servidor mesmo dentro de um SELECT
+	sideEffectFunctions = regexp.MustCompile(`(?i)\b(pg_terminate_backend|pg_cancel_backend|pg_reload_conf|pg_rotate_logfile|pg_promote|pg_switch_wal|pg_create_restore_point|pg_(try_)?advisory_\w+|pg_stat_reset\w*|pg_read_file|pg_read_binary_file|pg_ls_dir|pg_sleep\w*|set_config|nextval|setval|lo_import|lo_export|lo_unlink|dblink\w*|load_file|sleep|benchmark|get_lock|release_lock|xp_\w+|openrowset|opendatasource|openquery|dbms_\w+|utl_\w+)(\.\w+)?\s*\(`)
+)
+
+// Tools retorna as definições das ferramentas disponíveis
+func (t *Toolset) Tools() []ai.Tool {
+	queryParam := map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"query": map[string]interface{}{
+				"type":        "string",
+				"description": fmt.Sprintf("Query SQL (%s), apenas leitura", t.dbType),
+			},
+		},
+		"required": []string{"query"},
+	}
+
+	tools := []ai.Tool{
+		{
+			Name:        "list_tables",
+			Description: "Lista as tabelas do banco de dados",
+		},
+		{
+			Name:        "describe_table",
+			Description: "Mostra as colunas, tipos e nulabilidade de uma tabela",
+			Parameters: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"table": map[string]interface{}{
+						"type":        "string",
+						"description": "Nome da tabela (opcionalmente schema.tabela)",
+					},
+				},
+				"required": []string{"table"},
+			},
+		},
+		{
+			Name:        "run_readonly_query",
+			Description: fmt.Sprintf("Executa uma query de leitura e retorna até %d linhas em tabela markdown", maxRows),
+			Parameters:  queryParam,
+		},
+		{
+			Name:        "explain_query",
+			Description: "Mostra o plano de execução de uma query de leitura, sem executá-la",
+			Parameters:  queryParam,
+		},
+	}
+
+	if t.lookup != nil {
+		tools = append(tools, ai.Tool{
+			Name:        "get_analysis",
+			Description: "Retorna uma análise de banco de dados salva no snip (resultado e insights)",
+			Parameters: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"id": map[string]interface{}{
+						"type":        "integer",
+						"description": "ID da análise",
+					},
+				},
+				"required": []string{"id"},
+			},
+		})
+	}
+
+	return tools
+}
+
+// Execute executa uma ferramenta pelo nome (implementa ai.ToolExecutor)
+func (t *Toolset) Execute(name string, arguments json.RawMessage) (string, error) {
+	var args struct {
+		Table string `json:"table"`
+		Query string `json:"query"`
+		ID    int    `json:"id"`
+	}
+	if len(arguments) > 0 {
+		if err := json.Unmarshal(arguments, &args); err != nil {
+			return "", fmt.Errorf("argumentos inválidos: %w", err)
+		}
+	}
+
+	switch name {
+	case "list_tables":
+		return t.ListTables()
+	case "describe_table":
+		return t.DescribeTable(args.Table)
+	case "run_readonly_query":
+		return t.RunReadOnlyQuery(args.Query)
+	case "explain_query":
+		return t.ExplainQuery(args.Query)
+	case "get_analysis":
+		return t.GetAnalysis(args.ID)
+	default:
+		return "", fmt.Errorf("ferramenta desconhecida: %s", name)
+	}
+}
+
+// ListTables lista as tabelas do banco
+func (t *Toolset) ListTables() (string, error) {
+	var query string
+	switch t.dbType {
+	case dbtypes.DatabaseTypePostgreSQL:
+		query = "SELECT table_schema, table_name FROM information_schema.tables WHERE table_schema NOT IN ('pg_catalog', 'information_schema') ORDER BY 1, 2"
+	case dbtypes.DatabaseTypeMySQL:
+		query = "SELECT table_name FROM information_schema.tables WHERE table_schema = DATABASE() ORDER BY 1"
+	case dbtypes.DatabaseTypeSQLServer:
+		query = "SELECT TABLE_SCHEMA, TABLE_NAME FROM INFORMATION_SCHEMA.TABLES WHERE TABLE_TYPE = 'BASE TABLE' ORDER BY 1, 2"
+	case dbtypes.DatabaseTypeOracle:
+		query = "SELECT table_name FROM user_tables ORDER BY table_name"
+	case DatabaseTypeSQLite:
+		query = "SELECT name FROM sqlite_master WHERE type = 'table' AND name NOT LIKE 'sqlite_%' ORDER BY name"
+	default:
+		return "", fmt.Errorf("ferramenta não suportada para %s", t.dbType)
+	}
+	return t.query(query)
+}
+
+// DescribeTable mostra as colunas de uma tabela
+func (t *Toolset) DescribeTable(table string) (string, error) {
+	if !identifierPattern.MatchString(table) {
+		return "", fmt.Errorf("nome de tabela inválido: %q", table)
+	}
+
+	schema, name := "", table
+	if idx := strings.Index(table, "."); idx >= 0 {
+		schema, name = table[:idx], table[idx+1:]
+	}
+
+	var query string
+	switch t.dbType {
+	case dbtypes.DatabaseTypePostgreSQL, dbtypes.DatabaseTypeMySQL:
+		query = fmt.Sprintf("SELECT column_name, data_type, is_nullable, column_default FROM information_schema.columns WHERE table_name = '%s'", name)
+		if schema != "" {
+			query += fmt.Sprintf(" AND table_schema = '%s'", schema)
+		} else if t.dbType == dbtypes.DatabaseTypeMySQL {
+			query += " AND table_schema = DATABASE()"
+		}
+		query += " ORDER BY ordinal_position"
+	case dbtypes.DatabaseTypeSQLServer:
+		query = fmt.Sprintf("SELECT COLUMN_NAME, DATA_TYPE, IS_NULLABLE, COLUMN_DEFAULT FROM INFORMATION_SCHEMA.COLUMNS WHERE TABLE_NAME = '%s'", name)
+		if schema != "" {
+			query += fmt.Sprintf(" AND TABLE_SCHEMA = '%s'", schema)
+		}
+		query += " ORDER BY ORDINAL_POSITION"
+	case dbtypes.DatabaseTypeOracle:
+		query = fmt.Sprintf("SELECT column_name, data_type, data_length, nullable FROM all_tab_columns WHERE table_name = UPPER('%s')", name)
+		if schema != "" {
+			query += fmt.Sprintf(" AND owner = UPPER('%s')", schema)
+		} else {
+			query += " AND owner = USER"
+		}
+		query += " ORDER BY column_id"
+	case DatabaseTypeSQLite:
+		query = fmt.Sprintf("SELECT name, type, \"notnull\", dflt_value, pk FROM pragma_table_info('%s')", name)
+	default:
+		return "", fmt.Errorf("ferramenta não suportada para %s", t.dbType)
+	}
+
+	result, err := t.query(query)
+	if err == nil && result == noRows {
+		return "", fmt.Errorf("tabela não encontrada: %s", table)
+	}
+	return result, err
+}
+
+// RunReadOnlyQuery executa uma query de leitura em uma transação descartada
+func (t *Toolset) RunReadOnlyQuery(query string) (string, error) {
+	query, err := ValidateReadOnly(query)
+	if err != nil {
+		return "", err
+	}
+	return t.query(query)
+}
+
+// ExplainQuery retorna o plano de execução de uma query de leitura
+func (t *Toolset) ExplainQuery(query string) (string, error) {
+	query, err := ValidateReadOnly(query)
+	if err != nil {
+		return "", err
+	}
+
+	switch t.dbType {
+	case dbtypes.DatabaseTypePostgreSQL, dbtypes.DatabaseTypeMySQL:
+		return t.query("EXPLAIN " + query)
+	case DatabaseTypeSQLite:
+		return t.query("EXPLAIN QUERY PLAN " + query)
+	case dbtypes.DatabaseTypeOracle:
+		return t.query("SELECT plan_table_output FROM TABLE(DBMS_XPLAN.DISPLAY())", "EXPLAIN PLAN FOR "+query)
+	case dbtypes.DatabaseTypeSQLServer:
+		return t.query(query, "SET SHOWPLAN_TEXT ON")
+	default:
+		return "", fmt.Errorf("ferramenta não suportada para %s", t.dbType)
+	}
+}
+
+// GetAnalysis retorna uma análise salva
+func (t *Toolset) GetAnalysis(id int) (string, error) {
+	if t.lookup == nil {
+		return "", fmt.Errorf("histórico de análises indisponível")
+	}
+
+	analysis, err := t.lookup(id)
+	if err != nil {
+		return "", fmt.Errorf("análise %d não encontrada: %w", id, err)
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Análise #%d: %s\n", analysis.ID, analysis.Title))
+	sb.WriteString(fmt.Sprintf("Banco: %s | Tipo: %s | Status: %s | Data: %s\n\n",
+		analysis.DatabaseType, analysis.AnalysisType, analysis.Status, analysis.CreatedAt.Format("2006-01-02 15:04")))
+	if analysis.ErrorMessage != "" {
+		sb.WriteString(fmt.Sprintf("Erro: %s\n\n", analysis.ErrorMessage))
+	}
+	sb.WriteString("Resultado:\n")
+	sb.WriteString(analysis.Result)
+	if analysis.AIInsights != "" {
+		sb.WriteString("\n\nInsights:\n")
+		sb.WriteString(analysis.AIInsights)
+	}
+	return sb.String(), nil
+}
+
+// LastQuery retorna a última query de leitura bem-sucedida de uma investigação
+func LastQuery(steps []ai.AgentStep) (string, string) {
+	for i := len(steps) - 1; i >= 0; i-- {
+		step := steps[i]
+		if step.Name != "run_readonly_query" || step.Err != nil {
+			continue
+		}
+		var args struct {
+			Query string `json:"query"`
+		}
+		if json.Unmarshal([]byte(step.Arguments), &args) == nil {
+			return args.Query, step.Result
+		}
+	}
+	return "", ""
+}
+
+// ValidateReadOnly rejeita queries que possam alterar dados ou o schema e
+// retorna a query normalizada (sem comentários e sem ';' final). É uma
+// primeira barreira: a garantia é a transação read-only (e sempre desfeita)
+// em que query executa.
+func ValidateReadOnly(query string) (string, error) {
+	query = blockComment.ReplaceAllString(query, " ")
+	query = lineComment.ReplaceAllString(query, " ")
+	query = strings.TrimSpace(query)
+	query = strings.TrimSpace(strings.TrimRight(query, "; \n\t"))
+
+	if query == "" {
+		return "", fmt.Errorf("query vazia")
+	}
+	if strings.Contains(query, ";") {
+		return "", fmt.Errorf("apenas uma instrução por query é permitida")
+	}
+
+	fields := strings.Fields(strings.ToLower(query))
+	allowed := false
+	for _, prefix := range readOnlyPrefixes {
+		if fields[0] == prefix {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		return "", fmt.Errorf("apenas queries de leitura são permitidas (SELECT, WITH, SHOW, DESCRIBE, EXPLAIN)")
+	}
+
+	if match := writeKeywords.FindString(query); match != "" {
+		return "", fmt.Errorf("query rejeitada: contém %s", strings.ToUpper(match))
+	}
+	if match := sideEffectFunctions.FindStringSubmatch(query); match != nil {
+		return "", fmt.Errorf("query rejeitada: chama %s", strings.ToUpper(match[1]))
+	}
+
+	return query, nil
+}
+
+const noRows = "Nenhum resultado encontrado."
+
+// query executa as instruções de preparação e a query em uma sessão isolada,
+// dentro de uma transação que é sempre desfeita
+func (t *Toolset) query(query string, setup ...string) (string, error) {
+	if t.db == nil {
+		return "", fmt.Errorf("conexão com banco de dados não disponível")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), queryTimeout)
+	defer cancel()
+
+	conn, err := t.db.Conn(ctx)
+	if err != nil {
+		return "", fmt.Errorf("erro ao obter conexão: %w", err)
+	}
+	defer conn.Close()
+
+	// SHOWPLAN do SQL Server não pode ser usado dentro de transação
+	if t.dbType == dbtypes.DatabaseTypeSQLServer && len(setup) > 0 {
+		for _, stmt := range setup {
+			if _, err := conn.ExecContext(ctx, stmt); err != nil {
+				return "", err
+			}
+		}
+		defer conn.ExecContext(context.Background(), "SET SHOWPLAN_TEXT OFF")
+		return queryRows(ctx, conn, query)
+	}
+
+	// EXPLAIN PLAN do Oracle grava na PLAN_TABLE, então não pode ser read-only
+	tx, err := conn.BeginTx(ctx, &sql.TxOptions{ReadOnly: len(setup) == 0})
+	if err != nil {
+		tx, err = conn.BeginTx(ctx, nil)
+		if err != nil {
+			return "", fmt.Errorf("erro ao iniciar transação: %w", err)
+		}
+	}
+	defer tx.Rollback()
+
+	for _, stmt := range setup {
+		if _, err := tx.ExecContext(ctx, stmt); err != nil {
+			return "", err
+		}
+	}
+
+	return queryRows(ctx, tx, query)
+}
+
+type queryer interface {
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+}
+
+// queryRows formata o resultado como tabela markdown
+func queryRows(ctx context.Context, q queryer, query string) (string, error) {
+	rows, err := q.QueryContext(ctx, query)
+	if err != nil {
+		return "", err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return "", fmt.Errorf("erro ao obter colunas: %w", err)
+	}
+
+	var result strings.Builder
+	result.WriteString("| " + strings.Join(columns, " | ") + " |\n")
+	result.WriteString("|" + strings.Repeat(" --- |", len(columns)) + "\n")
+
+	values := make([]interface{}, len(columns))
+	valuePtrs := make([]interface{}, len(columns))
+	for i := range values {
+		valuePtrs[i] = &values[i]
+	}
+
+	count := 0
+	for rows.Next() {
+		if err := rows.Scan(valuePtrs...); err != nil {
+			return "", err
+		}
+
+		cells := make([]string, len(values))
+		for i, val := range values {
+			cells[i] = formatCell(val)
+		}
+		result.WriteString("| " + strings.Join(cells, " | ") + " |\n")
+
+		count++
+		if count >= maxRows {
+			result.WriteString(fmt.Sprintf("\n*... resultado limitado a %d linhas*\n", maxRows))
+			break
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return "", err
+	}
+
+	if count == 0 {
+		return noRows, nil
+	}
+	return result.String(), nil
+}
+
+func formatCell(val interface{}) string {
+	if val == nil {
+		return "NULL"
+	}
+
+	var s string
+	if b, ok := val.([]byte); ok {
+		s = string(b)
+	} else {
+		s = fmt.Sprintf("%v", val)
+	}
+
+	if len([]rune(s)) > maxCellWidth {
+		s = string([]rune(s)[:maxCellWidth]) + "..."
+	}
+	s = strings.ReplaceAll(s, "|", "\\|")
+	return strings.ReplaceAll(s, "\n", " ")
+}
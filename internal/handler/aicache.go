@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/snip/internal/ai"
+	"github.com/snip/internal/repository"
+)
+
+type AICacheHandler interface {
+	ShowStats() error
+	Clear(expiredOnly bool) error
+}
+
+type aiCacheHandler struct {
+	cacheRepo repository.AICacheRepository
+}
+
+func NewAICacheHandler(cacheRepo repository.AICacheRepository) AICacheHandler {
+	return &aiCacheHandler{cacheRepo: cacheRepo}
+}
+
+func (h *aiCacheHandler) ShowStats() error {
+	stats, err := h.cacheRepo.Stats()
+	if err != nil {
+		return fmt.Errorf("erro ao consultar cache de IA: %w", err)
+	}
+
+	status := "ativo"
+	ttl := ai.DefaultCacheTTL
+	if config, err := ai.LoadConfig(); err == nil {
+		if config.Cache != nil && config.Cache.Disabled {
+			status = "desativado"
+		}
+		ttl = config.Cache.GetTTL()
+	}
+
+	fmt.Println("🗃️  Cache de respostas da IA")
+	fmt.Printf("  Status: %s (TTL: %s)\n", status, ttl)
+	fmt.Printf("  Entradas: %d (%d expiradas)\n", stats.Entries, stats.Expired)
+	fmt.Printf("  Acertos: %d\n", stats.Hits)
+	fmt.Printf("  Tamanho: %.1f KB\n", float64(stats.SizeBytes)/1024)
+
+	if stats.Entries == 0 {
+		return nil
+	}
+
+	fmt.Printf("  Mais antiga: %s\n", stats.OldestEntry.Format("2006-01-02 15:04"))
+	fmt.Printf("  Mais recente: %s\n", stats.NewestEntry.Format("2006-01-02 15:04"))
+
+	models := make([]string, 0, len(stats.ByModel))
+	for model := range stats.ByModel {
+		models = append(models, model)
+	}
+	sort.Strings(models)
+
+	fmt.Println("\n  Por modelo:")
+	for _, model := range models {
+		fmt.Printf("    %-40s %d\n", model, stats.ByModel[model])
+	}
+
+	return nil
+}
+
+func (h *aiCacheHandler) Clear(expiredOnly bool) error {
+	removed, err := h.cacheRepo.Clear(expiredOnly)
+	if err != nil {
+		return fmt.Errorf("erro ao limpar cache de IA: %w", err)
+	}
+
+	if expiredOnly {
+		fmt.Printf("✓ %d entradas expiradas removidas do cache\n", removed)
+	} else {
+		fmt.Printf("✓ %d entradas removidas do cache\n", removed)
+	}
+	return nil
+}
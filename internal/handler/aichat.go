@@ -0,0 +1,310 @@
+package handler
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/snip/internal/ai"
+	"github.com/snip/internal/aichat"
+	"github.com/snip/internal/note"
+	"github.com/snip/internal/repository"
+)
+
+type AIChatHandler interface {
+	StartChat(resume string, title string, keepModel bool) error
+	ListSessions(limit int) error
+	ShowSession(idStr string) error
+	DeleteSession(idStr string) error
+}
+
+type aiChatHandler struct {
+	chatRepo repository.AIChatRepository
+	noteRepo repository.NoteRepository
+}
+
+func NewAIChatHandler(chatRepo repository.AIChatRepository, noteRepo repository.NoteRepository) AIChatHandler {
+	return &aiChatHandler{chatRepo: chatRepo, noteRepo: noteRepo}
+}
+
+// StartChat abre uma nova sessão ou retoma uma existente (id ou "last")
+func (h *aiChatHandler) StartChat(resume string, title string, keepModel bool) error {
+	var chat *aichat.Chat
+	if resume != "" {
+		session, err := h.findSession(resume)
+		if err != nil {
+			return err
+		}
+		chat, err = aichat.ResumeChat(session, h.chatRepo, h.noteRepo.GetByID, keepModel)
+		if err != nil {
+			return err
+		}
+	} else {
+		var err error
+		chat, err = aichat.NewChat(h.chatRepo, h.noteRepo.GetByID, title)
+		if err != nil {
+			return err
+		}
+	}
+
+	session := chat.GetSession()
+	if session.ID != 0 {
+		fmt.Printf("🤖 Retomando a sessão #%d: %s (%d mensagens)\n", session.ID, session.Title, len(session.Messages))
+		h.printLastExchange(session)
+	} else {
+		fmt.Println("🤖 Chat com IA iniciado!")
+	}
+	fmt.Printf("Modelo: %s (%s)\n", session.Model, session.Provider)
+	fmt.Print("Digite /help para ver os comandos e /exit para sair.\n\n")
+
+	scanner := bufio.NewScanner(os.Stdin)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for {
+		fmt.Print("Você: ")
+		if !scanner.Scan() {
+			break
+		}
+
+		input := strings.TrimSpace(scanner.Text())
+		if input == "" {
+			continue
+		}
+
+		lower := strings.ToLower(input)
+		if lower == "exit" || lower == "quit" || lower == "sair" || lower == "/exit" || lower == "/quit" {
+			break
+		}
+
+		if strings.HasPrefix(input, "/") {
+			h.runSlashCommand(chat, input)
+			continue
+		}
+
+		fmt.Print("\n🤖 Assistente:\n")
+		response, err := chat.SendMessage(input)
+		if err != nil {
+			fmt.Printf("❌ Erro: %v\n\n", err)
+			continue
+		}
+
+		fmt.Println(renderMarkdownContent(response))
+	}
+
+	if session.ID != 0 {
+		fmt.Printf("\nSessão #%d salva. Continue com: snip ai chat --resume %d\n", session.ID, session.ID)
+	}
+	fmt.Println("Até logo! 👋")
+	return nil
+}
+
+func (h *aiChatHandler) runSlashCommand(chat *aichat.Chat, input string) {
+	fields := strings.Fields(input)
+	command := strings.ToLower(fields[0])
+	arg := strings.TrimSpace(strings.TrimPrefix(input, fields[0]))
+
+	switch command {
+	case "/note":
+		h.pinNote(chat, arg)
+	case "/save":
+		h.saveTranscript(chat, arg)
+	case "/model":
+		h.switchModel(chat, arg)
+	case "/help":
+		printChatHelp()
+	default:
+		fmt.Printf("Comando desconhecido: %s (digite /help)\n\n", fields[0])
+	}
+}
+
+func (h *aiChatHandler) pinNote(chat *aichat.Chat, arg string) {
+	if arg == "" {
+		pinned := chat.GetSession().PinnedNotes
+		if len(pinned) == 0 {
+			fmt.Print("Nenhuma nota fixada. Use /note <id>.\n\n")
+			return
+		}
+		fmt.Println("📌 Notas fixadas:")
+		for _, id := range pinned {
+			if n, err := h.noteRepo.GetByID(id); err == nil {
+				fmt.Printf("  ● #%d %s\n", n.ID, n.Title)
+			}
+		}
+		fmt.Println()
+		return
+	}
+
+	id, err := strconv.Atoi(arg)
+	if err != nil {
+		fmt.Printf("ID de nota inválido: %s\n\n", arg)
+		return
+	}
+
+	n, err := chat.PinNote(id)
+	if err != nil {
+		fmt.Printf("❌ Erro: %v\n\n", err)
+		return
+	}
+	fmt.Printf("📌 Nota #%d fixada como contexto: %s\n\n", n.ID, n.Title)
+}
+
+func (h *aiChatHandler) saveTranscript(chat *aichat.Chat, title string) {
+	session := chat.GetSession()
+	if len(session.Messages) == 0 {
+		fmt.Print("Nada para salvar: a conversa ainda está vazia.\n\n")
+		return
+	}
+
+	if title == "" {
+		title = "Chat: " + session.Title
+	}
+
+	newNote := note.NewNote(title, chat.Transcript())
+	if err := h.noteRepo.Create(newNote); err != nil {
+		fmt.Printf("❌ Erro ao salvar nota: %v\n\n", err)
+		return
+	}
+
+	fmt.Printf("✓ Conversa salva como nota #%d: %s\n\n", newNote.ID, newNote.Title)
+}
+
+func (h *aiChatHandler) switchModel(chat *aichat.Chat, model string) {
+	if model == "" {
+		fmt.Printf("Modelo atual: %s (%s)\n", chat.GetSession().Model, chat.GetProvider())
+		if models := ai.GetAvailableModels(chat.GetProvider()); len(models) > 0 {
+			fmt.Println("Modelos disponíveis:")
+			for _, m := range models {
+				fmt.Printf("  - %s\n", m)
+			}
+		}
+		fmt.Println()
+		return
+	}
+
+	if err := chat.SetModel(model); err != nil {
+		fmt.Printf("❌ Erro: %v\n\n", err)
+		return
+	}
+	fmt.Printf("✓ Modelo alterado para %s\n\n", model)
+}
+
+func printChatHelp() {
+	fmt.Println("Comandos:")
+	fmt.Println("  /note <id>      Fixar uma nota como contexto (sem id lista as fixadas)")
+	fmt.Println("  /save [título]  Salvar a conversa como uma nova nota")
+	fmt.Println("  /model [nome]   Trocar o modelo (sem nome lista os disponíveis)")
+	fmt.Println("  /help           Mostrar esta ajuda")
+	fmt.Println("  /exit           Sair (a sessão continua salva)")
+	fmt.Println()
+}
+
+// printLastExchange mostra a última troca ao retomar uma sessão
+func (h *aiChatHandler) printLastExchange(session *aichat.ChatSession) {
+	messages := session.Messages
+	if len(messages) > 2 {
+		messages = messages[len(messages)-2:]
+	}
+	if len(messages) == 0 {
+		return
+	}
+
+	fmt.Println("\nÚltimas mensagens:")
+	for _, msg := range messages {
+		content := msg.Content
+		if runes := []rune(content); len(runes) > 200 {
+			content = string(runes[:197]) + "..."
+		}
+		if msg.Role == "user" {
+			fmt.Printf("  Você: %s\n", content)
+		} else {
+			fmt.Printf("  🤖 %s\n", content)
+		}
+	}
+	fmt.Println()
+}
+
+func (h *aiChatHandler) ListSessions(limit int) error {
+	sessions, err := h.chatRepo.ListSessions(limit)
+	if err != nil {
+		return fmt.Errorf("erro ao listar sessões: %w", err)
+	}
+
+	if len(sessions) == 0 {
+		fmt.Println("Nenhuma sessão de chat encontrada. Inicie uma com: snip ai chat")
+		return nil
+	}
+
+	fmt.Printf("💬 %d sessão(ões) de chat:\n\n", len(sessions))
+	for _, s := range sessions {
+		fmt.Printf("● #%d %s\n", s.ID, s.Title)
+		fmt.Printf("  └─ %d mensagens · %s · atualizada em %s\n",
+			s.MessageCount, s.Model, s.UpdatedAt.Format("2006-01-02 15:04"))
+	}
+
+	return nil
+}
+
+func (h *aiChatHandler) ShowSession(idStr string) error {
+	session, err := h.findSession(idStr)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("💬 Sessão #%d: %s\n", session.ID, session.Title)
+	fmt.Printf("  Modelo: %s (%s)\n", session.Model, session.Provider)
+	fmt.Printf("  Criada: %s · Atualizada: %s\n", session.CreatedAt.Format("2006-01-02 15:04"), session.UpdatedAt.Format("2006-01-02 15:04"))
+	if len(session.PinnedNotes) > 0 {
+		ids := make([]string, 0, len(session.PinnedNotes))
+		for _, id := range session.PinnedNotes {
+			ids = append(ids, fmt.Sprintf("#%d", id))
+		}
+		fmt.Printf("  Notas fixadas: %s\n", strings.Join(ids, ", "))
+	}
+	fmt.Println()
+
+	for _, msg := range session.Messages {
+		if msg.Role == "user" {
+			fmt.Printf("Você [%s]: %s\n\n", msg.Timestamp.Format("15:04"), msg.Content)
+		} else {
+			fmt.Printf("🤖 Assistente [%s]:\n%s\n", msg.Timestamp.Format("15:04"), renderMarkdownContent(msg.Content))
+		}
+	}
+
+	return nil
+}
+
+func (h *aiChatHandler) DeleteSession(idStr string) error {
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		return fmt.Errorf("ID de sessão inválido: %s", idStr)
+	}
+
+	if err := h.chatRepo.DeleteSession(id); err != nil {
+		return fmt.Errorf("erro ao remover sessão: %w", err)
+	}
+
+	fmt.Printf("✓ Sessão #%d removida\n", id)
+	return nil
+}
+
+func (h *aiChatHandler) findSession(idStr string) (*aichat.ChatSession, error) {
+	if strings.EqualFold(idStr, "last") {
+		session, err := h.chatRepo.GetLastSession()
+		if err != nil {
+			return nil, fmt.Errorf("erro ao buscar sessão: %w", err)
+		}
+		return session, nil
+	}
+
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		return nil, fmt.Errorf("ID de sessão inválido: %s (use um número ou 'last')", idStr)
+	}
+
+	session, err := h.chatRepo.GetSession(id)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar sessão: %w", err)
+	}
+	return session, nil
+}
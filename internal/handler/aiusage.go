@@ -0,0 +1,85 @@
+package handler
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/snip/internal/ai"
+	"github.com/snip/internal/repository"
+)
+
+type AIUsageHandler interface {
+	ShowUsage(since string, groupBy string) error
+}
+
+type aiUsageHandler struct {
+	usageRepo repository.AIUsageRepository
+}
+
+func NewAIUsageHandler(usageRepo repository.AIUsageRepository) AIUsageHandler {
+	return &aiUsageHandler{usageRepo: usageRepo}
+}
+
+func (h *aiUsageHandler) ShowUsage(since string, groupBy string) error {
+	sinceTime, err := parseSinceFilter(since)
+	if err != nil {
+		return fmt.Errorf("erro ao interpretar --since: %w", err)
+	}
+
+	summaries, err := h.usageRepo.Summarize(sinceTime, groupBy)
+	if err != nil {
+		return fmt.Errorf("erro ao consultar consumo de IA: %w", err)
+	}
+
+	totals, err := h.usageRepo.Totals(sinceTime)
+	if err != nil {
+		return fmt.Errorf("erro ao consultar consumo de IA: %w", err)
+	}
+
+	fmt.Printf("📊 Consumo de IA desde %s (por %s)\n\n", sinceTime.Format("2006-01-02"), groupBy)
+
+	if len(summaries) == 0 {
+		fmt.Println("Nenhuma chamada de IA registrada no período.")
+		return nil
+	}
+
+	fmt.Printf("%-32s %8s %12s %12s %10s %12s\n", groupBy, "Chamadas", "Tokens in", "Tokens out", "Latência", "Custo (US$)")
+	for _, s := range summaries {
+		fmt.Printf("%-32s %8d %12d %12d %8.0fms %12.4f\n",
+			s.Key, s.Requests, s.InputTokens, s.OutputTokens, s.AvgLatencyMs, s.CostUSD)
+	}
+	fmt.Printf("%-32s %8d %12d %12d %8.0fms %12.4f\n",
+		"TOTAL", totals.Requests, totals.InputTokens, totals.OutputTokens, totals.AvgLatencyMs, totals.CostUSD)
+
+	return h.printBudget()
+}
+
+// printBudget mostra o consumo do mês corrente frente ao orçamento configurado
+func (h *aiUsageHandler) printBudget() error {
+	config, err := ai.LoadConfig()
+	if err != nil || config.Budget == nil {
+		return nil
+	}
+
+	month, err := h.usageRepo.Totals(ai.StartOfMonth(time.Now()))
+	if err != nil {
+		return fmt.Errorf("erro ao consultar consumo de IA: %w", err)
+	}
+
+	action := config.Budget.Action
+	if action == "" {
+		action = "warn"
+	}
+
+	fmt.Printf("\n💰 Orçamento mensal (%s):\n", action)
+	if config.Budget.MonthlyLimitUSD > 0 {
+		fmt.Printf("  Custo: US$ %.4f / US$ %.2f (%.0f%%)\n", month.CostUSD, config.Budget.MonthlyLimitUSD,
+			month.CostUSD/config.Budget.MonthlyLimitUSD*100)
+	}
+	if config.Budget.MonthlyTokenLimit > 0 {
+		used := month.InputTokens + month.OutputTokens
+		fmt.Printf("  Tokens: %d / %d (%.0f%%)\n", used, config.Budget.MonthlyTokenLimit,
+			float64(used)/float64(config.Budget.MonthlyTokenLimit)*100)
+	}
+	return nil
+}
@@ -0,0 +1,266 @@
+package handler
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/snip/internal/task"
+	"golang.org/x/term"
+)
+
+// Teclas reconhecidas no modo interativo do quadro
+const (
+	keyNone = iota
+	keyUp
+	keyDown
+	keyLeft
+	keyRight
+	keyNextColumn
+	keyPrevColumn
+	keyQuit
+)
+
+// ShowBoard mostra as tarefas do projeto em colunas kanban. No modo interativo
+// as setas movem os cartões entre colunas e gravam o novo status.
+func (h *taskHandler) ShowBoard(projectID int, interactive bool) error {
+	p, err := h.projectRepo.GetByID(projectID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch project: %w", err)
+	}
+
+	board, err := h.loadBoard(projectID)
+	if err != nil {
+		return err
+	}
+
+	style := task.BoardStyle{
+		Width: terminalWidth(),
+		Color: term.IsTerminal(int(os.Stdout.Fd())) && os.Getenv("NO_COLOR") == "",
+		Now:   today(),
+	}
+
+	if !interactive {
+		fmt.Printf("● #%d %s [%s]\n\n", p.ID, p.Name, p.Status)
+		fmt.Print(board.Render(style))
+		return nil
+	}
+
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return fmt.Errorf("o modo interativo precisa de um terminal")
+	}
+	return h.runBoard(p.ID, p.Name, board, style)
+}
+
+func (h *taskHandler) loadBoard(projectID int) (*task.Board, error) {
+	tasks, err := h.taskRepo.GetByProjectID(projectID, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch tasks: %w", err)
+	}
+	deps, byID, err := h.dependencyGraph()
+	if err != nil {
+		return nil, err
+	}
+
+	board := task.NewBoard(tasks)
+	for _, t := range tasks {
+		if len(task.BlockedBy(t.ID, deps, byID)) > 0 {
+			board.Blocked[t.ID] = true
+		}
+	}
+	return board, nil
+}
+
+// runBoard é o laço do modo interativo: ↑/↓ escolhem o cartão, Tab troca de
+// coluna, ←/→ movem o cartão e q sai
+func (h *taskHandler) runBoard(projectID int, name string, board *task.Board, style task.BoardStyle) error {
+	fd := int(os.Stdin.Fd())
+	state, err := term.MakeRaw(fd)
+	if err != nil {
+		return fmt.Errorf("failed to configure terminal: %w", err)
+	}
+	defer term.Restore(fd, state)
+	defer fmt.Print("\033[?25h")
+
+	column, row := firstCard(board)
+	message := ""
+	for {
+		style.Width = terminalWidth()
+		style.Selected = 0
+		if row >= 0 {
+			style.Selected = board.Columns[column].Tasks[row].ID
+		}
+
+		var screen strings.Builder
+		screen.WriteString("\033[?25l\033[H\033[2J")
+		screen.WriteString(fmt.Sprintf("● #%d %s\n\n", projectID, name))
+		screen.WriteString(board.Render(style))
+		screen.WriteString("\n↑/↓ escolher · Tab trocar de coluna · ←/→ mover cartão · q sair\n")
+		if message != "" {
+			screen.WriteString(message + "\n")
+		}
+		// Em modo raw o terminal não converte \n em \r\n
+		fmt.Print(strings.ReplaceAll(screen.String(), "\n", "\r\n"))
+
+		key := readKey()
+		message = ""
+		switch key {
+		case keyQuit:
+			fmt.Print("\033[H\033[2J")
+			return nil
+		case keyUp:
+			if row > 0 {
+				row--
+			}
+		case keyDown:
+			if row >= 0 && row < len(board.Columns[column].Tasks)-1 {
+				row++
+			}
+		case keyNextColumn, keyPrevColumn:
+			step := 1
+			if key == keyPrevColumn {
+				step = -1
+			}
+			column, row = nextColumn(board, column, step)
+		case keyLeft, keyRight:
+			if row < 0 {
+				continue
+			}
+			delta := 1
+			if key == keyLeft {
+				delta = -1
+			}
+			t := board.Columns[column].Tasks[row]
+			moved, ok, err := h.moveCard(board, t, delta)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				continue
+			}
+			message = moved
+
+			// Recarrega para refletir bloqueios desfeitos e novas ocorrências
+			if board, err = h.loadBoard(projectID); err != nil {
+				return err
+			}
+			if column, row = board.Locate(t.ID); column < 0 {
+				column, row = firstCard(board)
+			}
+		}
+	}
+}
+
+// MoveCard move o cartão da tarefa uma coluna para a direita (delta 1) ou
+// para a esquerda (-1), como as setas do quadro interativo
+func (h *taskHandler) MoveCard(projectID int, id int, delta int) error {
+	t, err := h.taskRepo.GetByID(id)
+	if err != nil {
+		return fmt.Errorf("failed to fetch task: %w", err)
+	}
+	board, err := h.loadBoard(projectID)
+	if err != nil {
+		return err
+	}
+	c, r := board.Locate(id)
+	if c < 0 {
+		return fmt.Errorf("tarefa #%d não está no quadro do projeto #%d", id, projectID)
+	}
+
+	message, ok, err := h.moveCard(board, board.Columns[c].Tasks[r], delta)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("#%d já está na coluna %s", id, task.BoardTitle(t.Status))
+	}
+	fmt.Println(message)
+	return nil
+}
+
+// moveCard grava o novo status do cartão e retorna a mensagem do quadro;
+// ok é falso quando o cartão já está na borda
+func (h *taskHandler) moveCard(board *task.Board, t *task.Task, delta int) (string, bool, error) {
+	status, ok := board.Move(t.ID, delta)
+	if !ok {
+		return "", false, nil
+	}
+	if err := h.taskRepo.Update(t.ID, t.Title, t.Description, status, t.Priority, t.DueDate); err != nil {
+		return "", false, fmt.Errorf("failed to update task: %w", err)
+	}
+	message := fmt.Sprintf("#%d → %s", t.ID, task.BoardTitle(status))
+
+	// Concluir uma tarefa recorrente pelo quadro também agenda a próxima
+	if t.IsCompleted() && t.Recurrence != "" {
+		next, err := h.scheduleNextOccurrence(t)
+		if err != nil {
+			return "", false, err
+		}
+		message += fmt.Sprintf(" · ↻ próxima ocorrência #%d em %s", next.ID, next.DueDate.Format("2006-01-02"))
+	}
+	return message, true, nil
+}
+
+// firstCard retorna a posição do primeiro cartão do quadro (row -1 se vazio)
+func firstCard(board *task.Board) (int, int) {
+	for c, col := range board.Columns {
+		if len(col.Tasks) > 0 {
+			return c, 0
+		}
+	}
+	return 0, -1
+}
+
+// nextColumn avança para a próxima coluna com cartões na direção step
+func nextColumn(board *task.Board, column int, step int) (int, int) {
+	n := len(board.Columns)
+	for i := 1; i < n; i++ {
+		c := ((column+step*i)%n + n) % n
+		if len(board.Columns[c].Tasks) > 0 {
+			return c, 0
+		}
+	}
+	if len(board.Columns[column].Tasks) == 0 {
+		return column, -1
+	}
+	return column, 0
+}
+
+// readKey lê uma tecla do terminal em modo raw, incluindo as sequências das setas
+func readKey() int {
+	buf := make([]byte, 8)
+	n, err := os.Stdin.Read(buf)
+	if err != nil || n == 0 {
+		return keyQuit
+	}
+
+	switch string(buf[:n]) {
+	case "\033[A", "k":
+		return keyUp
+	case "\033[B", "j":
+		return keyDown
+	case "\033[D":
+		return keyLeft
+	case "\033[C":
+		return keyRight
+	case "\t":
+		return keyNextColumn
+	case "\033[Z":
+		return keyPrevColumn
+	case "q", "Q", "\033", "\x03":
+		return keyQuit
+	}
+	return keyNone
+}
+
+// terminalWidth usa o tamanho do terminal, a variável COLUMNS ou 80 colunas
+func terminalWidth() int {
+	if width, _, err := term.GetSize(int(os.Stdout.Fd())); err == nil && width > 0 {
+		return width
+	}
+	if width, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && width > 0 {
+		return width
+	}
+	return 80
+}
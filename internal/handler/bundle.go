@@ -0,0 +1,345 @@
+package handler
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/snip/internal/bundle"
+	"github.com/snip/internal/checklist"
+	"github.com/snip/internal/project"
+	"github.com/snip/internal/repository"
+	"github.com/snip/internal/task"
+)
+
+// BundleHandler exporta e importa projetos completos (tarefas, dependências,
+// checklists e itens) como pacotes YAML/JSON
+type BundleHandler interface {
+	ExportProject(id int, format, output string, template bool) error
+	ImportProject(path, name string, start *time.Time, template, dryRun bool) error
+}
+
+type bundleHandler struct {
+	projectRepo       repository.ProjectRepository
+	taskRepo          repository.TaskRepository
+	checklistRepo     repository.ChecklistRepository
+	checklistItemRepo repository.ChecklistItemRepository
+}
+
+func NewBundleHandler(projectRepo repository.ProjectRepository, taskRepo repository.TaskRepository, checklistRepo repository.ChecklistRepository, checklistItemRepo repository.ChecklistItemRepository) BundleHandler {
+	return &bundleHandler{
+		projectRepo:       projectRepo,
+		taskRepo:          taskRepo,
+		checklistRepo:     checklistRepo,
+		checklistItemRepo: checklistItemRepo,
+	}
+}
+
+var nonSlugChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+// ExportProject grava o pacote do projeto em output (ou em ~/.snip/export)
+func (h *bundleHandler) ExportProject(id int, format, output string, template bool) error {
+	format = strings.ToLower(format)
+	if format == "yml" {
+		format = bundle.FormatYAML
+	}
+
+	p, err := h.projectRepo.GetByID(id)
+	if err != nil {
+		return fmt.Errorf("failed to fetch project: %w", err)
+	}
+	tasks, err := h.taskRepo.GetByProjectID(id, "")
+	if err != nil {
+		return fmt.Errorf("failed to fetch tasks: %w", err)
+	}
+	deps, err := h.taskRepo.GetDependencies()
+	if err != nil {
+		return fmt.Errorf("failed to fetch task dependencies: %w", err)
+	}
+
+	// Checklists do projeto e das tarefas dele
+	checklists, err := h.checklistRepo.GetByProjectID(id)
+	if err != nil {
+		return fmt.Errorf("failed to fetch checklists: %w", err)
+	}
+	seen := make(map[int]bool)
+	for _, c := range checklists {
+		seen[c.ID] = true
+	}
+	for _, t := range tasks {
+		taskChecklists, err := h.checklistRepo.GetByTaskID(t.ID)
+		if err != nil {
+			return fmt.Errorf("failed to fetch checklists: %w", err)
+		}
+		for _, c := range taskChecklists {
+			if !seen[c.ID] {
+				seen[c.ID] = true
+				checklists = append(checklists, c)
+			}
+		}
+	}
+
+	items := make(map[int][]*checklist.ChecklistItem)
+	itemCount := 0
+	for _, c := range checklists {
+		list, err := h.checklistItemRepo.GetByChecklistID(c.ID)
+		if err != nil {
+			return fmt.Errorf("failed to fetch checklist items: %w", err)
+		}
+		items[c.ID] = list
+		itemCount += len(list)
+	}
+
+	b := bundle.New(p, tasks, deps, checklists, items)
+	if template {
+		b.AsTemplate()
+	}
+	data, err := b.Marshal(format)
+	if err != nil {
+		return err
+	}
+
+	if output == "" {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return fmt.Errorf("failed to get home directory: %w", err)
+		}
+		exportDir := filepath.Join(homeDir, ".snip", "export")
+		if err := os.MkdirAll(exportDir, 0755); err != nil {
+			return fmt.Errorf("failed to create export directory: %w", err)
+		}
+		slug := strings.Trim(nonSlugChars.ReplaceAllString(strings.ToLower(p.Name), "-"), "-")
+		output = filepath.Join(exportDir, fmt.Sprintf("project-%d-%s-%s.%s", p.ID, slug, time.Now().Format("20060102-150405"), format))
+	}
+	if err := os.WriteFile(output, data, 0644); err != nil {
+		return fmt.Errorf("failed to write bundle: %w", err)
+	}
+
+	kind := "Projeto exportado"
+	if template {
+		kind = "Modelo de projeto exportado"
+	}
+	fmt.Printf("✓ %s: #%d %s\n", kind, p.ID, p.Name)
+	fmt.Printf("  %d tarefa(s), %d checklist(s), %d item(ns)\n", len(b.Tasks), len(b.Checklists), itemCount)
+	fmt.Printf("  Arquivo: %s\n", output)
+	return nil
+}
+
+// ImportProject cria um novo projeto a partir do pacote, com novos IDs para
+// tarefas e checklists. Com dryRun apenas valida e mostra o que seria criado.
+func (h *bundleHandler) ImportProject(path, name string, start *time.Time, template, dryRun bool) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read bundle: %w", err)
+	}
+	b, err := bundle.Unmarshal(path, data)
+	if err != nil {
+		return err
+	}
+	if err := b.Validate(); err != nil {
+		return err
+	}
+
+	if template || b.Template {
+		b.AsTemplate()
+	}
+	if name != "" {
+		b.Project.Name = name
+	}
+	if start != nil {
+		if err := b.ShiftDueDates(*start); err != nil {
+			return err
+		}
+	}
+
+	if dryRun {
+		printBundlePlan(b)
+		return nil
+	}
+
+	created, err := h.createFromBundle(b)
+	if err != nil {
+		if rollbackErr := h.rollback(created); rollbackErr != nil {
+			return fmt.Errorf("%w (falha ao desfazer importação parcial: %v)", err, rollbackErr)
+		}
+		return err
+	}
+
+	fmt.Printf("✓ Projeto importado: #%d %s\n", created.projectID, b.Project.Name)
+	fmt.Printf("  %d tarefa(s), %d dependência(s), %d checklist(s), %d item(ns)\n",
+		len(created.tasks), created.dependencies, len(created.checklists), len(created.items))
+	if len(created.tasks) > 0 {
+		var mapping []string
+		for _, t := range b.Tasks {
+			mapping = append(mapping, fmt.Sprintf("%d→#%d", t.Ref, created.tasks[t.Ref]))
+		}
+		fmt.Printf("  Tarefas: %s\n", strings.Join(mapping, ", "))
+	}
+	return nil
+}
+
+// importResult guarda o que já foi criado, para desfazer em caso de erro
+type importResult struct {
+	projectID    int
+	tasks        map[int]int // ref → novo ID
+	checklists   []int
+	items        []int
+	dependencies int
+}
+
+func (h *bundleHandler) createFromBundle(b *bundle.Bundle) (*importResult, error) {
+	result := &importResult{tasks: make(map[int]int)}
+
+	p := project.NewProject(b.Project.Name, b.Project.Description)
+	if err := h.projectRepo.Create(p); err != nil {
+		return result, fmt.Errorf("failed to create project: %w", err)
+	}
+	result.projectID = p.ID
+	if b.Project.Status != "" && b.Project.Status != p.Status {
+		if err := h.projectRepo.Update(p.ID, p.Name, p.Description, b.Project.Status); err != nil {
+			return result, fmt.Errorf("failed to update project status: %w", err)
+		}
+	}
+
+	for _, bt := range b.TaskOrder() {
+		priority := bt.Priority
+		if priority == "" {
+			priority = "medium"
+		}
+		t := task.NewTask(p.ID, bt.Title, bt.Description, priority)
+		if bt.Status != "" {
+			t.Status = bt.Status
+		}
+		t.ParentTaskID = result.tasks[bt.Parent]
+		if bt.DueDate != "" {
+			due, _ := time.Parse("2006-01-02", bt.DueDate)
+			t.DueDate = &due
+		}
+		t.EstimatedTime, _ = task.ParseEstimate(bt.Estimate)
+		if bt.Recurrence != "" {
+			rule, _ := task.ParseRecurrence(bt.Recurrence)
+			if t.DueDate != nil {
+				rule.Anchor(*t.DueDate)
+			}
+			t.Recurrence = rule.String()
+		}
+		if err := h.taskRepo.Create(t); err != nil {
+			return result, fmt.Errorf("failed to create task %s: %w", bt.Title, err)
+		}
+		result.tasks[bt.Ref] = t.ID
+	}
+
+	for _, bt := range b.Tasks {
+		for _, dep := range bt.DependsOn {
+			if err := h.taskRepo.AddDependency(result.tasks[bt.Ref], result.tasks[dep]); err != nil {
+				return result, fmt.Errorf("failed to add dependency: %w", err)
+			}
+			result.dependencies++
+		}
+	}
+
+	for _, bc := range b.Checklists {
+		c := checklist.NewChecklist(bc.Title, bc.Description)
+		if bc.Task != 0 {
+			taskID := result.tasks[bc.Task]
+			c.TaskID = &taskID
+		} else {
+			projectID := p.ID
+			c.ProjectID = &projectID
+		}
+		if err := h.checklistRepo.Create(c); err != nil {
+			return result, fmt.Errorf("failed to create checklist %s: %w", bc.Title, err)
+		}
+		result.checklists = append(result.checklists, c.ID)
+
+		for i, bi := range bc.Items {
+			item := checklist.NewChecklistItem(c.ID, bi.Title, bi.Description, i+1)
+			item.Completed = bi.Completed
+			item.Category = bi.Category
+			item.Priority = bi.Priority
+			item.Notes = bi.Notes
+			if err := h.checklistItemRepo.Create(item); err != nil {
+				return result, fmt.Errorf("failed to create checklist item %s: %w", bi.Title, err)
+			}
+			result.items = append(result.items, item.ID)
+		}
+	}
+	return result, nil
+}
+
+// rollback apaga o que foi criado até o erro (os repositórios não compartilham transação)
+func (h *bundleHandler) rollback(r *importResult) error {
+	for _, id := range r.items {
+		if err := h.checklistItemRepo.Delete(id); err != nil {
+			return err
+		}
+	}
+	for _, id := range r.checklists {
+		if err := h.checklistRepo.Delete(id); err != nil {
+			return err
+		}
+	}
+	for _, id := range r.tasks {
+		if err := h.taskRepo.Delete(id); err != nil {
+			return err
+		}
+	}
+	if r.projectID != 0 {
+		return h.projectRepo.Delete(r.projectID)
+	}
+	return nil
+}
+
+// printBundlePlan mostra o que a importação criaria, sem gravar nada
+func printBundlePlan(b *bundle.Bundle) {
+	fmt.Println("Simulação (--dry-run): nada foi gravado.")
+	fmt.Println()
+	fmt.Printf("● Projeto: %s", b.Project.Name)
+	if b.Template {
+		fmt.Print(" (modelo)")
+	}
+	fmt.Println()
+
+	dependencies := 0
+	for _, t := range b.TaskOrder() {
+		indent := "  "
+		if t.Parent != 0 {
+			indent = "      "
+		}
+		fmt.Printf("%s○ [%d] %s [%s]", indent, t.Ref, t.Title, valueOr(t.Priority, "medium"))
+		if t.DueDate != "" {
+			fmt.Printf(" (prazo: %s)", t.DueDate)
+		}
+		if t.Estimate != "" {
+			fmt.Printf(" (estimativa: %s)", t.Estimate)
+		}
+		if len(t.DependsOn) > 0 {
+			fmt.Printf(" depende de %v", t.DependsOn)
+		}
+		fmt.Println()
+		dependencies += len(t.DependsOn)
+	}
+
+	items := 0
+	for _, c := range b.Checklists {
+		owner := "projeto"
+		if c.Task != 0 {
+			owner = fmt.Sprintf("tarefa [%d]", c.Task)
+		}
+		fmt.Printf("  ☐ Checklist %s (%s) — %d item(ns)\n", c.Title, owner, len(c.Items))
+		items += len(c.Items)
+	}
+
+	fmt.Printf("\nSeriam criados: 1 projeto, %d tarefa(s), %d dependência(s), %d checklist(s), %d item(ns)\n",
+		len(b.Tasks), dependencies, len(b.Checklists), items)
+}
+
+func valueOr(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
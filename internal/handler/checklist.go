@@ -11,35 +11,44 @@ import (
 )
 
 type ChecklistHandler interface {
-	CreateChecklist(title, description string, taskID, projectID *int) error
+	CreateChecklist(title, description string, taskID, projectID *int, isTemplate bool) error
 	CreateChecklistWithAI(topic, context string, numItems int, taskID, projectID *int) error
-	ListChecklists(taskID, projectID *int) error
+	ListChecklists(taskID, projectID *int, templatesOnly bool) error
 	ShowChecklist(id int) error
 	AddChecklistItem(checklistID int, title, description string) error
 	ToggleChecklistItem(id int) error
 	DeleteChecklistItem(id int) error
 	DeleteChecklist(id int) error
+	SetTemplate(id int, isTemplate bool) error
+	RunTemplate(ref string) error
+	ShowHistory(ref string, limit int) error
+	ReorderItems(checklistID int, itemIDs string) error
+	ImportBulk(csvPath string, projectID *int, checklistID int, title string) error
+	ExportChecklist(id int, asCSV bool, output string) error
 }
 
 type checklistHandler struct {
 	checklistRepo     repository.ChecklistRepository
 	checklistItemRepo repository.ChecklistItemRepository
+	checklistRunRepo  repository.ChecklistRunRepository
 	aiClient        ai.AIClient
 }
 
-func NewChecklistHandler(checklistRepo repository.ChecklistRepository, checklistItemRepo repository.ChecklistItemRepository) ChecklistHandler {
-	aiClient, _ := ai.NewAIClient()
+func NewChecklistHandler(checklistRepo repository.ChecklistRepository, checklistItemRepo repository.ChecklistItemRepository, checklistRunRepo repository.ChecklistRunRepository) ChecklistHandler {
+	aiClient, _ := ai.NewAIClientFor(ai.FeatureChecklist)
 	return &checklistHandler{
 		checklistRepo:     checklistRepo,
 		checklistItemRepo:  checklistItemRepo,
+		checklistRunRepo:   checklistRunRepo,
 		aiClient:           aiClient,
 	}
 }
 
-func (h *checklistHandler) CreateChecklist(title, description string, taskID, projectID *int) error {
+func (h *checklistHandler) CreateChecklist(title, description string, taskID, projectID *int, isTemplate bool) error {
 	c := checklist.NewChecklist(title, description)
 	c.TaskID = taskID
 	c.ProjectID = projectID
+	c.IsTemplate = isTemplate
 
 	if err := h.checklistRepo.Create(c); err != nil {
 		return fmt.Errorf("failed to create checklist: %w", err)
@@ -47,6 +56,9 @@ func (h *checklistHandler) CreateChecklist(title, description string, taskID, pr
 
 	fmt.Printf("Checklist criada com sucesso!\n")
 	fmt.Printf("● #%d  %s\n", c.ID, c.Title)
+	if c.IsTemplate {
+		fmt.Printf("   └── Template: execute com snip checklist run %d\n", c.ID)
+	}
 	return nil
 }
 
@@ -88,7 +100,7 @@ func (h *checklistHandler) CreateChecklistWithAI(topic, context string, numItems
 	return nil
 }
 
-func (h *checklistHandler) ListChecklists(taskID, projectID *int) error {
+func (h *checklistHandler) ListChecklists(taskID, projectID *int, templatesOnly bool) error {
 	var checklists []*checklist.Checklist
 	var err error
 
@@ -104,6 +116,16 @@ func (h *checklistHandler) ListChecklists(taskID, projectID *int) error {
 		return fmt.Errorf("failed to fetch checklists: %w", err)
 	}
 
+	if templatesOnly {
+		var templates []*checklist.Checklist
+		for _, c := range checklists {
+			if c.IsTemplate {
+				templates = append(templates, c)
+			}
+		}
+		checklists = templates
+	}
+
 	if len(checklists) == 0 {
 		fmt.Println("Nenhuma checklist encontrada.")
 		return nil
@@ -111,7 +133,11 @@ func (h *checklistHandler) ListChecklists(taskID, projectID *int) error {
 
 	fmt.Printf("Encontradas %d checklist(s):\n\n", len(checklists))
 	for _, c := range checklists {
-		fmt.Printf("● #%d %s\n", c.ID, c.Title)
+		fmt.Printf("● #%d %s", c.ID, c.Title)
+		if c.IsTemplate {
+			fmt.Print(" [template]")
+		}
+		fmt.Println()
 		if c.Description != "" {
 			desc := c.Description
 			if len(desc) > 60 {
@@ -131,7 +157,11 @@ func (h *checklistHandler) ShowChecklist(id int) error {
 		return fmt.Errorf("failed to fetch checklist: %w", err)
 	}
 
-	fmt.Printf("● #%d %s\n", c.ID, c.Title)
+	fmt.Printf("● #%d %s", c.ID, c.Title)
+	if c.IsTemplate {
+		fmt.Print(" [template]")
+	}
+	fmt.Println()
 	if c.Description != "" {
 		fmt.Printf("   └── %s\n\n", c.Description)
 	}
@@ -151,14 +181,26 @@ func (h *checklistHandler) ShowChecklist(id int) error {
 	completedCount := 0
 	for _, item := range items {
 		icon := "○"
-		if item.Completed {
+		if item.Completed && !c.IsTemplate {
 			icon = "✓"
 			completedCount++
 		}
-		fmt.Printf("  %s %s\n", icon, item.Title)
+		fmt.Printf("  %s #%d %s", icon, item.ID, item.Title)
+		if item.Category != "" || item.Priority != "" {
+			fmt.Printf(" [%s]", strings.Trim(item.Category+" · "+item.Priority, " ·"))
+		}
+		fmt.Println()
 		if item.Description != "" {
 			fmt.Printf("     └── %s\n", item.Description)
 		}
+		if item.Notes != "" {
+			fmt.Printf("     └── 📝 %s\n", item.Notes)
+		}
+	}
+
+	// Templates não são marcados: o progresso fica em cada execução
+	if c.IsTemplate {
+		return h.printLastRun(c.ID, len(items))
 	}
 
 	fmt.Printf("\nProgresso: %d/%d concluído(s)\n", completedCount, len(items))
@@ -171,7 +213,13 @@ func (h *checklistHandler) AddChecklistItem(checklistID int, title, description
 		return fmt.Errorf("failed to get checklist items: %w", err)
 	}
 
-	order := len(items) + 1
+	// Depois do último item, mesmo que haja buracos na numeração de item_order
+	order := 1
+	for _, existing := range items {
+		if existing.Order >= order {
+			order = existing.Order + 1
+		}
+	}
 	item := checklist.NewChecklistItem(checklistID, title, description, order)
 	if err := h.checklistItemRepo.Create(item); err != nil {
 		return fmt.Errorf("failed to create checklist item: %w", err)
@@ -0,0 +1,226 @@
+package handler
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/snip/internal/checklist"
+)
+
+// ImportBulk grava as linhas do CSV em massa. Sem checklistID cria uma
+// checklist nova (no projeto, se informado); com checklistID sincroniza a
+// checklist existente: linhas com id atualizam o item, linhas sem id são
+// associadas pelo título ou acrescentadas no fim. Itens ausentes do CSV não
+// são apagados.
+func (h *checklistHandler) ImportBulk(csvPath string, projectID *int, checklistID int, title string) error {
+	result, err := checklist.ProcessBulkChecklistFromCSV(csvPath)
+	if err != nil {
+		return fmt.Errorf("failed to read CSV: %w", err)
+	}
+
+	if checklistID != 0 {
+		return h.syncBulk(checklistID, result.Items)
+	}
+
+	if title == "" {
+		title = strings.TrimSuffix(filepath.Base(csvPath), filepath.Ext(csvPath))
+	}
+	c := checklist.NewChecklist(title, "Importada de "+filepath.Base(csvPath))
+	c.ProjectID = projectID
+	if err := h.checklistRepo.Create(c); err != nil {
+		return fmt.Errorf("failed to create checklist: %w", err)
+	}
+
+	// Os IDs de um CSV exportado pertencem à checklist de origem: aqui todos os itens são novos
+	for i, b := range result.Items {
+		item := checklist.NewItemFromBulk(c.ID, b, i+1)
+		if err := h.checklistItemRepo.Create(item); err != nil {
+			// Sem transação entre os repositórios: apagar a checklist remove a importação parcial
+			if deleteErr := h.deleteWithItems(c.ID); deleteErr != nil {
+				return fmt.Errorf("failed to create checklist item %s: %w (rollback: %v)", b.Title, err, deleteErr)
+			}
+			return fmt.Errorf("failed to create checklist item %s: %w", b.Title, err)
+		}
+	}
+
+	fmt.Printf("✅ Checklist importada: %d item(ns) (%d concluído(s), %d pendente(s))\n",
+		result.TotalItems, result.Completed, result.TotalItems-result.Completed)
+	fmt.Printf("● #%d  %s\n", c.ID, c.Title)
+	fmt.Printf("   └── Exporte para a planilha com: snip checklist export %d --csv\n", c.ID)
+	return nil
+}
+
+func (h *checklistHandler) syncBulk(checklistID int, rows []checklist.BulkChecklistItem) error {
+	c, err := h.checklistRepo.GetByID(checklistID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch checklist: %w", err)
+	}
+	items, err := h.checklistItemRepo.GetByChecklistID(c.ID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch checklist items: %w", err)
+	}
+
+	byID := make(map[int]*checklist.ChecklistItem, len(items))
+	order := 0
+	for _, item := range items {
+		byID[item.ID] = item
+		if item.Order > order {
+			order = item.Order
+		}
+	}
+
+	// Valida os IDs antes de gravar qualquer linha
+	seen := make(map[int]bool)
+	for _, b := range rows {
+		if b.ID == 0 {
+			continue
+		}
+		if _, ok := byID[b.ID]; !ok {
+			return fmt.Errorf("item #%d (%s) não pertence à checklist #%d", b.ID, b.Title, c.ID)
+		}
+		if seen[b.ID] {
+			return fmt.Errorf("item #%d aparece mais de uma vez no CSV", b.ID)
+		}
+		seen[b.ID] = true
+	}
+
+	// Linhas sem id (digitadas na planilha) casam com itens de mesmo título que
+	// nenhuma outra linha referencia, para que reimportar não duplique itens
+	byTitle := make(map[string][]*checklist.ChecklistItem)
+	for _, item := range items {
+		if !seen[item.ID] {
+			byTitle[item.Title] = append(byTitle[item.Title], item)
+		}
+	}
+	for i, b := range rows {
+		if candidates := byTitle[b.Title]; b.ID == 0 && len(candidates) > 0 {
+			rows[i].ID = candidates[0].ID
+			byTitle[b.Title] = candidates[1:]
+			seen[candidates[0].ID] = true
+		}
+	}
+
+	// Sem transação entre os repositórios: as alterações ficam registradas para
+	// serem desfeitas se alguma linha falhar no meio da sincronização
+	var created []int
+	var previous []checklist.ChecklistItem
+	fail := func(err error) error {
+		if undoErr := h.revertSync(created, previous); undoErr != nil {
+			return fmt.Errorf("%w (rollback: %v)", err, undoErr)
+		}
+		return err
+	}
+
+	updated, unchanged := 0, 0
+	for _, b := range rows {
+		if b.ID == 0 {
+			order++
+			item := checklist.NewItemFromBulk(c.ID, b, order)
+			if err := h.checklistItemRepo.Create(item); err != nil {
+				return fail(fmt.Errorf("failed to create checklist item %s: %w", b.Title, err))
+			}
+			created = append(created, item.ID)
+			continue
+		}
+
+		item := byID[b.ID]
+		before := *item
+		item.ApplyBulk(b)
+		if *item == before {
+			unchanged++
+			continue
+		}
+		if err := h.checklistItemRepo.Update(item); err != nil {
+			return fail(fmt.Errorf("failed to update checklist item %s: %w", b.Title, err))
+		}
+		previous = append(previous, before)
+		updated++
+	}
+
+	fmt.Printf("✅ Checklist #%d %s sincronizada\n", c.ID, c.Title)
+	fmt.Printf("  Criados: %d · Atualizados: %d · Sem alteração: %d\n", len(created), updated, unchanged)
+	if missing := len(items) - len(seen); missing > 0 {
+		fmt.Printf("  %d item(ns) da checklist não estavam no CSV e foram mantidos\n", missing)
+	}
+	return nil
+}
+
+// revertSync desfaz uma sincronização interrompida: apaga os itens criados e
+// grava de volta os valores anteriores dos atualizados
+func (h *checklistHandler) revertSync(created []int, previous []checklist.ChecklistItem) error {
+	for _, id := range created {
+		if err := h.checklistItemRepo.Delete(id); err != nil {
+			return err
+		}
+	}
+	for i := range previous {
+		if err := h.checklistItemRepo.Update(&previous[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteWithItems apaga a checklist e os itens dela
+func (h *checklistHandler) deleteWithItems(checklistID int) error {
+	items, err := h.checklistItemRepo.GetByChecklistID(checklistID)
+	if err != nil {
+		return err
+	}
+	for _, item := range items {
+		if err := h.checklistItemRepo.Delete(item.ID); err != nil {
+			return err
+		}
+	}
+	return h.checklistRepo.Delete(checklistID)
+}
+
+// ExportChecklist grava os itens no formato do CSV em massa (asCSV) ou no
+// relatório markdown do bulk. Com output "-" o CSV vai para a saída padrão.
+func (h *checklistHandler) ExportChecklist(id int, asCSV bool, output string) error {
+	c, err := h.checklistRepo.GetByID(id)
+	if err != nil {
+		return fmt.Errorf("failed to fetch checklist: %w", err)
+	}
+	items, err := h.checklistItemRepo.GetByChecklistID(c.ID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch checklist items: %w", err)
+	}
+
+	if !asCSV {
+		result := &checklist.BulkChecklistResult{GeneratedAt: time.Now()}
+		for _, item := range items {
+			result.AddItem(item.ToBulk())
+		}
+		path, err := checklist.ExportBulkChecklistToMarkdown(result, output)
+		if err != nil {
+			return fmt.Errorf("failed to export checklist: %w", err)
+		}
+		fmt.Printf("📄 Checklist #%d exportada para: %s\n", c.ID, path)
+		return nil
+	}
+
+	if output == "-" {
+		return checklist.WriteBulkChecklistCSV(os.Stdout, items)
+	}
+	if output == "" {
+		output = fmt.Sprintf("checklist_%d.csv", c.ID)
+	}
+
+	file, err := os.Create(output)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer file.Close()
+
+	if err := checklist.WriteBulkChecklistCSV(file, items); err != nil {
+		return fmt.Errorf("failed to export checklist: %w", err)
+	}
+
+	fmt.Printf("✅ %d item(ns) de #%d %s exportados para: %s\n", len(items), c.ID, c.Title, output)
+	fmt.Printf("   Reimporte com: snip checklist bulk --import --csv %s --checklist %d\n", output, c.ID)
+	return nil
+}
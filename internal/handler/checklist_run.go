@@ -0,0 +1,289 @@
+package handler
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/snip/internal/checklist"
+	"github.com/snip/internal/repository"
+	"github.com/snip/internal/task"
+)
+
+// maxHistoryTitle limita a coluna de itens do histórico
+const maxHistoryTitle = 40
+
+func (h *checklistHandler) SetTemplate(id int, isTemplate bool) error {
+	c, err := h.checklistRepo.GetByID(id)
+	if err != nil {
+		return fmt.Errorf("failed to fetch checklist: %w", err)
+	}
+	if err := h.checklistRepo.SetTemplate(id, isTemplate); err != nil {
+		return fmt.Errorf("failed to update checklist: %w", err)
+	}
+
+	if isTemplate {
+		fmt.Printf("● #%d %s agora é um template\n", c.ID, c.Title)
+		fmt.Printf("   └── Execute com: snip checklist run %d\n", c.ID)
+	} else {
+		fmt.Printf("● #%d %s não é mais um template\n", c.ID, c.Title)
+	}
+	return nil
+}
+
+// resolveTemplate busca o template pelo ID ou pelo título
+func (h *checklistHandler) resolveTemplate(ref string) (*checklist.Checklist, error) {
+	var c *checklist.Checklist
+	var err error
+	if id, convErr := strconv.Atoi(ref); convErr == nil {
+		c, err = h.checklistRepo.GetByID(id)
+	} else {
+		c, err = h.checklistRepo.GetTemplateByTitle(strings.TrimSpace(ref))
+	}
+	if errors.Is(err, repository.ErrChecklistNotFound) {
+		return nil, fmt.Errorf("template não encontrado: %s", ref)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch checklist: %w", err)
+	}
+	if !c.IsTemplate {
+		return nil, fmt.Errorf("checklist #%d não é um template (converta com: snip checklist template %d)", c.ID, c.ID)
+	}
+	return c, nil
+}
+
+// RunTemplate percorre os itens do template pedindo o resultado e uma nota de
+// cada um. Os resultados são gravados item a item, então uma execução
+// interrompida (q ou fim da entrada) guarda o que já foi respondido.
+func (h *checklistHandler) RunTemplate(ref string) error {
+	c, err := h.resolveTemplate(ref)
+	if err != nil {
+		return err
+	}
+
+	items, err := h.checklistItemRepo.GetByChecklistID(c.ID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch checklist items: %w", err)
+	}
+	if len(items) == 0 {
+		return fmt.Errorf("template #%d não tem itens (adicione com: snip checklist item-add %d <título>)", c.ID, c.ID)
+	}
+
+	fmt.Printf("▶ Executando #%d %s (%d itens)\n", c.ID, c.Title, len(items))
+	fmt.Println("  o ok · f falha · s pular   (q encerra)")
+
+	reader := bufio.NewReader(os.Stdin)
+	run := checklist.NewRun(c.ID)
+	for i, item := range items {
+		fmt.Printf("\n[%d/%d] #%d %s\n", i+1, len(items), item.ID, item.Title)
+		if item.Description != "" {
+			fmt.Printf("     └── %s\n", item.Description)
+		}
+
+		result, quit := readRunResult(reader)
+		if quit {
+			break
+		}
+		fmt.Print("   Notas (Enter para nenhuma): ")
+		notes, _ := reader.ReadString('\n')
+
+		// A execução só é gravada a partir do primeiro resultado
+		if run.ID == 0 {
+			if err := h.checklistRunRepo.Create(run); err != nil {
+				return fmt.Errorf("failed to create checklist run: %w", err)
+			}
+		}
+		runItem := &checklist.RunItem{
+			ItemID: item.ID,
+			Title:  item.Title,
+			Result: result,
+			Notes:  strings.TrimSpace(notes),
+		}
+		if err := h.checklistRunRepo.AddResult(run, runItem); err != nil {
+			return fmt.Errorf("failed to save item result: %w", err)
+		}
+	}
+
+	fmt.Println()
+	if run.ID == 0 {
+		fmt.Println("Nenhum resultado registrado.")
+		return nil
+	}
+	if len(run.Results) == len(items) {
+		if err := h.checklistRunRepo.Finish(run, time.Now()); err != nil {
+			return fmt.Errorf("failed to finish checklist run: %w", err)
+		}
+	}
+
+	fmt.Printf("✓ Execução #%d: %s\n", run.ID, runSummary(run, len(items)))
+	fmt.Printf("  Compare com: snip checklist history %d\n", c.ID)
+	return nil
+}
+
+// readRunResult lê o resultado do item, repetindo a pergunta até receber um valor válido
+func readRunResult(reader *bufio.Reader) (checklist.RunResult, bool) {
+	for {
+		fmt.Print("   Resultado [o/f/s]: ")
+		input, err := reader.ReadString('\n')
+		input = strings.TrimSpace(strings.ToLower(input))
+		if input == "q" || (err != nil && input == "") {
+			return "", true
+		}
+
+		result, parseErr := checklist.ParseRunResult(input)
+		if parseErr == nil {
+			return result, false
+		}
+		fmt.Println("   Responda o (ok), f (falha) ou s (pular)")
+	}
+}
+
+func runSummary(run *checklist.Run, total int) string {
+	summary := fmt.Sprintf("%d ok · %d falha(s) · %d pulado(s)",
+		run.Count(checklist.ResultOK), run.Count(checklist.ResultFailed), run.Count(checklist.ResultSkipped))
+	if run.FinishedAt == nil {
+		summary += fmt.Sprintf(" (interrompida em %d/%d)", len(run.Results), total)
+	} else {
+		summary += " em " + task.FormatDuration(run.FinishedAt.Sub(run.StartedAt))
+	}
+	return summary
+}
+
+func (h *checklistHandler) printLastRun(checklistID int, total int) error {
+	runs, err := h.checklistRunRepo.GetByChecklistID(checklistID, 1)
+	if err != nil {
+		return fmt.Errorf("failed to fetch checklist runs: %w", err)
+	}
+	if len(runs) == 0 {
+		fmt.Printf("\nNenhuma execução ainda. Execute com: snip checklist run %d\n", checklistID)
+		return nil
+	}
+
+	last := runs[0]
+	fmt.Printf("\nÚltima execução: #%d em %s — %s\n", last.ID, last.StartedAt.Local().Format("2006-01-02 15:04"), runSummary(last, total))
+	fmt.Printf("  Histórico: snip checklist history %d\n", checklistID)
+	return nil
+}
+
+// ShowHistory compara as últimas execuções do template lado a lado, item a item
+func (h *checklistHandler) ShowHistory(ref string, limit int) error {
+	c, err := h.resolveTemplate(ref)
+	if err != nil {
+		return err
+	}
+
+	runs, err := h.checklistRunRepo.GetByChecklistID(c.ID, limit)
+	if err != nil {
+		return fmt.Errorf("failed to fetch checklist runs: %w", err)
+	}
+	if len(runs) == 0 {
+		fmt.Printf("Nenhuma execução de #%d %s. Execute com: snip checklist run %d\n", c.ID, c.Title, c.ID)
+		return nil
+	}
+
+	items, err := h.checklistItemRepo.GetByChecklistID(c.ID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch checklist items: %w", err)
+	}
+	rows := checklist.History(items, runs)
+
+	titles := make([]string, len(rows))
+	titleWidth := len([]rune("Item"))
+	for i, row := range rows {
+		titles[i] = row.Title
+		if row.Removed {
+			titles[i] += " (removido)"
+		}
+		if w := len([]rune(titles[i])); w > titleWidth {
+			titleWidth = w
+		}
+	}
+	if titleWidth > maxHistoryTitle {
+		titleWidth = maxHistoryTitle
+	}
+
+	// Cada coluna é uma execução, identificada pelo ID
+	widths := make([]int, len(runs))
+	fmt.Printf("Histórico de #%d %s — %d execução(ões)\n\n", c.ID, c.Title, len(runs))
+	fmt.Print("  " + padRight("Item", titleWidth))
+	for i, run := range runs {
+		label := fmt.Sprintf("#%d", run.ID)
+		widths[i] = len(label) + 2
+		fmt.Printf("%*s", widths[i], label)
+	}
+	fmt.Println()
+
+	var regressed []string
+	for i, row := range rows {
+		fmt.Print("  " + padRight(titles[i], titleWidth))
+		for j, result := range row.Results {
+			fmt.Printf("%*s", widths[j], result.Icon())
+		}
+		if row.Regressed() {
+			fmt.Print("  ⚠ regrediu")
+			regressed = append(regressed, row.Title)
+		}
+		fmt.Println()
+	}
+	fmt.Println("\n  Legenda: ✓ ok · ✗ falha · – pulado · · sem registro")
+
+	fmt.Println("\nExecuções:")
+	for _, run := range runs {
+		fmt.Printf("  #%d  %s  %s\n", run.ID, run.StartedAt.Local().Format("2006-01-02 15:04"), runSummary(run, len(items)))
+	}
+
+	last := runs[len(runs)-1]
+	var notes []*checklist.RunItem
+	for _, result := range last.Results {
+		if result.Notes != "" {
+			notes = append(notes, result)
+		}
+	}
+	if len(notes) > 0 {
+		fmt.Printf("\nNotas da execução #%d:\n", last.ID)
+		for _, result := range notes {
+			fmt.Printf("  %s %s: %s\n", result.Result.Icon(), result.Title, result.Notes)
+		}
+	}
+
+	if len(regressed) > 0 {
+		fmt.Printf("\n⚠ %d item(ns) falharam depois de passar na execução anterior\n", len(regressed))
+	}
+	return nil
+}
+
+// ReorderItems grava a nova ordem dos itens: os IDs informados primeiro, na
+// ordem dada, e os demais em seguida na ordem atual
+func (h *checklistHandler) ReorderItems(checklistID int, itemIDs string) error {
+	c, err := h.checklistRepo.GetByID(checklistID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch checklist: %w", err)
+	}
+
+	ids, err := parseIDs(itemIDs)
+	if err != nil {
+		return err
+	}
+	items, err := h.checklistItemRepo.GetByChecklistID(c.ID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch checklist items: %w", err)
+	}
+
+	ordered, err := checklist.Reorder(items, ids)
+	if err != nil {
+		return err
+	}
+	if err := h.checklistItemRepo.UpdateOrder(ordered); err != nil {
+		return fmt.Errorf("failed to reorder checklist items: %w", err)
+	}
+
+	fmt.Printf("Ordem atualizada em #%d %s:\n", c.ID, c.Title)
+	for _, item := range ordered {
+		fmt.Printf("  %d. #%d %s\n", item.Order, item.ID, item.Title)
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package handler
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// clipboardCommands lista, por sistema, os utilitários tentados em ordem
+var clipboardCommands = map[string][][]string{
+	"darwin":  {{"pbcopy"}},
+	"windows": {{"clip"}},
+	"linux": {
+		{"wl-copy"},
+		{"xclip", "-selection", "clipboard"},
+		{"xsel", "--clipboard", "--input"},
+	},
+}
+
+// copyToClipboard envia o texto para a área de transferência do sistema
+func copyToClipboard(text string) error {
+	candidates, ok := clipboardCommands[runtime.GOOS]
+	if !ok {
+		candidates = clipboardCommands["linux"]
+	}
+
+	var names []string
+	for _, candidate := range candidates {
+		names = append(names, candidate[0])
+		if _, err := exec.LookPath(candidate[0]); err != nil {
+			continue
+		}
+
+		cmd := exec.Command(candidate[0], candidate[1:]...)
+		cmd.Stdin = strings.NewReader(text)
+		if output, err := cmd.CombinedOutput(); err != nil {
+			return fmt.Errorf("%s failed: %v %s", candidate[0], err, strings.TrimSpace(string(output)))
+		}
+		return nil
+	}
+
+	return fmt.Errorf("no clipboard utility found (install one of: %s)", strings.Join(names, ", "))
+}
@@ -6,6 +6,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/snip/internal/ai"
 	"github.com/snip/internal/dbanalysis"
 	"github.com/snip/internal/exporter"
 	"github.com/snip/internal/integration"
@@ -177,6 +178,10 @@ func (h *dbAnalysisHandler) GetAnalysis(idStr string, verbose bool) error {
 		fmt.Println(strings.Repeat("─", 70) + "\n")
 		fmt.Println(analysis.AIInsights)
 		fmt.Println()
+		if strategy := insightsStrategy(analysis); strategy != "" {
+			fmt.Println(strategy)
+			fmt.Println()
+		}
 	}
 
 	if analysis.ErrorMessage != "" {
@@ -212,7 +217,7 @@ func (h *dbAnalysisHandler) RunAnalysis(idStr string, exportFilename string, cre
 	}
 
 	fmt.Printf("Executando análise #%d: %s\n", analysis.ID, analysis.Title)
-	fmt.Println("Aguarde...\n")
+	fmt.Print("Aguarde...\n\n")
 
 	// Deserializar configuração
 	config, err := dbanalysis.DeserializeConnectionConfig(analysis.ConnectionConfig)
@@ -271,6 +276,10 @@ func (h *dbAnalysisHandler) RunAnalysis(idStr string, exportFilename string, cre
 		fmt.Println(strings.Repeat("─", 70) + "\n")
 		fmt.Println(analysis.AIInsights)
 		fmt.Println()
+		if strategy := insightsStrategy(analysis); strategy != "" {
+			fmt.Println(strategy)
+			fmt.Println()
+		}
 	}
 
 	// Exportar para markdown se solicitado
@@ -337,6 +346,19 @@ func (h *dbAnalysisHandler) GetAnalysisByID(id int) (*dbanalysis.DBAnalysis, err
 	return analysis, nil
 }
 
+// insightsStrategy descreve como os insights foram gerados (metadados da análise)
+func insightsStrategy(analysis *dbanalysis.DBAnalysis) string {
+	strategy := analysis.Metadata["insights_strategy"]
+	if strategy == "" {
+		return ""
+	}
+	if strategy == ai.StrategyMapReduce {
+		return fmt.Sprintf("ℹ️  Estratégia: %s (%s partes, ~%s tokens; janela do modelo: %s)",
+			strategy, analysis.Metadata["insights_chunks"], analysis.Metadata["insights_estimated_tokens"], analysis.Metadata["insights_context_window"])
+	}
+	return fmt.Sprintf("ℹ️  Estratégia: %s (~%s tokens)", strategy, analysis.Metadata["insights_estimated_tokens"])
+}
+
 // ExportAnalysisToMarkdown exporta uma análise para markdown
 func (h *dbAnalysisHandler) ExportAnalysisToMarkdown(idStr string, filename string) (string, error) {
 	id, err := strconv.Atoi(idStr)
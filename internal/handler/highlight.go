@@ -0,0 +1,74 @@
+package handler
+
+import (
+	"os"
+	"strings"
+
+	"github.com/alecthomas/chroma/quick"
+	"github.com/snip/internal/snippet"
+)
+
+const highlightStyle = "monokai"
+
+// colorEnabled desativa cores quando a saída não é um terminal ou NO_COLOR está definido
+func colorEnabled() bool {
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	info, err := os.Stdout.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// highlightCode colore o código com a linguagem informada
+func highlightCode(code, language string) string {
+	if !colorEnabled() {
+		return code
+	}
+
+	var sb strings.Builder
+	if err := quick.Highlight(&sb, code, snippet.NormalizeLanguage(language), "terminal256", highlightStyle); err != nil {
+		return code
+	}
+	return strings.TrimRight(sb.String(), "\n")
+}
+
+// highlightMarkdown mantém o texto e colore os blocos cercados; blocos sem
+// linguagem declarada usam a linguagem padrão do snippet
+func highlightMarkdown(content, language string) string {
+	var out []string
+	var code []string
+	fence := ""
+	blockLanguage := ""
+
+	for _, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+
+		if fence == "" {
+			if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+				fence = trimmed[:3]
+				blockLanguage = language
+				if info := strings.Fields(strings.TrimLeft(trimmed, fence[:1])); len(info) > 0 {
+					blockLanguage = info[0]
+				}
+				code = nil
+				out = append(out, line)
+				continue
+			}
+			out = append(out, line)
+			continue
+		}
+
+		if strings.HasPrefix(trimmed, fence) && strings.Trim(trimmed, fence[:1]) == "" {
+			out = append(out, highlightCode(strings.Join(code, "\n"), blockLanguage), line)
+			fence = ""
+			continue
+		}
+		code = append(code, line)
+	}
+
+	if fence != "" && len(code) > 0 {
+		out = append(out, highlightCode(strings.Join(code, "\n"), blockLanguage))
+	}
+
+	return strings.Join(out, "\n")
+}
@@ -40,26 +40,38 @@ type Handler interface {
 	ImproveSearchWithAI(query string) error
 	AskAI(question string) error
 	GenerateCodeWithAI(language string, description string, context string) error
+	TagNotesWithAI(idStr string, all bool, assumeYes bool) error
+	SetAutoTag(enabled bool)
+	SetLinkContext(projectID, taskID int)
+	LinkNote(idStr string, kind string, targetIDStr string) error
+	UnlinkNote(idStr string, kind string, targetIDStr string) error
 }
 
 type handler struct {
 	noteRepo      repository.NoteRepository
 	tagRepo       repository.TagRepository
+	linkRepo      repository.NoteLinkRepository
 	validator     *validation.Validator
 	editorHandler *EditorHandler
 	dateFormat    string
 	aiClient    ai.AIClient
+	autoTag     bool
+	// Contexto de projeto/tarefa: vincula notas novas e filtra list e find
+	projectID int
+	taskID    int
 }
 
-func NewHandler(noteRepo repository.NoteRepository, tagRepo repository.TagRepository) Handler {
-	aiClient, _ := ai.NewAIClient()
+func NewHandler(noteRepo repository.NoteRepository, tagRepo repository.TagRepository, linkRepo repository.NoteLinkRepository) Handler {
+	aiClient, _ := ai.NewAIClientFor(ai.FeatureNotes)
 	return &handler{
 		noteRepo:      noteRepo,
 		tagRepo:       tagRepo,
+		linkRepo:      linkRepo,
 		validator:     validation.NewValidator(),
 		dateFormat:    "2006-01-02 15:04:05",
 		editorHandler: NewEditorHandler(),
 		aiClient:      aiClient,
+		autoTag:       ai.IsAutoTagEnabled(),
 	}
 }
 
@@ -68,6 +80,14 @@ func (h *handler) CreateNote(title string, message *string, tag *string) error {
 		return err
 	}
 
+	// O projeto e a tarefa são conferidos antes de abrir o editor
+	links := h.contextLinks()
+	for _, link := range links {
+		if err := h.linkRepo.CheckTarget(link.Kind, link.TargetID); err != nil {
+			return err
+		}
+	}
+
 	contentStr, err := HandleMessage(message, h)
 	if err != nil {
 		return err
@@ -84,8 +104,21 @@ func (h *handler) CreateNote(title string, message *string, tag *string) error {
 		}
 	}
 
+	for _, link := range links {
+		if err := h.linkRepo.Link(newNote.ID, link.Kind, link.TargetID); err != nil {
+			return fmt.Errorf("failed to link note: %w", err)
+		}
+	}
+
 	fmt.Printf("Note created successfully!\n")
 	fmt.Printf("● #%d  %s\n", newNote.ID, newNote.Title)
+	if len(links) > 0 {
+		if err := h.printLinks(newNote.ID); err != nil {
+			return err
+		}
+	}
+
+	h.runAutoTag(newNote.ID)
 
 	return nil
 }
@@ -106,6 +139,20 @@ func (h *handler) ListNotes(isAsc, verbose bool, tag *string) error {
 		return fmt.Errorf("failed to fetch notes: %w", err)
 	}
 
+	linked, err := h.contextNoteIDs()
+	if err != nil {
+		return err
+	}
+	if linked != nil {
+		var filtered []*note.NoteWithTags
+		for _, n := range notes {
+			if linked[n.ID] {
+				filtered = append(filtered, n)
+			}
+		}
+		notes = filtered
+	}
+
 	if len(notes) == 0 {
 		fmt.Println("No notes found.")
 		return nil
@@ -137,6 +184,9 @@ func (h *handler) ListNotes(isAsc, verbose bool, tag *string) error {
 		}
 
 		if verbose {
+			if note.Summary != "" {
+				fmt.Fprintf(writer, "  └─ Summary: %s\n", note.Summary)
+			}
 			fmt.Fprintf(writer, "  └─ Created: %s\n", note.CreatedAt.Format(h.dateFormat))
 			fmt.Fprintf(writer, "  └─ Updated: %s\n", note.UpdatedAt.Format(h.dateFormat))
 		}
@@ -160,11 +210,18 @@ func (h *handler) GetNote(idStr string, verbose bool, render bool) error {
 	}
 	tags := strings.Join(note.Tags, ", ")
 
-	fmt.Printf("● #%d %s [%s]\n", note.ID, note.Title, tags)
+	if note.Language != "" {
+		fmt.Printf("● #%d %s [%s] (snippet: %s)\n", note.ID, note.Title, tags, note.Language)
+	} else {
+		fmt.Printf("● #%d %s [%s]\n", note.ID, note.Title, tags)
+	}
 
 	if note.Content != "" {
 		if render {
 			fmt.Println("\n" + renderMarkdownContent(note.Content))
+		} else if note.Language != "" {
+			// Snippets: mantém o código sem quebra de linha e colorido pela linguagem
+			fmt.Println("\n" + highlightMarkdown(note.Content, note.Language))
 		} else {
 			lines := strings.Split(strings.TrimRight(wordwrap.WrapString(note.Content, lineLimit), "\n"), "\n")
 			fmt.Printf("  └── ")
@@ -179,7 +236,14 @@ func (h *handler) GetNote(idStr string, verbose bool, render bool) error {
 		}
 	}
 
+	if err := h.printLinks(note.ID); err != nil {
+		return err
+	}
+
 	if verbose {
+		if note.Summary != "" {
+			fmt.Printf("  └─ Summary: %s\n", note.Summary)
+		}
 		fmt.Printf("  └─ Created: %s\n", note.CreatedAt.Format(h.dateFormat))
 		fmt.Printf("  └─ Updated: %s\n", note.UpdatedAt.Format(h.dateFormat))
 	}
@@ -193,6 +257,20 @@ func (h *handler) FindNotes(term string) error {
 		return fmt.Errorf("failed to search notes: %w", err)
 	}
 
+	linked, err := h.contextNoteIDs()
+	if err != nil {
+		return err
+	}
+	if linked != nil {
+		var filtered []*note.Note
+		for _, n := range notes {
+			if linked[n.ID] {
+				filtered = append(filtered, n)
+			}
+		}
+		notes = filtered
+	}
+
 	if len(notes) == 0 {
 		fmt.Println("No notes found.")
 		return nil
@@ -287,6 +365,9 @@ func (h *handler) UpdateNote(idStr string, title string) error {
 	}
 
 	fmt.Printf("Note updated successfully!\n")
+
+	h.runAutoTag(id)
+
 	return nil
 }
 
@@ -681,4 +762,181 @@ func (h *handler) GenerateCodeWithAI(language string, description string, contex
 
 	fmt.Println("\n" + renderMarkdownContent(code))
 	return nil
-}
\ No newline at end of file
+}
+
+func (h *handler) SetAutoTag(enabled bool) {
+	h.autoTag = enabled
+}
+
+// runAutoTag é o hook opcional de create/update: falhas da IA não desfazem a
+// operação, apenas geram um aviso
+func (h *handler) runAutoTag(id int) {
+	if !h.autoTag || h.aiClient == nil {
+		return
+	}
+
+	existing, err := h.existingTagNames()
+	if err == nil {
+		var n *note.NoteWithTags
+		n, err = h.noteRepo.GetByID(id)
+		if err == nil {
+			fmt.Println()
+			err = h.tagNote(n, existing, false, bufio.NewReader(os.Stdin))
+		}
+	}
+
+	if err != nil {
+		fmt.Printf("Warning: AI tagging failed: %v\n", err)
+	}
+}
+
+func (h *handler) TagNotesWithAI(idStr string, all bool, assumeYes bool) error {
+	if h.aiClient == nil {
+		return fmt.Errorf("AI client not available")
+	}
+
+	var notes []*note.NoteWithTags
+	if all {
+		allNotes, err := h.noteRepo.GetAll(true, 0)
+		if err != nil {
+			return fmt.Errorf("failed to fetch notes: %w", err)
+		}
+		notes = allNotes
+	} else {
+		id, err := strconv.Atoi(idStr)
+		if err != nil {
+			return fmt.Errorf("invalid note ID: %s", idStr)
+		}
+		n, err := h.noteRepo.GetByID(id)
+		if err != nil {
+			return fmt.Errorf("failed to fetch note: %w", err)
+		}
+		notes = append(notes, n)
+	}
+
+	if len(notes) == 0 {
+		fmt.Println("No notes found.")
+		return nil
+	}
+
+	existing, err := h.existingTagNames()
+	if err != nil {
+		return fmt.Errorf("failed to fetch tags: %w", err)
+	}
+
+	reader := bufio.NewReader(os.Stdin)
+	failed := 0
+	for i, n := range notes {
+		if all {
+			fmt.Printf("[%d/%d] ", i+1, len(notes))
+		}
+		if err := h.tagNote(n, existing, assumeYes, reader); err != nil {
+			if !all {
+				return err
+			}
+			failed++
+			fmt.Printf("  Error: %v\n\n", err)
+			continue
+		}
+
+		// Tags criadas nesta execução passam a ser sugeridas para as próximas notas
+		existing = mergeTagNames(existing, n.Tags)
+	}
+
+	if all {
+		fmt.Printf("✓ %d note(s) processed, %d failed\n", len(notes)-failed, failed)
+	}
+	return nil
+}
+
+// tagNote gera o resumo (gravado direto) e aplica as tags novas após confirmação
+func (h *handler) tagNote(n *note.NoteWithTags, existing []string, assumeYes bool, reader *bufio.Reader) error {
+	fmt.Printf("● #%d %s\n", n.ID, n.Title)
+
+	suggestion, err := ai.SuggestNoteTags(h.aiClient, n.Title, n.Content, existing)
+	if err != nil {
+		return fmt.Errorf("failed to summarize note with AI: %w", err)
+	}
+
+	if err := h.noteRepo.UpdateSummary(n.ID, suggestion.Summary); err != nil {
+		return fmt.Errorf("failed to save summary: %w", err)
+	}
+	n.Summary = suggestion.Summary
+	fmt.Printf("  └─ Summary: %s\n", suggestion.Summary)
+
+	newTags := missingTags(suggestion.Tags, n.Tags)
+	if len(newTags) == 0 {
+		fmt.Printf("  └─ No new tags suggested\n\n")
+		return nil
+	}
+
+	labels := make([]string, 0, len(newTags))
+	for _, t := range newTags {
+		if containsTag(existing, t) {
+			labels = append(labels, t)
+		} else {
+			labels = append(labels, t+" (new)")
+		}
+	}
+	fmt.Printf("  └─ Suggested tags: %s\n", strings.Join(labels, ", "))
+
+	if !assumeYes {
+		fmt.Print("  Apply suggested tags? [y/N]: ")
+		answer, _ := reader.ReadString('\n')
+		answer = strings.ToLower(strings.TrimSpace(answer))
+		if answer != "y" && answer != "yes" && answer != "s" && answer != "sim" {
+			fmt.Printf("  Tags not applied.\n\n")
+			return nil
+		}
+	}
+
+	tags := strings.Join(newTags, " ")
+	if err := h.AssociateTagsWithNote(&tags, n.ID); err != nil {
+		return fmt.Errorf("failed to associate tags with note: %w", err)
+	}
+	n.Tags = append(n.Tags, newTags...)
+
+	fmt.Printf("  ✓ Tags applied: %s\n\n", strings.Join(newTags, ", "))
+	return nil
+}
+
+func (h *handler) existingTagNames() ([]string, error) {
+	tags, err := h.tagRepo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(tags))
+	for _, t := range tags {
+		names = append(names, t.Name)
+	}
+	return names, nil
+}
+
+func missingTags(suggested, current []string) []string {
+	var result []string
+	for _, t := range suggested {
+		if !containsTag(current, t) {
+			result = append(result, t)
+		}
+	}
+	return result
+}
+
+func mergeTagNames(existing, tags []string) []string {
+	for _, t := range tags {
+		if !containsTag(existing, t) {
+			existing = append(existing, t)
+		}
+	}
+	return existing
+}
+
+func containsTag(tags []string, name string) bool {
+	for _, t := range tags {
+		if strings.EqualFold(t, name) {
+			return true
+		}
+	}
+	return false
+}
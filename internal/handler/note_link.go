@@ -0,0 +1,122 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/snip/internal/note"
+	"github.com/snip/internal/repository"
+)
+
+// SetLinkContext define o projeto e/ou a tarefa (0 = nenhum) usados para
+// vincular notas novas e filtrar list e find
+func (h *handler) SetLinkContext(projectID, taskID int) {
+	h.projectID = projectID
+	h.taskID = taskID
+}
+
+// contextLinks retorna os vínculos que uma nota nova recebe do contexto
+func (h *handler) contextLinks() []note.Link {
+	var links []note.Link
+	if h.projectID != 0 {
+		links = append(links, note.Link{Kind: note.LinkProject, TargetID: h.projectID})
+	}
+	if h.taskID != 0 {
+		links = append(links, note.Link{Kind: note.LinkTask, TargetID: h.taskID})
+	}
+	return links
+}
+
+// contextNoteIDs retorna as notas do contexto: as vinculadas ao projeto (ou a
+// uma das tarefas dele) e à tarefa. Retorna nil quando não há contexto.
+func (h *handler) contextNoteIDs() (map[int]bool, error) {
+	var ids map[int]bool
+	for _, link := range h.contextLinks() {
+		if err := h.linkRepo.CheckTarget(link.Kind, link.TargetID); err != nil {
+			return nil, err
+		}
+
+		var notes []*note.LinkedNote
+		var err error
+		if link.Kind == note.LinkProject {
+			notes, err = h.linkRepo.GetNotesByProject(link.TargetID)
+		} else {
+			notes, err = h.linkRepo.GetNotesByTask(link.TargetID)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch linked notes: %w", err)
+		}
+
+		// Projeto e tarefa juntos: apenas as notas presentes nos dois
+		matched := make(map[int]bool, len(notes))
+		for _, n := range notes {
+			if ids == nil || ids[n.ID] {
+				matched[n.ID] = true
+			}
+		}
+		ids = matched
+	}
+	return ids, nil
+}
+
+func (h *handler) printLinks(noteID int) error {
+	links, err := h.linkRepo.GetLinks(noteID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch note links: %w", err)
+	}
+	for _, link := range links {
+		fmt.Printf("  └─ Linked to %s #%d %s\n", link.Kind, link.TargetID, link.Title)
+	}
+	return nil
+}
+
+// parseLink valida os argumentos de link/unlink: nota, tipo e alvo
+func (h *handler) parseLink(idStr string, kind string, targetIDStr string) (int, int, error) {
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid note ID: %s", idStr)
+	}
+	if kind != note.LinkProject && kind != note.LinkTask {
+		return 0, 0, fmt.Errorf("unknown link type: %s (use project or task)", kind)
+	}
+	targetID, err := strconv.Atoi(targetIDStr)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid %s ID: %s", kind, targetIDStr)
+	}
+	if err := h.noteRepo.CheckByID(id); err != nil {
+		return 0, 0, fmt.Errorf("failed to fetch note: %w", err)
+	}
+	return id, targetID, nil
+}
+
+func (h *handler) LinkNote(idStr string, kind string, targetIDStr string) error {
+	id, targetID, err := h.parseLink(idStr, kind, targetIDStr)
+	if err != nil {
+		return err
+	}
+
+	if err := h.linkRepo.Link(id, kind, targetID); err != nil {
+		return fmt.Errorf("failed to link note: %w", err)
+	}
+
+	fmt.Printf("Note #%d linked to %s #%d\n", id, kind, targetID)
+	return h.printLinks(id)
+}
+
+func (h *handler) UnlinkNote(idStr string, kind string, targetIDStr string) error {
+	id, targetID, err := h.parseLink(idStr, kind, targetIDStr)
+	if err != nil {
+		return err
+	}
+
+	if err := h.linkRepo.Unlink(id, kind, targetID); err != nil {
+		if errors.Is(err, repository.ErrLinkNotFound) {
+			return fmt.Errorf("note #%d is not linked to %s #%d", id, kind, targetID)
+		}
+		return fmt.Errorf("failed to unlink note: %w", err)
+	}
+
+	fmt.Printf("Note #%d unlinked from %s #%d\n", id, kind, targetID)
+	return nil
+}
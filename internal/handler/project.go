@@ -2,11 +2,19 @@ package handler
 
 import (
 	"fmt"
+	"math"
+	"os"
+	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/snip/internal/ai"
+	"github.com/snip/internal/dbcharts"
+	"github.com/snip/internal/dbproject"
+	"github.com/snip/internal/note"
 	"github.com/snip/internal/project"
 	"github.com/snip/internal/repository"
+	"github.com/snip/internal/task"
 )
 
 type ProjectHandler interface {
@@ -16,19 +24,24 @@ type ProjectHandler interface {
 	UpdateProject(id int, name, description, status string) error
 	DeleteProject(id int) error
 	CreateProjectWithAI(name, description string) error
+	ShowCriticalPath(id int) error
+	ShowGantt(id int, output string) error
+	SaveGeneratedProject(plan *dbproject.ProjectFromAnalysis) error
 }
 
 type projectHandler struct {
 	projectRepo repository.ProjectRepository
 	taskRepo    repository.TaskRepository
+	linkRepo    repository.NoteLinkRepository
 	aiClient  ai.AIClient
 }
 
-func NewProjectHandler(projectRepo repository.ProjectRepository, taskRepo repository.TaskRepository) ProjectHandler {
-	aiClient, _ := ai.NewAIClient()
+func NewProjectHandler(projectRepo repository.ProjectRepository, taskRepo repository.TaskRepository, linkRepo repository.NoteLinkRepository) ProjectHandler {
+	aiClient, _ := ai.NewAIClientFor(ai.FeatureProject)
 	return &projectHandler{
 		projectRepo: projectRepo,
 		taskRepo:    taskRepo,
+		linkRepo:    linkRepo,
 		aiClient:    aiClient,
 	}
 }
@@ -65,6 +78,10 @@ func (h *projectHandler) ListProjects(status string) error {
 			}
 			fmt.Printf("   └── %s\n", desc)
 		}
+		if tasks, err := h.taskRepo.GetByProjectID(p.ID, ""); err == nil && len(tasks) > 0 {
+			done, total := task.TreeProgress(task.BuildTree(tasks))
+			fmt.Printf("   └── Progresso: %s\n", formatProgress(done, total))
+		}
 		fmt.Println()
 	}
 
@@ -85,21 +102,43 @@ func (h *projectHandler) ShowProject(id int) error {
 	// Show tasks
 	tasks, err := h.taskRepo.GetByProjectID(id, "")
 	if err == nil && len(tasks) > 0 {
-		fmt.Printf("Tarefas (%d):\n", len(tasks))
-		for _, t := range tasks {
-			statusIcon := "○"
-			if t.Status == "completed" {
-				statusIcon = "✓"
-			} else if t.Status == "in_progress" {
-				statusIcon = "◐"
-			}
-			fmt.Printf("  %s #%d %s [%s]\n", statusIcon, t.ID, t.Title, t.Priority)
+		roots := task.BuildTree(tasks)
+		done, total := task.TreeProgress(roots)
+		fmt.Printf("Tarefas (%d) — progresso %s:\n", len(tasks), formatProgress(done, total))
+		printTaskTree(roots, "  ")
+
+		var columns []string
+		for _, column := range task.NewBoard(tasks).Columns {
+			columns = append(columns, fmt.Sprintf("%s %d", task.BoardTitle(column.Status), len(column.Tasks)))
 		}
+		fmt.Printf("\n%s (veja: snip board %d)\n", strings.Join(columns, " · "), p.ID)
 	}
 
+	notes, err := h.linkRepo.GetNotesByProject(id)
+	if err != nil {
+		return fmt.Errorf("failed to fetch linked notes: %w", err)
+	}
+	printLinkedNotes(notes)
+
 	return nil
 }
 
+// printLinkedNotes lista as notas vinculadas (snip create --project/--task, snip link)
+func printLinkedNotes(notes []*note.LinkedNote) {
+	if len(notes) == 0 {
+		return
+	}
+	fmt.Printf("\nNotas (%d):\n", len(notes))
+	for _, n := range notes {
+		fmt.Printf("  ● #%d %s (%s)", n.ID, n.Title, n.CreatedAt.Format("2006-01-02"))
+		if n.TaskID != 0 {
+			fmt.Printf(" via tarefa #%d", n.TaskID)
+		}
+		fmt.Println()
+	}
+	fmt.Println("  (veja: snip show <id>)")
+}
+
 func (h *projectHandler) UpdateProject(id int, name, description, status string) error {
 	if err := h.projectRepo.Update(id, name, description, status); err != nil {
 		return fmt.Errorf("failed to update project: %w", err)
@@ -143,3 +182,231 @@ func (h *projectHandler) CreateProjectWithAI(name, description string) error {
 	return nil
 }
 
+// SaveGeneratedProject grava o projeto gerado por db-project com as tarefas,
+// mantendo a estimativa de cada uma para comparar com as horas apontadas
+func (h *projectHandler) SaveGeneratedProject(plan *dbproject.ProjectFromAnalysis) error {
+	p := project.NewProject(plan.ProjectName, plan.ProjectDescription)
+	if err := h.projectRepo.Create(p); err != nil {
+		return fmt.Errorf("failed to create project: %w", err)
+	}
+
+	var estimated time.Duration
+	for _, pt := range plan.Tasks {
+		description := pt.Description
+		if len(pt.Steps) > 0 {
+			var steps strings.Builder
+			for i, step := range pt.Steps {
+				steps.WriteString(fmt.Sprintf("\n%d. %s", i+1, step))
+			}
+			description = strings.TrimSpace(description + "\n\nPasso a passo:" + steps.String())
+		}
+
+		priority := strings.ToLower(strings.TrimSpace(pt.Priority))
+		if priority != "low" && priority != "medium" && priority != "high" {
+			priority = "medium"
+		}
+
+		t := task.NewTask(p.ID, pt.Title, description, priority)
+		t.DueDate = pt.DueDate
+		t.EstimatedTime = pt.EstimatedTime
+		if err := h.taskRepo.Create(t); err != nil {
+			return fmt.Errorf("failed to create task %s: %w", pt.Title, err)
+		}
+		estimated += pt.EstimatedTime
+	}
+
+	fmt.Printf("Projeto criado com sucesso!\n")
+	fmt.Printf("● #%d  %s — %d tarefa(s), %s estimadas\n", p.ID, p.Name, len(plan.Tasks), task.FormatDuration(estimated))
+	return nil
+}
+
+// ShowCriticalPath mostra a cadeia de dependências mais longa do projeto,
+// com as datas projetadas a partir de agora e as tarefas que estourariam o prazo
+func (h *projectHandler) ShowCriticalPath(id int) error {
+	p, err := h.projectRepo.GetByID(id)
+	if err != nil {
+		return fmt.Errorf("failed to fetch project: %w", err)
+	}
+
+	tasks, err := h.taskRepo.GetByProjectID(id, "")
+	if err != nil {
+		return fmt.Errorf("failed to fetch tasks: %w", err)
+	}
+	if len(tasks) == 0 {
+		fmt.Println("Nenhuma tarefa encontrada.")
+		return nil
+	}
+
+	deps, err := h.taskRepo.GetDependencies()
+	if err != nil {
+		return fmt.Errorf("failed to fetch task dependencies: %w", err)
+	}
+
+	result, err := task.CriticalPath(tasks, deps, time.Now())
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("● #%d %s — caminho crítico (%d tarefa(s))\n\n", p.ID, p.Name, len(result.Steps))
+	for i, step := range result.Steps {
+		t := step.Task
+		statusIcon := "○"
+		if t.Status == "completed" {
+			statusIcon = "✓"
+		} else if t.Status == "in_progress" {
+			statusIcon = "◐"
+		}
+
+		estimate := "sem estimativa"
+		if t.IsCompleted() {
+			estimate = "concluída"
+		} else if t.EstimatedTime > 0 {
+			estimate = task.FormatDuration(t.EstimatedTime)
+		}
+
+		fmt.Printf("  %d. %s #%d %s [%s]\n", i+1, statusIcon, t.ID, t.Title, estimate)
+		if !t.IsCompleted() {
+			fmt.Printf("     └── %s → %s", step.Start.Format("2006-01-02 15:04"), step.Finish.Format("2006-01-02 15:04"))
+			if t.DueDate != nil {
+				fmt.Printf(" (prazo: %s)", t.DueDate.Format("2006-01-02"))
+			}
+			if step.Late > 0 {
+				fmt.Printf(" ⚠️  atraso de %s", formatLate(step.Late))
+			}
+			fmt.Println()
+		}
+	}
+
+	fmt.Printf("\nDuração restante: %s (%d dia(s) de %s)\n", task.FormatDuration(result.Duration), int((result.Duration+task.WorkDay-1)/task.WorkDay), task.FormatDuration(task.WorkDay))
+	fmt.Printf("Término projetado: %s\n", result.End.Format("2006-01-02 15:04"))
+	if result.Unestimated > 0 {
+		fmt.Printf("⚠️  %d tarefa(s) do caminho sem estimativa (use 'snip task update <id> <título> --estimate 2h')\n", result.Unestimated)
+	}
+
+	if len(result.Late) > 0 {
+		fmt.Printf("\nTarefas que terminariam após o prazo:\n")
+		for _, step := range result.Late {
+			fmt.Printf("  ⚠️  #%d %s — prazo %s, término projetado %s (atraso de %s)\n",
+				step.Task.ID, step.Task.Title, step.Task.DueDate.Format("2006-01-02"), step.Finish.Format("2006-01-02 15:04"), formatLate(step.Late))
+		}
+	}
+
+	return nil
+}
+
+// ShowGantt mostra o cronograma do projeto em ASCII ou, com output, grava um
+// gráfico HTML (ou SVG, pela extensão) autocontido
+func (h *projectHandler) ShowGantt(id int, output string) error {
+	p, err := h.projectRepo.GetByID(id)
+	if err != nil {
+		return fmt.Errorf("failed to fetch project: %w", err)
+	}
+
+	tasks, err := h.taskRepo.GetByProjectID(id, "")
+	if err != nil {
+		return fmt.Errorf("failed to fetch tasks: %w", err)
+	}
+	if len(tasks) == 0 {
+		fmt.Println("Nenhuma tarefa encontrada.")
+		return nil
+	}
+
+	deps, err := h.taskRepo.GetDependencies()
+	if err != nil {
+		return fmt.Errorf("failed to fetch task dependencies: %w", err)
+	}
+
+	now := time.Now()
+	rows, err := task.Schedule(tasks, deps, now)
+	if err != nil {
+		return err
+	}
+	data := ganttData(p, rows, deps, now)
+
+	if output == "" {
+		chart, err := dbcharts.GenerateGantt(data, dbcharts.ChartTypeASCII, terminalWidth())
+		if err != nil {
+			return err
+		}
+		fmt.Print(chart)
+
+		unestimated := 0
+		end := rows[0].End
+		for _, row := range rows {
+			if !row.Estimated && !row.Task.IsCompleted() {
+				unestimated++
+			}
+			if row.End.After(end) {
+				end = row.End
+			}
+		}
+		fmt.Printf("\nTérmino projetado: %s\n", end.Format("2006-01-02 15:04"))
+		if unestimated > 0 {
+			fmt.Printf("⚠️  %d tarefa(s) sem estimativa: a barra vai até o prazo ou ocupa um dia\n", unestimated)
+		}
+		return nil
+	}
+
+	chartType := dbcharts.ChartTypeHTML
+	if strings.EqualFold(filepath.Ext(output), ".svg") {
+		chartType = dbcharts.ChartTypeSVG
+	}
+	chart, err := dbcharts.GenerateGantt(data, chartType, 0)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(output, []byte(chart), 0644); err != nil {
+		return fmt.Errorf("failed to write gantt chart: %w", err)
+	}
+	fmt.Printf("✓ Gantt salvo em: %s\n", output)
+	return nil
+}
+
+// ganttData converte o cronograma das tarefas nas barras do gráfico
+func ganttData(p *project.Project, rows []task.ScheduledTask, deps map[int][]int, now time.Time) dbcharts.GanttData {
+	index := make(map[int]int, len(rows))
+	for i, row := range rows {
+		index[row.Task.ID] = i
+	}
+
+	data := dbcharts.GanttData{Title: fmt.Sprintf("Gantt — #%d %s", p.ID, p.Name), Today: now}
+	for _, row := range rows {
+		t := row.Task
+		bar := dbcharts.GanttBar{
+			Label:    fmt.Sprintf("#%d %s", t.ID, t.Title),
+			Start:    row.Start,
+			End:      row.End,
+			Due:      t.DueDate,
+			Indent:   row.Depth,
+			Critical: row.Critical,
+			Late:     row.Late,
+		}
+		switch {
+		case t.IsCompleted():
+			bar.Progress = 1
+		case t.Status == "in_progress" && row.End.After(row.Start):
+			// Em andamento: a parte já decorrida da barra
+			bar.Progress = math.Min(1, math.Max(0, float64(now.Sub(row.Start))/float64(row.End.Sub(row.Start))))
+		}
+		if t.EstimatedTime > 0 {
+			bar.Note = "estimativa: " + task.FormatDuration(t.EstimatedTime)
+		} else {
+			bar.Note = "sem estimativa"
+		}
+		for _, depID := range deps[t.ID] {
+			if i, ok := index[depID]; ok {
+				bar.DependsOn = append(bar.DependsOn, i)
+			}
+		}
+		data.Bars = append(data.Bars, bar)
+	}
+	return data
+}
+
+// formatLate mostra atrasos em dias quando passam de um dia
+func formatLate(d time.Duration) string {
+	if d >= 24*time.Hour {
+		return fmt.Sprintf("%d dia(s)", int(d.Hours()/24))
+	}
+	return task.FormatDuration(d)
+}
@@ -0,0 +1,186 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/snip/internal/ai"
+	"github.com/snip/internal/note"
+	"github.com/snip/internal/repository"
+	"github.com/snip/internal/snippet"
+)
+
+// maxSnippetTitle limita o título gerado a partir da descrição do ai-code
+const maxSnippetTitle = 80
+
+type SnippetHandler interface {
+	GenerateAndSave(language string, description string, context string) error
+	ListSnippets(language string) error
+	ShowSnippet(idStr string) error
+	CopySnippet(idStr string) error
+	WriteSnippet(idStr string, path string, force bool) error
+}
+
+type snippetHandler struct {
+	snippetRepo repository.SnippetRepository
+	aiClient    ai.AIClient
+	dateFormat  string
+}
+
+func NewSnippetHandler(snippetRepo repository.SnippetRepository) SnippetHandler {
+	aiClient, _ := ai.NewAIClientFor(ai.FeatureNotes)
+	return &snippetHandler{
+		snippetRepo: snippetRepo,
+		aiClient:    aiClient,
+		dateFormat:  "2006-01-02 15:04",
+	}
+}
+
+// GenerateAndSave gera o código (como snip ai-code) e salva a resposta como nota snippet
+func (h *snippetHandler) GenerateAndSave(language string, description string, context string) error {
+	if h.aiClient == nil {
+		return fmt.Errorf("AI client not available")
+	}
+
+	fmt.Printf("Generating %s code with AI...\n", language)
+	content, err := h.aiClient.GenerateCode(language, description, context)
+	if err != nil {
+		return fmt.Errorf("failed to generate code with AI: %w", err)
+	}
+
+	fmt.Println("\n" + renderMarkdownContent(content))
+
+	prompt := description
+	if context != "" {
+		prompt += "\nContext: " + context
+	}
+
+	s := snippet.NewSnippet(snippetTitle(description), language, content, prompt)
+	n := note.NewNote(s.Title, content)
+	if err := h.snippetRepo.Create(n, s); err != nil {
+		return fmt.Errorf("failed to save snippet: %w", err)
+	}
+
+	fmt.Printf("✓ Snippet saved as note #%d (%s, %d lines)\n", s.NoteID, s.Language, countLines(s.Code))
+	fmt.Printf("  snip snippet copy %d | snip snippet write %d <path>\n", s.NoteID, s.NoteID)
+	return nil
+}
+
+func (h *snippetHandler) ListSnippets(language string) error {
+	snippets, err := h.snippetRepo.List(snippet.NormalizeLanguage(language))
+	if err != nil {
+		return fmt.Errorf("failed to list snippets: %w", err)
+	}
+
+	if len(snippets) == 0 {
+		if language != "" {
+			fmt.Printf("No %s snippets found.\n", language)
+		} else {
+			fmt.Println("No snippets found. Save one with: snip ai-code \"description\" --save")
+		}
+		return nil
+	}
+
+	fmt.Printf("%-6s %-12s %6s  %-17s %s\n", "ID", "LANGUAGE", "LINES", "CREATED", "TITLE")
+	for _, s := range snippets {
+		fmt.Printf("%-6d %-12s %6d  %-17s %s\n", s.NoteID, s.Language, countLines(s.Code), s.CreatedAt.Format(h.dateFormat), s.Title)
+	}
+	return nil
+}
+
+// ShowSnippet mostra apenas o código, colorido pela linguagem
+func (h *snippetHandler) ShowSnippet(idStr string) error {
+	s, err := h.getSnippet(idStr)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("● #%d %s [%s]\n", s.NoteID, s.Title, s.Language)
+	if s.Prompt != "" {
+		fmt.Printf("  └─ Prompt: %s\n", strings.ReplaceAll(s.Prompt, "\n", " | "))
+	}
+	fmt.Println()
+	fmt.Println(highlightCode(s.Code, s.Language))
+	return nil
+}
+
+func (h *snippetHandler) CopySnippet(idStr string) error {
+	s, err := h.getSnippet(idStr)
+	if err != nil {
+		return err
+	}
+
+	if err := copyToClipboard(s.Code); err != nil {
+		return fmt.Errorf("failed to copy to clipboard: %w", err)
+	}
+
+	fmt.Printf("✓ Copied %d lines of %s from snippet #%d to the clipboard\n", countLines(s.Code), s.Language, s.NoteID)
+	return nil
+}
+
+// WriteSnippet grava o código em um arquivo; se path for um diretório usa
+// snippet-<id>.<ext>. Código com shebang é gravado como executável.
+func (h *snippetHandler) WriteSnippet(idStr string, path string, force bool) error {
+	s, err := h.getSnippet(idStr)
+	if err != nil {
+		return err
+	}
+
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		path = filepath.Join(path, fmt.Sprintf("snippet-%d.%s", s.NoteID, snippet.Extension(s.Language)))
+	}
+
+	if _, err := os.Stat(path); err == nil && !force {
+		return fmt.Errorf("file already exists: %s (use --force to overwrite)", path)
+	}
+
+	mode := os.FileMode(0644)
+	if strings.HasPrefix(s.Code, "#!") {
+		mode = 0755
+	}
+
+	if err := os.WriteFile(path, []byte(s.Code+"\n"), mode); err != nil {
+		return fmt.Errorf("failed to write snippet: %w", err)
+	}
+	if err := os.Chmod(path, mode); err != nil {
+		return fmt.Errorf("failed to set file mode: %w", err)
+	}
+
+	fmt.Printf("✓ Snippet #%d written to %s\n", s.NoteID, path)
+	return nil
+}
+
+func (h *snippetHandler) getSnippet(idStr string) (*snippet.Snippet, error) {
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid snippet ID: %s", idStr)
+	}
+
+	s, err := h.snippetRepo.GetByNoteID(id)
+	if err != nil {
+		if errors.Is(err, repository.ErrSnippetNotFound) {
+			return nil, fmt.Errorf("snippet #%d not found (only notes saved with --save are snippets)", id)
+		}
+		return nil, fmt.Errorf("failed to fetch snippet: %w", err)
+	}
+	return s, nil
+}
+
+func snippetTitle(description string) string {
+	title := strings.Join(strings.Fields(description), " ")
+	if runes := []rune(title); len(runes) > maxSnippetTitle {
+		title = string(runes[:maxSnippetTitle-3]) + "..."
+	}
+	return title
+}
+
+func countLines(code string) int {
+	if code == "" {
+		return 0
+	}
+	return strings.Count(code, "\n") + 1
+}
@@ -0,0 +1,250 @@
+package handler
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/snip/internal/ai"
+	"github.com/snip/internal/note"
+	"github.com/snip/internal/repository"
+	"github.com/snip/internal/study"
+)
+
+type StudyHandler interface {
+	GenerateCards(tag string, noteIDStr string, maxCards int, replace bool) error
+	Review(tag string, limit int) error
+	ShowStats(tag string) error
+}
+
+type studyHandler struct {
+	studyRepo  repository.StudyRepository
+	noteRepo   repository.NoteRepository
+	tagRepo    repository.TagRepository
+	aiClient   ai.AIClient
+	dateFormat string
+}
+
+func NewStudyHandler(studyRepo repository.StudyRepository, noteRepo repository.NoteRepository, tagRepo repository.TagRepository) StudyHandler {
+	aiClient, _ := ai.NewAIClientFor(ai.FeatureNotes)
+	return &studyHandler{
+		studyRepo:  studyRepo,
+		noteRepo:   noteRepo,
+		tagRepo:    tagRepo,
+		aiClient:   aiClient,
+		dateFormat: "2006-01-02",
+	}
+}
+
+// GenerateCards cria flashcards para a nota informada ou para todas as notas da tag.
+// Notas que já têm cartões são puladas, a menos que replace seja usado.
+func (h *studyHandler) GenerateCards(tag string, noteIDStr string, maxCards int, replace bool) error {
+	if h.aiClient == nil {
+		return fmt.Errorf("AI client not available")
+	}
+
+	notes, err := h.notesToStudy(tag, noteIDStr)
+	if err != nil {
+		return err
+	}
+	if len(notes) == 0 {
+		fmt.Println("No notes found.")
+		return nil
+	}
+
+	created, skipped, failed := 0, 0, 0
+	for i, n := range notes {
+		fmt.Printf("[%d/%d] ● #%d %s\n", i+1, len(notes), n.ID, n.Title)
+
+		existing, err := h.studyRepo.CountByNote(n.ID)
+		if err != nil {
+			return fmt.Errorf("failed to fetch cards: %w", err)
+		}
+		if existing > 0 && !replace {
+			fmt.Printf("  └─ Already has %d card(s), skipping (use --replace to regenerate)\n\n", existing)
+			skipped++
+			continue
+		}
+
+		flashcards, err := ai.GenerateFlashcards(h.aiClient, n.Title, n.Content, maxCards)
+		if err != nil {
+			fmt.Printf("  Error: failed to generate cards with AI: %v\n\n", err)
+			failed++
+			continue
+		}
+
+		if existing > 0 {
+			if _, err := h.studyRepo.DeleteByNote(n.ID); err != nil {
+				return fmt.Errorf("failed to replace cards: %w", err)
+			}
+		}
+
+		cards := make([]*study.Card, 0, len(flashcards))
+		for _, f := range flashcards {
+			cards = append(cards, study.NewCard(n.ID, f.Question, f.Answer))
+		}
+		if err := h.studyRepo.CreateCards(cards); err != nil {
+			return fmt.Errorf("failed to save cards: %w", err)
+		}
+
+		for _, c := range cards {
+			fmt.Printf("  └─ Q: %s\n", c.Question)
+		}
+		fmt.Printf("  ✓ %d card(s) created\n\n", len(cards))
+		created += len(cards)
+	}
+
+	fmt.Printf("✓ %d card(s) created from %d note(s), %d skipped, %d failed\n", created, len(notes)-skipped-failed, skipped, failed)
+	if created > 0 {
+		fmt.Println("  Start reviewing with: snip study review")
+	}
+	return nil
+}
+
+// Review conduz uma sessão SM-2 com os cartões vencidos: mostra a pergunta,
+// revela a resposta com Enter e pede a nota de 0 a 5
+func (h *studyHandler) Review(tag string, limit int) error {
+	tagID, err := h.resolveTag(tag)
+	if err != nil {
+		return err
+	}
+
+	cards, err := h.studyRepo.GetDue(time.Now(), tagID, limit)
+	if err != nil {
+		return fmt.Errorf("failed to fetch due cards: %w", err)
+	}
+	if len(cards) == 0 {
+		fmt.Println("No cards due for review. 🎉")
+		return h.printGenerateHint(tagID)
+	}
+
+	fmt.Printf("%d card(s) due. Rate each answer:\n", len(cards))
+	fmt.Println("  0 blackout · 1 wrong · 2 wrong, but familiar · 3 hard · 4 good · 5 easy   (q quits)")
+
+	reader := bufio.NewReader(os.Stdin)
+	reviewed, correct := 0, 0
+	for i, c := range cards {
+		fmt.Printf("\n[%d/%d] #%d %s\n", i+1, len(cards), c.NoteID, c.NoteTitle)
+		fmt.Printf("Q: %s\n", c.Question)
+		fmt.Print("   (Enter to show the answer) ")
+		input, err := reader.ReadString('\n')
+		if strings.TrimSpace(strings.ToLower(input)) == "q" || (err != nil && input == "") {
+			break
+		}
+		fmt.Printf("A: %s\n", c.Answer)
+
+		quality, quit := readQuality(reader)
+		if quit {
+			break
+		}
+
+		review := c.Schedule(quality, time.Now())
+		if err := h.studyRepo.SaveReview(c, review); err != nil {
+			return fmt.Errorf("failed to save review: %w", err)
+		}
+
+		reviewed++
+		if quality >= study.PassQuality {
+			correct++
+		}
+		fmt.Printf("   → next review in %d day(s) (%s), ease %.2f\n", c.Interval, c.DueAt.Format(h.dateFormat), c.EaseFactor)
+	}
+
+	fmt.Printf("\n✓ %d card(s) reviewed, %d correct", reviewed, correct)
+	if reviewed > 0 {
+		fmt.Printf(" (%.0f%%)", float64(correct)*100/float64(reviewed))
+	}
+	fmt.Println()
+	if remaining := len(cards) - reviewed; remaining > 0 {
+		fmt.Printf("  %d card(s) still due\n", remaining)
+	}
+	return nil
+}
+
+func (h *studyHandler) ShowStats(tag string) error {
+	tagID, err := h.resolveTag(tag)
+	if err != nil {
+		return err
+	}
+
+	stats, err := h.studyRepo.GetStats(time.Now(), tagID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch study stats: %w", err)
+	}
+
+	if tag != "" {
+		fmt.Printf("Study cards tagged %s\n", tag)
+	}
+	fmt.Printf("  Total:          %d\n", stats.Total)
+	fmt.Printf("  Due now:        %d\n", stats.Due)
+	fmt.Printf("  Never studied:  %d\n", stats.New)
+	fmt.Printf("  Reviewed today: %d\n", stats.ReviewsToday)
+	return nil
+}
+
+func (h *studyHandler) notesToStudy(tag string, noteIDStr string) ([]*note.NoteWithTags, error) {
+	if noteIDStr != "" {
+		id, err := strconv.Atoi(noteIDStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid note ID: %s", noteIDStr)
+		}
+		n, err := h.noteRepo.GetByID(id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch note: %w", err)
+		}
+		return []*note.NoteWithTags{n}, nil
+	}
+
+	tagID, err := h.resolveTag(tag)
+	if err != nil {
+		return nil, err
+	}
+	notes, err := h.noteRepo.GetAll(true, tagID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch notes: %w", err)
+	}
+	return notes, nil
+}
+
+func (h *studyHandler) resolveTag(tag string) (int, error) {
+	if tag == "" {
+		return 0, nil
+	}
+	t, err := h.tagRepo.GetByName(tag)
+	if err != nil {
+		return 0, fmt.Errorf("tag not found: %s", tag)
+	}
+	return t.ID, nil
+}
+
+func (h *studyHandler) printGenerateHint(tagID int) error {
+	stats, err := h.studyRepo.GetStats(time.Now(), tagID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch study stats: %w", err)
+	}
+	if stats.Total == 0 {
+		fmt.Println("  Generate cards with: snip study generate --tag <tag>")
+	}
+	return nil
+}
+
+// readQuality lê uma nota de 0 a 5, repetindo a pergunta até receber um valor válido
+func readQuality(reader *bufio.Reader) (int, bool) {
+	for {
+		fmt.Print("   Quality [0-5]: ")
+		input, err := reader.ReadString('\n')
+		input = strings.TrimSpace(strings.ToLower(input))
+		if input == "q" || (err != nil && input == "") {
+			return 0, true
+		}
+
+		quality, convErr := strconv.Atoi(input)
+		if convErr == nil && study.ValidateQuality(quality) == nil {
+			return quality, false
+		}
+		fmt.Printf("   Please answer with a number from %d to %d\n", study.MinQuality, study.MaxQuality)
+	}
+}
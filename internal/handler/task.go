@@ -1,7 +1,11 @@
 package handler
 
 import (
+	"bufio"
 	"fmt"
+	"os"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/snip/internal/repository"
@@ -9,35 +13,72 @@ import (
 )
 
 type TaskHandler interface {
-	CreateTask(projectID int, title, description, priority string, dueDate *time.Time) error
-	ListTasks(projectID int, status string) error
+	CreateTask(projectID int, parentID int, title, description, priority string, dueDate *time.Time, estimate time.Duration, recurrence string) error
+	ListTasks(projectID int, status string, hideBlocked bool) error
 	ShowTask(id int) error
-	UpdateTask(id int, title, description, status, priority string, dueDate *time.Time) error
+	UpdateTask(id int, title, description, status, priority string, dueDate *time.Time, estimate *time.Duration, recurrence *string) error
 	DeleteTask(id int) error
-	ToggleTaskComplete(id int) error
+	ToggleTaskComplete(id int, assumeYes bool) error
+	DependTask(id int, dependsOn []int, remove bool) error
+	MoveTask(id int, projectID int, parentID int, toRoot bool) error
+	ListUpcoming(projectID int, days int) error
+	ShowBoard(projectID int, interactive bool) error
+	MoveCard(projectID int, id int, delta int) error
 }
 
 type taskHandler struct {
 	taskRepo    repository.TaskRepository
 	projectRepo repository.ProjectRepository
+	linkRepo    repository.NoteLinkRepository
 }
 
-func NewTaskHandler(taskRepo repository.TaskRepository, projectRepo repository.ProjectRepository) TaskHandler {
+func NewTaskHandler(taskRepo repository.TaskRepository, projectRepo repository.ProjectRepository, linkRepo repository.NoteLinkRepository) TaskHandler {
 	return &taskHandler{
 		taskRepo:    taskRepo,
 		projectRepo: projectRepo,
+		linkRepo:    linkRepo,
 	}
 }
 
-func (h *taskHandler) CreateTask(projectID int, title, description, priority string, dueDate *time.Time) error {
+func (h *taskHandler) CreateTask(projectID int, parentID int, title, description, priority string, dueDate *time.Time, estimate time.Duration, recurrence string) error {
 	if priority == "" {
 		priority = "medium"
 	}
 
+	// Subtarefas herdam o projeto do pai
+	if parentID != 0 {
+		parent, err := h.taskRepo.GetByID(parentID)
+		if err != nil {
+			return fmt.Errorf("failed to fetch parent task: %w", err)
+		}
+		if projectID == 0 {
+			projectID = parent.ProjectID
+		} else if projectID != parent.ProjectID {
+			return fmt.Errorf("a tarefa pai #%d é do projeto #%d", parent.ID, parent.ProjectID)
+		}
+	}
+
 	t := task.NewTask(projectID, title, description, priority)
+	t.ParentTaskID = parentID
 	if dueDate != nil {
 		t.DueDate = dueDate
 	}
+	t.EstimatedTime = estimate
+
+	// Tarefas recorrentes sem prazo começam na primeira ocorrência a partir de
+	// hoje (inclusive); regras mensais sem dia ficam ancoradas no dia do prazo
+	if recurrence != "" {
+		rule, err := task.ParseRecurrence(recurrence)
+		if err != nil {
+			return err
+		}
+		if t.DueDate == nil {
+			first := rule.First(today())
+			t.DueDate = &first
+		}
+		rule.Anchor(*t.DueDate)
+		t.Recurrence = rule.String()
+	}
 
 	if err := h.taskRepo.Create(t); err != nil {
 		return fmt.Errorf("failed to create task: %w", err)
@@ -45,10 +86,18 @@ func (h *taskHandler) CreateTask(projectID int, title, description, priority str
 
 	fmt.Printf("Tarefa criada com sucesso!\n")
 	fmt.Printf("● #%d  %s [%s]\n", t.ID, t.Title, t.Priority)
+	if t.ParentTaskID != 0 {
+		fmt.Printf("   └── subtarefa de #%d\n", t.ParentTaskID)
+	}
+	if t.Recurrence != "" {
+		fmt.Printf("   └── ↻ %s, primeira em %s\n", describeRecurrence(t.Recurrence), t.DueDate.Format("2006-01-02"))
+	}
 	return nil
 }
 
-func (h *taskHandler) ListTasks(projectID int, status string) error {
+// ListTasks lista as tarefas marcando as bloqueadas por dependências abertas;
+// com hideBlocked elas são omitidas
+func (h *taskHandler) ListTasks(projectID int, status string, hideBlocked bool) error {
 	var tasks []*task.Task
 	var err error
 
@@ -62,8 +111,29 @@ func (h *taskHandler) ListTasks(projectID int, status string) error {
 		return fmt.Errorf("failed to fetch tasks: %w", err)
 	}
 
+	deps, byID, err := h.dependencyGraph()
+	if err != nil {
+		return err
+	}
+
+	hidden := 0
+	if hideBlocked {
+		var unblocked []*task.Task
+		for _, t := range tasks {
+			if !t.IsCompleted() && len(task.BlockedBy(t.ID, deps, byID)) > 0 {
+				hidden++
+				continue
+			}
+			unblocked = append(unblocked, t)
+		}
+		tasks = unblocked
+	}
+
 	if len(tasks) == 0 {
 		fmt.Println("Nenhuma tarefa encontrada.")
+		if hidden > 0 {
+			fmt.Printf("(%d tarefa(s) bloqueada(s) ocultada(s))\n", hidden)
+		}
 		return nil
 	}
 
@@ -76,12 +146,30 @@ func (h *taskHandler) ListTasks(projectID int, status string) error {
 			statusIcon = "◐"
 		}
 
+		blockedBy := task.BlockedBy(t.ID, deps, byID)
+		if !t.IsCompleted() && len(blockedBy) > 0 {
+			statusIcon = "⊘"
+		}
+
 		fmt.Printf("%s #%d %s [%s]", statusIcon, t.ID, t.Title, t.Priority)
 		if t.DueDate != nil {
 			fmt.Printf(" (prazo: %s)", t.DueDate.Format("2006-01-02"))
 		}
+		if t.EstimatedTime > 0 {
+			fmt.Printf(" (estimativa: %s)", task.FormatDuration(t.EstimatedTime))
+		}
+		if t.Recurrence != "" {
+			fmt.Printf(" ↻ %s", describeRecurrence(t.Recurrence))
+		}
 		fmt.Println()
 
+		if !t.IsCompleted() && len(blockedBy) > 0 {
+			fmt.Printf("   └── bloqueada por %s\n", formatTaskIDs(blockedBy))
+		}
+		if t.ParentTaskID != 0 {
+			fmt.Printf("   └── subtarefa de #%d\n", t.ParentTaskID)
+		}
+
 		if t.Description != "" {
 			desc := t.Description
 			if len(desc) > 60 {
@@ -92,6 +180,9 @@ func (h *taskHandler) ListTasks(projectID int, status string) error {
 		fmt.Println()
 	}
 
+	if hidden > 0 {
+		fmt.Printf("(%d tarefa(s) bloqueada(s) ocultada(s))\n", hidden)
+	}
 	return nil
 }
 
@@ -115,14 +206,79 @@ func (h *taskHandler) ShowTask(id int) error {
 	if t.DueDate != nil {
 		fmt.Printf("   └── Prazo: %s\n", t.DueDate.Format("2006-01-02 15:04"))
 	}
+	if t.EstimatedTime > 0 {
+		fmt.Printf("   └── Estimativa: %s\n", task.FormatDuration(t.EstimatedTime))
+	}
+	if t.Recurrence != "" {
+		fmt.Printf("   └── Recorrência: ↻ %s (%s)\n", describeRecurrence(t.Recurrence), t.Recurrence)
+	}
+
+	deps, byID, err := h.dependencyGraph()
+	if err != nil {
+		return err
+	}
+	for _, depID := range deps[t.ID] {
+		if dep, ok := byID[depID]; ok {
+			mark := "aberta"
+			if dep.IsCompleted() {
+				mark = "concluída"
+			}
+			fmt.Printf("   └── Depende de #%d %s (%s)\n", dep.ID, dep.Title, mark)
+		}
+	}
+	for _, dependent := range dependentsOf(t.ID, deps, byID) {
+		fmt.Printf("   └── Bloqueia #%d %s\n", dependent.ID, dependent.Title)
+	}
+	if parent, ok := byID[t.ParentTaskID]; ok {
+		fmt.Printf("   └── Subtarefa de #%d %s\n", parent.ID, parent.Title)
+	}
+
+	siblings, err := h.taskRepo.GetByProjectID(t.ProjectID, "")
+	if err != nil {
+		return fmt.Errorf("failed to fetch tasks: %w", err)
+	}
+	if node := task.Find(task.BuildTree(siblings), t.ID); node != nil && len(node.Children) > 0 {
+		done, total := node.Progress()
+		fmt.Printf("\nSubtarefas — progresso %s:\n", formatProgress(done, total))
+		printTaskTree(node.Children, "  ")
+	}
+
+	notes, err := h.linkRepo.GetNotesByTask(id)
+	if err != nil {
+		return fmt.Errorf("failed to fetch linked notes: %w", err)
+	}
+	printLinkedNotes(notes)
 
 	return nil
 }
 
-func (h *taskHandler) UpdateTask(id int, title, description, status, priority string, dueDate *time.Time) error {
+func (h *taskHandler) UpdateTask(id int, title, description, status, priority string, dueDate *time.Time, estimate *time.Duration, recurrence *string) error {
+	// A regra é validada antes de alterar a tarefa; "none" ou vazio removem a recorrência
+	rule := ""
+	if recurrence != nil && *recurrence != "" && *recurrence != "none" {
+		parsed, err := task.ParseRecurrence(*recurrence)
+		if err != nil {
+			return err
+		}
+		if dueDate != nil {
+			parsed.Anchor(*dueDate)
+		}
+		rule = parsed.String()
+	}
+
 	if err := h.taskRepo.Update(id, title, description, status, priority, dueDate); err != nil {
 		return fmt.Errorf("failed to update task: %w", err)
 	}
+	if estimate != nil {
+		if err := h.taskRepo.SetEstimate(id, *estimate); err != nil {
+			return fmt.Errorf("failed to update task estimate: %w", err)
+		}
+	}
+	if recurrence != nil {
+		if err := h.taskRepo.SetRecurrence(id, rule); err != nil {
+			return fmt.Errorf("failed to update task recurrence: %w", err)
+		}
+	}
 
 	fmt.Printf("Tarefa atualizada com sucesso!\n")
 	return nil
@@ -137,7 +293,36 @@ func (h *taskHandler) DeleteTask(id int) error {
 	return nil
 }
 
-func (h *taskHandler) ToggleTaskComplete(id int) error {
+// ToggleTaskComplete alterna a conclusão; concluir uma tarefa com subtarefas
+// abertas pede confirmação (a menos que assumeYes)
+func (h *taskHandler) ToggleTaskComplete(id int, assumeYes bool) error {
+	current, err := h.taskRepo.GetByID(id)
+	if err != nil {
+		return fmt.Errorf("failed to fetch task: %w", err)
+	}
+
+	if !current.IsCompleted() {
+		siblings, err := h.taskRepo.GetByProjectID(current.ProjectID, "")
+		if err != nil {
+			return fmt.Errorf("failed to fetch tasks: %w", err)
+		}
+		if open := task.OpenDescendants(siblings, id); len(open) > 0 {
+			fmt.Printf("⚠️  #%d %s tem %d subtarefa(s) aberta(s):\n", current.ID, current.Title, len(open))
+			for _, child := range open {
+				fmt.Printf("   ○ #%d %s\n", child.ID, child.Title)
+			}
+			if !assumeYes {
+				fmt.Print("Concluir mesmo assim? [s/N]: ")
+				answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+				answer = strings.ToLower(strings.TrimSpace(answer))
+				if answer != "s" && answer != "sim" && answer != "y" && answer != "yes" {
+					fmt.Println("Tarefa não alterada.")
+					return nil
+				}
+			}
+		}
+	}
+
 	if err := h.taskRepo.ToggleComplete(id); err != nil {
 		return fmt.Errorf("failed to toggle task: %w", err)
 	}
@@ -153,6 +338,368 @@ func (h *taskHandler) ToggleTaskComplete(id int) error {
 	}
 
 	fmt.Printf("Tarefa marcada como %s!\n", status)
+
+	if t.IsCompleted() {
+		deps, byID, err := h.dependencyGraph()
+		if err != nil {
+			return err
+		}
+		if blockedBy := task.BlockedBy(t.ID, deps, byID); len(blockedBy) > 0 {
+			fmt.Printf("⚠️  Atenção: as dependências %s ainda estão abertas\n", formatTaskIDs(blockedBy))
+		}
+		for _, dependent := range dependentsOf(t.ID, deps, byID) {
+			if !dependent.IsCompleted() && len(task.BlockedBy(dependent.ID, deps, byID)) == 0 {
+				fmt.Printf("   └── #%d %s foi desbloqueada\n", dependent.ID, dependent.Title)
+			}
+		}
+
+		if t.Recurrence != "" {
+			next, err := h.scheduleNextOccurrence(t)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("   └── ↻ Próxima ocorrência: #%d em %s\n", next.ID, next.DueDate.Format("2006-01-02"))
+		}
+	}
+	return nil
+}
+
+// scheduleNextOccurrence cria a próxima ocorrência de uma tarefa recorrente concluída.
+// A regra passa para a nova tarefa, então desmarcar a concluída não gera outra.
+func (h *taskHandler) scheduleNextOccurrence(t *task.Task) (*task.Task, error) {
+	rule, err := task.ParseRecurrence(t.Recurrence)
+	if err != nil {
+		return nil, fmt.Errorf("tarefa #%d: %w", t.ID, err)
+	}
+
+	base := today()
+	if t.DueDate != nil {
+		base = *t.DueDate
+	}
+	// Regras gravadas antes da âncora passam a manter o dia do prazo atual
+	rule.Anchor(base)
+	due := rule.NextOnOrAfter(base, today())
+
+	next := task.NewTask(t.ProjectID, t.Title, t.Description, t.Priority)
+	next.ParentTaskID = t.ParentTaskID
+	next.EstimatedTime = t.EstimatedTime
+	next.Recurrence = rule.String()
+	next.DueDate = &due
+
+	if err := h.taskRepo.Create(next); err != nil {
+		return nil, fmt.Errorf("failed to create next occurrence: %w", err)
+	}
+	if err := h.taskRepo.SetRecurrence(t.ID, ""); err != nil {
+		return nil, fmt.Errorf("failed to update task recurrence: %w", err)
+	}
+	return next, nil
+}
+
+// upcomingItem é uma ocorrência prevista na agenda de ListUpcoming
+type upcomingItem struct {
+	task      *task.Task
+	date      time.Time
+	recurring bool
+}
+
+// ListUpcoming mostra as próximas ocorrências das tarefas recorrentes abertas e
+// os prazos das demais tarefas abertas nos próximos days dias
+func (h *taskHandler) ListUpcoming(projectID int, days int) error {
+	var tasks []*task.Task
+	var err error
+	if projectID > 0 {
+		tasks, err = h.taskRepo.GetByProjectID(projectID, "")
+	} else {
+		tasks, err = h.taskRepo.GetAll("")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to fetch tasks: %w", err)
+	}
+
+	start := today()
+	end := start.AddDate(0, 0, days)
+
+	var items []upcomingItem
+	for _, t := range tasks {
+		if t.IsCompleted() || t.DueDate == nil {
+			continue
+		}
+		if t.Recurrence == "" {
+			if t.DueDate.Before(end) {
+				items = append(items, upcomingItem{task: t, date: *t.DueDate})
+			}
+			continue
+		}
+
+		rule, err := task.ParseRecurrence(t.Recurrence)
+		if err != nil {
+			return fmt.Errorf("tarefa #%d: %w", t.ID, err)
+		}
+		// A ocorrência atual (mesmo atrasada) e as seguintes até o fim do período;
+		// ocorrências perdidas entre o prazo atrasado e hoje não são repetidas
+		date := *t.DueDate
+		if date.Before(start) {
+			items = append(items, upcomingItem{task: t, date: date, recurring: true})
+			date = rule.NextOnOrAfter(date, start)
+		}
+		for ; date.Before(end); date = rule.Next(date) {
+			items = append(items, upcomingItem{task: t, date: date, recurring: true})
+		}
+	}
+
+	if len(items) == 0 {
+		fmt.Printf("Nenhuma tarefa prevista para os próximos %d dia(s).\n", days)
+		return nil
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		if !items[i].date.Equal(items[j].date) {
+			return items[i].date.Before(items[j].date)
+		}
+		return items[i].task.ID < items[j].task.ID
+	})
+
+	fmt.Printf("Próximos %d dia(s):\n\n", days)
+	currentDay := ""
+	for _, item := range items {
+		day := item.date.Format("2006-01-02")
+		if day != currentDay {
+			label := task.WeekdayName(item.date.Weekday())
+			if item.date.Before(start) {
+				label += " — atrasada"
+			}
+			fmt.Printf("%s (%s)\n", day, label)
+			currentDay = day
+		}
+
+		fmt.Printf("  ○ #%d %s [%s]", item.task.ID, item.task.Title, item.task.Priority)
+		if item.recurring {
+			fmt.Printf(" ↻ %s", describeRecurrence(item.task.Recurrence))
+		}
+		fmt.Println()
+	}
+	return nil
+}
+
+// today retorna a data de hoje à meia-noite UTC, como os prazos lidos de --due
+func today() time.Time {
+	now := time.Now()
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func describeRecurrence(rule string) string {
+	parsed, err := task.ParseRecurrence(rule)
+	if err != nil {
+		return rule
+	}
+	return parsed.Describe()
+}
+
+// DependTask registra (ou remove) que a tarefa id só pode começar após as tarefas dependsOn
+func (h *taskHandler) DependTask(id int, dependsOn []int, remove bool) error {
+	t, err := h.taskRepo.GetByID(id)
+	if err != nil {
+		return fmt.Errorf("failed to fetch task: %w", err)
+	}
+
+	deps, err := h.taskRepo.GetDependencies()
+	if err != nil {
+		return fmt.Errorf("failed to fetch task dependencies: %w", err)
+	}
+
+	for _, depID := range dependsOn {
+		if remove {
+			if err := h.taskRepo.RemoveDependency(id, depID); err != nil {
+				return fmt.Errorf("failed to remove dependency: %w", err)
+			}
+			fmt.Printf("✓ #%d não depende mais de #%d\n", id, depID)
+			continue
+		}
+
+		dep, err := h.taskRepo.GetByID(depID)
+		if err != nil {
+			return fmt.Errorf("failed to fetch task #%d: %w", depID, err)
+		}
+		if cycle := task.FindCycle(deps, id, depID); cycle != nil {
+			return fmt.Errorf("dependência circular: %s", formatCycle(cycle))
+		}
+
+		if err := h.taskRepo.AddDependency(id, depID); err != nil {
+			return fmt.Errorf("failed to add dependency: %w", err)
+		}
+		deps[id] = append(deps[id], depID)
+
+		fmt.Printf("✓ #%d %s depende de #%d %s\n", t.ID, t.Title, dep.ID, dep.Title)
+		if dep.ProjectID != t.ProjectID {
+			fmt.Printf("   └── atenção: #%d é de outro projeto e não entra no caminho crítico\n", dep.ID)
+		}
+	}
+	return nil
+}
+
+// MoveTask move a tarefa e suas subtarefas para outro pai e/ou projeto.
+// Com parentID o projeto passa a ser o do novo pai; com toRoot a tarefa vira
+// de primeiro nível; só com projectID ela sai do pai se ele ficar no projeto antigo.
+func (h *taskHandler) MoveTask(id int, projectID int, parentID int, toRoot bool) error {
+	t, err := h.taskRepo.GetByID(id)
+	if err != nil {
+		return fmt.Errorf("failed to fetch task: %w", err)
+	}
+
+	newParent := t.ParentTaskID
+	newProject := t.ProjectID
+	switch {
+	case parentID != 0:
+		parent, err := h.taskRepo.GetByID(parentID)
+		if err != nil {
+			return fmt.Errorf("failed to fetch parent task: %w", err)
+		}
+		if projectID != 0 && projectID != parent.ProjectID {
+			return fmt.Errorf("a tarefa pai #%d é do projeto #%d", parent.ID, parent.ProjectID)
+		}
+		all, err := h.taskRepo.GetAll("")
+		if err != nil {
+			return fmt.Errorf("failed to fetch tasks: %w", err)
+		}
+		if parent.ID == t.ID || containsTask(task.Descendants(all, t.ID), parent.ID) {
+			return fmt.Errorf("#%d é subtarefa de #%d e não pode ser o pai dela", parent.ID, t.ID)
+		}
+		newParent = parent.ID
+		newProject = parent.ProjectID
+	case toRoot:
+		newParent = 0
+		if projectID != 0 {
+			newProject = projectID
+		}
+	case projectID != 0:
+		newProject = projectID
+		if projectID != t.ProjectID {
+			newParent = 0
+		}
+	default:
+		return fmt.Errorf("informe --project, --parent ou --root")
+	}
+
+	if newProject != t.ProjectID {
+		if _, err := h.projectRepo.GetByID(newProject); err != nil {
+			return fmt.Errorf("failed to fetch project: %w", err)
+		}
+	}
+
+	all, err := h.taskRepo.GetAll("")
+	if err != nil {
+		return fmt.Errorf("failed to fetch tasks: %w", err)
+	}
+	moved := len(task.Descendants(all, t.ID))
+
+	if err := h.taskRepo.Move(t.ID, newParent, newProject); err != nil {
+		return fmt.Errorf("failed to move task: %w", err)
+	}
+
+	fmt.Printf("✓ #%d %s movida", t.ID, t.Title)
+	if moved > 0 {
+		fmt.Printf(" com %d subtarefa(s)", moved)
+	}
+	fmt.Printf(" para o projeto #%d", newProject)
+	if newParent != 0 {
+		fmt.Printf(", sob #%d", newParent)
+	}
+	fmt.Println()
 	return nil
 }
 
+// printTaskTree mostra as tarefas em árvore; tarefas com subtarefas exibem o
+// progresso acumulado das folhas
+func printTaskTree(nodes []*task.Node, prefix string) {
+	for i, n := range nodes {
+		branch, next := "├── ", "│   "
+		if i == len(nodes)-1 {
+			branch, next = "└── ", "    "
+		}
+
+		t := n.Task
+		statusIcon := "○"
+		if t.Status == "completed" {
+			statusIcon = "✓"
+		} else if t.Status == "in_progress" {
+			statusIcon = "◐"
+		}
+
+		fmt.Printf("%s%s%s #%d %s [%s]", prefix, branch, statusIcon, t.ID, t.Title, t.Priority)
+		if len(n.Children) > 0 {
+			done, total := n.Progress()
+			fmt.Printf(" %s", formatProgress(done, total))
+		}
+		fmt.Println()
+
+		printTaskTree(n.Children, prefix+next)
+	}
+}
+
+func formatProgress(done, total int) string {
+	if total == 0 {
+		return "0/0"
+	}
+	return fmt.Sprintf("%d/%d (%d%%)", done, total, done*100/total)
+}
+
+func containsTask(tasks []*task.Task, id int) bool {
+	for _, t := range tasks {
+		if t.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// dependencyGraph carrega as dependências e todas as tarefas indexadas por ID
+func (h *taskHandler) dependencyGraph() (map[int][]int, map[int]*task.Task, error) {
+	deps, err := h.taskRepo.GetDependencies()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch task dependencies: %w", err)
+	}
+
+	all, err := h.taskRepo.GetAll("")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch tasks: %w", err)
+	}
+	byID := make(map[int]*task.Task, len(all))
+	for _, t := range all {
+		byID[t.ID] = t
+	}
+	return deps, byID, nil
+}
+
+// dependentsOf retorna as tarefas que dependem de id, em ordem de ID
+func dependentsOf(id int, deps map[int][]int, byID map[int]*task.Task) []*task.Task {
+	var dependents []*task.Task
+	for taskID, dependsOn := range deps {
+		for _, depID := range dependsOn {
+			if depID == id {
+				if t, ok := byID[taskID]; ok {
+					dependents = append(dependents, t)
+				}
+				break
+			}
+		}
+	}
+	sort.Slice(dependents, func(i, j int) bool { return dependents[i].ID < dependents[j].ID })
+	return dependents
+}
+
+func formatTaskIDs(ids []int) string {
+	parts := make([]string, 0, len(ids))
+	for _, id := range ids {
+		parts = append(parts, fmt.Sprintf("#%d", id))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func formatCycle(cycle []int) string {
+	parts := make([]string, 0, len(cycle))
+	for _, id := range cycle {
+		parts = append(parts, fmt.Sprintf("#%d", id))
+	}
+	return strings.Join(parts, " → ")
+}
+
@@ -0,0 +1,333 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/snip/internal/repository"
+	"github.com/snip/internal/task"
+)
+
+// Agrupamentos aceitos por "report time --by"
+const (
+	ReportByProject = "project"
+	ReportByTask    = "task"
+	ReportByTag     = "tag"
+)
+
+var reportGroupNames = map[string]string{
+	ReportByProject: "projeto",
+	ReportByTask:    "tarefa",
+	ReportByTag:     "tag",
+}
+
+// TimeHandler cuida do apontamento de horas nas tarefas e do relatório de tempo
+type TimeHandler interface {
+	StartTimer(taskID int, note string, tags []string) error
+	StopTimer(note string) error
+	LogTime(taskID int, duration time.Duration, note string, tags []string, date *time.Time) error
+	ReportTime(since time.Time, by string) error
+}
+
+type timeHandler struct {
+	timeRepo    repository.TimeEntryRepository
+	taskRepo    repository.TaskRepository
+	projectRepo repository.ProjectRepository
+	tagRepo     repository.TagRepository
+}
+
+func NewTimeHandler(timeRepo repository.TimeEntryRepository, taskRepo repository.TaskRepository, projectRepo repository.ProjectRepository, tagRepo repository.TagRepository) TimeHandler {
+	return &timeHandler{
+		timeRepo:    timeRepo,
+		taskRepo:    taskRepo,
+		projectRepo: projectRepo,
+		tagRepo:     tagRepo,
+	}
+}
+
+// StartTimer inicia o cronômetro da tarefa. Só pode haver um ativo por vez.
+func (h *timeHandler) StartTimer(taskID int, note string, tags []string) error {
+	t, err := h.taskRepo.GetByID(taskID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch task: %w", err)
+	}
+
+	if active, err := h.timeRepo.GetActive(); err == nil {
+		return fmt.Errorf("já existe um cronômetro ativo em #%d desde %s (use 'snip task stop')",
+			active.TaskID, active.StartedAt.Local().Format("15:04"))
+	} else if !errors.Is(err, repository.ErrNoActiveTimer) {
+		return fmt.Errorf("failed to fetch active timer: %w", err)
+	}
+
+	tagIDs, err := h.tagIDs(tags)
+	if err != nil {
+		return err
+	}
+
+	entry := &task.TimeEntry{TaskID: t.ID, StartedAt: time.Now(), Note: note}
+	if err := h.timeRepo.Create(entry, tagIDs); err != nil {
+		if errors.Is(err, repository.ErrTimerRunning) {
+			return fmt.Errorf("já existe um cronômetro ativo (use 'snip task stop')")
+		}
+		return fmt.Errorf("failed to start timer: %w", err)
+	}
+
+	fmt.Printf("⏱  Cronômetro iniciado em #%d %s às %s\n", t.ID, t.Title, entry.StartedAt.Format("15:04"))
+	return nil
+}
+
+// StopTimer encerra o cronômetro ativo; note é acrescentada à nota do início
+func (h *timeHandler) StopTimer(note string) error {
+	active, err := h.timeRepo.GetActive()
+	if errors.Is(err, repository.ErrNoActiveTimer) {
+		return fmt.Errorf("nenhum cronômetro ativo")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to fetch active timer: %w", err)
+	}
+
+	if note != "" && active.Note != "" {
+		note = active.Note + "; " + note
+	} else if note == "" {
+		note = active.Note
+	}
+	entry, err := h.timeRepo.Stop(active.ID, time.Now(), note)
+	if err != nil {
+		return fmt.Errorf("failed to stop timer: %w", err)
+	}
+
+	fmt.Printf("⏹  Cronômetro parado: #%d — %s\n", entry.TaskID, task.FormatDuration(entry.Duration))
+	return h.printTaskTotal(entry.TaskID)
+}
+
+// LogTime lança horas já trabalhadas. Com date a entrada começa às 09:00 desse dia;
+// sem date ela termina agora.
+func (h *timeHandler) LogTime(taskID int, duration time.Duration, note string, tags []string, date *time.Time) error {
+	if duration <= 0 {
+		return fmt.Errorf("a duração deve ser maior que zero")
+	}
+	t, err := h.taskRepo.GetByID(taskID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch task: %w", err)
+	}
+
+	tagIDs, err := h.tagIDs(tags)
+	if err != nil {
+		return err
+	}
+
+	end := time.Now()
+	start := end.Add(-duration)
+	if date != nil {
+		start = time.Date(date.Year(), date.Month(), date.Day(), 9, 0, 0, 0, time.Local)
+		end = start.Add(duration)
+	}
+
+	entry := &task.TimeEntry{TaskID: t.ID, StartedAt: start, EndedAt: &end, Duration: duration, Note: note}
+	if err := h.timeRepo.Create(entry, tagIDs); err != nil {
+		return fmt.Errorf("failed to log time: %w", err)
+	}
+
+	fmt.Printf("✓ %s lançadas em #%d %s (%s)\n", task.FormatDuration(duration), t.ID, t.Title, start.Format("2006-01-02"))
+	return h.printTaskTotal(t.ID)
+}
+
+// ReportTime soma as horas desde since agrupadas por projeto, tarefa ou tag e
+// compara estimativa e tempo real das tarefas envolvidas
+func (h *timeHandler) ReportTime(since time.Time, by string) error {
+	if _, ok := reportGroupNames[by]; !ok {
+		return fmt.Errorf("agrupamento inválido: %s (use project, task ou tag)", by)
+	}
+
+	entries, err := h.timeRepo.GetSince(since)
+	if err != nil {
+		return fmt.Errorf("failed to fetch time entries: %w", err)
+	}
+
+	now := time.Now()
+	fmt.Printf("Tempo registrado desde %s — por %s\n\n", since.Local().Format("2006-01-02 15:04"), reportGroupNames[by])
+
+	for _, e := range entries {
+		if e.IsRunning() {
+			fmt.Printf("⏱  Cronômetro ativo em #%d há %s\n\n", e.TaskID, task.FormatDuration(e.Elapsed(now)))
+		}
+	}
+	if len(entries) == 0 {
+		fmt.Println("Nenhum tempo registrado no período.")
+		return nil
+	}
+
+	tasks, err := h.taskRepo.GetAll("")
+	if err != nil {
+		return fmt.Errorf("failed to fetch tasks: %w", err)
+	}
+	byID := make(map[int]*task.Task, len(tasks))
+	for _, t := range tasks {
+		byID[t.ID] = t
+	}
+
+	var totals []task.TimeTotal
+	switch by {
+	case ReportByProject:
+		totals = task.GroupTime(entries, now, func(e *task.TimeEntry) []string {
+			return []string{strconv.Itoa(e.ProjectID)}
+		})
+	case ReportByTask:
+		totals = task.GroupTime(entries, now, func(e *task.TimeEntry) []string {
+			return []string{strconv.Itoa(e.TaskID)}
+		})
+	case ReportByTag:
+		totals = task.GroupTime(entries, now, func(e *task.TimeEntry) []string {
+			if len(e.Tags) == 0 {
+				return []string{"(sem tag)"}
+			}
+			return e.Tags
+		})
+	}
+
+	var grand time.Duration
+	for _, e := range entries {
+		grand += e.Elapsed(now)
+	}
+	max := totals[0].Duration
+
+	width := 0
+	names := make([]string, len(totals))
+	for i, total := range totals {
+		names[i] = h.reportLabel(by, total.Key, byID)
+		if n := len([]rune(names[i])); n > width {
+			width = n
+		}
+	}
+	if width > 40 {
+		width = 40
+	}
+
+	for i, total := range totals {
+		bar := 0
+		if max > 0 {
+			bar = int(float64(total.Duration) / float64(max) * 20)
+		}
+		percent := 0.0
+		if grand > 0 {
+			percent = float64(total.Duration) / float64(grand) * 100
+		}
+		fmt.Printf("  %s  %8s  %7s  %-20s %3.0f%%\n", padRight(names[i], width), task.FormatDuration(total.Duration),
+			task.FormatHours(total.Duration), strings.Repeat("█", bar), percent)
+	}
+	fmt.Printf("  %s\n", strings.Repeat("─", width+44))
+	fmt.Printf("  %s  %8s  %7s  (%d entrada(s))\n", padRight("Total", width), task.FormatDuration(grand), task.FormatHours(grand), len(entries))
+	if by == ReportByTag {
+		fmt.Println("\n(uma entrada com várias tags soma em cada uma delas)")
+	}
+
+	return h.printEstimates(entries, byID)
+}
+
+// printEstimates compara a estimativa com o total já apontado (em todo o histórico)
+// das tarefas que tiveram horas no período
+func (h *timeHandler) printEstimates(entries []*task.TimeEntry, byID map[int]*task.Task) error {
+	totals, err := h.timeRepo.TotalsByTask()
+	if err != nil {
+		return fmt.Errorf("failed to fetch time totals: %w", err)
+	}
+
+	seen := make(map[int]bool)
+	var estimated []*task.Task
+	for _, e := range entries {
+		if t, ok := byID[e.TaskID]; ok && !seen[t.ID] && t.EstimatedTime > 0 {
+			seen[t.ID] = true
+			estimated = append(estimated, t)
+		}
+	}
+	if len(estimated) == 0 {
+		return nil
+	}
+
+	fmt.Println("\nEstimado x real (total da tarefa):")
+	for _, t := range estimated {
+		actual := totals[t.ID]
+		diff := actual - t.EstimatedTime
+		mark := ""
+		if diff > 0 {
+			mark = " ⚠️"
+		}
+		fmt.Printf("  #%d %s — estimado %s · real %s · %s (%+.0f%%)%s\n", t.ID, t.Title,
+			task.FormatDuration(t.EstimatedTime), task.FormatDuration(actual), formatSignedDuration(diff),
+			float64(diff)/float64(t.EstimatedTime)*100, mark)
+	}
+	return nil
+}
+
+func (h *timeHandler) reportLabel(by string, key string, byID map[int]*task.Task) string {
+	id, _ := strconv.Atoi(key)
+	switch by {
+	case ReportByProject:
+		if p, err := h.projectRepo.GetByID(id); err == nil {
+			return fmt.Sprintf("#%d %s", p.ID, p.Name)
+		}
+		return fmt.Sprintf("#%d (removido)", id)
+	case ReportByTask:
+		if t, ok := byID[id]; ok {
+			return fmt.Sprintf("#%d %s", t.ID, t.Title)
+		}
+		return fmt.Sprintf("#%d (removida)", id)
+	}
+	return key
+}
+
+// printTaskTotal mostra o total apontado na tarefa e quanto da estimativa já foi usado
+func (h *timeHandler) printTaskTotal(taskID int) error {
+	totals, err := h.timeRepo.TotalsByTask()
+	if err != nil {
+		return fmt.Errorf("failed to fetch time totals: %w", err)
+	}
+	t, err := h.taskRepo.GetByID(taskID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch task: %w", err)
+	}
+
+	total := totals[taskID]
+	if t.EstimatedTime > 0 {
+		fmt.Printf("   └── Total: %s de %s estimadas (%.0f%%)\n", task.FormatDuration(total),
+			task.FormatDuration(t.EstimatedTime), float64(total)/float64(t.EstimatedTime)*100)
+	} else {
+		fmt.Printf("   └── Total: %s\n", task.FormatDuration(total))
+	}
+	return nil
+}
+
+func (h *timeHandler) tagIDs(names []string) ([]int, error) {
+	var ids []int
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		t, err := h.tagRepo.GetOrCreate(name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to save tag %s: %w", name, err)
+		}
+		ids = append(ids, t.ID)
+	}
+	return ids, nil
+}
+
+func formatSignedDuration(d time.Duration) string {
+	if d < 0 {
+		return "-" + task.FormatDuration(-d)
+	}
+	return "+" + task.FormatDuration(d)
+}
+
+// padRight completa com espaços (ou corta) até width runas
+func padRight(s string, width int) string {
+	runes := []rune(s)
+	if len(runes) > width {
+		return string(runes[:width-1]) + "…"
+	}
+	return s + strings.Repeat(" ", width-len(runes))
+}
@@ -0,0 +1,153 @@
+package httpclient
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"golang.org/x/net/http/httpproxy"
+)
+
+// New cria o cliente HTTP de uma integração a partir de ~/.snip/http_config.json
+func New(integration Integration) (*http.Client, error) {
+	config, err := LoadConfig()
+	if err != nil {
+		return nil, fmt.Errorf("erro ao carregar configuração de rede: %w", err)
+	}
+	return NewWithConfig(config, integration)
+}
+
+// NewWithConfig cria o cliente HTTP de uma integração a partir de uma configuração
+func NewWithConfig(config *Config, integration Integration) (*http.Client, error) {
+	settings := config.Resolve(integration)
+
+	timeout, err := parseTimeout(settings.Timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	tlsConfig, err := newTLSConfig(settings)
+	if err != nil {
+		return nil, fmt.Errorf("configuração TLS de %s: %w", integration, err)
+	}
+
+	proxy, err := newProxyFunc(settings)
+	if err != nil {
+		return nil, err
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = proxy
+	transport.TLSClientConfig = tlsConfig
+
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+	}, nil
+}
+
+func parseTimeout(value string) (time.Duration, error) {
+	if value == "" {
+		value = DefaultTimeout
+	}
+	timeout, err := time.ParseDuration(value)
+	if err != nil || timeout <= 0 {
+		return 0, fmt.Errorf("timeout inválido: %s (use ex: 30s, 2m)", value)
+	}
+	return timeout, nil
+}
+
+// ParseTLSVersion converte "1.2" em tls.VersionTLS12; vazio retorna TLS 1.2
+func ParseTLSVersion(value string) (uint16, error) {
+	switch strings.TrimPrefix(strings.ToLower(value), "tls") {
+	case "1.0":
+		return tls.VersionTLS10, nil
+	case "1.1":
+		return tls.VersionTLS11, nil
+	case "", "1.2":
+		return tls.VersionTLS12, nil
+	case "1.3":
+		return tls.VersionTLS13, nil
+	default:
+		return 0, fmt.Errorf("versão mínima de TLS inválida: %s (use 1.0, 1.1, 1.2 ou 1.3)", value)
+	}
+}
+
+func newTLSConfig(settings Settings) (*tls.Config, error) {
+	minVersion, err := ParseTLSVersion(settings.TLSMinVersion)
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig := &tls.Config{MinVersion: minVersion}
+
+	if len(settings.CAFiles) > 0 {
+		// As CAs extras complementam as do sistema em vez de substituí-las
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		for _, file := range settings.CAFiles {
+			pem, err := os.ReadFile(expandHome(file))
+			if err != nil {
+				return nil, fmt.Errorf("erro ao ler CA %s: %w", file, err)
+			}
+			if !pool.AppendCertsFromPEM(pem) {
+				return nil, fmt.Errorf("nenhum certificado PEM válido em %s", file)
+			}
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if settings.ClientCert != "" || settings.ClientKey != "" {
+		if settings.ClientCert == "" || settings.ClientKey == "" {
+			return nil, fmt.Errorf("client_cert e client_key devem ser informados juntos")
+		}
+		cert, err := tls.LoadX509KeyPair(expandHome(settings.ClientCert), expandHome(settings.ClientKey))
+		if err != nil {
+			return nil, fmt.Errorf("erro ao carregar certificado de cliente: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
+
+// newProxyFunc parte de HTTPS_PROXY/HTTP_PROXY/NO_PROXY e aplica a configuração por cima
+func newProxyFunc(settings Settings) (func(*http.Request) (*url.URL, error), error) {
+	if settings.Proxy == ProxyDirect {
+		return nil, nil
+	}
+
+	proxyConfig := httpproxy.FromEnvironment()
+	if settings.Proxy != "" {
+		if _, err := url.Parse(settings.Proxy); err != nil {
+			return nil, fmt.Errorf("proxy inválido %s: %w", settings.Proxy, err)
+		}
+		proxyConfig.HTTPProxy = settings.Proxy
+		proxyConfig.HTTPSProxy = settings.Proxy
+	}
+	if settings.NoProxy != "" {
+		proxyConfig.NoProxy = settings.NoProxy
+	}
+
+	proxyFunc := proxyConfig.ProxyFunc()
+	return func(req *http.Request) (*url.URL, error) {
+		return proxyFunc(req.URL)
+	}, nil
+}
+
+// expandHome expande ~/ para o diretório home
+func expandHome(path string) string {
+	if path == "~" || strings.HasPrefix(path, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, strings.TrimPrefix(path, "~"))
+		}
+	}
+	return path
+}
@@ -0,0 +1,149 @@
+package httpclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Integration identifica quem faz a chamada, permitindo ajustes por integração
+type Integration string
+
+const (
+	IntegrationAI         Integration = "ai"
+	IntegrationJira       Integration = "jira"
+	IntegrationConfluence Integration = "confluence"
+)
+
+// ProxyDirect desativa o proxy (inclusive o de HTTPS_PROXY/HTTP_PROXY)
+const ProxyDirect = "direct"
+
+// DefaultTimeout é o timeout usado quando nenhum é configurado
+const DefaultTimeout = "30s"
+
+// Settings descreve o transporte HTTP; campos vazios herdam o nível acima
+// (integração → configuração global → variáveis de ambiente/padrões)
+type Settings struct {
+	// Proxy é a URL do proxy (ex: http://proxy.empresa:3128) ou "direct".
+	// Vazio usa HTTPS_PROXY/HTTP_PROXY do ambiente.
+	Proxy string `json:"proxy,omitempty"`
+	// NoProxy lista hosts, domínios (.empresa.com) e CIDRs sem proxy; vazio usa NO_PROXY
+	NoProxy string `json:"no_proxy,omitempty"`
+	// CAFiles são bundles PEM adicionados às CAs do sistema
+	CAFiles []string `json:"ca_files,omitempty"`
+	// ClientCert e ClientKey são o certificado e a chave PEM para mTLS
+	ClientCert string `json:"client_cert,omitempty"`
+	ClientKey  string `json:"client_key,omitempty"`
+	// TLSMinVersion é a versão mínima de TLS: 1.0, 1.1, 1.2 (padrão) ou 1.3
+	TLSMinVersion string `json:"tls_min_version,omitempty"`
+	// Timeout é a duração máxima de uma requisição (ex: 30s, 2m)
+	Timeout string `json:"timeout,omitempty"`
+}
+
+// Config é a configuração global de rede com ajustes por integração
+type Config struct {
+	Settings
+	Integrations map[Integration]*Settings `json:"integrations,omitempty"`
+}
+
+// GetIntegrations retorna as integrações que aceitam ajustes próprios
+func GetIntegrations() []Integration {
+	return []Integration{IntegrationAI, IntegrationJira, IntegrationConfluence}
+}
+
+// IsValidIntegration verifica se a integração é conhecida
+func IsValidIntegration(integration Integration) bool {
+	for _, i := range GetIntegrations() {
+		if i == integration {
+			return true
+		}
+	}
+	return false
+}
+
+// GetConfigPath retorna o caminho do arquivo de configuração de rede
+func GetConfigPath() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("erro ao obter diretório home: %w", err)
+	}
+	configDir := filepath.Join(homeDir, ".snip")
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		return "", fmt.Errorf("erro ao criar diretório de configuração: %w", err)
+	}
+	return filepath.Join(configDir, "http_config.json"), nil
+}
+
+// LoadConfig carrega a configuração de rede
+func LoadConfig() (*Config, error) {
+	configPath, err := GetConfigPath()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &Config{}, nil
+		}
+		return nil, fmt.Errorf("erro ao ler configuração: %w", err)
+	}
+
+	var config Config
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("erro ao parsear configuração: %w", err)
+	}
+
+	return &config, nil
+}
+
+// SaveConfig salva a configuração de rede
+func SaveConfig(config *Config) error {
+	configPath, err := GetConfigPath()
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(config, "", "  ")
+	if err != nil {
+		return fmt.Errorf("erro ao serializar configuração: %w", err)
+	}
+
+	if err := os.WriteFile(configPath, data, 0600); err != nil {
+		return fmt.Errorf("erro ao salvar configuração: %w", err)
+	}
+
+	return nil
+}
+
+// Resolve retorna as configurações efetivas de uma integração
+func (c *Config) Resolve(integration Integration) Settings {
+	settings := c.Settings
+	settings.CAFiles = append([]string{}, c.CAFiles...)
+
+	override, ok := c.Integrations[integration]
+	if !ok || override == nil {
+		return settings
+	}
+
+	if override.Proxy != "" {
+		settings.Proxy = override.Proxy
+	}
+	if override.NoProxy != "" {
+		settings.NoProxy = override.NoProxy
+	}
+	// CAs são somadas: uma integração pode confiar em uma CA a mais
+	settings.CAFiles = append(settings.CAFiles, override.CAFiles...)
+	if override.ClientCert != "" {
+		settings.ClientCert = override.ClientCert
+		settings.ClientKey = override.ClientKey
+	}
+	if override.TLSMinVersion != "" {
+		settings.TLSMinVersion = override.TLSMinVersion
+	}
+	if override.Timeout != "" {
+		settings.Timeout = override.Timeout
+	}
+	return settings
+}
@@ -7,7 +7,8 @@ import (
 	"fmt"
 	"io"
 	"net/http"
-	"time"
+
+	"github.com/snip/internal/httpclient"
 )
 
 // Client é o cliente para interagir com a API do Jira
@@ -29,12 +30,15 @@ func NewClient(config *JiraConfig) (*Client, error) {
 		return nil, fmt.Errorf("API token não configurado")
 	}
 
+	httpClient, err := httpclient.New(httpclient.IntegrationJira)
+	if err != nil {
+		return nil, err
+	}
+
 	return &Client{
-		config: config,
-		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
-		},
-		baseURL: config.URL,
+		config:     config,
+		httpClient: httpClient,
+		baseURL:    config.URL,
 	}, nil
 }
 
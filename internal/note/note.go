@@ -15,10 +15,35 @@ type NoteWithTags struct {
 	Title     string    `json:"title"`
 	Content   string    `json:"content"`
 	Tags      []string  `json:"tags"`
+	Summary   string    `json:"summary,omitempty"`
+	Language  string    `json:"language,omitempty"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
 
+// Tipos de vínculo entre notas e o trabalho que descrevem
+const (
+	LinkProject = "project"
+	LinkTask    = "task"
+)
+
+// Link liga uma nota a um projeto ou tarefa; Title é o nome do alvo
+type Link struct {
+	NoteID   int    `json:"note_id"`
+	Kind     string `json:"kind"` // project, task
+	TargetID int    `json:"target_id"`
+	Title    string `json:"title"`
+}
+
+// LinkedNote é uma nota vinculada a um projeto, direto ou por uma das tarefas
+type LinkedNote struct {
+	ID        int       `json:"id"`
+	Title     string    `json:"title"`
+	CreatedAt time.Time `json:"created_at"`
+	// TaskID é a tarefa pela qual a nota chegou ao projeto (0 = vínculo direto)
+	TaskID int `json:"task_id,omitempty"`
+}
+
 func NewNote(title, content string) *Note {
 	now := time.Now()
 	return &Note{
@@ -1,6 +1,7 @@
 package oracle
 
 import (
+	"errors"
 	"fmt"
 	"regexp"
 	"strings"
@@ -16,111 +17,94 @@ type AINaturalLanguageInterpreter struct {
 
 // NewAINaturalLanguageInterpreter cria um novo interpretador
 func NewAINaturalLanguageInterpreter() (*AINaturalLanguageInterpreter, error) {
-	aiClient, err := ai.NewAIClient()
+	aiClient, err := ai.NewAIClientFor(ai.FeatureOracle)
 	if err != nil {
 		return nil, fmt.Errorf("erro ao criar cliente IA: %w", err)
 	}
 	return &AINaturalLanguageInterpreter{aiClient: aiClient}, nil
 }
 
-// ParseAWRRequest interpreta uma requisição em linguagem natural para AWR
-func (i *AINaturalLanguageInterpreter) ParseAWRRequest(naturalLanguage string, availableSnapshots []Snapshot) (*AWRReportRequest, error) {
-	prompt := fmt.Sprintf(`Você é um especialista em Oracle Database. Interprete a seguinte requisição do usuário e extraia os parâmetros para gerar um relatório AWR.
-
-Requisição do usuário: "%s"
-
-Snapshots disponíveis:
-%s
-
-Retorne APENAS um JSON com os seguintes campos (use null para valores não especificados):
-{
-  "begin_time": "YYYY-MM-DD HH:MM:SS" ou null,
-  "end_time": "YYYY-MM-DD HH:MM:SS" ou null,
-  "begin_snapshot": número ou null,
-  "end_snapshot": número ou null,
-  "duration_hours": número de horas ou null,
-  "report_type": "html" ou "text"
+// awrRequestResponse é o formato JSON pedido à IA para AWR
+type awrRequestResponse struct {
+	BeginTime     *string  `json:"begin_time"`
+	EndTime       *string  `json:"end_time"`
+	BeginSnapshot *int     `json:"begin_snapshot"`
+	EndSnapshot   *int     `json:"end_snapshot"`
+	DurationHours *float64 `json:"duration_hours"`
+	ReportType    *string  `json:"report_type"`
 }
 
-Exemplos de interpretação:
-- "relatório das últimas 2 horas" -> duration_hours: 2, end_time: agora
-- "relatório de ontem" -> begin_time: início de ontem, end_time: fim de ontem
-- "relatório entre os snaps 100 e 200" -> begin_snapshot: 100, end_snapshot: 200
-- "relatório de hoje de manhã" -> begin_time: início de hoje, end_time: meio-dia
-
-Retorne APENAS o JSON, sem explicações.`, naturalLanguage, formatSnapshots(availableSnapshots))
+// ashRequestResponse é o formato JSON pedido à IA para ASH
+type ashRequestResponse struct {
+	SQLID           *string `json:"sql_id"`
+	SID             *int    `json:"sid"`
+	Serial          *int    `json:"serial"`
+	BeginTime       *string `json:"begin_time"`
+	EndTime         *string `json:"end_time"`
+	DurationMinutes *int    `json:"duration_minutes"`
+}
 
-	response, err := i.aiClient.GenerateContent(prompt, 500)
+// ParseAWRRequest interpreta uma requisição em linguagem natural para AWR
+func (i *AINaturalLanguageInterpreter) ParseAWRRequest(naturalLanguage string, availableSnapshots []Snapshot) (*AWRReportRequest, error) {
+	messages, err := ai.PromptMessages("oracle.awr_request", ai.PromptData{
+		"Request":   naturalLanguage,
+		"Snapshots": formatSnapshots(availableSnapshots),
+	})
 	if err != nil {
-		return nil, fmt.Errorf("erro ao interpretar requisição: %w", err)
+		return nil, err
 	}
 
-	// Parsear JSON da resposta
-	req, err := parseAWRRequestFromJSON(response)
-	if err != nil {
-		// Tentar parsing manual se JSON falhar
-		req = parseAWRRequestManual(naturalLanguage, availableSnapshots)
+	var response awrRequestResponse
+	if err := i.aiClient.ChatJSON(messages, &response, 500, 0.7); err != nil {
+		if errors.Is(err, ai.ErrInvalidJSON) {
+			// Tentar parsing manual se JSON falhar
+			return parseAWRRequestManual(naturalLanguage, availableSnapshots), nil
+		}
+		return nil, fmt.Errorf("erro ao interpretar requisição: %w", err)
 	}
 
-	return req, nil
+	return response.toRequest(), nil
 }
 
 // ParseASHRequest interpreta uma requisição em linguagem natural para ASH
 func (i *AINaturalLanguageInterpreter) ParseASHRequest(naturalLanguage string) (*ASHRequest, error) {
-	prompt := fmt.Sprintf(`Você é um especialista em Oracle Database. Interprete a seguinte requisição do usuário e extraia os parâmetros para análise ASH.
-
-Requisição do usuário: "%s"
-
-Retorne APENAS um JSON com os seguintes campos (use null para valores não especificados):
-{
-  "sql_id": "string" ou null,
-  "sid": número ou null,
-  "serial": número ou null,
-  "begin_time": "YYYY-MM-DD HH:MM:SS" ou null,
-  "end_time": "YYYY-MM-DD HH:MM:SS" ou null,
-  "duration_minutes": número de minutos ou null
-}
-
-Exemplos:
-- "sessão 123" -> sid: 123
-- "SQL ID abc123def" -> sql_id: "abc123def"
-- "últimos 30 minutos" -> duration_minutes: 30
-- "sessão 456 serial 789" -> sid: 456, serial: 789
-
-Retorne APENAS o JSON, sem explicações.`, naturalLanguage)
-
-	response, err := i.aiClient.GenerateContent(prompt, 500)
+	messages, err := ai.PromptMessages("oracle.ash_request", ai.PromptData{
+		"Request": naturalLanguage,
+	})
 	if err != nil {
-		return nil, fmt.Errorf("erro ao interpretar requisição: %w", err)
+		return nil, err
 	}
 
-	req, err := parseASHRequestFromJSON(response)
-	if err != nil {
-		req = parseASHRequestManual(naturalLanguage)
+	var response ashRequestResponse
+	if err := i.aiClient.ChatJSON(messages, &response, 500, 0.7); err != nil {
+		if errors.Is(err, ai.ErrInvalidJSON) {
+			return parseASHRequestManual(naturalLanguage), nil
+		}
+		return nil, fmt.Errorf("erro ao interpretar requisição: %w", err)
 	}
 
-	return req, nil
+	return response.toRequest(), nil
 }
 
 // InterpretAnalysisResults interpreta resultados de análise e fornece recomendações
 func (i *AINaturalLanguageInterpreter) InterpretAnalysisResults(analysisType string, rawResults string) (string, error) {
-	prompt := fmt.Sprintf(`Você é um DBA experiente em Oracle Database. Analise os seguintes resultados e forneça:
-
-1. Resumo executivo em português
-2. Principais problemas identificados
-3. Recomendações de ação prioritárias
-4. Explicação técnica simplificada
-
-Tipo de Análise: %s
-
-Resultados:
-%s
-
-Formate a resposta em markdown, sendo claro e objetivo. Use linguagem técnica mas acessível.`, analysisType, rawResults)
+	prompt, err := renderUserPrompt("oracle.interpret", ai.PromptData{
+		"AnalysisType": analysisType,
+		"Result":       rawResults,
+	})
+	if err != nil {
+		return "", err
+	}
 
 	return i.aiClient.GenerateContent(prompt, 2000)
 }
 
+// renderUserPrompt renderiza um template de prompt que contém apenas a parte do usuário
+func renderUserPrompt(name string, data ai.PromptData) (string, error) {
+	_, prompt, err := ai.RenderPrompt(name, data)
+	return prompt, err
+}
+
 // formatSnapshots formata snapshots para exibição
 func formatSnapshots(snapshots []Snapshot) string {
 	if len(snapshots) == 0 {
@@ -139,53 +123,45 @@ func formatSnapshots(snapshots []Snapshot) string {
 	return sb.String()
 }
 
-// parseAWRRequestFromJSON parseia JSON da resposta da IA
-func parseAWRRequestFromJSON(jsonStr string) (*AWRReportRequest, error) {
-	// Limpar resposta (pode conter markdown code blocks)
-	jsonStr = strings.TrimSpace(jsonStr)
-	jsonStr = strings.TrimPrefix(jsonStr, "```json")
-	jsonStr = strings.TrimPrefix(jsonStr, "```")
-	jsonStr = strings.TrimSuffix(jsonStr, "```")
-	jsonStr = strings.TrimSpace(jsonStr)
+// parseRequestTime converte um horário da resposta da IA
+func parseRequestTime(value *string) time.Time {
+	if value == nil {
+		return time.Time{}
+	}
+	t, err := time.ParseInLocation("2006-01-02 15:04:05", *value, time.Local)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
 
+// toRequest converte a resposta da IA em requisição AWR
+func (r *awrRequestResponse) toRequest() *AWRReportRequest {
 	req := &AWRReportRequest{
 		ReportType: "html",
 		InstanceID: 1,
+		BeginTime:  parseRequestTime(r.BeginTime),
+		EndTime:    parseRequestTime(r.EndTime),
 	}
 
-	// Parsing básico (implementação simplificada)
-	// Em produção, usar encoding/json
-
-	// Extrair begin_time
-	if match := regexp.MustCompile(`"begin_time"\s*:\s*"([^"]+)"`).FindStringSubmatch(jsonStr); len(match) > 1 {
-		if t, err := time.Parse("2006-01-02 15:04:05", match[1]); err == nil {
-			req.BeginTime = t
-		}
+	if r.BeginSnapshot != nil {
+		req.BeginSnapshot = *r.BeginSnapshot
 	}
-
-	// Extrair end_time
-	if match := regexp.MustCompile(`"end_time"\s*:\s*"([^"]+)"`).FindStringSubmatch(jsonStr); len(match) > 1 {
-		if t, err := time.Parse("2006-01-02 15:04:05", match[1]); err == nil {
-			req.EndTime = t
-		}
+	if r.EndSnapshot != nil {
+		req.EndSnapshot = *r.EndSnapshot
 	}
-
-	// Extrair begin_snapshot
-	if match := regexp.MustCompile(`"begin_snapshot"\s*:\s*(\d+)`).FindStringSubmatch(jsonStr); len(match) > 1 {
-		fmt.Sscanf(match[1], "%d", &req.BeginSnapshot)
-	}
-
-	// Extrair end_snapshot
-	if match := regexp.MustCompile(`"end_snapshot"\s*:\s*(\d+)`).FindStringSubmatch(jsonStr); len(match) > 1 {
-		fmt.Sscanf(match[1], "%d", &req.EndSnapshot)
+	if r.ReportType != nil && (*r.ReportType == "html" || *r.ReportType == "text") {
+		req.ReportType = *r.ReportType
 	}
 
-	// Extrair report_type
-	if match := regexp.MustCompile(`"report_type"\s*:\s*"([^"]+)"`).FindStringSubmatch(jsonStr); len(match) > 1 {
-		req.ReportType = match[1]
+	if r.DurationHours != nil && *r.DurationHours > 0 && req.BeginTime.IsZero() {
+		if req.EndTime.IsZero() {
+			req.EndTime = time.Now()
+		}
+		req.BeginTime = req.EndTime.Add(-time.Duration(*r.DurationHours * float64(time.Hour)))
 	}
 
-	return req, nil
+	return req
 }
 
 // parseAWRRequestManual parsing manual como fallback
@@ -234,55 +210,30 @@ func parseAWRRequestManual(naturalLanguage string, snapshots []Snapshot) *AWRRep
 	return req
 }
 
-// parseASHRequestFromJSON parseia JSON da resposta da IA para ASH
-func parseASHRequestFromJSON(jsonStr string) (*ASHRequest, error) {
-	jsonStr = strings.TrimSpace(jsonStr)
-	jsonStr = strings.TrimPrefix(jsonStr, "```json")
-	jsonStr = strings.TrimPrefix(jsonStr, "```")
-	jsonStr = strings.TrimSuffix(jsonStr, "```")
-	jsonStr = strings.TrimSpace(jsonStr)
-
-	req := &ASHRequest{}
-
-	// Extrair sql_id
-	if match := regexp.MustCompile(`"sql_id"\s*:\s*"([^"]+)"`).FindStringSubmatch(jsonStr); len(match) > 1 {
-		req.SQLID = match[1]
+// toRequest converte a resposta da IA em requisição ASH
+func (r *ashRequestResponse) toRequest() *ASHRequest {
+	req := &ASHRequest{
+		BeginTime: parseRequestTime(r.BeginTime),
+		EndTime:   parseRequestTime(r.EndTime),
 	}
 
-	// Extrair sid
-	if match := regexp.MustCompile(`"sid"\s*:\s*(\d+)`).FindStringSubmatch(jsonStr); len(match) > 1 {
-		fmt.Sscanf(match[1], "%d", &req.SID)
+	if r.SQLID != nil {
+		req.SQLID = *r.SQLID
 	}
-
-	// Extrair serial
-	if match := regexp.MustCompile(`"serial"\s*:\s*(\d+)`).FindStringSubmatch(jsonStr); len(match) > 1 {
-		fmt.Sscanf(match[1], "%d", &req.Serial)
-	}
-
-	// Extrair begin_time
-	if match := regexp.MustCompile(`"begin_time"\s*:\s*"([^"]+)"`).FindStringSubmatch(jsonStr); len(match) > 1 {
-		if t, err := time.Parse("2006-01-02 15:04:05", match[1]); err == nil {
-			req.BeginTime = t
-		}
+	if r.SID != nil {
+		req.SID = *r.SID
 	}
-
-	// Extrair end_time
-	if match := regexp.MustCompile(`"end_time"\s*:\s*"([^"]+)"`).FindStringSubmatch(jsonStr); len(match) > 1 {
-		if t, err := time.Parse("2006-01-02 15:04:05", match[1]); err == nil {
-			req.EndTime = t
-		}
+	if r.Serial != nil {
+		req.Serial = *r.Serial
 	}
 
-	// Extrair duration_minutes
-	if match := regexp.MustCompile(`"duration_minutes"\s*:\s*(\d+)`).FindStringSubmatch(jsonStr); len(match) > 1 {
-		var minutes int
-		fmt.Sscanf(match[1], "%d", &minutes)
+	if r.DurationMinutes != nil && *r.DurationMinutes > 0 {
 		now := time.Now()
 		req.EndTime = now
-		req.BeginTime = now.Add(-time.Duration(minutes) * time.Minute)
+		req.BeginTime = now.Add(-time.Duration(*r.DurationMinutes) * time.Minute)
 	}
 
-	return req, nil
+	return req
 }
 
 // parseASHRequestManual parsing manual como fallback
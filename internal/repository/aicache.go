@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/snip/internal/ai"
+)
+
+// AICacheRepository persiste o cache de respostas de IA
+type AICacheRepository interface {
+	Get(key string) (string, bool, error)
+	Put(entry *ai.CacheEntry) error
+	Stats() (*ai.CacheStats, error)
+	Clear(expiredOnly bool) (int64, error)
+	Close() error
+}
+
+type aiCacheRepository struct {
+	db *sql.DB
+}
+
+func NewAICacheRepository(db *sql.DB) (AICacheRepository, error) {
+	return &aiCacheRepository{db: db}, nil
+}
+
+func (r *aiCacheRepository) Close() error {
+	return r.db.Close()
+}
+
+func (r *aiCacheRepository) Get(key string) (string, bool, error) {
+	var response string
+	err := r.db.QueryRow(
+		"SELECT response FROM ai_cache WHERE cache_key = ? AND expires_at > ?",
+		key, time.Now(),
+	).Scan(&response)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", false, nil
+		}
+		return "", false, err
+	}
+
+	if _, err := r.db.Exec("UPDATE ai_cache SET hits = hits + 1 WHERE cache_key = ?", key); err != nil {
+		return "", false, err
+	}
+
+	return response, true, nil
+}
+
+func (r *aiCacheRepository) Put(entry *ai.CacheEntry) error {
+	query := `
+		INSERT OR REPLACE INTO ai_cache (cache_key, provider, model, response, hits, created_at, expires_at)
+		VALUES (?, ?, ?, ?, 0, ?, ?)
+	`
+
+	_, err := r.db.Exec(
+		query,
+		entry.Key,
+		string(entry.Provider),
+		entry.Model,
+		entry.Response,
+		entry.CreatedAt,
+		entry.ExpiresAt,
+	)
+	return err
+}
+
+func (r *aiCacheRepository) Stats() (*ai.CacheStats, error) {
+	now := time.Now()
+	stats := &ai.CacheStats{ByModel: make(map[string]int)}
+
+	query := `
+		SELECT COUNT(*), COALESCE(SUM(CASE WHEN expires_at <= ? THEN 1 ELSE 0 END), 0),
+		       COALESCE(SUM(hits), 0), COALESCE(SUM(LENGTH(response)), 0)
+		FROM ai_cache
+	`
+	if err := r.db.QueryRow(query, now).Scan(&stats.Entries, &stats.Expired, &stats.Hits, &stats.SizeBytes); err != nil {
+		return nil, err
+	}
+
+	if stats.Entries == 0 {
+		return stats, nil
+	}
+
+	if err := r.db.QueryRow("SELECT created_at FROM ai_cache ORDER BY created_at ASC LIMIT 1").Scan(&stats.OldestEntry); err != nil {
+		return nil, err
+	}
+	if err := r.db.QueryRow("SELECT created_at FROM ai_cache ORDER BY created_at DESC LIMIT 1").Scan(&stats.NewestEntry); err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.Query("SELECT provider || '/' || model, COUNT(*) FROM ai_cache GROUP BY 1 ORDER BY 2 DESC")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var model string
+		var count int
+		if err := rows.Scan(&model, &count); err != nil {
+			return nil, err
+		}
+		stats.ByModel[model] = count
+	}
+
+	return stats, rows.Err()
+}
+
+func (r *aiCacheRepository) Clear(expiredOnly bool) (int64, error) {
+	var result sql.Result
+	var err error
+	if expiredOnly {
+		result, err = r.db.Exec("DELETE FROM ai_cache WHERE expires_at <= ?", time.Now())
+	} else {
+		result, err = r.db.Exec("DELETE FROM ai_cache")
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
@@ -0,0 +1,240 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/snip/internal/ai"
+	"github.com/snip/internal/aichat"
+)
+
+// ErrSessionNotFound indica que a sessão de chat não existe
+var ErrSessionNotFound = errors.New("sessão de chat não encontrada")
+
+// AIChatRepository persiste as sessões do snip ai chat
+type AIChatRepository interface {
+	CreateSession(session *aichat.ChatSession) error
+	UpdateSession(session *aichat.ChatSession) error
+	GetSession(id int) (*aichat.ChatSession, error)
+	GetLastSession() (*aichat.ChatSession, error)
+	ListSessions(limit int) ([]*aichat.ChatSession, error)
+	DeleteSession(id int) error
+	AddMessage(message *aichat.ChatMessage) error
+	PinNote(sessionID, noteID int) error
+	Close() error
+}
+
+type aiChatRepository struct {
+	db *sql.DB
+}
+
+func NewAIChatRepository(db *sql.DB) (AIChatRepository, error) {
+	return &aiChatRepository{db: db}, nil
+}
+
+func (r *aiChatRepository) Close() error {
+	return r.db.Close()
+}
+
+func (r *aiChatRepository) CreateSession(session *aichat.ChatSession) error {
+	query := `
+		INSERT INTO ai_sessions (title, provider, model, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?)
+	`
+
+	result, err := r.db.Exec(query, session.Title, string(session.Provider), session.Model, session.CreatedAt, session.UpdatedAt)
+	if err != nil {
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	session.ID = int(id)
+	return nil
+}
+
+func (r *aiChatRepository) UpdateSession(session *aichat.ChatSession) error {
+	query := `UPDATE ai_sessions SET title = ?, provider = ?, model = ?, updated_at = ? WHERE id = ?`
+	_, err := r.db.Exec(query, session.Title, string(session.Provider), session.Model, session.UpdatedAt, session.ID)
+	return err
+}
+
+// GetSession carrega a sessão com as mensagens e as notas fixadas
+func (r *aiChatRepository) GetSession(id int) (*aichat.ChatSession, error) {
+	query := `
+		SELECT id, title, COALESCE(provider, ''), COALESCE(model, ''), created_at, updated_at
+		FROM ai_sessions
+		WHERE id = ?
+	`
+
+	session := &aichat.ChatSession{}
+	var provider string
+	err := r.db.QueryRow(query, id).Scan(
+		&session.ID, &session.Title, &provider, &session.Model, &session.CreatedAt, &session.UpdatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrSessionNotFound
+		}
+		return nil, err
+	}
+	session.Provider = ai.Provider(provider)
+
+	if session.Messages, err = r.getMessages(id); err != nil {
+		return nil, err
+	}
+	session.MessageCount = len(session.Messages)
+
+	if session.PinnedNotes, err = r.getPinnedNotes(id); err != nil {
+		return nil, err
+	}
+
+	return session, nil
+}
+
+// GetLastSession carrega a sessão usada mais recentemente (--resume last)
+func (r *aiChatRepository) GetLastSession() (*aichat.ChatSession, error) {
+	var id int
+	err := r.db.QueryRow(`SELECT id FROM ai_sessions ORDER BY updated_at DESC, id DESC LIMIT 1`).Scan(&id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrSessionNotFound
+		}
+		return nil, err
+	}
+	return r.GetSession(id)
+}
+
+// ListSessions retorna as sessões mais recentes, sem as mensagens
+func (r *aiChatRepository) ListSessions(limit int) ([]*aichat.ChatSession, error) {
+	query := `
+		SELECT s.id, s.title, COALESCE(s.provider, ''), COALESCE(s.model, ''), s.created_at, s.updated_at,
+		       (SELECT COUNT(*) FROM ai_messages m WHERE m.session_id = s.id)
+		FROM ai_sessions s
+		ORDER BY s.updated_at DESC, s.id DESC
+		LIMIT ?
+	`
+
+	rows, err := r.db.Query(query, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var sessions []*aichat.ChatSession
+	for rows.Next() {
+		session := &aichat.ChatSession{}
+		var provider string
+		if err := rows.Scan(
+			&session.ID, &session.Title, &provider, &session.Model,
+			&session.CreatedAt, &session.UpdatedAt, &session.MessageCount,
+		); err != nil {
+			return nil, err
+		}
+		session.Provider = ai.Provider(provider)
+		sessions = append(sessions, session)
+	}
+
+	return sessions, rows.Err()
+}
+
+// DeleteSession remove a sessão com as mensagens e notas fixadas
+func (r *aiChatRepository) DeleteSession(id int) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec(`DELETE FROM ai_sessions WHERE id = ?`, id)
+	if err != nil {
+		return err
+	}
+	if affected, err := result.RowsAffected(); err == nil && affected == 0 {
+		return ErrSessionNotFound
+	}
+
+	if _, err := tx.Exec(`DELETE FROM ai_messages WHERE session_id = ?`, id); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`DELETE FROM ai_session_notes WHERE session_id = ?`, id); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *aiChatRepository) AddMessage(message *aichat.ChatMessage) error {
+	if message.Timestamp.IsZero() {
+		message.Timestamp = time.Now()
+	}
+
+	query := `INSERT INTO ai_messages (session_id, role, content, created_at) VALUES (?, ?, ?, ?)`
+	result, err := r.db.Exec(query, message.SessionID, message.Role, message.Content, message.Timestamp)
+	if err != nil {
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	message.ID = int(id)
+	return nil
+}
+
+func (r *aiChatRepository) PinNote(sessionID, noteID int) error {
+	query := `INSERT OR IGNORE INTO ai_session_notes (session_id, note_id) VALUES (?, ?)`
+	_, err := r.db.Exec(query, sessionID, noteID)
+	return err
+}
+
+func (r *aiChatRepository) getMessages(sessionID int) ([]aichat.ChatMessage, error) {
+	query := `
+		SELECT id, session_id, role, content, created_at
+		FROM ai_messages
+		WHERE session_id = ?
+		ORDER BY id
+	`
+
+	rows, err := r.db.Query(query, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	messages := []aichat.ChatMessage{}
+	for rows.Next() {
+		var msg aichat.ChatMessage
+		if err := rows.Scan(&msg.ID, &msg.SessionID, &msg.Role, &msg.Content, &msg.Timestamp); err != nil {
+			return nil, err
+		}
+		messages = append(messages, msg)
+	}
+
+	return messages, rows.Err()
+}
+
+func (r *aiChatRepository) getPinnedNotes(sessionID int) ([]int, error) {
+	rows, err := r.db.Query(`SELECT note_id FROM ai_session_notes WHERE session_id = ? ORDER BY rowid`, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
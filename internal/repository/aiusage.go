@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/snip/internal/ai"
+)
+
+// AIUsageRepository persiste o consumo das chamadas de IA
+type AIUsageRepository interface {
+	Record(record *ai.UsageRecord) error
+	Totals(since time.Time) (*ai.UsageSummary, error)
+	Summarize(since time.Time, groupBy string) ([]*ai.UsageSummary, error)
+	Close() error
+}
+
+type aiUsageRepository struct {
+	db *sql.DB
+}
+
+// usageGroupColumns mapeia os agrupamentos aceitos para colunas da tabela
+var usageGroupColumns = map[string]string{
+	"feature":  "feature",
+	"model":    "model",
+	"provider": "provider",
+	"day":      "date(created_at)",
+}
+
+func NewAIUsageRepository(db *sql.DB) (AIUsageRepository, error) {
+	return &aiUsageRepository{db: db}, nil
+}
+
+func (r *aiUsageRepository) Close() error {
+	return r.db.Close()
+}
+
+func (r *aiUsageRepository) Record(record *ai.UsageRecord) error {
+	if record.CreatedAt.IsZero() {
+		record.CreatedAt = time.Now()
+	}
+
+	query := `
+		INSERT INTO ai_usage (
+			provider, model, feature, input_tokens, output_tokens,
+			latency_ms, cost_usd, created_at
+		)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	result, err := r.db.Exec(
+		query,
+		string(record.Provider),
+		record.Model,
+		string(record.Feature),
+		record.InputTokens,
+		record.OutputTokens,
+		record.LatencyMs,
+		record.CostUSD,
+		record.CreatedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	record.ID = int(id)
+	return nil
+}
+
+func (r *aiUsageRepository) Totals(since time.Time) (*ai.UsageSummary, error) {
+	query := `
+		SELECT COUNT(*), COALESCE(SUM(input_tokens), 0), COALESCE(SUM(output_tokens), 0),
+		       COALESCE(AVG(latency_ms), 0), COALESCE(SUM(cost_usd), 0)
+		FROM ai_usage
+		WHERE created_at >= ?
+	`
+
+	summary := &ai.UsageSummary{Key: "total"}
+	err := r.db.QueryRow(query, since).Scan(
+		&summary.Requests,
+		&summary.InputTokens,
+		&summary.OutputTokens,
+		&summary.AvgLatencyMs,
+		&summary.CostUSD,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return summary, nil
+}
+
+func (r *aiUsageRepository) Summarize(since time.Time, groupBy string) ([]*ai.UsageSummary, error) {
+	column, ok := usageGroupColumns[groupBy]
+	if !ok {
+		return nil, fmt.Errorf("agrupamento inválido: %s (use: feature, model, provider, day)", groupBy)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT COALESCE(NULLIF(%s, ''), 'default'), COUNT(*),
+		       COALESCE(SUM(input_tokens), 0), COALESCE(SUM(output_tokens), 0),
+		       COALESCE(AVG(latency_ms), 0), COALESCE(SUM(cost_usd), 0)
+		FROM ai_usage
+		WHERE created_at >= ?
+		GROUP BY 1
+		ORDER BY 6 DESC, 2 DESC
+	`, column)
+
+	rows, err := r.db.Query(query, since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var summaries []*ai.UsageSummary
+	for rows.Next() {
+		s := &ai.UsageSummary{}
+		if err := rows.Scan(&s.Key, &s.Requests, &s.InputTokens, &s.OutputTokens, &s.AvgLatencyMs, &s.CostUSD); err != nil {
+			return nil, err
+		}
+		summaries = append(summaries, s)
+	}
+
+	return summaries, rows.Err()
+}
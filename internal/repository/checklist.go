@@ -16,7 +16,10 @@ type ChecklistRepository interface {
 	GetByTaskID(taskID int) ([]*checklist.Checklist, error)
 	GetByProjectID(projectID int) ([]*checklist.Checklist, error)
 	GetAll() ([]*checklist.Checklist, error)
+	// GetTemplateByTitle busca um template pelo título, sem diferenciar maiúsculas
+	GetTemplateByTitle(title string) (*checklist.Checklist, error)
 	Update(id int, title, description string) error
+	SetTemplate(id int, isTemplate bool) error
 	Delete(id int) error
 	Close() error
 }
@@ -24,8 +27,11 @@ type ChecklistRepository interface {
 type ChecklistItemRepository interface {
 	Create(item *checklist.ChecklistItem) error
 	GetByChecklistID(checklistID int) ([]*checklist.ChecklistItem, error)
-	Update(id int, title, description string, completed bool) error
+	// Update grava título, descrição, conclusão, categoria, prioridade, status e notas do item
+	Update(item *checklist.ChecklistItem) error
 	ToggleComplete(id int) error
+	// UpdateOrder grava o campo Order de cada item
+	UpdateOrder(items []*checklist.ChecklistItem) error
 	Delete(id int) error
 	Close() error
 }
@@ -56,8 +62,8 @@ func (r *checklistItemRepository) Close() error {
 
 func (r *checklistRepository) Create(c *checklist.Checklist) error {
 	query := `
-		INSERT INTO checklists (task_id, project_id, title, description, created_at, updated_at)
-		VALUES (?, ?, ?, ?, ?, ?)
+		INSERT INTO checklists (task_id, project_id, title, description, is_template, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
 	`
 	var taskID, projectID interface{}
 	if c.TaskID != nil {
@@ -67,7 +73,12 @@ func (r *checklistRepository) Create(c *checklist.Checklist) error {
 		projectID = *c.ProjectID
 	}
 
-	result, err := r.db.Exec(query, taskID, projectID, c.Title, c.Description, c.CreatedAt, c.UpdatedAt)
+	isTemplate := 0
+	if c.IsTemplate {
+		isTemplate = 1
+	}
+
+	result, err := r.db.Exec(query, taskID, projectID, c.Title, c.Description, isTemplate, c.CreatedAt, c.UpdatedAt)
 	if err != nil {
 		return err
 	}
@@ -82,12 +93,25 @@ func (r *checklistRepository) Create(c *checklist.Checklist) error {
 }
 
 func (r *checklistRepository) GetByID(id int) (*checklist.Checklist, error) {
-	query := `SELECT id, task_id, project_id, title, description, created_at, updated_at FROM checklists WHERE id = ?`
+	query := `SELECT id, task_id, project_id, title, description, COALESCE(is_template, 0), created_at, updated_at FROM checklists WHERE id = ?`
 	
+	return r.scanChecklist(r.db.QueryRow(query, id))
+}
+
+// GetTemplateByTitle busca o template pelo título; com títulos repetidos
+// retorna o mais recente
+func (r *checklistRepository) GetTemplateByTitle(title string) (*checklist.Checklist, error) {
+	query := `SELECT id, task_id, project_id, title, description, COALESCE(is_template, 0), created_at, updated_at
+		FROM checklists WHERE is_template = 1 AND title = ? COLLATE NOCASE ORDER BY created_at DESC LIMIT 1`
+
+	return r.scanChecklist(r.db.QueryRow(query, title))
+}
+
+func (r *checklistRepository) scanChecklist(row *sql.Row) (*checklist.Checklist, error) {
 	c := &checklist.Checklist{}
 	var taskID, projectID sql.NullInt64
-	err := r.db.QueryRow(query, id).Scan(
-		&c.ID, &taskID, &projectID, &c.Title, &c.Description, &c.CreatedAt, &c.UpdatedAt,
+	err := row.Scan(
+		&c.ID, &taskID, &projectID, &c.Title, &c.Description, &c.IsTemplate, &c.CreatedAt, &c.UpdatedAt,
 	)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -109,7 +133,7 @@ func (r *checklistRepository) GetByID(id int) (*checklist.Checklist, error) {
 }
 
 func (r *checklistRepository) GetByTaskID(taskID int) ([]*checklist.Checklist, error) {
-	query := `SELECT id, task_id, project_id, title, description, created_at, updated_at 
+	query := `SELECT id, task_id, project_id, title, description, COALESCE(is_template, 0), created_at, updated_at 
 		FROM checklists WHERE task_id = ? ORDER BY created_at DESC`
 	
 	rows, err := r.db.Query(query, taskID)
@@ -122,7 +146,7 @@ func (r *checklistRepository) GetByTaskID(taskID int) ([]*checklist.Checklist, e
 }
 
 func (r *checklistRepository) GetByProjectID(projectID int) ([]*checklist.Checklist, error) {
-	query := `SELECT id, task_id, project_id, title, description, created_at, updated_at 
+	query := `SELECT id, task_id, project_id, title, description, COALESCE(is_template, 0), created_at, updated_at 
 		FROM checklists WHERE project_id = ? ORDER BY created_at DESC`
 	
 	rows, err := r.db.Query(query, projectID)
@@ -135,7 +159,7 @@ func (r *checklistRepository) GetByProjectID(projectID int) ([]*checklist.Checkl
 }
 
 func (r *checklistRepository) GetAll() ([]*checklist.Checklist, error) {
-	query := `SELECT id, task_id, project_id, title, description, created_at, updated_at 
+	query := `SELECT id, task_id, project_id, title, description, COALESCE(is_template, 0), created_at, updated_at 
 		FROM checklists ORDER BY created_at DESC`
 	
 	rows, err := r.db.Query(query)
@@ -152,7 +176,7 @@ func (r *checklistRepository) scanChecklists(rows *sql.Rows) ([]*checklist.Check
 	for rows.Next() {
 		c := &checklist.Checklist{}
 		var taskID, projectID sql.NullInt64
-		err := rows.Scan(&c.ID, &taskID, &projectID, &c.Title, &c.Description, &c.CreatedAt, &c.UpdatedAt)
+		err := rows.Scan(&c.ID, &taskID, &projectID, &c.Title, &c.Description, &c.IsTemplate, &c.CreatedAt, &c.UpdatedAt)
 		if err != nil {
 			return nil, err
 		}
@@ -175,7 +199,31 @@ func (r *checklistRepository) Update(id int, title, description string) error {
 	return err
 }
 
+func (r *checklistRepository) SetTemplate(id int, isTemplate bool) error {
+	value := 0
+	if isTemplate {
+		value = 1
+	}
+	query := `UPDATE checklists SET is_template = ?, updated_at = ? WHERE id = ?`
+	result, err := r.db.Exec(query, value, time.Now(), id)
+	if err != nil {
+		return err
+	}
+	if affected, err := result.RowsAffected(); err == nil && affected == 0 {
+		return ErrChecklistNotFound
+	}
+	return nil
+}
+
 func (r *checklistRepository) Delete(id int) error {
+	// Chaves estrangeiras não são aplicadas pelo SQLite por padrão
+	if _, err := r.db.Exec(`DELETE FROM checklist_run_items WHERE run_id IN (SELECT id FROM checklist_runs WHERE checklist_id = ?)`, id); err != nil {
+		return err
+	}
+	if _, err := r.db.Exec(`DELETE FROM checklist_runs WHERE checklist_id = ?`, id); err != nil {
+		return err
+	}
+
 	query := `DELETE FROM checklists WHERE id = ?`
 	_, err := r.db.Exec(query, id)
 	return err
@@ -185,15 +233,16 @@ func (r *checklistRepository) Delete(id int) error {
 
 func (r *checklistItemRepository) Create(item *checklist.ChecklistItem) error {
 	query := `
-		INSERT INTO checklist_items (checklist_id, title, description, completed, item_order, created_at, updated_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?)
+		INSERT INTO checklist_items (checklist_id, title, description, completed, item_order, category, priority, status, notes, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 	completed := 0
 	if item.Completed {
 		completed = 1
 	}
 
-	result, err := r.db.Exec(query, item.ChecklistID, item.Title, item.Description, completed, item.Order, item.CreatedAt, item.UpdatedAt)
+	result, err := r.db.Exec(query, item.ChecklistID, item.Title, item.Description, completed, item.Order,
+		item.Category, item.Priority, item.Status, item.Notes, item.CreatedAt, item.UpdatedAt)
 	if err != nil {
 		return err
 	}
@@ -208,7 +257,8 @@ func (r *checklistItemRepository) Create(item *checklist.ChecklistItem) error {
 }
 
 func (r *checklistItemRepository) GetByChecklistID(checklistID int) ([]*checklist.ChecklistItem, error) {
-	query := `SELECT id, checklist_id, title, description, completed, item_order, created_at, updated_at 
+	query := `SELECT id, checklist_id, title, description, completed, item_order,
+		COALESCE(category, ''), COALESCE(priority, ''), COALESCE(status, ''), COALESCE(notes, ''), created_at, updated_at
 		FROM checklist_items WHERE checklist_id = ? ORDER BY item_order ASC, created_at ASC`
 	
 	rows, err := r.db.Query(query, checklistID)
@@ -221,7 +271,8 @@ func (r *checklistItemRepository) GetByChecklistID(checklistID int) ([]*checklis
 	for rows.Next() {
 		item := &checklist.ChecklistItem{}
 		var completed int
-		err := rows.Scan(&item.ID, &item.ChecklistID, &item.Title, &item.Description, &completed, &item.Order, &item.CreatedAt, &item.UpdatedAt)
+		err := rows.Scan(&item.ID, &item.ChecklistID, &item.Title, &item.Description, &completed, &item.Order,
+			&item.Category, &item.Priority, &item.Status, &item.Notes, &item.CreatedAt, &item.UpdatedAt)
 		if err != nil {
 			return nil, err
 		}
@@ -232,13 +283,16 @@ func (r *checklistItemRepository) GetByChecklistID(checklistID int) ([]*checklis
 	return items, nil
 }
 
-func (r *checklistItemRepository) Update(id int, title, description string, completed bool) error {
+func (r *checklistItemRepository) Update(item *checklist.ChecklistItem) error {
 	completedVal := 0
-	if completed {
+	if item.Completed {
 		completedVal = 1
 	}
-	query := `UPDATE checklist_items SET title = ?, description = ?, completed = ?, updated_at = ? WHERE id = ?`
-	_, err := r.db.Exec(query, title, description, completedVal, time.Now(), id)
+	item.UpdatedAt = time.Now()
+	query := `UPDATE checklist_items
+		SET title = ?, description = ?, completed = ?, category = ?, priority = ?, status = ?, notes = ?, updated_at = ?
+		WHERE id = ?`
+	_, err := r.db.Exec(query, item.Title, item.Description, completedVal, item.Category, item.Priority, item.Status, item.Notes, item.UpdatedAt, item.ID)
 	return err
 }
 
@@ -253,6 +307,23 @@ func (r *checklistItemRepository) ToggleComplete(id int) error {
 	return err
 }
 
+func (r *checklistItemRepository) UpdateOrder(items []*checklist.ChecklistItem) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	for _, item := range items {
+		if _, err := tx.Exec(`UPDATE checklist_items SET item_order = ?, updated_at = ? WHERE id = ?`, item.Order, now, item.ID); err != nil {
+			return err
+		}
+		item.UpdatedAt = now
+	}
+	return tx.Commit()
+}
+
 func (r *checklistItemRepository) Delete(id int) error {
 	query := `DELETE FROM checklist_items WHERE id = ?`
 	_, err := r.db.Exec(query, id)
@@ -0,0 +1,148 @@
+package repository
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/snip/internal/checklist"
+)
+
+// ChecklistRunRepository persiste as execuções dos templates de checklist e o
+// resultado de cada item
+type ChecklistRunRepository interface {
+	Create(run *checklist.Run) error
+	// AddResult grava o resultado de um item e o acrescenta a run.Results
+	AddResult(run *checklist.Run, result *checklist.RunItem) error
+	Finish(run *checklist.Run, finishedAt time.Time) error
+	// GetByChecklistID retorna as últimas limit execuções (0 = todas) em ordem
+	// cronológica, com os resultados
+	GetByChecklistID(checklistID int, limit int) ([]*checklist.Run, error)
+	Close() error
+}
+
+type checklistRunRepository struct {
+	db *sql.DB
+}
+
+func NewChecklistRunRepository(db *sql.DB) (ChecklistRunRepository, error) {
+	return &checklistRunRepository{db: db}, nil
+}
+
+func (r *checklistRunRepository) Close() error {
+	return r.db.Close()
+}
+
+func (r *checklistRunRepository) Create(run *checklist.Run) error {
+	result, err := r.db.Exec(`INSERT INTO checklist_runs (checklist_id, started_at) VALUES (?, ?)`,
+		run.ChecklistID, run.StartedAt.UTC())
+	if err != nil {
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	run.ID = int(id)
+	return nil
+}
+
+func (r *checklistRunRepository) AddResult(run *checklist.Run, item *checklist.RunItem) error {
+	if item.RecordedAt.IsZero() {
+		item.RecordedAt = time.Now()
+	}
+	item.RunID = run.ID
+
+	result, err := r.db.Exec(`
+		INSERT INTO checklist_run_items (run_id, item_id, title, result, notes, recorded_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`, item.RunID, item.ItemID, item.Title, string(item.Result), item.Notes, item.RecordedAt.UTC())
+	if err != nil {
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	item.ID = int(id)
+	run.Results = append(run.Results, item)
+	return nil
+}
+
+func (r *checklistRunRepository) Finish(run *checklist.Run, finishedAt time.Time) error {
+	if _, err := r.db.Exec(`UPDATE checklist_runs SET finished_at = ? WHERE id = ?`, finishedAt.UTC(), run.ID); err != nil {
+		return err
+	}
+	run.FinishedAt = &finishedAt
+	return nil
+}
+
+func (r *checklistRunRepository) GetByChecklistID(checklistID int, limit int) ([]*checklist.Run, error) {
+	if limit <= 0 {
+		limit = -1
+	}
+	query := `
+		SELECT id, checklist_id, started_at, finished_at FROM (
+			SELECT id, checklist_id, started_at, finished_at
+			FROM checklist_runs
+			WHERE checklist_id = ?
+			ORDER BY started_at DESC, id DESC
+			LIMIT ?
+		) ORDER BY started_at ASC, id ASC
+	`
+
+	rows, err := r.db.Query(query, checklistID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var runs []*checklist.Run
+	byID := make(map[int]*checklist.Run)
+	for rows.Next() {
+		run := &checklist.Run{}
+		var finishedAt sql.NullTime
+		if err := rows.Scan(&run.ID, &run.ChecklistID, &run.StartedAt, &finishedAt); err != nil {
+			return nil, err
+		}
+		if finishedAt.Valid {
+			run.FinishedAt = &finishedAt.Time
+		}
+		runs = append(runs, run)
+		byID[run.ID] = run
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(runs) == 0 {
+		return runs, nil
+	}
+
+	items, err := r.db.Query(`
+		SELECT ri.id, ri.run_id, ri.item_id, ri.title, ri.result, COALESCE(ri.notes, ''), ri.recorded_at
+		FROM checklist_run_items ri
+		JOIN checklist_runs cr ON cr.id = ri.run_id
+		WHERE cr.checklist_id = ?
+		ORDER BY ri.recorded_at ASC, ri.id ASC
+	`, checklistID)
+	if err != nil {
+		return nil, err
+	}
+	defer items.Close()
+
+	for items.Next() {
+		item := &checklist.RunItem{}
+		var result string
+		if err := items.Scan(&item.ID, &item.RunID, &item.ItemID, &item.Title, &result, &item.Notes, &item.RecordedAt); err != nil {
+			return nil, err
+		}
+		item.Result = checklist.RunResult(result)
+		if run, ok := byID[item.RunID]; ok {
+			run.Results = append(run.Results, item)
+		}
+	}
+	return runs, items.Err()
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"time"
 
@@ -35,9 +36,9 @@ func (r *dbAnalysisRepository) Create(analysis *dbanalysis.DBAnalysis) error {
 		INSERT INTO db_analyses (
 			title, database_type, analysis_type, connection_config, 
 			log_file_path, output_type, result, ai_insights, 
-			status, error_message, created_at, updated_at
+			status, error_message, metadata, created_at, updated_at
 		)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 
 	result, err := r.db.Exec(
@@ -52,6 +53,7 @@ func (r *dbAnalysisRepository) Create(analysis *dbanalysis.DBAnalysis) error {
 		analysis.AIInsights,
 		analysis.Status,
 		analysis.ErrorMessage,
+		encodeMetadata(analysis.Metadata),
 		analysis.CreatedAt,
 		analysis.UpdatedAt,
 	)
@@ -72,14 +74,14 @@ func (r *dbAnalysisRepository) GetByID(id int) (*dbanalysis.DBAnalysis, error) {
 	query := `
 		SELECT id, title, database_type, analysis_type, connection_config,
 		       log_file_path, output_type, result, ai_insights, status,
-		       error_message, created_at, updated_at
+		       error_message, metadata, created_at, updated_at
 		FROM db_analyses
 		WHERE id = ?
 	`
 
 	analysis := &dbanalysis.DBAnalysis{}
 	var dbType, analysisType, outputType, status string
-	var logPath, result, aiInsights, errorMsg sql.NullString
+	var logPath, result, aiInsights, errorMsg, metadata sql.NullString
 
 	err := r.db.QueryRow(query, id).Scan(
 		&analysis.ID,
@@ -93,6 +95,7 @@ func (r *dbAnalysisRepository) GetByID(id int) (*dbanalysis.DBAnalysis, error) {
 		&aiInsights,
 		&status,
 		&errorMsg,
+		&metadata,
 		&analysis.CreatedAt,
 		&analysis.UpdatedAt,
 	)
@@ -121,6 +124,7 @@ func (r *dbAnalysisRepository) GetByID(id int) (*dbanalysis.DBAnalysis, error) {
 	if errorMsg.Valid {
 		analysis.ErrorMessage = errorMsg.String
 	}
+	analysis.Metadata = decodeMetadata(metadata.String)
 
 	return analysis, nil
 }
@@ -129,7 +133,7 @@ func (r *dbAnalysisRepository) GetAll(limit int, dbType dbanalysis.DatabaseType,
 	query := `
 		SELECT id, title, database_type, analysis_type, connection_config,
 		       log_file_path, output_type, result, ai_insights, status,
-		       error_message, created_at, updated_at
+		       error_message, metadata, created_at, updated_at
 		FROM db_analyses
 		WHERE 1=1
 	`
@@ -162,7 +166,7 @@ func (r *dbAnalysisRepository) GetAll(limit int, dbType dbanalysis.DatabaseType,
 	for rows.Next() {
 		analysis := &dbanalysis.DBAnalysis{}
 		var dbType, analysisType, outputType, status string
-		var logPath, result, aiInsights, errorMsg sql.NullString
+		var logPath, result, aiInsights, errorMsg, metadata sql.NullString
 
 		err := rows.Scan(
 			&analysis.ID,
@@ -176,6 +180,7 @@ func (r *dbAnalysisRepository) GetAll(limit int, dbType dbanalysis.DatabaseType,
 			&aiInsights,
 			&status,
 			&errorMsg,
+			&metadata,
 			&analysis.CreatedAt,
 			&analysis.UpdatedAt,
 		)
@@ -200,6 +205,7 @@ func (r *dbAnalysisRepository) GetAll(limit int, dbType dbanalysis.DatabaseType,
 		if errorMsg.Valid {
 			analysis.ErrorMessage = errorMsg.String
 		}
+		analysis.Metadata = decodeMetadata(metadata.String)
 
 		analyses = append(analyses, analysis)
 	}
@@ -212,7 +218,7 @@ func (r *dbAnalysisRepository) Update(analysis *dbanalysis.DBAnalysis) error {
 	query := `
 		UPDATE db_analyses
 		SET title = ?, result = ?, ai_insights = ?, status = ?, 
-		    error_message = ?, updated_at = ?
+		    error_message = ?, metadata = ?, updated_at = ?
 		WHERE id = ?
 	`
 
@@ -223,6 +229,7 @@ func (r *dbAnalysisRepository) Update(analysis *dbanalysis.DBAnalysis) error {
 		analysis.AIInsights,
 		analysis.Status,
 		analysis.ErrorMessage,
+		encodeMetadata(analysis.Metadata),
 		analysis.UpdatedAt,
 		analysis.ID,
 	)
@@ -240,3 +247,25 @@ func (r *dbAnalysisRepository) GetRecent(limit int) ([]*dbanalysis.DBAnalysis, e
 	return r.GetAll(limit, "", "")
 }
 
+// encodeMetadata grava os metadados da análise como JSON (nil quando vazio)
+func encodeMetadata(metadata map[string]string) interface{} {
+	if len(metadata) == 0 {
+		return nil
+	}
+	data, err := json.Marshal(metadata)
+	if err != nil {
+		return nil
+	}
+	return string(data)
+}
+
+func decodeMetadata(data string) map[string]string {
+	if data == "" {
+		return nil
+	}
+	var metadata map[string]string
+	if err := json.Unmarshal([]byte(data), &metadata); err != nil {
+		return nil
+	}
+	return metadata
+}
@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/snip/internal/note"
+	"github.com/snip/internal/snippet"
 	"github.com/snip/internal/tag"
 )
 
@@ -25,6 +26,7 @@ type NoteRepository interface {
 	Patch(id int, title string) error
 	GetRecent(limit int) ([]*note.NoteWithTags, error)
 	ExportNotes(exportDir string, since *time.Time, format string) error
+	UpdateSummary(id int, summary string) error
 
 	// Tag operations
 	AddTagToNote(noteID, tagID int) error
@@ -68,7 +70,8 @@ func (r *repository) Create(note *note.Note) error {
 
 func (r *repository) GetByID(id int) (*note.NoteWithTags, error) {
 	query := `
-		SELECT n.id, n.title, n.content, n.created_at, n.updated_at, GROUP_CONCAT(t.name) AS tags
+		SELECT n.id, n.title, n.content, n.created_at, n.updated_at, GROUP_CONCAT(t.name) AS tags, COALESCE(n.summary, ''),
+		       COALESCE((SELECT s.language FROM note_snippets s WHERE s.note_id = n.id), '')
 		FROM notes n
 		LEFT JOIN notes_tags nt ON n.id = nt.note_id
 		LEFT JOIN tags t ON nt.tag_id = t.id
@@ -79,7 +82,7 @@ func (r *repository) GetByID(id int) (*note.NoteWithTags, error) {
 	var tagsStr sql.NullString
 
 	err := r.db.QueryRow(query, id).Scan(
-		&note.ID, &note.Title, &note.Content, &note.CreatedAt, &note.UpdatedAt, &tagsStr,
+		&note.ID, &note.Title, &note.Content, &note.CreatedAt, &note.UpdatedAt, &tagsStr, &note.Summary, &note.Language,
 	)
 
 	if err != nil {
@@ -122,7 +125,7 @@ func (r *repository) GetAll(isAsc bool, tagID int) ([]*note.NoteWithTags, error)
 	args := []any{}
 
 	query := `
-		SELECT n.id, n.title, n.content, n.created_at, n.updated_at, GROUP_CONCAT(t.name) AS tags
+		SELECT n.id, n.title, n.content, n.created_at, n.updated_at, GROUP_CONCAT(t.name) AS tags, COALESCE(n.summary, '')
 		FROM notes n
 		LEFT JOIN notes_tags nt ON n.id = nt.note_id
 		LEFT JOIN tags t ON nt.tag_id = t.id
@@ -147,7 +150,7 @@ func (r *repository) GetAll(isAsc bool, tagID int) ([]*note.NoteWithTags, error)
 	for db.Next() {
 		note := &note.NoteWithTags{}
 		var tagsStr sql.NullString
-		err := db.Scan(&note.ID, &note.Title, &note.Content, &note.CreatedAt, &note.UpdatedAt, &tagsStr)
+		err := db.Scan(&note.ID, &note.Title, &note.Content, &note.CreatedAt, &note.UpdatedAt, &tagsStr, &note.Summary)
 		if err != nil {
 			return nil, err
 		}
@@ -183,14 +186,62 @@ func (r *repository) Update(id int, content string, title string) error {
 
 	args = append(args, id)
 
-	_, err := r.db.Exec(query, args...)
-	return err
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(query, args...); err != nil {
+		return err
+	}
+
+	// Snippets acompanham a edição da nota: o código é extraído de novo
+	var language string
+	err = tx.QueryRow(`SELECT language FROM note_snippets WHERE note_id = ?`, id).Scan(&language)
+	if err == sql.ErrNoRows {
+		return tx.Commit()
+	}
+	if err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`UPDATE note_snippets SET code = ? WHERE note_id = ?`, snippet.ExtractCode(content, language), id); err != nil {
+		return err
+	}
+
+	return tx.Commit()
 }
 
 func (r *repository) Delete(id int) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Chaves estrangeiras não são aplicadas pelo SQLite por padrão
+	if _, err := tx.Exec(`DELETE FROM note_snippets WHERE note_id = ?`, id); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`DELETE FROM study_reviews WHERE card_id IN (SELECT id FROM study_cards WHERE note_id = ?)`, id); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`DELETE FROM study_cards WHERE note_id = ?`, id); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`DELETE FROM note_projects WHERE note_id = ?`, id); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`DELETE FROM note_tasks WHERE note_id = ?`, id); err != nil {
+		return err
+	}
+
 	query := `DELETE FROM notes WHERE id = ?`
-	_, err := r.db.Exec(query, id)
-	return err
+	if _, err := tx.Exec(query, id); err != nil {
+		return err
+	}
+
+	return tx.Commit()
 }
 
 func (r *repository) Search(term string) ([]*note.Note, error) {
@@ -219,6 +270,13 @@ func (r *repository) Search(term string) ([]*note.Note, error) {
 	return notes, nil
 }
 
+// UpdateSummary grava o resumo de uma linha gerado para a nota
+func (r *repository) UpdateSummary(id int, summary string) error {
+	query := `UPDATE notes SET summary = ? WHERE id = ?`
+	_, err := r.db.Exec(query, summary, id)
+	return err
+}
+
 func (r *repository) AddTagToNote(noteID, tagID int) error {
 	query := `INSERT OR IGNORE INTO notes_tags (note_id, tag_id) VALUES (?, ?)`
 	_, err := r.db.Exec(query, noteID, tagID)
@@ -271,7 +329,7 @@ func (r *repository) Patch(id int, title string) error {
 
 func (r *repository) GetRecent(limit int) ([]*note.NoteWithTags, error) {
 	query := `
-		SELECT n.id, n.title, n.content, n.created_at, n.updated_at, GROUP_CONCAT(t.name) AS tags
+		SELECT n.id, n.title, n.content, n.created_at, n.updated_at, GROUP_CONCAT(t.name) AS tags, COALESCE(n.summary, '')
 		FROM notes n
 		LEFT JOIN notes_tags nt ON n.id = nt.note_id
 		LEFT JOIN tags t ON nt.tag_id = t.id
@@ -291,7 +349,7 @@ func (r *repository) GetRecent(limit int) ([]*note.NoteWithTags, error) {
 	for db.Next() {
 		note := &note.NoteWithTags{}
 		var tagsStr sql.NullString
-		err := db.Scan(&note.ID, &note.Title, &note.Content, &note.CreatedAt, &note.UpdatedAt, &tagsStr)
+		err := db.Scan(&note.ID, &note.Title, &note.Content, &note.CreatedAt, &note.UpdatedAt, &tagsStr, &note.Summary)
 		if err != nil {
 			return nil, err
 		}
@@ -0,0 +1,183 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/snip/internal/note"
+)
+
+// ErrLinkTargetNotFound indica que o projeto ou a tarefa do vínculo não existe
+var ErrLinkTargetNotFound = errors.New("link target not found")
+
+// ErrLinkNotFound indica que a nota não está vinculada ao alvo informado
+var ErrLinkNotFound = errors.New("link not found")
+
+// NoteLinkRepository persiste os vínculos (muitos-para-muitos) entre notas,
+// projetos e tarefas
+type NoteLinkRepository interface {
+	CheckTarget(kind string, targetID int) error
+	Link(noteID int, kind string, targetID int) error
+	Unlink(noteID int, kind string, targetID int) error
+	GetLinks(noteID int) ([]*note.Link, error)
+	GetNotesByProject(projectID int) ([]*note.LinkedNote, error)
+	GetNotesByTask(taskID int) ([]*note.LinkedNote, error)
+	Close() error
+}
+
+// linkTable descreve a tabela de vínculo de cada tipo de alvo
+type linkTable struct {
+	table  string
+	column string
+	target string
+}
+
+var linkTables = map[string]linkTable{
+	note.LinkProject: {table: "note_projects", column: "project_id", target: "projects"},
+	note.LinkTask:    {table: "note_tasks", column: "task_id", target: "tasks"},
+}
+
+type noteLinkRepository struct {
+	db *sql.DB
+}
+
+func NewNoteLinkRepository(db *sql.DB) (NoteLinkRepository, error) {
+	return &noteLinkRepository{db: db}, nil
+}
+
+func (r *noteLinkRepository) Close() error {
+	return r.db.Close()
+}
+
+func tableFor(kind string) (linkTable, error) {
+	t, ok := linkTables[kind]
+	if !ok {
+		return linkTable{}, fmt.Errorf("unknown link type: %s (use project or task)", kind)
+	}
+	return t, nil
+}
+
+// CheckTarget confere se o projeto ou a tarefa existe
+func (r *noteLinkRepository) CheckTarget(kind string, targetID int) error {
+	t, err := tableFor(kind)
+	if err != nil {
+		return err
+	}
+	var id int
+	err = r.db.QueryRow(`SELECT id FROM `+t.target+` WHERE id = ?`, targetID).Scan(&id)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("%w: %s #%d", ErrLinkTargetNotFound, kind, targetID)
+	}
+	return err
+}
+
+// Link vincula a nota ao alvo; vincular de novo não tem efeito
+func (r *noteLinkRepository) Link(noteID int, kind string, targetID int) error {
+	t, err := tableFor(kind)
+	if err != nil {
+		return err
+	}
+	if err := r.CheckTarget(kind, targetID); err != nil {
+		return err
+	}
+	query := `INSERT OR IGNORE INTO ` + t.table + ` (note_id, ` + t.column + `) VALUES (?, ?)`
+	_, err = r.db.Exec(query, noteID, targetID)
+	return err
+}
+
+func (r *noteLinkRepository) Unlink(noteID int, kind string, targetID int) error {
+	t, err := tableFor(kind)
+	if err != nil {
+		return err
+	}
+	result, err := r.db.Exec(`DELETE FROM `+t.table+` WHERE note_id = ? AND `+t.column+` = ?`, noteID, targetID)
+	if err != nil {
+		return err
+	}
+	if affected, err := result.RowsAffected(); err == nil && affected == 0 {
+		return ErrLinkNotFound
+	}
+	return nil
+}
+
+// GetLinks retorna os projetos e as tarefas da nota, com o nome de cada um
+func (r *noteLinkRepository) GetLinks(noteID int) ([]*note.Link, error) {
+	query := `
+		SELECT np.note_id, 'project', np.project_id, COALESCE(p.name, '')
+		FROM note_projects np
+		LEFT JOIN projects p ON p.id = np.project_id
+		WHERE np.note_id = ?
+		UNION ALL
+		SELECT nt.note_id, 'task', nt.task_id, COALESCE(t.title, '')
+		FROM note_tasks nt
+		LEFT JOIN tasks t ON t.id = nt.task_id
+		WHERE nt.note_id = ?
+		ORDER BY 2, 3
+	`
+
+	rows, err := r.db.Query(query, noteID, noteID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var links []*note.Link
+	for rows.Next() {
+		link := &note.Link{}
+		if err := rows.Scan(&link.NoteID, &link.Kind, &link.TargetID, &link.Title); err != nil {
+			return nil, err
+		}
+		links = append(links, link)
+	}
+	return links, rows.Err()
+}
+
+// GetNotesByProject retorna as notas vinculadas ao projeto ou a uma das tarefas
+// dele; uma nota com as duas formas de vínculo aparece uma vez, como direta
+func (r *noteLinkRepository) GetNotesByProject(projectID int) ([]*note.LinkedNote, error) {
+	query := `
+		SELECT n.id, n.title, n.created_at, MIN(l.task_id)
+		FROM notes n
+		JOIN (
+			SELECT note_id, 0 AS task_id FROM note_projects WHERE project_id = ?
+			UNION ALL
+			SELECT nt.note_id, nt.task_id
+			FROM note_tasks nt
+			JOIN tasks t ON t.id = nt.task_id
+			WHERE t.project_id = ?
+		) l ON l.note_id = n.id
+		GROUP BY n.id
+		ORDER BY n.created_at DESC
+	`
+	return r.queryLinkedNotes(query, projectID, projectID)
+}
+
+func (r *noteLinkRepository) GetNotesByTask(taskID int) ([]*note.LinkedNote, error) {
+	query := `
+		SELECT n.id, n.title, n.created_at, 0
+		FROM notes n
+		JOIN note_tasks nt ON nt.note_id = n.id
+		WHERE nt.task_id = ?
+		ORDER BY n.created_at DESC
+	`
+	return r.queryLinkedNotes(query, taskID)
+}
+
+func (r *noteLinkRepository) queryLinkedNotes(query string, args ...any) ([]*note.LinkedNote, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var notes []*note.LinkedNote
+	for rows.Next() {
+		n := &note.LinkedNote{}
+		if err := rows.Scan(&n.ID, &n.Title, &n.CreatedAt, &n.TaskID); err != nil {
+			return nil, err
+		}
+		notes = append(notes, n)
+	}
+	return notes, rows.Err()
+}
@@ -108,6 +108,11 @@ func (r *projectRepository) Update(id int, name, description, status string) err
 }
 
 func (r *projectRepository) Delete(id int) error {
+	// Chaves estrangeiras não são aplicadas pelo SQLite por padrão
+	if _, err := r.db.Exec(`DELETE FROM note_projects WHERE project_id = ?`, id); err != nil {
+		return err
+	}
+
 	query := `DELETE FROM projects WHERE id = ?`
 	_, err := r.db.Exec(query, id)
 	return err
@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/snip/internal/note"
+	"github.com/snip/internal/snippet"
+)
+
+// ErrSnippetNotFound indica que a nota não existe ou não é um snippet
+var ErrSnippetNotFound = errors.New("snippet not found")
+
+// SnippetRepository persiste os snippets (código e linguagem ligados a uma nota)
+type SnippetRepository interface {
+	Create(n *note.Note, s *snippet.Snippet) error
+	GetByNoteID(noteID int) (*snippet.Snippet, error)
+	List(language string) ([]*snippet.Snippet, error)
+	Close() error
+}
+
+type snippetRepository struct {
+	db *sql.DB
+}
+
+func NewSnippetRepository(db *sql.DB) (SnippetRepository, error) {
+	return &snippetRepository{db: db}, nil
+}
+
+func (r *snippetRepository) Close() error {
+	return r.db.Close()
+}
+
+// Create grava a nota com a resposta completa e o snippet na mesma transação
+func (r *snippetRepository) Create(n *note.Note, s *snippet.Snippet) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec(
+		`INSERT INTO notes (title, content, created_at, updated_at) VALUES (?, ?, ?, ?)`,
+		n.Title, n.Content, n.CreatedAt, n.UpdatedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(
+		`INSERT INTO note_snippets (note_id, language, code, prompt, created_at) VALUES (?, ?, ?, ?, ?)`,
+		id, s.Language, s.Code, s.Prompt, s.CreatedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	n.ID = int(id)
+	s.NoteID = int(id)
+	s.Title = n.Title
+	return nil
+}
+
+func (r *snippetRepository) GetByNoteID(noteID int) (*snippet.Snippet, error) {
+	query := `
+		SELECT s.note_id, n.title, s.language, s.code, COALESCE(s.prompt, ''), s.created_at
+		FROM note_snippets s
+		JOIN notes n ON n.id = s.note_id
+		WHERE s.note_id = ?
+	`
+
+	s := &snippet.Snippet{}
+	err := r.db.QueryRow(query, noteID).Scan(&s.NoteID, &s.Title, &s.Language, &s.Code, &s.Prompt, &s.CreatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrSnippetNotFound
+		}
+		return nil, err
+	}
+	return s, nil
+}
+
+// List retorna os snippets mais recentes primeiro; language vazio retorna todos
+func (r *snippetRepository) List(language string) ([]*snippet.Snippet, error) {
+	query := `
+		SELECT s.note_id, n.title, s.language, s.code, COALESCE(s.prompt, ''), s.created_at
+		FROM note_snippets s
+		JOIN notes n ON n.id = s.note_id
+		WHERE (? = '' OR s.language = ?)
+		ORDER BY s.created_at DESC, s.note_id DESC
+	`
+
+	rows, err := r.db.Query(query, language, language)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var snippets []*snippet.Snippet
+	for rows.Next() {
+		s := &snippet.Snippet{}
+		if err := rows.Scan(&s.NoteID, &s.Title, &s.Language, &s.Code, &s.Prompt, &s.CreatedAt); err != nil {
+			return nil, err
+		}
+		snippets = append(snippets, s)
+	}
+	return snippets, rows.Err()
+}
@@ -0,0 +1,182 @@
+package repository
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/snip/internal/study"
+)
+
+// StudyRepository persiste os flashcards e o histórico de revisões
+type StudyRepository interface {
+	CreateCards(cards []*study.Card) error
+	CountByNote(noteID int) (int, error)
+	DeleteByNote(noteID int) (int, error)
+	// GetDue retorna os cartões vencidos até now; tagID 0 não filtra e limit 0 não limita
+	GetDue(now time.Time, tagID int, limit int) ([]*study.Card, error)
+	SaveReview(card *study.Card, review *study.Review) error
+	GetStats(now time.Time, tagID int) (*study.Stats, error)
+	Close() error
+}
+
+type studyRepository struct {
+	db *sql.DB
+}
+
+func NewStudyRepository(db *sql.DB) (StudyRepository, error) {
+	return &studyRepository{db: db}, nil
+}
+
+func (r *studyRepository) Close() error {
+	return r.db.Close()
+}
+
+func (r *studyRepository) CreateCards(cards []*study.Card) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO study_cards (note_id, question, answer, ease_factor, interval_days, repetitions, due_at, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`
+	for _, c := range cards {
+		result, err := tx.Exec(query, c.NoteID, c.Question, c.Answer, c.EaseFactor, c.Interval, c.Repetitions, c.DueAt, c.CreatedAt)
+		if err != nil {
+			return err
+		}
+		id, err := result.LastInsertId()
+		if err != nil {
+			return err
+		}
+		c.ID = int(id)
+	}
+
+	return tx.Commit()
+}
+
+func (r *studyRepository) CountByNote(noteID int) (int, error) {
+	var count int
+	err := r.db.QueryRow(`SELECT COUNT(*) FROM study_cards WHERE note_id = ?`, noteID).Scan(&count)
+	return count, err
+}
+
+// DeleteByNote remove os cartões da nota e as revisões deles
+func (r *studyRepository) DeleteByNote(noteID int) (int, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`DELETE FROM study_reviews WHERE card_id IN (SELECT id FROM study_cards WHERE note_id = ?)`, noteID); err != nil {
+		return 0, err
+	}
+	result, err := tx.Exec(`DELETE FROM study_cards WHERE note_id = ?`, noteID)
+	if err != nil {
+		return 0, err
+	}
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(deleted), tx.Commit()
+}
+
+func (r *studyRepository) GetDue(now time.Time, tagID int, limit int) ([]*study.Card, error) {
+	query := `
+		SELECT c.id, c.note_id, n.title, c.question, c.answer, c.ease_factor, c.interval_days,
+		       c.repetitions, c.due_at, c.last_reviewed_at, c.created_at
+		FROM study_cards c
+		JOIN notes n ON n.id = c.note_id
+		WHERE c.due_at <= ?
+		  AND (? = 0 OR c.note_id IN (SELECT note_id FROM notes_tags WHERE tag_id = ?))
+		ORDER BY c.due_at ASC, c.id ASC
+	`
+	args := []interface{}{now, tagID, tagID}
+	if limit > 0 {
+		query += ` LIMIT ?`
+		args = append(args, limit)
+	}
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var cards []*study.Card
+	for rows.Next() {
+		c := &study.Card{}
+		var lastReviewed sql.NullTime
+		if err := rows.Scan(&c.ID, &c.NoteID, &c.NoteTitle, &c.Question, &c.Answer, &c.EaseFactor, &c.Interval,
+			&c.Repetitions, &c.DueAt, &lastReviewed, &c.CreatedAt); err != nil {
+			return nil, err
+		}
+		if lastReviewed.Valid {
+			c.LastReviewedAt = &lastReviewed.Time
+		}
+		cards = append(cards, c)
+	}
+	return cards, rows.Err()
+}
+
+// SaveReview grava o novo agendamento do cartão e a revisão na mesma transação
+func (r *studyRepository) SaveReview(card *study.Card, review *study.Review) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(`
+		UPDATE study_cards
+		SET ease_factor = ?, interval_days = ?, repetitions = ?, due_at = ?, last_reviewed_at = ?
+		WHERE id = ?
+	`, card.EaseFactor, card.Interval, card.Repetitions, card.DueAt, card.LastReviewedAt, card.ID)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO study_reviews (card_id, quality, ease_factor, interval_days, reviewed_at)
+		VALUES (?, ?, ?, ?, ?)
+	`, review.CardID, review.Quality, review.EaseFactor, review.Interval, review.ReviewedAt)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *studyRepository) GetStats(now time.Time, tagID int) (*study.Stats, error) {
+	query := `
+		SELECT COUNT(*),
+		       COALESCE(SUM(CASE WHEN c.due_at <= ? THEN 1 ELSE 0 END), 0),
+		       COALESCE(SUM(CASE WHEN c.last_reviewed_at IS NULL THEN 1 ELSE 0 END), 0)
+		FROM study_cards c
+		WHERE (? = 0 OR c.note_id IN (SELECT note_id FROM notes_tags WHERE tag_id = ?))
+	`
+
+	stats := &study.Stats{}
+	if err := r.db.QueryRow(query, now, tagID, tagID).Scan(&stats.Total, &stats.Due, &stats.New); err != nil {
+		return nil, err
+	}
+
+	startOfDay := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	err := r.db.QueryRow(`
+		SELECT COUNT(*)
+		FROM study_reviews rv
+		JOIN study_cards c ON c.id = rv.card_id
+		WHERE rv.reviewed_at >= ?
+		  AND (? = 0 OR c.note_id IN (SELECT note_id FROM notes_tags WHERE tag_id = ?))
+	`, startOfDay, tagID, tagID).Scan(&stats.ReviewsToday)
+	if err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
@@ -18,6 +18,14 @@ type TaskRepository interface {
 	Update(id int, title, description, status, priority string, dueDate *time.Time) error
 	Delete(id int) error
 	ToggleComplete(id int) error
+	SetEstimate(id int, estimate time.Duration) error
+	SetRecurrence(id int, rule string) error
+	// Move troca o pai da tarefa (0 = primeiro nível) e leva ela e as subtarefas para projectID
+	Move(id int, parentID int, projectID int) error
+	AddDependency(taskID, dependsOnID int) error
+	RemoveDependency(taskID, dependsOnID int) error
+	// GetDependencies mapeia cada tarefa para as tarefas das quais ela depende
+	GetDependencies() (map[int][]int, error)
 	Close() error
 }
 
@@ -35,15 +43,20 @@ func (r *taskRepository) Close() error {
 
 func (r *taskRepository) Create(t *task.Task) error {
 	query := `
-		INSERT INTO tasks (project_id, title, description, status, priority, due_date, created_at, updated_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		INSERT INTO tasks (project_id, parent_task_id, title, description, status, priority, due_date, estimated_minutes, recurrence, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 	var dueDate interface{}
 	if t.DueDate != nil {
 		dueDate = t.DueDate
 	}
 
-	result, err := r.db.Exec(query, t.ProjectID, t.Title, t.Description, t.Status, t.Priority, dueDate, t.CreatedAt, t.UpdatedAt)
+	var parentID interface{}
+	if t.ParentTaskID != 0 {
+		parentID = t.ParentTaskID
+	}
+
+	result, err := r.db.Exec(query, t.ProjectID, parentID, t.Title, t.Description, t.Status, t.Priority, dueDate, int(t.EstimatedTime/time.Minute), t.Recurrence, t.CreatedAt, t.UpdatedAt)
 	if err != nil {
 		return err
 	}
@@ -58,12 +71,13 @@ func (r *taskRepository) Create(t *task.Task) error {
 }
 
 func (r *taskRepository) GetByID(id int) (*task.Task, error) {
-	query := `SELECT id, project_id, title, description, status, priority, due_date, created_at, updated_at FROM tasks WHERE id = ?`
+	query := `SELECT id, project_id, title, description, status, priority, due_date, created_at, updated_at, COALESCE(estimated_minutes, 0), COALESCE(parent_task_id, 0), COALESCE(recurrence, '') FROM tasks WHERE id = ?`
 	
 	t := &task.Task{}
 	var dueDate sql.NullTime
+	var estimatedMinutes int
 	err := r.db.QueryRow(query, id).Scan(
-		&t.ID, &t.ProjectID, &t.Title, &t.Description, &t.Status, &t.Priority, &dueDate, &t.CreatedAt, &t.UpdatedAt,
+		&t.ID, &t.ProjectID, &t.Title, &t.Description, &t.Status, &t.Priority, &dueDate, &t.CreatedAt, &t.UpdatedAt, &estimatedMinutes, &t.ParentTaskID, &t.Recurrence,
 	)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -75,6 +89,7 @@ func (r *taskRepository) GetByID(id int) (*task.Task, error) {
 	if dueDate.Valid {
 		t.DueDate = &dueDate.Time
 	}
+	t.EstimatedTime = time.Duration(estimatedMinutes) * time.Minute
 
 	return t, nil
 }
@@ -84,11 +99,11 @@ func (r *taskRepository) GetByProjectID(projectID int, status string) ([]*task.T
 	var args []interface{}
 
 	if status != "" {
-		query = `SELECT id, project_id, title, description, status, priority, due_date, created_at, updated_at 
+		query = `SELECT id, project_id, title, description, status, priority, due_date, created_at, updated_at, COALESCE(estimated_minutes, 0), COALESCE(parent_task_id, 0), COALESCE(recurrence, '') 
 			FROM tasks WHERE project_id = ? AND status = ? ORDER BY created_at DESC`
 		args = []interface{}{projectID, status}
 	} else {
-		query = `SELECT id, project_id, title, description, status, priority, due_date, created_at, updated_at 
+		query = `SELECT id, project_id, title, description, status, priority, due_date, created_at, updated_at, COALESCE(estimated_minutes, 0), COALESCE(parent_task_id, 0), COALESCE(recurrence, '') 
 			FROM tasks WHERE project_id = ? ORDER BY created_at DESC`
 		args = []interface{}{projectID}
 	}
@@ -103,13 +118,15 @@ func (r *taskRepository) GetByProjectID(projectID int, status string) ([]*task.T
 	for rows.Next() {
 		t := &task.Task{}
 		var dueDate sql.NullTime
-		err := rows.Scan(&t.ID, &t.ProjectID, &t.Title, &t.Description, &t.Status, &t.Priority, &dueDate, &t.CreatedAt, &t.UpdatedAt)
+		var estimatedMinutes int
+		err := rows.Scan(&t.ID, &t.ProjectID, &t.Title, &t.Description, &t.Status, &t.Priority, &dueDate, &t.CreatedAt, &t.UpdatedAt, &estimatedMinutes, &t.ParentTaskID, &t.Recurrence)
 		if err != nil {
 			return nil, err
 		}
 		if dueDate.Valid {
 			t.DueDate = &dueDate.Time
 		}
+		t.EstimatedTime = time.Duration(estimatedMinutes) * time.Minute
 		tasks = append(tasks, t)
 	}
 
@@ -121,11 +138,11 @@ func (r *taskRepository) GetAll(status string) ([]*task.Task, error) {
 	var args []interface{}
 
 	if status != "" {
-		query = `SELECT id, project_id, title, description, status, priority, due_date, created_at, updated_at 
+		query = `SELECT id, project_id, title, description, status, priority, due_date, created_at, updated_at, COALESCE(estimated_minutes, 0), COALESCE(parent_task_id, 0), COALESCE(recurrence, '') 
 			FROM tasks WHERE status = ? ORDER BY created_at DESC`
 		args = []interface{}{status}
 	} else {
-		query = `SELECT id, project_id, title, description, status, priority, due_date, created_at, updated_at 
+		query = `SELECT id, project_id, title, description, status, priority, due_date, created_at, updated_at, COALESCE(estimated_minutes, 0), COALESCE(parent_task_id, 0), COALESCE(recurrence, '') 
 			FROM tasks ORDER BY created_at DESC`
 	}
 
@@ -139,13 +156,15 @@ func (r *taskRepository) GetAll(status string) ([]*task.Task, error) {
 	for rows.Next() {
 		t := &task.Task{}
 		var dueDate sql.NullTime
-		err := rows.Scan(&t.ID, &t.ProjectID, &t.Title, &t.Description, &t.Status, &t.Priority, &dueDate, &t.CreatedAt, &t.UpdatedAt)
+		var estimatedMinutes int
+		err := rows.Scan(&t.ID, &t.ProjectID, &t.Title, &t.Description, &t.Status, &t.Priority, &dueDate, &t.CreatedAt, &t.UpdatedAt, &estimatedMinutes, &t.ParentTaskID, &t.Recurrence)
 		if err != nil {
 			return nil, err
 		}
 		if dueDate.Valid {
 			t.DueDate = &dueDate.Time
 		}
+		t.EstimatedTime = time.Duration(estimatedMinutes) * time.Minute
 		tasks = append(tasks, t)
 	}
 
@@ -167,6 +186,25 @@ func (r *taskRepository) Update(id int, title, description, status, priority str
 }
 
 func (r *taskRepository) Delete(id int) error {
+	// Chaves estrangeiras não são aplicadas pelo SQLite por padrão
+	if _, err := r.db.Exec(`DELETE FROM task_dependencies WHERE task_id = ? OR depends_on_id = ?`, id, id); err != nil {
+		return err
+	}
+	if _, err := r.db.Exec(`DELETE FROM time_entry_tags WHERE entry_id IN (SELECT id FROM time_entries WHERE task_id = ?)`, id); err != nil {
+		return err
+	}
+	if _, err := r.db.Exec(`DELETE FROM time_entries WHERE task_id = ?`, id); err != nil {
+		return err
+	}
+	if _, err := r.db.Exec(`DELETE FROM note_tasks WHERE task_id = ?`, id); err != nil {
+		return err
+	}
+	// As subtarefas sobem um nível em vez de ficarem órfãs
+	promote := `UPDATE tasks SET parent_task_id = (SELECT parent_task_id FROM tasks WHERE id = ?) WHERE parent_task_id = ?`
+	if _, err := r.db.Exec(promote, id, id); err != nil {
+		return err
+	}
+
 	query := `DELETE FROM tasks WHERE id = ?`
 	_, err := r.db.Exec(query, id)
 	return err
@@ -183,3 +221,75 @@ func (r *taskRepository) ToggleComplete(id int) error {
 	return err
 }
 
+func (r *taskRepository) SetEstimate(id int, estimate time.Duration) error {
+	query := `UPDATE tasks SET estimated_minutes = ?, updated_at = ? WHERE id = ?`
+	_, err := r.db.Exec(query, int(estimate/time.Minute), time.Now(), id)
+	return err
+}
+
+func (r *taskRepository) SetRecurrence(id int, rule string) error {
+	query := `UPDATE tasks SET recurrence = ?, updated_at = ? WHERE id = ?`
+	_, err := r.db.Exec(query, rule, time.Now(), id)
+	return err
+}
+
+func (r *taskRepository) AddDependency(taskID, dependsOnID int) error {
+	query := `INSERT OR IGNORE INTO task_dependencies (task_id, depends_on_id, created_at) VALUES (?, ?, ?)`
+	_, err := r.db.Exec(query, taskID, dependsOnID, time.Now())
+	return err
+}
+
+func (r *taskRepository) RemoveDependency(taskID, dependsOnID int) error {
+	query := `DELETE FROM task_dependencies WHERE task_id = ? AND depends_on_id = ?`
+	_, err := r.db.Exec(query, taskID, dependsOnID)
+	return err
+}
+
+func (r *taskRepository) GetDependencies() (map[int][]int, error) {
+	rows, err := r.db.Query(`SELECT task_id, depends_on_id FROM task_dependencies ORDER BY task_id, depends_on_id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	deps := make(map[int][]int)
+	for rows.Next() {
+		var taskID, dependsOnID int
+		if err := rows.Scan(&taskID, &dependsOnID); err != nil {
+			return nil, err
+		}
+		deps[taskID] = append(deps[taskID], dependsOnID)
+	}
+	return deps, rows.Err()
+}
+
+func (r *taskRepository) Move(id int, parentID int, projectID int) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var parent interface{}
+	if parentID != 0 {
+		parent = parentID
+	}
+	now := time.Now()
+	if _, err := tx.Exec(`UPDATE tasks SET parent_task_id = ?, project_id = ?, updated_at = ? WHERE id = ?`, parent, projectID, now, id); err != nil {
+		return err
+	}
+
+	subtree := `
+		WITH RECURSIVE subtree(id) AS (
+			SELECT id FROM tasks WHERE parent_task_id = ?
+			UNION
+			SELECT t.id FROM tasks t JOIN subtree s ON t.parent_task_id = s.id
+		)
+		UPDATE tasks SET project_id = ?, updated_at = ? WHERE id IN (SELECT id FROM subtree)
+	`
+	if _, err := tx.Exec(subtree, id, projectID, now); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,214 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/snip/internal/task"
+)
+
+var ErrNoActiveTimer = errors.New("no active timer")
+var ErrTimerRunning = errors.New("a timer is already running")
+
+// TimeEntryRepository persiste os apontamentos de horas das tarefas
+type TimeEntryRepository interface {
+	// Create grava a entrada; sem EndedAt ela vira o cronômetro ativo e falha
+	// com ErrTimerRunning se já houver outro
+	Create(e *task.TimeEntry, tagIDs []int) error
+	GetActive() (*task.TimeEntry, error)
+	// Stop encerra o cronômetro ativo id e grava a nota final
+	Stop(id int, endedAt time.Time, note string) (*task.TimeEntry, error)
+	// GetSince retorna as entradas iniciadas a partir de since, incluindo o cronômetro ativo
+	GetSince(since time.Time) ([]*task.TimeEntry, error)
+	// TotalsByTask soma as entradas encerradas de cada tarefa
+	TotalsByTask() (map[int]time.Duration, error)
+	Close() error
+}
+
+type timeEntryRepository struct {
+	db *sql.DB
+}
+
+func NewTimeEntryRepository(db *sql.DB) (TimeEntryRepository, error) {
+	return &timeEntryRepository{db: db}, nil
+}
+
+func (r *timeEntryRepository) Close() error {
+	return r.db.Close()
+}
+
+func (r *timeEntryRepository) Create(e *task.TimeEntry, tagIDs []int) error {
+	if e.IsRunning() {
+		if _, err := r.GetActive(); err == nil {
+			return ErrTimerRunning
+		} else if !errors.Is(err, ErrNoActiveTimer) {
+			return err
+		}
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Datas em UTC para que as comparações de texto do SQLite funcionem
+	var endedAt interface{}
+	if e.EndedAt != nil {
+		endedAt = e.EndedAt.UTC()
+	}
+	if e.CreatedAt.IsZero() {
+		e.CreatedAt = time.Now()
+	}
+
+	result, err := tx.Exec(`
+		INSERT INTO time_entries (task_id, started_at, ended_at, duration_seconds, note, created_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`, e.TaskID, e.StartedAt.UTC(), endedAt, int64(e.Duration/time.Second), e.Note, e.CreatedAt.UTC())
+	if err != nil {
+		return err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	for _, tagID := range tagIDs {
+		if _, err := tx.Exec(`INSERT OR IGNORE INTO time_entry_tags (entry_id, tag_id) VALUES (?, ?)`, id, tagID); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	e.ID = int(id)
+	return nil
+}
+
+func (r *timeEntryRepository) GetActive() (*task.TimeEntry, error) {
+	entries, err := r.query(`WHERE e.ended_at IS NULL`)
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, ErrNoActiveTimer
+	}
+	return entries[0], nil
+}
+
+func (r *timeEntryRepository) Stop(id int, endedAt time.Time, note string) (*task.TimeEntry, error) {
+	active, err := r.GetActive()
+	if err != nil {
+		return nil, err
+	}
+	if active.ID != id {
+		return nil, ErrNoActiveTimer
+	}
+
+	duration := endedAt.Sub(active.StartedAt)
+	if duration < 0 {
+		duration = 0
+	}
+	_, err = r.db.Exec(`UPDATE time_entries SET ended_at = ?, duration_seconds = ?, note = ? WHERE id = ?`,
+		endedAt.UTC(), int64(duration/time.Second), note, id)
+	if err != nil {
+		return nil, err
+	}
+
+	active.EndedAt = &endedAt
+	active.Note = note
+	active.Duration = duration.Truncate(time.Second)
+	return active, nil
+}
+
+func (r *timeEntryRepository) GetSince(since time.Time) ([]*task.TimeEntry, error) {
+	return r.query(`WHERE e.started_at >= ? OR e.ended_at IS NULL`, since.UTC())
+}
+
+func (r *timeEntryRepository) TotalsByTask() (map[int]time.Duration, error) {
+	rows, err := r.db.Query(`
+		SELECT task_id, SUM(duration_seconds)
+		FROM time_entries
+		WHERE ended_at IS NOT NULL
+		GROUP BY task_id
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	totals := make(map[int]time.Duration)
+	for rows.Next() {
+		var taskID int
+		var seconds int64
+		if err := rows.Scan(&taskID, &seconds); err != nil {
+			return nil, err
+		}
+		totals[taskID] = time.Duration(seconds) * time.Second
+	}
+	return totals, rows.Err()
+}
+
+// query busca as entradas com o projeto da tarefa e carrega as tags
+func (r *timeEntryRepository) query(where string, args ...interface{}) ([]*task.TimeEntry, error) {
+	rows, err := r.db.Query(`
+		SELECT e.id, e.task_id, COALESCE(t.project_id, 0), e.started_at, e.ended_at,
+		       e.duration_seconds, COALESCE(e.note, ''), e.created_at
+		FROM time_entries e
+		LEFT JOIN tasks t ON t.id = e.task_id
+		`+where+`
+		ORDER BY e.started_at
+	`, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []*task.TimeEntry
+	byID := make(map[int]*task.TimeEntry)
+	for rows.Next() {
+		e := &task.TimeEntry{}
+		var endedAt sql.NullTime
+		var seconds int64
+		if err := rows.Scan(&e.ID, &e.TaskID, &e.ProjectID, &e.StartedAt, &endedAt, &seconds, &e.Note, &e.CreatedAt); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		if endedAt.Valid {
+			e.EndedAt = &endedAt.Time
+		}
+		e.Duration = time.Duration(seconds) * time.Second
+		entries = append(entries, e)
+		byID[e.ID] = e
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return entries, nil
+	}
+
+	tagRows, err := r.db.Query(`
+		SELECT et.entry_id, tg.name
+		FROM time_entry_tags et
+		JOIN tags tg ON tg.id = et.tag_id
+		ORDER BY tg.name
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer tagRows.Close()
+	for tagRows.Next() {
+		var entryID int
+		var name string
+		if err := tagRows.Scan(&entryID, &name); err != nil {
+			return nil, err
+		}
+		if e, ok := byID[entryID]; ok {
+			e.Tags = append(e.Tags, name)
+		}
+	}
+	return entries, tagRows.Err()
+}
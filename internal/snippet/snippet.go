@@ -0,0 +1,165 @@
+package snippet
+
+import (
+	"strings"
+	"time"
+)
+
+// Snippet é o código salvo de uma nota (ex: gerado com snip ai-code --save).
+// O ID do snippet é o ID da nota que guarda a resposta completa.
+type Snippet struct {
+	NoteID    int       `json:"note_id"`
+	Title     string    `json:"title"`
+	Language  string    `json:"language"`
+	Code      string    `json:"code"`
+	Prompt    string    `json:"prompt,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// CodeBlock é um bloco de código cercado (```lang) extraído de markdown
+type CodeBlock struct {
+	Language string
+	Code     string
+}
+
+// languageAliases normaliza os nomes mais comuns de linguagem
+var languageAliases = map[string]string{
+	"golang":     "go",
+	"py":         "python",
+	"python3":    "python",
+	"js":         "javascript",
+	"node":       "javascript",
+	"ts":         "typescript",
+	"sh":         "bash",
+	"shell":      "bash",
+	"zsh":        "bash",
+	"ps1":        "powershell",
+	"pwsh":       "powershell",
+	"yml":        "yaml",
+	"rb":         "ruby",
+	"rs":         "rust",
+	"kt":         "kotlin",
+	"c++":        "cpp",
+	"cs":         "csharp",
+	"c#":         "csharp",
+	"plsql":      "sql",
+	"postgresql": "sql",
+	"tsql":       "sql",
+}
+
+// extensions mapeia a linguagem para a extensão usada por snippet write em diretórios
+var extensions = map[string]string{
+	"go":         "go",
+	"python":     "py",
+	"javascript": "js",
+	"typescript": "ts",
+	"bash":       "sh",
+	"powershell": "ps1",
+	"yaml":       "yaml",
+	"json":       "json",
+	"ruby":       "rb",
+	"rust":       "rs",
+	"kotlin":     "kt",
+	"java":       "java",
+	"cpp":        "cpp",
+	"c":          "c",
+	"csharp":     "cs",
+	"sql":        "sql",
+	"php":        "php",
+	"html":       "html",
+	"css":        "css",
+	"dockerfile": "Dockerfile",
+}
+
+// NormalizeLanguage retorna o nome canônico da linguagem (ex: golang → go)
+func NormalizeLanguage(language string) string {
+	language = strings.ToLower(strings.TrimSpace(language))
+	if canonical, ok := languageAliases[language]; ok {
+		return canonical
+	}
+	return language
+}
+
+// Extension retorna a extensão de arquivo da linguagem ("txt" quando desconhecida)
+func Extension(language string) string {
+	if ext, ok := extensions[NormalizeLanguage(language)]; ok {
+		return ext
+	}
+	return "txt"
+}
+
+// ExtractCodeBlocks retorna os blocos cercados por ``` ou ~~~ do markdown
+func ExtractCodeBlocks(content string) []CodeBlock {
+	var blocks []CodeBlock
+	var current *CodeBlock
+	var fence string
+	var lines []string
+
+	for _, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+
+		if current == nil {
+			if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+				fence = trimmed[:3]
+				info := strings.Fields(strings.TrimLeft(trimmed, fence[:1]))
+				current = &CodeBlock{}
+				if len(info) > 0 {
+					current.Language = NormalizeLanguage(info[0])
+				}
+				lines = nil
+			}
+			continue
+		}
+
+		if strings.HasPrefix(trimmed, fence) && strings.Trim(trimmed, fence[:1]) == "" {
+			current.Code = strings.Join(lines, "\n")
+			blocks = append(blocks, *current)
+			current = nil
+			continue
+		}
+		lines = append(lines, line)
+	}
+
+	// Bloco sem fechamento (resposta truncada): mantém o que veio
+	if current != nil && len(lines) > 0 {
+		current.Code = strings.Join(lines, "\n")
+		blocks = append(blocks, *current)
+	}
+
+	return blocks
+}
+
+// ExtractCode junta os blocos da linguagem (ou sem linguagem declarada). Se
+// nenhum bloco combinar, usa todos; sem blocos, o conteúdo inteiro é o código.
+func ExtractCode(content, language string) string {
+	blocks := ExtractCodeBlocks(content)
+	if len(blocks) == 0 {
+		return strings.TrimSpace(content)
+	}
+
+	language = NormalizeLanguage(language)
+	var matching, all []string
+	for _, block := range blocks {
+		all = append(all, block.Code)
+		if block.Language == "" || block.Language == language {
+			matching = append(matching, block.Code)
+		}
+	}
+
+	if len(matching) == 0 {
+		matching = all
+	}
+	return strings.Join(matching, "\n\n")
+}
+
+// NewSnippet cria o snippet a partir da resposta em markdown
+func NewSnippet(title, language, content, prompt string) *Snippet {
+	language = NormalizeLanguage(language)
+	return &Snippet{
+		Title:     title,
+		Language:  language,
+		Code:      ExtractCode(content, language),
+		Prompt:    prompt,
+		CreatedAt: time.Now(),
+	}
+}
@@ -0,0 +1,114 @@
+package study
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// Valores iniciais e limites do algoritmo SM-2
+const (
+	DefaultEaseFactor = 2.5
+	MinEaseFactor     = 1.3
+
+	// MinQuality e MaxQuality são as notas de resposta do SM-2 (0 = esqueci, 5 = perfeito)
+	MinQuality = 0
+	MaxQuality = 5
+	// PassQuality é a menor nota considerada acerto
+	PassQuality = 3
+)
+
+// Card é um flashcard ligado à nota de onde foi gerado
+type Card struct {
+	ID             int
+	NoteID         int
+	NoteTitle      string
+	Question       string
+	Answer         string
+	EaseFactor     float64
+	Interval       int
+	Repetitions    int
+	DueAt          time.Time
+	LastReviewedAt *time.Time
+	CreatedAt      time.Time
+}
+
+// Review é o registro de uma revisão de cartão
+type Review struct {
+	CardID     int
+	Quality    int
+	EaseFactor float64
+	Interval   int
+	ReviewedAt time.Time
+}
+
+// Stats resume a situação dos cartões
+type Stats struct {
+	Total        int
+	Due          int
+	New          int
+	ReviewsToday int
+}
+
+func NewCard(noteID int, question, answer string) *Card {
+	now := time.Now()
+	return &Card{
+		NoteID:     noteID,
+		Question:   question,
+		Answer:     answer,
+		EaseFactor: DefaultEaseFactor,
+		DueAt:      now,
+		CreatedAt:  now,
+	}
+}
+
+// ValidateQuality verifica se a nota está entre 0 e 5
+func ValidateQuality(quality int) error {
+	if quality < MinQuality || quality > MaxQuality {
+		return fmt.Errorf("quality must be between %d and %d", MinQuality, MaxQuality)
+	}
+	return nil
+}
+
+// Schedule aplica o SM-2 ao cartão: respostas abaixo de PassQuality reiniciam
+// as repetições; acertos aumentam o intervalo (1, 6 e depois intervalo * EF).
+// O fator de facilidade é ajustado em toda revisão e nunca fica abaixo de 1.3.
+func (c *Card) Schedule(quality int, now time.Time) *Review {
+	if quality < PassQuality {
+		c.Repetitions = 0
+		c.Interval = 1
+	} else {
+		switch c.Repetitions {
+		case 0:
+			c.Interval = 1
+		case 1:
+			c.Interval = 6
+		default:
+			c.Interval = int(math.Round(float64(c.Interval) * c.EaseFactor))
+		}
+		c.Repetitions++
+	}
+
+	q := float64(MaxQuality - quality)
+	c.EaseFactor += 0.1 - q*(0.08+q*0.02)
+	if c.EaseFactor < MinEaseFactor {
+		c.EaseFactor = MinEaseFactor
+	}
+	c.EaseFactor = math.Round(c.EaseFactor*100) / 100
+
+	c.DueAt = now.AddDate(0, 0, c.Interval)
+	c.LastReviewedAt = &now
+
+	return &Review{
+		CardID:     c.ID,
+		Quality:    quality,
+		EaseFactor: c.EaseFactor,
+		Interval:   c.Interval,
+		ReviewedAt: now,
+	}
+}
+
+// IsNew indica se o cartão nunca foi revisado
+func (c *Card) IsNew() bool {
+	return c.LastReviewedAt == nil
+}
@@ -0,0 +1,325 @@
+package task
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mattn/go-runewidth"
+)
+
+// BoardStatuses são as colunas do quadro, na ordem do fluxo
+var BoardStatuses = []string{"pending", "in_progress", "completed"}
+
+var boardTitles = map[string]string{
+	"pending":     "A fazer",
+	"in_progress": "Em andamento",
+	"completed":   "Concluído",
+}
+
+var priorityRank = map[string]int{"high": 0, "medium": 1, "low": 2}
+
+// Cores ANSI das prioridades e dos prazos
+const (
+	colorReset  = "\033[0m"
+	colorRed    = "\033[31m"
+	colorYellow = "\033[33m"
+	colorGreen  = "\033[32m"
+	colorDim    = "\033[2m"
+)
+
+// minColumnWidth é a largura mínima de uma coluna lado a lado; abaixo disso as
+// colunas são empilhadas
+const minColumnWidth = 22
+
+// Column é uma coluna do quadro kanban
+type Column struct {
+	Status string
+	Tasks  []*Task
+}
+
+// Board agrupa as tarefas de um projeto por status
+type Board struct {
+	Columns []*Column
+	// Blocked marca as tarefas com dependências abertas
+	Blocked map[int]bool
+}
+
+// BoardStyle controla a renderização do quadro
+type BoardStyle struct {
+	Width    int       // largura do terminal
+	Color    bool      // usar cores ANSI
+	Now      time.Time // referência para os prazos
+	Selected int       // ID do cartão destacado (modo interativo)
+}
+
+// NewBoard distribui as tarefas nas colunas. Status desconhecidos vão para
+// "A fazer"; em cada coluna a ordem é prioridade, prazo e ID.
+func NewBoard(tasks []*Task) *Board {
+	b := &Board{Blocked: make(map[int]bool)}
+	index := make(map[string]*Column, len(BoardStatuses))
+	for _, status := range BoardStatuses {
+		column := &Column{Status: status}
+		b.Columns = append(b.Columns, column)
+		index[status] = column
+	}
+
+	for _, t := range tasks {
+		column, ok := index[t.Status]
+		if !ok {
+			column = index["pending"]
+		}
+		column.Tasks = append(column.Tasks, t)
+	}
+
+	for _, column := range b.Columns {
+		sortCards(column.Tasks)
+	}
+	return b
+}
+
+func sortCards(tasks []*Task) {
+	sort.SliceStable(tasks, func(i, j int) bool {
+		a, b := tasks[i], tasks[j]
+		if priorityRank[a.Priority] != priorityRank[b.Priority] {
+			return priorityRank[a.Priority] < priorityRank[b.Priority]
+		}
+		if (a.DueDate == nil) != (b.DueDate == nil) {
+			return a.DueDate != nil
+		}
+		if a.DueDate != nil && !a.DueDate.Equal(*b.DueDate) {
+			return a.DueDate.Before(*b.DueDate)
+		}
+		return a.ID < b.ID
+	})
+}
+
+// BoardTitle retorna o título da coluna de um status
+func BoardTitle(status string) string {
+	if title, ok := boardTitles[status]; ok {
+		return title
+	}
+	return status
+}
+
+// Locate retorna a coluna e a posição do cartão id, ou -1, -1
+func (b *Board) Locate(id int) (column int, row int) {
+	for c, col := range b.Columns {
+		for r, t := range col.Tasks {
+			if t.ID == id {
+				return c, r
+			}
+		}
+	}
+	return -1, -1
+}
+
+// Move leva o cartão id para a coluna vizinha (delta -1 ou +1) e retorna o
+// novo status. ok é falso se o cartão não existe ou já está na borda.
+func (b *Board) Move(id int, delta int) (status string, ok bool) {
+	c, r := b.Locate(id)
+	target := c + delta
+	if c < 0 || target < 0 || target >= len(b.Columns) {
+		return "", false
+	}
+
+	t := b.Columns[c].Tasks[r]
+	b.Columns[c].Tasks = append(b.Columns[c].Tasks[:r], b.Columns[c].Tasks[r+1:]...)
+	t.Status = b.Columns[target].Status
+	b.Columns[target].Tasks = append(b.Columns[target].Tasks, t)
+	sortCards(b.Columns[target].Tasks)
+	return t.Status, true
+}
+
+// Render desenha o quadro com caixas unicode. As colunas ficam lado a lado
+// quando cabem na largura e empilhadas em terminais estreitos.
+func (b *Board) Render(style BoardStyle) string {
+	width := style.Width
+	if width <= 0 {
+		width = 80
+	}
+	if style.Now.IsZero() {
+		style.Now = time.Now()
+	}
+
+	columnWidth := (width - (len(b.Columns) - 1)) / len(b.Columns)
+	stacked := columnWidth < minColumnWidth
+	if stacked {
+		columnWidth = width
+	}
+
+	rendered := make([][]string, len(b.Columns))
+	for i, column := range b.Columns {
+		rendered[i] = b.renderColumn(column, columnWidth, style)
+	}
+
+	var out strings.Builder
+	if stacked {
+		for _, lines := range rendered {
+			for _, line := range lines {
+				out.WriteString(line + "\n")
+			}
+		}
+		return out.String()
+	}
+
+	height := 0
+	for _, lines := range rendered {
+		if len(lines) > height {
+			height = len(lines)
+		}
+	}
+	blank := strings.Repeat(" ", columnWidth)
+	for row := 0; row < height; row++ {
+		parts := make([]string, len(rendered))
+		for i, lines := range rendered {
+			parts[i] = blank
+			if row < len(lines) {
+				parts[i] = lines[row]
+			}
+		}
+		out.WriteString(strings.TrimRight(strings.Join(parts, " "), " ") + "\n")
+	}
+	return out.String()
+}
+
+// renderColumn retorna as linhas da coluna, todas com exatamente width colunas visíveis
+func (b *Board) renderColumn(column *Column, width int, style BoardStyle) []string {
+	inner := width - 2
+	header := fmt.Sprintf("─ %s (%d) ", BoardTitle(column.Status), len(column.Tasks))
+	lines := []string{"╭" + fill(header, inner, "─") + "╮"}
+
+	if len(column.Tasks) == 0 {
+		lines = append(lines, "│"+fill(" —", inner, " ")+"│")
+	}
+	for _, t := range column.Tasks {
+		for _, line := range b.renderCard(t, inner-2, style) {
+			lines = append(lines, "│ "+line+" │")
+		}
+	}
+
+	lines = append(lines, "╰"+strings.Repeat("─", inner)+"╯")
+	return lines
+}
+
+// renderCard desenha um cartão com width colunas: título e linhas de detalhes
+func (b *Board) renderCard(t *Task, width int, style BoardStyle) []string {
+	// O cartão selecionado usa borda dupla
+	border := []string{"┌", "┐", "└", "┘", "─", "│"}
+	if t.ID == style.Selected {
+		border = []string{"╔", "╗", "╚", "╝", "═", "║"}
+	}
+	inner := width - 4
+
+	title := fit(fmt.Sprintf("#%d %s", t.ID, t.Title), inner)
+
+	marker, markerColor := priorityMarker(t.Priority)
+	details := []string{marker}
+	badge, badgeColor := dueBadge(t, style.Now)
+	if badge != "" {
+		details = append(details, badge)
+	}
+	if t.Recurrence != "" {
+		details = append(details, "↻")
+	}
+	if b.Blocked[t.ID] && !t.IsCompleted() {
+		details = append(details, "⊘ bloqueada")
+	}
+	if style.Color && t.IsCompleted() {
+		title = colorDim + title + colorReset
+	}
+
+	horizontal := strings.Repeat(border[4], width-2)
+	lines := []string{
+		border[0] + horizontal + border[1],
+		border[5] + " " + title + " " + border[5],
+	}
+	for _, meta := range wrapDetails(details, inner) {
+		meta = fit(meta, inner)
+		// As cores são aplicadas depois do ajuste de largura para não contar os códigos ANSI
+		if style.Color {
+			meta = colorize(meta, marker, markerColor)
+			if badge != "" {
+				meta = colorize(meta, badge, badgeColor)
+			}
+		}
+		lines = append(lines, border[5]+" "+meta+" "+border[5])
+	}
+	return append(lines, border[2]+horizontal+border[3])
+}
+
+// wrapDetails junta os detalhes com " · " quebrando a linha quando passam de width
+func wrapDetails(details []string, width int) []string {
+	var lines []string
+	current := ""
+	for _, detail := range details {
+		candidate := detail
+		if current != "" {
+			candidate = current + " · " + detail
+		}
+		if current != "" && runewidth.StringWidth(candidate) > width {
+			lines = append(lines, current)
+			candidate = detail
+		}
+		current = candidate
+	}
+	return append(lines, current)
+}
+
+func priorityMarker(priority string) (string, string) {
+	switch priority {
+	case "high":
+		return "▲ alta", colorRed
+	case "low":
+		return "▼ baixa", colorGreen
+	default:
+		return "■ média", colorYellow
+	}
+}
+
+// dueBadge descreve o prazo relativo a now: atrasada, hoje, em N dias ou a data
+func dueBadge(t *Task, now time.Time) (string, string) {
+	if t.DueDate == nil {
+		return "", ""
+	}
+	due := *t.DueDate
+	if t.IsCompleted() {
+		return due.Format("02/01"), colorDim
+	}
+
+	days := daysBetween(now, due)
+	switch {
+	case days < 0:
+		return fmt.Sprintf("atrasada %dd", -days), colorRed
+	case days == 0:
+		return "vence hoje", colorRed
+	case days <= 7:
+		return fmt.Sprintf("em %dd", days), colorYellow
+	default:
+		return due.Format("02/01"), ""
+	}
+}
+
+// fit corta s com "…" ou completa com espaços até width colunas visíveis
+func fit(s string, width int) string {
+	return fill(s, width, " ")
+}
+
+func fill(s string, width int, pad string) string {
+	if width <= 0 {
+		return ""
+	}
+	if runewidth.StringWidth(s) > width {
+		s = runewidth.Truncate(s, width, "…")
+	}
+	return s + strings.Repeat(pad, width-runewidth.StringWidth(s))
+}
+
+// colorize pinta a primeira ocorrência de part em line
+func colorize(line, part, color string) string {
+	if color == "" {
+		return line
+	}
+	return strings.Replace(line, part, color+part+colorReset, 1)
+}
@@ -0,0 +1,234 @@
+package task
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// IsCompleted indica se a tarefa está concluída
+func (t *Task) IsCompleted() bool {
+	return t.Status == "completed"
+}
+
+// FindCycle verifica se adicionar "taskID depende de dependsOnID" cria um ciclo.
+// deps mapeia cada tarefa para as tarefas das quais ela depende. Retorna o
+// caminho do ciclo (começando e terminando em taskID) ou nil.
+func FindCycle(deps map[int][]int, taskID, dependsOnID int) []int {
+	if taskID == dependsOnID {
+		return []int{taskID, taskID}
+	}
+
+	// Existe ciclo se taskID já é alcançável a partir de dependsOnID
+	visited := make(map[int]bool)
+	var path []int
+	var walk func(id int) bool
+	walk = func(id int) bool {
+		path = append(path, id)
+		if id == taskID {
+			return true
+		}
+		if !visited[id] {
+			visited[id] = true
+			for _, next := range deps[id] {
+				if walk(next) {
+					return true
+				}
+			}
+		}
+		path = path[:len(path)-1]
+		return false
+	}
+
+	if walk(dependsOnID) {
+		return append([]int{taskID}, path...)
+	}
+	return nil
+}
+
+// BlockedBy retorna as dependências ainda não concluídas da tarefa
+func BlockedBy(taskID int, deps map[int][]int, byID map[int]*Task) []int {
+	var blocking []int
+	for _, depID := range deps[taskID] {
+		if dep, ok := byID[depID]; ok && !dep.IsCompleted() {
+			blocking = append(blocking, depID)
+		}
+	}
+	sort.Ints(blocking)
+	return blocking
+}
+
+// PathStep é uma tarefa no caminho crítico com o início e o fim projetados
+type PathStep struct {
+	Task   *Task
+	Start  time.Time
+	Finish time.Time
+	// Late é quanto o fim projetado passa do prazo (0 quando está no prazo ou sem prazo)
+	Late time.Duration
+}
+
+// CriticalPathResult é a maior cadeia de dependências de um projeto
+type CriticalPathResult struct {
+	Steps    []PathStep
+	Duration time.Duration
+	End      time.Time
+	// Unestimated conta as tarefas abertas do caminho sem estimativa
+	Unestimated int
+	// Late são as tarefas do projeto (no caminho ou não) que terminariam após o prazo
+	Late []PathStep
+}
+
+// CriticalPath calcula a cadeia mais longa de tarefas a partir de start,
+// somando as estimativas das tarefas abertas (concluídas contam zero) e
+// projetando as datas com WorkDay de trabalho por dia.
+// Empates são decididos pelo número de tarefas e depois pelo prazo mais próximo.
+// Dependências para tarefas fora da lista são ignoradas.
+func CriticalPath(tasks []*Task, deps map[int][]int, start time.Time) (*CriticalPathResult, error) {
+	byID := make(map[int]*Task, len(tasks))
+	for _, t := range tasks {
+		byID[t.ID] = t
+	}
+
+	order, err := topologicalOrder(tasks, deps, byID)
+	if err != nil {
+		return nil, err
+	}
+
+	finish := make(map[int]time.Duration, len(tasks))
+	length := make(map[int]int, len(tasks))
+	prev := make(map[int]int, len(tasks))
+	for _, t := range order {
+		best := 0
+		for _, depID := range deps[t.ID] {
+			if _, ok := byID[depID]; !ok {
+				continue
+			}
+			if best == 0 || longer(finish[depID], length[depID], byID[depID], finish[best], length[best], byID[best]) {
+				best = depID
+			}
+		}
+
+		finish[t.ID] = duration(t)
+		length[t.ID] = 1
+		if best != 0 {
+			finish[t.ID] += finish[best]
+			length[t.ID] += length[best]
+			prev[t.ID] = best
+		}
+	}
+
+	result := &CriticalPathResult{End: start}
+	last := 0
+	for _, t := range order {
+		if last == 0 || longer(finish[t.ID], length[t.ID], t, finish[last], length[last], byID[last]) {
+			last = t.ID
+		}
+
+		if t.DueDate != nil && !t.IsCompleted() {
+			step := newStep(t, start, finish[t.ID])
+			if step.Late > 0 {
+				result.Late = append(result.Late, step)
+			}
+		}
+	}
+	if last == 0 {
+		return result, nil
+	}
+
+	for id := last; id != 0; id = prev[id] {
+		t := byID[id]
+		result.Steps = append([]PathStep{newStep(t, start, finish[id])}, result.Steps...)
+		if !t.IsCompleted() && t.EstimatedTime == 0 {
+			result.Unestimated++
+		}
+	}
+	result.Duration = finish[last]
+	result.End = addWorkTime(start, finish[last])
+	return result, nil
+}
+
+func newStep(t *Task, start time.Time, finish time.Duration) PathStep {
+	step := PathStep{
+		Task:   t,
+		Start:  addWorkTime(start, finish-duration(t)),
+		Finish: addWorkTime(start, finish),
+	}
+	if t.DueDate != nil && !t.IsCompleted() {
+		// O prazo vale até o fim do dia informado
+		deadline := t.DueDate.AddDate(0, 0, 1)
+		if step.Finish.After(deadline) {
+			step.Late = step.Finish.Sub(deadline)
+		}
+	}
+	return step
+}
+
+// addWorkTime converte tempo de trabalho em data, contando WorkDay por dia corrido
+func addWorkTime(start time.Time, work time.Duration) time.Time {
+	days := int(work / WorkDay)
+	return start.AddDate(0, 0, days).Add(work % WorkDay)
+}
+
+func duration(t *Task) time.Duration {
+	if t.IsCompleted() {
+		return 0
+	}
+	return t.EstimatedTime
+}
+
+// longer compara duas cadeias: maior duração, depois mais tarefas, depois prazo mais próximo
+func longer(d1 time.Duration, n1 int, t1 *Task, d2 time.Duration, n2 int, t2 *Task) bool {
+	if d1 != d2 {
+		return d1 > d2
+	}
+	if n1 != n2 {
+		return n1 > n2
+	}
+	if t1.DueDate != nil && (t2.DueDate == nil || t1.DueDate.Before(*t2.DueDate)) {
+		return true
+	}
+	return false
+}
+
+// topologicalOrder ordena as tarefas de forma que as dependências venham antes
+func topologicalOrder(tasks []*Task, deps map[int][]int, byID map[int]*Task) ([]*Task, error) {
+	sorted := make([]*Task, len(tasks))
+	copy(sorted, tasks)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].ID < sorted[j].ID })
+
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := make(map[int]int, len(tasks))
+	var order []*Task
+
+	var visit func(t *Task) error
+	visit = func(t *Task) error {
+		switch state[t.ID] {
+		case visiting:
+			return fmt.Errorf("dependência circular envolvendo a tarefa #%d", t.ID)
+		case done:
+			return nil
+		}
+		state[t.ID] = visiting
+		for _, depID := range deps[t.ID] {
+			if dep, ok := byID[depID]; ok {
+				if err := visit(dep); err != nil {
+					return err
+				}
+			}
+		}
+		state[t.ID] = done
+		order = append(order, t)
+		return nil
+	}
+
+	for _, t := range sorted {
+		if err := visit(t); err != nil {
+			return nil, err
+		}
+	}
+	return order, nil
+}
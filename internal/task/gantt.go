@@ -0,0 +1,87 @@
+package task
+
+import "time"
+
+// ScheduledTask é uma tarefa posicionada no tempo para o gráfico de Gantt
+type ScheduledTask struct {
+	Task  *Task
+	Depth int // nível na árvore de subtarefas (0 = primeiro nível)
+	Start time.Time
+	End   time.Time
+	// Estimated é falso quando a duração veio do prazo ou do padrão de um dia
+	Estimated bool
+	Critical  bool // tarefa aberta no caminho crítico
+	Late      bool // termina depois do prazo
+}
+
+// Schedule posiciona as tarefas no tempo. Cada tarefa aberta começa na data de
+// criação ou quando a última dependência termina e dura a estimativa (dias de
+// WorkDay); sem estimativa, vai até o prazo ou ocupa um dia. Tarefas abertas que
+// já deveriam ter terminado se estendem até now, empurrando as dependentes.
+// Tarefas concluídas vão da criação até a última atualização.
+// As linhas seguem a árvore de subtarefas.
+func Schedule(tasks []*Task, deps map[int][]int, now time.Time) ([]ScheduledTask, error) {
+	byID := make(map[int]*Task, len(tasks))
+	for _, t := range tasks {
+		byID[t.ID] = t
+	}
+
+	order, err := topologicalOrder(tasks, deps, byID)
+	if err != nil {
+		return nil, err
+	}
+	path, err := CriticalPath(tasks, deps, now)
+	if err != nil {
+		return nil, err
+	}
+	critical := make(map[int]bool, len(path.Steps))
+	for _, step := range path.Steps {
+		critical[step.Task.ID] = !step.Task.IsCompleted()
+	}
+
+	scheduled := make(map[int]*ScheduledTask, len(tasks))
+	for _, t := range order {
+		s := &ScheduledTask{Task: t, Start: t.CreatedAt, Estimated: t.EstimatedTime > 0, Critical: critical[t.ID]}
+		if t.IsCompleted() {
+			s.End = t.UpdatedAt
+			if s.End.Before(s.Start) {
+				s.End = s.Start
+			}
+		} else {
+			for _, depID := range deps[t.ID] {
+				if dep, ok := scheduled[depID]; ok && dep.End.After(s.Start) {
+					s.Start = dep.End
+				}
+			}
+			switch {
+			case t.EstimatedTime > 0:
+				s.End = addWorkTime(s.Start, t.EstimatedTime)
+			case t.DueDate != nil && t.DueDate.AddDate(0, 0, 1).After(s.Start):
+				s.End = t.DueDate.AddDate(0, 0, 1)
+			default:
+				s.End = s.Start.AddDate(0, 0, 1)
+			}
+			if s.End.Before(now) {
+				s.End = now
+			}
+		}
+		// O prazo vale até o fim do dia informado
+		if t.DueDate != nil && s.End.After(t.DueDate.AddDate(0, 0, 1)) {
+			s.Late = true
+		}
+		scheduled[t.ID] = s
+	}
+
+	rows := make([]ScheduledTask, 0, len(tasks))
+	var walk func(nodes []*Node, depth int)
+	walk = func(nodes []*Node, depth int) {
+		for _, n := range nodes {
+			s := scheduled[n.Task.ID]
+			s.Depth = depth
+			rows = append(rows, *s)
+			walk(n.Children, depth+1)
+		}
+	}
+	walk(BuildTree(tasks), 0)
+	return rows, nil
+}
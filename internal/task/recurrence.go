@@ -0,0 +1,273 @@
+package task
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Frequências suportadas do subconjunto de RRULE
+const (
+	FreqDaily   = "DAILY"
+	FreqWeekly  = "WEEKLY"
+	FreqMonthly = "MONTHLY"
+)
+
+// Recurrence é uma regra de repetição (subconjunto de RRULE: FREQ, INTERVAL, BYDAY e BYMONTHDAY)
+type Recurrence struct {
+	Freq     string
+	Interval int
+	Weekdays []time.Weekday
+	MonthDay int
+}
+
+var rruleWeekdays = map[string]time.Weekday{
+	"SU": time.Sunday, "MO": time.Monday, "TU": time.Tuesday, "WE": time.Wednesday,
+	"TH": time.Thursday, "FR": time.Friday, "SA": time.Saturday,
+}
+
+var weekdayNames = []string{"dom", "seg", "ter", "qua", "qui", "sex", "sáb"}
+
+// recurrenceAliases são atalhos aceitos na linha de comando
+var recurrenceAliases = map[string]string{
+	"daily":     "FREQ=DAILY",
+	"weekdays":  "FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR",
+	"weekly":    "FREQ=WEEKLY",
+	"biweekly":  "FREQ=WEEKLY;INTERVAL=2",
+	"monthly":   "FREQ=MONTHLY",
+	"quarterly": "FREQ=MONTHLY;INTERVAL=3",
+}
+
+// ParseRecurrence interpreta regras como "FREQ=WEEKLY;BYDAY=MO,WE",
+// "FREQ=MONTHLY;BYMONTHDAY=15", "FREQ=DAILY;INTERVAL=3" ou os atalhos
+// daily, weekdays, weekly, biweekly, monthly e quarterly
+func ParseRecurrence(rule string) (*Recurrence, error) {
+	rule = strings.TrimSpace(rule)
+	if alias, ok := recurrenceAliases[strings.ToLower(rule)]; ok {
+		rule = alias
+	}
+	rule = strings.TrimPrefix(strings.ToUpper(rule), "RRULE:")
+
+	r := &Recurrence{Interval: 1}
+	for _, part := range strings.Split(rule, ";") {
+		if part == "" {
+			continue
+		}
+		key, value, found := strings.Cut(part, "=")
+		if !found {
+			return nil, fmt.Errorf("regra de recorrência inválida: %s", part)
+		}
+
+		switch key {
+		case "FREQ":
+			if value != FreqDaily && value != FreqWeekly && value != FreqMonthly {
+				return nil, fmt.Errorf("frequência não suportada: %s (use DAILY, WEEKLY ou MONTHLY)", value)
+			}
+			r.Freq = value
+		case "INTERVAL":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("INTERVAL inválido: %s", value)
+			}
+			r.Interval = n
+		case "BYDAY":
+			for _, day := range strings.Split(value, ",") {
+				weekday, ok := rruleWeekdays[day]
+				if !ok {
+					return nil, fmt.Errorf("dia da semana inválido: %s (use MO, TU, WE, TH, FR, SA, SU)", day)
+				}
+				r.Weekdays = append(r.Weekdays, weekday)
+			}
+		case "BYMONTHDAY":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 || n > 31 {
+				return nil, fmt.Errorf("BYMONTHDAY inválido: %s", value)
+			}
+			r.MonthDay = n
+		default:
+			return nil, fmt.Errorf("parte da regra não suportada: %s", key)
+		}
+	}
+
+	if r.Freq == "" {
+		return nil, fmt.Errorf("regra de recorrência sem FREQ: %s", rule)
+	}
+	if len(r.Weekdays) > 0 && r.Freq != FreqWeekly {
+		return nil, fmt.Errorf("BYDAY só é suportado com FREQ=WEEKLY")
+	}
+	if r.MonthDay > 0 && r.Freq != FreqMonthly {
+		return nil, fmt.Errorf("BYMONTHDAY só é suportado com FREQ=MONTHLY")
+	}
+
+	sort.Slice(r.Weekdays, func(i, j int) bool { return r.Weekdays[i] < r.Weekdays[j] })
+	return r, nil
+}
+
+// String retorna a regra no formato RRULE canônico (o que é gravado no banco)
+func (r *Recurrence) String() string {
+	parts := []string{"FREQ=" + r.Freq}
+	if r.Interval > 1 {
+		parts = append(parts, fmt.Sprintf("INTERVAL=%d", r.Interval))
+	}
+	if len(r.Weekdays) > 0 {
+		days := make([]string, 0, len(r.Weekdays))
+		for _, weekday := range r.Weekdays {
+			for code, d := range rruleWeekdays {
+				if d == weekday {
+					days = append(days, code)
+				}
+			}
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+	if r.MonthDay > 0 {
+		parts = append(parts, fmt.Sprintf("BYMONTHDAY=%d", r.MonthDay))
+	}
+	return strings.Join(parts, ";")
+}
+
+// Describe descreve a regra em texto curto (ex: "semanal (seg, qua)")
+func (r *Recurrence) Describe() string {
+	var desc string
+	switch r.Freq {
+	case FreqDaily:
+		desc = "diária"
+		if r.Interval > 1 {
+			desc = fmt.Sprintf("a cada %d dias", r.Interval)
+		}
+	case FreqWeekly:
+		desc = "semanal"
+		if r.Interval > 1 {
+			desc = fmt.Sprintf("a cada %d semanas", r.Interval)
+		}
+		if len(r.Weekdays) > 0 {
+			days := make([]string, 0, len(r.Weekdays))
+			for _, weekday := range r.Weekdays {
+				days = append(days, weekdayNames[weekday])
+			}
+			desc += " (" + strings.Join(days, ", ") + ")"
+		}
+	case FreqMonthly:
+		desc = "mensal"
+		if r.Interval > 1 {
+			desc = fmt.Sprintf("a cada %d meses", r.Interval)
+		}
+		if r.MonthDay > 0 {
+			desc += fmt.Sprintf(" no dia %d", r.MonthDay)
+		}
+	}
+	return desc
+}
+
+// Next retorna a primeira ocorrência estritamente depois de after, mantendo o
+// horário de after. Sem BYDAY/BYMONTHDAY, o dia da semana/mês de after é usado.
+func (r *Recurrence) Next(after time.Time) time.Time {
+	interval := r.Interval
+	if interval < 1 {
+		interval = 1
+	}
+
+	switch r.Freq {
+	case FreqWeekly:
+		if len(r.Weekdays) == 0 {
+			return after.AddDate(0, 0, 7*interval)
+		}
+		startOfWeek := after.AddDate(0, 0, -int(after.Weekday()))
+		for d := 1; d <= 7*interval+7; d++ {
+			candidate := after.AddDate(0, 0, d)
+			week := daysBetween(startOfWeek, candidate) / 7
+			if week%interval == 0 && r.hasWeekday(candidate.Weekday()) {
+				return candidate
+			}
+		}
+		return after.AddDate(0, 0, 7*interval)
+	case FreqMonthly:
+		day := r.MonthDay
+		if day == 0 {
+			day = after.Day()
+		}
+		if day > after.Day() {
+			if candidate := dateInMonth(after, 0, day); candidate.After(after) {
+				return candidate
+			}
+		}
+		return dateInMonth(after, interval, day)
+	default:
+		return after.AddDate(0, 0, interval)
+	}
+}
+
+// Matches indica se day é uma ocorrência da regra pelo dia da semana/mês,
+// sem considerar o intervalo. Sem BYDAY/BYMONTHDAY qualquer dia serve.
+func (r *Recurrence) Matches(day time.Time) bool {
+	switch r.Freq {
+	case FreqWeekly:
+		return len(r.Weekdays) == 0 || r.hasWeekday(day.Weekday())
+	case FreqMonthly:
+		// BYMONTHDAY=31 cai no último dia dos meses mais curtos, como em Next
+		return r.MonthDay == 0 || day.Day() == dateInMonth(day, 0, r.MonthDay).Day()
+	default:
+		return true
+	}
+}
+
+// First retorna a primeira ocorrência em ou depois de from: o próprio from
+// quando ele casa com a regra, senão a seguinte
+func (r *Recurrence) First(from time.Time) time.Time {
+	if r.Matches(from) {
+		return from
+	}
+	return r.Next(from)
+}
+
+// Anchor fixa em BYMONTHDAY o dia de start quando uma regra mensal não tem
+// dia. Sem isso cada ocorrência herdaria o dia da anterior e um prazo no dia
+// 31 derivaria para 28 depois de fevereiro.
+func (r *Recurrence) Anchor(start time.Time) {
+	if r.Freq == FreqMonthly && r.MonthDay == 0 {
+		r.MonthDay = start.Day()
+	}
+}
+
+// NextOnOrAfter avança a partir de base até a primeira ocorrência em ou depois de
+// notBefore, pulando ocorrências perdidas
+func (r *Recurrence) NextOnOrAfter(base time.Time, notBefore time.Time) time.Time {
+	next := r.Next(base)
+	for next.Before(notBefore) {
+		next = r.Next(next)
+	}
+	return next
+}
+
+// WeekdayName retorna a abreviação do dia da semana (seg, ter, ...)
+func WeekdayName(weekday time.Weekday) string {
+	return weekdayNames[weekday]
+}
+
+func (r *Recurrence) hasWeekday(weekday time.Weekday) bool {
+	for _, d := range r.Weekdays {
+		if d == weekday {
+			return true
+		}
+	}
+	return false
+}
+
+// daysBetween conta dias de calendário, sem depender de horário de verão
+func daysBetween(from, to time.Time) int {
+	a := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	b := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+	return int(b.Sub(a).Hours() / 24)
+}
+
+// dateInMonth retorna o dia day do mês base+months, limitado ao último dia do mês
+func dateInMonth(base time.Time, months int, day int) time.Time {
+	first := time.Date(base.Year(), base.Month(), 1, base.Hour(), base.Minute(), base.Second(), base.Nanosecond(), base.Location()).AddDate(0, months, 0)
+	lastDay := first.AddDate(0, 1, -1).Day()
+	if day > lastDay {
+		day = lastDay
+	}
+	return first.AddDate(0, 0, day-1)
+}
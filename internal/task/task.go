@@ -1,17 +1,28 @@
 package task
 
-import "time"
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// WorkDay é a duração de um dia de trabalho nas estimativas ("3d" = 3 dias de 8h)
+const WorkDay = 8 * time.Hour
 
 type Task struct {
-	ID          int       `json:"id"`
-	ProjectID   int       `json:"project_id"`
-	Title       string    `json:"title"`
-	Description string    `json:"description"`
-	Status      string    `json:"status"` // pending, in_progress, completed
-	Priority    string    `json:"priority"` // low, medium, high
-	DueDate     *time.Time `json:"due_date,omitempty"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID            int           `json:"id"`
+	ProjectID     int           `json:"project_id"`
+	ParentTaskID  int           `json:"parent_task_id,omitempty"` // 0 quando é uma tarefa de primeiro nível
+	Title         string        `json:"title"`
+	Description   string        `json:"description"`
+	Status        string        `json:"status"`   // pending, in_progress, completed
+	Priority      string        `json:"priority"` // low, medium, high
+	DueDate       *time.Time    `json:"due_date,omitempty"`
+	EstimatedTime time.Duration `json:"estimated_time,omitempty"`
+	Recurrence    string        `json:"recurrence,omitempty"` // RRULE, ex: FREQ=WEEKLY;BYDAY=MO
+	CreatedAt     time.Time     `json:"created_at"`
+	UpdatedAt     time.Time     `json:"updated_at"`
 }
 
 func NewTask(projectID int, title, description, priority string) *Task {
@@ -27,3 +38,47 @@ func NewTask(projectID int, title, description, priority string) *Task {
 	}
 }
 
+// ParseEstimate converte estimativas como "90m", "2h30m" ou "3d" (dias de 8h)
+func ParseEstimate(value string) (time.Duration, error) {
+	value = strings.TrimSpace(strings.ToLower(value))
+	if value == "" {
+		return 0, nil
+	}
+
+	var days time.Duration
+	if i := strings.Index(value, "d"); i > 0 {
+		n, err := strconv.Atoi(value[:i])
+		if err != nil {
+			return 0, fmt.Errorf("estimativa inválida: %s", value)
+		}
+		days = time.Duration(n) * WorkDay
+		value = value[i+1:]
+		if value == "" {
+			return days, nil
+		}
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("estimativa inválida: %s (use por exemplo 90m, 2h30m ou 3d)", value)
+	}
+	return days + d, nil
+}
+
+// FormatDuration mostra durações de forma compacta (ex: 1h30m, 45m)
+func FormatDuration(d time.Duration) string {
+	d = d.Round(time.Minute)
+	if d == 0 {
+		return "0m"
+	}
+	hours := int(d / time.Hour)
+	minutes := int((d % time.Hour) / time.Minute)
+	switch {
+	case hours == 0:
+		return fmt.Sprintf("%dm", minutes)
+	case minutes == 0:
+		return fmt.Sprintf("%dh", hours)
+	default:
+		return fmt.Sprintf("%dh%dm", hours, minutes)
+	}
+}
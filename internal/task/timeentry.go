@@ -0,0 +1,106 @@
+package task
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TimeEntry é um período de trabalho registrado em uma tarefa. Um cronômetro
+// ativo tem EndedAt nil; entradas lançadas com "task log" já nascem encerradas.
+type TimeEntry struct {
+	ID        int           `json:"id"`
+	TaskID    int           `json:"task_id"`
+	ProjectID int           `json:"project_id,omitempty"` // preenchido nas consultas com join em tasks
+	StartedAt time.Time     `json:"started_at"`
+	EndedAt   *time.Time    `json:"ended_at,omitempty"`
+	Duration  time.Duration `json:"duration"`
+	Note      string        `json:"note,omitempty"`
+	Tags      []string      `json:"tags,omitempty"`
+	CreatedAt time.Time     `json:"created_at"`
+}
+
+// TimeTotal é a soma de tempo de um grupo do relatório
+type TimeTotal struct {
+	Key      string
+	Duration time.Duration
+	Entries  int
+}
+
+// IsRunning indica se o cronômetro ainda está ativo
+func (e *TimeEntry) IsRunning() bool {
+	return e.EndedAt == nil
+}
+
+// Elapsed retorna a duração da entrada; para o cronômetro ativo, até now
+func (e *TimeEntry) Elapsed(now time.Time) time.Duration {
+	if e.IsRunning() {
+		return now.Sub(e.StartedAt)
+	}
+	return e.Duration
+}
+
+// GroupTime soma as entradas pelas chaves retornadas por keys. Uma entrada com
+// várias chaves (ex: várias tags) conta em cada uma. O resultado vem do maior
+// para o menor total.
+func GroupTime(entries []*TimeEntry, now time.Time, keys func(*TimeEntry) []string) []TimeTotal {
+	index := make(map[string]*TimeTotal)
+	var order []string
+	for _, e := range entries {
+		for _, key := range keys(e) {
+			total, ok := index[key]
+			if !ok {
+				total = &TimeTotal{Key: key}
+				index[key] = total
+				order = append(order, key)
+			}
+			total.Duration += e.Elapsed(now)
+			total.Entries++
+		}
+	}
+
+	totals := make([]TimeTotal, 0, len(order))
+	for _, key := range order {
+		totals = append(totals, *index[key])
+	}
+	sort.SliceStable(totals, func(i, j int) bool { return totals[i].Duration > totals[j].Duration })
+	return totals
+}
+
+// ParseSince converte períodos como "7d", "2w" e "12h" (contados a partir de now)
+// ou uma data "2006-01-02" no início do intervalo do relatório
+func ParseSince(value string, now time.Time) (time.Time, error) {
+	value = strings.TrimSpace(strings.ToLower(value))
+	if value == "" {
+		return time.Time{}, fmt.Errorf("período vazio")
+	}
+	if date, err := time.ParseInLocation("2006-01-02", value, now.Location()); err == nil {
+		return date, nil
+	}
+
+	unit := value[len(value)-1]
+	n, err := strconv.Atoi(value[:len(value)-1])
+	if err != nil || n < 0 {
+		return time.Time{}, fmt.Errorf("período inválido: %s (use 7d, 2w, 12h ou YYYY-MM-DD)", value)
+	}
+	switch unit {
+	case 'h':
+		return now.Add(-time.Duration(n) * time.Hour), nil
+	case 'd':
+		return startOfDay(now).AddDate(0, 0, -n), nil
+	case 'w':
+		return startOfDay(now).AddDate(0, 0, -7*n), nil
+	}
+	return time.Time{}, fmt.Errorf("período inválido: %s (use 7d, 2w, 12h ou YYYY-MM-DD)", value)
+}
+
+// FormatHours formata a duração em horas decimais, como nas planilhas de faturamento
+func FormatHours(d time.Duration) string {
+	return fmt.Sprintf("%.2fh", d.Hours())
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
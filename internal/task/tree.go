@@ -0,0 +1,116 @@
+package task
+
+import "sort"
+
+// Node é uma tarefa com suas subtarefas
+type Node struct {
+	Task     *Task
+	Children []*Node
+}
+
+// BuildTree monta a hierarquia a partir de parent_task_id. Tarefas cujo pai não
+// está na lista viram raízes. Irmãos ficam em ordem de ID.
+func BuildTree(tasks []*Task) []*Node {
+	nodes := make(map[int]*Node, len(tasks))
+	for _, t := range tasks {
+		nodes[t.ID] = &Node{Task: t}
+	}
+
+	var roots []*Node
+	for _, t := range tasks {
+		node := nodes[t.ID]
+		if parent, ok := nodes[t.ParentTaskID]; ok && t.ParentTaskID != t.ID {
+			parent.Children = append(parent.Children, node)
+		} else {
+			roots = append(roots, node)
+		}
+	}
+
+	sortNodes(roots)
+	return roots
+}
+
+func sortNodes(nodes []*Node) {
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].Task.ID < nodes[j].Task.ID })
+	for _, n := range nodes {
+		sortNodes(n.Children)
+	}
+}
+
+// Progress soma as folhas da subárvore: retorna quantas estão concluídas e o total.
+// Uma tarefa sem subtarefas conta como uma folha.
+func (n *Node) Progress() (done int, total int) {
+	if len(n.Children) == 0 {
+		if n.Task.IsCompleted() {
+			return 1, 1
+		}
+		return 0, 1
+	}
+	for _, child := range n.Children {
+		d, t := child.Progress()
+		done += d
+		total += t
+	}
+	return done, total
+}
+
+// TreeProgress soma o progresso de várias raízes (ex: um projeto inteiro)
+func TreeProgress(roots []*Node) (done int, total int) {
+	for _, root := range roots {
+		d, t := root.Progress()
+		done += d
+		total += t
+	}
+	return done, total
+}
+
+// Find procura a tarefa id na árvore
+func Find(roots []*Node, id int) *Node {
+	for _, n := range roots {
+		if n.Task.ID == id {
+			return n
+		}
+		if found := Find(n.Children, id); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// Descendants retorna todas as subtarefas (em qualquer nível) de id
+func Descendants(tasks []*Task, id int) []*Task {
+	children := make(map[int][]*Task)
+	for _, t := range tasks {
+		if t.ParentTaskID != 0 {
+			children[t.ParentTaskID] = append(children[t.ParentTaskID], t)
+		}
+	}
+
+	var result []*Task
+	visited := map[int]bool{id: true}
+	queue := []int{id}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, child := range children[current] {
+			if visited[child.ID] {
+				continue
+			}
+			visited[child.ID] = true
+			result = append(result, child)
+			queue = append(queue, child.ID)
+		}
+	}
+	return result
+}
+
+// OpenDescendants retorna as subtarefas ainda não concluídas de id
+func OpenDescendants(tasks []*Task, id int) []*Task {
+	var open []*Task
+	for _, t := range Descendants(tasks, id) {
+		if !t.IsCompleted() {
+			open = append(open, t)
+		}
+	}
+	return open
+}
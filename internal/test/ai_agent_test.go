@@ -0,0 +1,116 @@
+package test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/snip/internal/ai"
+)
+
+// toolsClient pede uma chamada de ferramenta a cada turno até esgotar calls,
+// depois responde com texto; registra as conversas recebidas
+type toolsClient struct {
+	ai.AIClient
+	calls         int
+	conversations [][]ai.Message
+	err           error
+}
+
+func (c *toolsClient) ChatWithTools(messages []ai.Message, tools []ai.Tool, maxTokens int, temperature float64) (*ai.ToolResponse, error) {
+	c.conversations = append(c.conversations, messages)
+	if c.err != nil {
+		return nil, c.err
+	}
+	if len(c.conversations) > c.calls {
+		return &ai.ToolResponse{Content: "resposta final"}, nil
+	}
+	n := len(c.conversations)
+	return &ai.ToolResponse{ToolCalls: []ai.ToolCall{{
+		ID:       fmt.Sprintf("call_%d", n),
+		Type:     "function",
+		Function: ai.ToolCallFunction{Name: "run_readonly_query", Arguments: fmt.Sprintf(`{"query": "SELECT %d"}`, n)},
+	}}}, nil
+}
+
+func TestAgentRun(t *testing.T) {
+	tests := []struct {
+		name          string
+		toolCalls     int
+		maxSteps      int
+		toolErr       error
+		expectedSteps int
+		expectLimit   bool
+	}{
+		{name: "answers without tools", toolCalls: 0, maxSteps: 3, expectedSteps: 0},
+		{name: "answers after tools", toolCalls: 2, maxSteps: 3, expectedSteps: 2},
+		{name: "tool errors go back to the model", toolCalls: 1, maxSteps: 3, toolErr: errors.New("tabela não encontrada"), expectedSteps: 1},
+		{name: "step limit", toolCalls: 10, maxSteps: 3, expectedSteps: 3, expectLimit: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &toolsClient{calls: tt.toolCalls}
+			var traced []ai.AgentStep
+			agent := ai.Agent{
+				Client: client,
+				Execute: func(name string, arguments json.RawMessage) (string, error) {
+					if tt.toolErr != nil {
+						return "", tt.toolErr
+					}
+					return "| 1 |", nil
+				},
+				MaxSteps: tt.maxSteps,
+				OnStep:   func(step ai.AgentStep) { traced = append(traced, step) },
+			}
+
+			response, steps, err := agent.Run([]ai.Message{{Role: "user", Content: "quantos pedidos?"}}, 500, 0)
+
+			if len(steps) != tt.expectedSteps || len(traced) != tt.expectedSteps {
+				t.Fatalf("expected %d steps, got %d (%d traced)", tt.expectedSteps, len(steps), len(traced))
+			}
+			if tt.expectLimit {
+				if !errors.Is(err, ai.ErrAgentStepLimit) || response != "" {
+					t.Fatalf("expected ErrAgentStepLimit, got %q (%v)", response, err)
+				}
+				// Ao atingir o limite o modelo é chamado mais uma vez, instruído a concluir
+				if len(client.conversations) != tt.maxSteps+1 {
+					t.Errorf("expected %d model calls, got %d", tt.maxSteps+1, len(client.conversations))
+				}
+				return
+			}
+			if err != nil || response != "resposta final" {
+				t.Fatalf("expected final answer, got %q (%v)", response, err)
+			}
+
+			for i, step := range steps {
+				if step.Step != i+1 || step.Name != "run_readonly_query" || !errors.Is(step.Err, tt.toolErr) {
+					t.Errorf("unexpected step: %+v", step)
+				}
+			}
+
+			if tt.toolErr != nil {
+				// O erro da ferramenta volta ao modelo como resultado da chamada
+				last := client.conversations[len(client.conversations)-1]
+				result := last[len(last)-1]
+				if result.Role != "tool" || result.ToolCallID != "call_1" || !strings.Contains(result.Content, "ERRO: tabela não encontrada") {
+					t.Errorf("expected tool error in the conversation, got %+v", result)
+				}
+			}
+		})
+	}
+}
+
+func TestAgentRunClientError(t *testing.T) {
+	client := &toolsClient{err: errors.New("503")}
+	agent := ai.Agent{
+		Client:  client,
+		Execute: func(name string, arguments json.RawMessage) (string, error) { return "", nil },
+	}
+
+	if _, _, err := agent.Run([]ai.Message{{Role: "user", Content: "oi"}}, 500, 0); err == nil || err.Error() != "503" {
+		t.Errorf("expected client error to be returned, got %v", err)
+	}
+}
@@ -0,0 +1,196 @@
+package test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/snip/internal/ai"
+	"github.com/snip/internal/database"
+	"github.com/snip/internal/repository"
+)
+
+// memCacheStore é um CacheStore em memória que respeita a validade das entradas
+type memCacheStore struct {
+	entries map[string]*ai.CacheEntry
+}
+
+func (s *memCacheStore) Get(key string) (string, bool, error) {
+	entry, ok := s.entries[key]
+	if !ok || !entry.ExpiresAt.After(time.Now()) {
+		return "", false, nil
+	}
+	entry.Hits++
+	return entry.Response, true, nil
+}
+
+func (s *memCacheStore) Put(entry *ai.CacheEntry) error {
+	s.entries[entry.Key] = entry
+	return nil
+}
+
+// echoClient responde com o conteúdo da última mensagem ou falha com err
+type echoClient struct {
+	ai.AIClient
+	provider ai.Provider
+	model    string
+	err      error
+	calls    int
+}
+
+func (c *echoClient) GetProvider() ai.Provider { return c.provider }
+
+func (c *echoClient) GetModel() string { return c.model }
+
+func (c *echoClient) Chat(messages []ai.Message, maxTokens int, temperature float64) (string, error) {
+	c.calls++
+	if c.err != nil {
+		return "", c.err
+	}
+	return "eco: " + messages[len(messages)-1].Content, nil
+}
+
+func withCacheStore(t *testing.T, store ai.CacheStore) {
+	t.Helper()
+	ai.SetCacheStore(store)
+	t.Cleanup(func() {
+		ai.SetCacheStore(nil)
+		ai.SetCacheDisabled(false)
+	})
+}
+
+func TestCacheKey(t *testing.T) {
+	messages := []ai.Message{{Role: "system", Content: "sys"}, {Role: "user", Content: "oi"}}
+	base := ai.CacheKey(ai.ProviderGroq, "llama-3.1-8b-instant", messages, 100, 0.3, false)
+
+	if again := ai.CacheKey(ai.ProviderGroq, "llama-3.1-8b-instant", []ai.Message{{Role: "system", Content: "sys"}, {Role: "user", Content: "oi"}}, 100, 0.3, false); again != base {
+		t.Errorf("expected the same key for the same request")
+	}
+
+	variants := map[string]string{
+		"provider":    ai.CacheKey(ai.ProviderOpenAI, "llama-3.1-8b-instant", messages, 100, 0.3, false),
+		"model":       ai.CacheKey(ai.ProviderGroq, "llama-3.1-70b-versatile", messages, 100, 0.3, false),
+		"messages":    ai.CacheKey(ai.ProviderGroq, "llama-3.1-8b-instant", messages[1:], 100, 0.3, false),
+		"max tokens":  ai.CacheKey(ai.ProviderGroq, "llama-3.1-8b-instant", messages, 200, 0.3, false),
+		"temperature": ai.CacheKey(ai.ProviderGroq, "llama-3.1-8b-instant", messages, 100, 0.7, false),
+		"json mode":   ai.CacheKey(ai.ProviderGroq, "llama-3.1-8b-instant", messages, 100, 0.3, true),
+	}
+	for name, key := range variants {
+		if key == base {
+			t.Errorf("expected %s to change the key", name)
+		}
+	}
+}
+
+func TestCachedClient(t *testing.T) {
+	store := &memCacheStore{entries: make(map[string]*ai.CacheEntry)}
+	withCacheStore(t, store)
+
+	inner := &echoClient{provider: ai.ProviderGroq, model: "llama-3.1-8b-instant"}
+	client := ai.NewCachedClient(inner, time.Hour)
+	messages := []ai.Message{{Role: "user", Content: "oi"}}
+
+	for i := 0; i < 2; i++ {
+		if response, err := client.Chat(messages, 100, 0.3); err != nil || response != "eco: oi" {
+			t.Fatalf("unexpected response %q (%v)", response, err)
+		}
+	}
+	if inner.calls != 1 || len(store.entries) != 1 {
+		t.Fatalf("expected the repeated request to be served from cache, got %d calls", inner.calls)
+	}
+
+	// Outra temperatura é outra requisição
+	if _, err := client.Chat(messages, 100, 0.9); err != nil {
+		t.Fatal(err)
+	}
+	if inner.calls != 2 {
+		t.Errorf("expected a new call for another temperature, got %d calls", inner.calls)
+	}
+
+	// --no-cache ignora o cache nas duas direções
+	ai.SetCacheDisabled(true)
+	if _, err := client.Chat([]ai.Message{{Role: "user", Content: "sem cache"}}, 100, 0.3); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Chat(messages, 100, 0.3); err != nil {
+		t.Fatal(err)
+	}
+	ai.SetCacheDisabled(false)
+	if inner.calls != 4 || len(store.entries) != 2 {
+		t.Errorf("expected --no-cache to bypass the store, got %d calls and %d entries", inner.calls, len(store.entries))
+	}
+
+	// Entradas vencidas são pedidas de novo
+	for _, entry := range store.entries {
+		entry.ExpiresAt = time.Now().Add(-time.Minute)
+	}
+	if _, err := client.Chat(messages, 100, 0.3); err != nil {
+		t.Fatal(err)
+	}
+	if inner.calls != 5 {
+		t.Errorf("expected expired entry to be refreshed, got %d calls", inner.calls)
+	}
+}
+
+func TestCachedClientRecordsFallbackResponder(t *testing.T) {
+	store := &memCacheStore{entries: make(map[string]*ai.CacheEntry)}
+	withCacheStore(t, store)
+
+	primary := &echoClient{provider: ai.ProviderGroq, model: "llama-3.1-8b-instant", err: errors.New("503")}
+	secondary := &echoClient{provider: ai.ProviderAnthropic, model: "claude-3-5-haiku-20241022"}
+	client := ai.NewCachedClient(ai.NewFallbackClient(primary, secondary), time.Hour)
+
+	messages := []ai.Message{{Role: "user", Content: "oi"}}
+	if _, err := client.Chat(messages, 100, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	key := ai.CacheKey(ai.ProviderGroq, "llama-3.1-8b-instant", messages, 100, 0, false)
+	entry, ok := store.entries[key]
+	if !ok {
+		t.Fatalf("expected entry under the requested provider/model key, got %v", store.entries)
+	}
+	if entry.Provider != ai.ProviderAnthropic || entry.Model != "claude-3-5-haiku-20241022" {
+		t.Errorf("expected entry to record the fallback that answered, got %s/%s", entry.Provider, entry.Model)
+	}
+}
+
+func TestAICacheRepositoryExpiry(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	db, err := database.Connect()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	repo, _ := repository.NewAICacheRepository(db)
+	now := time.Now()
+	entries := []*ai.CacheEntry{
+		{Key: "valida", Provider: ai.ProviderGroq, Model: "llama-3.1-8b-instant", Response: "ok", CreatedAt: now, ExpiresAt: now.Add(time.Hour)},
+		{Key: "vencida", Provider: ai.ProviderGroq, Model: "llama-3.1-8b-instant", Response: "velha", CreatedAt: now.Add(-2 * time.Hour), ExpiresAt: now.Add(-time.Hour)},
+	}
+	for _, entry := range entries {
+		if err := repo.Put(entry); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if response, ok, err := repo.Get("valida"); err != nil || !ok || response != "ok" {
+		t.Errorf("expected valid entry, got %q %v (%v)", response, ok, err)
+	}
+	if _, ok, err := repo.Get("vencida"); err != nil || ok {
+		t.Errorf("expected expired entry to be a miss, got %v (%v)", ok, err)
+	}
+	if _, ok, _ := repo.Get("ausente"); ok {
+		t.Error("expected missing entry to be a miss")
+	}
+
+	stats, err := repo.Stats()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.Entries != 2 || stats.Expired != 1 || stats.Hits != 1 || stats.ByModel["groq/llama-3.1-8b-instant"] != 2 {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+}
@@ -0,0 +1,126 @@
+package test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/snip/internal/ai"
+	"github.com/snip/internal/aichat"
+	"github.com/snip/internal/database"
+	"github.com/snip/internal/repository"
+)
+
+func TestAIChatRepository(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	db, err := database.Connect()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	repo, _ := repository.NewAIChatRepository(db)
+
+	if _, err := repo.GetLastSession(); !errors.Is(err, repository.ErrSessionNotFound) {
+		t.Fatalf("expected ErrSessionNotFound without sessions, got %v", err)
+	}
+
+	now := time.Now().Truncate(time.Second)
+	first := &aichat.ChatSession{Title: "deploy", Provider: ai.ProviderGroq, Model: "llama-3.1-8b-instant", CreatedAt: now.Add(-time.Hour), UpdatedAt: now.Add(-time.Hour)}
+	second := &aichat.ChatSession{Title: "backup", Provider: ai.ProviderOpenAI, Model: "gpt-4o-mini", CreatedAt: now, UpdatedAt: now}
+	for _, session := range []*aichat.ChatSession{first, second} {
+		if err := repo.CreateSession(session); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if first.ID == 0 || second.ID == first.ID {
+		t.Fatalf("expected distinct IDs, got %d and %d", first.ID, second.ID)
+	}
+
+	for _, content := range []string{"como faço o deploy?", "use o pipeline", "e o rollback?"} {
+		role := "user"
+		if content == "use o pipeline" {
+			role = "assistant"
+		}
+		if err := repo.AddMessage(&aichat.ChatMessage{SessionID: first.ID, Role: role, Content: content}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, noteID := range []int{7, 3, 7} {
+		if err := repo.PinNote(first.ID, noteID); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Run("resume session", func(t *testing.T) {
+		session, err := repo.GetSession(first.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if session.Title != "deploy" || session.Provider != ai.ProviderGroq || session.Model != "llama-3.1-8b-instant" {
+			t.Errorf("unexpected session: %+v", session)
+		}
+		if session.MessageCount != 3 || session.Messages[0].Content != "como faço o deploy?" ||
+			session.Messages[1].Role != "assistant" || session.Messages[2].Content != "e o rollback?" {
+			t.Errorf("expected messages in order, got %+v", session.Messages)
+		}
+		if len(session.PinnedNotes) != 2 || session.PinnedNotes[0] != 7 || session.PinnedNotes[1] != 3 {
+			t.Errorf("expected pinned notes [7 3], got %v", session.PinnedNotes)
+		}
+
+		empty, err := repo.GetSession(second.ID)
+		if err != nil || empty.Messages == nil || len(empty.Messages) != 0 || empty.PinnedNotes != nil {
+			t.Errorf("expected session without messages, got %+v (%v)", empty, err)
+		}
+	})
+
+	t.Run("last session follows updates", func(t *testing.T) {
+		last, err := repo.GetLastSession()
+		if err != nil || last.ID != second.ID {
+			t.Fatalf("expected session %d, got %+v (%v)", second.ID, last, err)
+		}
+
+		first.Title = "deploy de sexta"
+		first.UpdatedAt = now.Add(time.Minute)
+		if err := repo.UpdateSession(first); err != nil {
+			t.Fatal(err)
+		}
+		last, err = repo.GetLastSession()
+		if err != nil || last.ID != first.ID || last.Title != "deploy de sexta" || last.MessageCount != 3 {
+			t.Errorf("expected updated session %d to be last, got %+v (%v)", first.ID, last, err)
+		}
+	})
+
+	t.Run("list sessions", func(t *testing.T) {
+		sessions, err := repo.ListSessions(10)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(sessions) != 2 || sessions[0].ID != first.ID || sessions[0].MessageCount != 3 ||
+			sessions[1].ID != second.ID || sessions[1].MessageCount != 0 || sessions[0].Messages != nil {
+			t.Errorf("unexpected sessions: %+v %+v", sessions[0], sessions[1])
+		}
+
+		if limited, err := repo.ListSessions(1); err != nil || len(limited) != 1 {
+			t.Errorf("expected limit to apply, got %d sessions (%v)", len(limited), err)
+		}
+	})
+
+	t.Run("delete session", func(t *testing.T) {
+		if err := repo.DeleteSession(first.ID); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := repo.GetSession(first.ID); !errors.Is(err, repository.ErrSessionNotFound) {
+			t.Errorf("expected ErrSessionNotFound, got %v", err)
+		}
+		if err := repo.DeleteSession(first.ID); !errors.Is(err, repository.ErrSessionNotFound) {
+			t.Errorf("expected ErrSessionNotFound on second delete, got %v", err)
+		}
+
+		var orphans int
+		if err := db.QueryRow(`SELECT (SELECT COUNT(*) FROM ai_messages WHERE session_id = ?) + (SELECT COUNT(*) FROM ai_session_notes WHERE session_id = ?)`, first.ID, first.ID).Scan(&orphans); err != nil || orphans != 0 {
+			t.Errorf("expected messages and pinned notes to be removed, got %d (%v)", orphans, err)
+		}
+	})
+}
@@ -0,0 +1,191 @@
+package test
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/snip/internal/ai"
+)
+
+// scriptedClient devolve as respostas na ordem e registra as mensagens de cada chamada
+type scriptedClient struct {
+	ai.AIClient
+	responses []string
+	calls     [][]ai.Message
+}
+
+func (c *scriptedClient) GetProvider() ai.Provider { return ai.ProviderReplay }
+
+func (c *scriptedClient) GetModel() string { return "roteiro" }
+
+func (c *scriptedClient) Chat(messages []ai.Message, maxTokens int, temperature float64) (string, error) {
+	c.calls = append(c.calls, messages)
+	if len(c.calls) > len(c.responses) {
+		return "", errors.New("sem mais respostas no roteiro")
+	}
+	return c.responses[len(c.calls)-1], nil
+}
+
+type jsonStep struct {
+	Title string `json:"title" ai:"required"`
+	Owner string `json:"owner"`
+}
+
+type jsonPlan struct {
+	Name  string     `json:"name" ai:"required"`
+	Steps []jsonStep `json:"steps" ai:"required"`
+	Meta  *struct {
+		Source string `json:"source" ai:"required"`
+	} `json:"meta"`
+}
+
+func TestExtractJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		response string
+		expected string
+	}{
+		{name: "plain object", response: `{"a": 1}`, expected: `{"a": 1}`},
+		{name: "fenced json block", response: "Aqui está:\n```json\n{\"a\": 1}\n```\nAté mais", expected: `{"a": 1}`},
+		{name: "fenced block without language", response: "```\n[1, 2]\n```", expected: `[1, 2]`},
+		{name: "text around", response: `Resposta: {"a": {"b": 2}} espero ter ajudado {x}`, expected: `{"a": {"b": 2}}`},
+		{name: "braces inside strings", response: `{"sql": "SELECT '{' || x || '}'", "n": "a\"}"} fim`, expected: `{"sql": "SELECT '{' || x || '}'", "n": "a\"}"}`},
+		{name: "array before object", response: `itens: [{"a": 1}, {"a": 2}] e {"b": 3}`, expected: `[{"a": 1}, {"a": 2}]`},
+		{name: "truncated", response: `{"a": [1, 2`, expected: `{"a": [1, 2`},
+		{name: "no json", response: "não sei responder", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ai.ExtractJSON(tt.response); got != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestRepairJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		raw      string
+		expected string
+	}{
+		{name: "valid json untouched", raw: `{"a": [1, 2]}`, expected: `{"a": [1, 2]}`},
+		{name: "trailing commas", raw: `{"a": [1, 2,], "b": 3,}`, expected: `{"a": [1, 2], "b": 3}`},
+		{name: "smart quotes", raw: `{“a”: “b”}`, expected: `{"a": "b"}`},
+		{name: "truncated object", raw: `{"a": {"b": 1`, expected: `{"a": {"b": 1}}`},
+		{name: "truncated array", raw: `[{"a": 1}, {"a": 2},`, expected: `[{"a": 1}, {"a": 2}]`},
+		{name: "truncated string", raw: `{"items": ["um", "do`, expected: `{"items": ["um", "do"]}`},
+		{name: "brackets inside strings", raw: `{"a": "[{", "b": [1`, expected: `{"a": "[{", "b": [1]}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ai.RepairJSON(tt.raw)
+			if got != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, got)
+			}
+			if !json.Valid([]byte(got)) {
+				t.Errorf("expected repaired JSON to be valid: %q", got)
+			}
+		})
+	}
+}
+
+func TestValidateJSONSchema(t *testing.T) {
+	tests := []struct {
+		name        string
+		raw         string
+		expectedErr string
+	}{
+		{name: "complete", raw: `{"name": "p", "steps": [{"title": "a"}], "meta": {"source": "ia"}}`},
+		{name: "optional fields missing", raw: `{"name": "p", "steps": [{"title": "a"}]}`},
+		{name: "top level field", raw: `{"steps": [{"title": "a"}]}`, expectedErr: "name"},
+		{name: "empty required slice", raw: `{"name": "p", "steps": []}`, expectedErr: "steps"},
+		{name: "field inside slice", raw: `{"name": "p", "steps": [{"title": "a"}, {"owner": "b"}]}`, expectedErr: "steps[1].title"},
+		{name: "nested struct", raw: `{"name": "p", "steps": [{"title": "a"}], "meta": {}}`, expectedErr: "meta.source"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var plan jsonPlan
+			if err := json.Unmarshal([]byte(tt.raw), &plan); err != nil {
+				t.Fatal(err)
+			}
+
+			err := ai.ValidateJSONSchema(&plan)
+			if tt.expectedErr == "" {
+				if err != nil {
+					t.Errorf("expected no error, got %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.HasSuffix(err.Error(), ": "+tt.expectedErr) {
+				t.Errorf("expected missing %s, got %v", tt.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestChatJSON(t *testing.T) {
+	tests := []struct {
+		name          string
+		responses     []string
+		expectedCalls int
+		expectErr     bool
+	}{
+		{name: "valid first time", responses: []string{"```json\n{\"name\": \"p\", \"steps\": [{\"title\": \"a\"},]}\n```"}, expectedCalls: 1},
+		{name: "retry after invalid json", responses: []string{"não consigo", `{"name": "p", "steps": [{"title": "a"}]}`}, expectedCalls: 2},
+		{name: "retry after missing field", responses: []string{`{"name": "p", "steps": [{"owner": "b"}]}`, `{"name": "p", "steps": [{"title": "a"}]}`}, expectedCalls: 2},
+		{name: "single retry only", responses: []string{`{"steps": []}`, `{"steps": []}`, `{"name": "p", "steps": [{"title": "a"}]}`}, expectedCalls: 2, expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inner := &scriptedClient{responses: tt.responses}
+			var plan jsonPlan
+			err := ai.NewFallbackClient(inner).ChatJSON([]ai.Message{{Role: "user", Content: "plano"}}, &plan, 500, 0)
+
+			if len(inner.calls) != tt.expectedCalls {
+				t.Fatalf("expected %d calls, got %d", tt.expectedCalls, len(inner.calls))
+			}
+			if tt.expectErr {
+				if !errors.Is(err, ai.ErrInvalidJSON) {
+					t.Errorf("expected ErrInvalidJSON, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if plan.Name != "p" || len(plan.Steps) != 1 || plan.Steps[0].Title != "a" {
+				t.Errorf("unexpected result: %+v", plan)
+			}
+
+			if tt.expectedCalls > 1 {
+				// A nova tentativa leva a resposta anterior e o erro de validação
+				retry := inner.calls[1]
+				if len(retry) != 3 || retry[1].Role != "assistant" || retry[1].Content != tt.responses[0] {
+					t.Fatalf("expected retry to include the previous answer, got %+v", retry)
+				}
+				if !strings.Contains(retry[2].Content, `"title"`) {
+					t.Errorf("expected repair prompt to describe the schema, got %q", retry[2].Content)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateChecklist(t *testing.T) {
+	inner := &scriptedClient{responses: []string{`{"items": ["  Revisar backups ", "", "Testar restore", "Validar alertas"]}`}}
+
+	items, err := ai.NewFallbackClient(inner).GenerateChecklist("deploy", "", 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 2 || items[0] != "Revisar backups" || items[1] != "Testar restore" {
+		t.Errorf("expected blank items dropped and list trimmed to 2, got %q", items)
+	}
+}
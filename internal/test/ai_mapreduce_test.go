@@ -0,0 +1,158 @@
+package test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/snip/internal/ai"
+)
+
+// summaryClient responde aos resumos de parte e à síntese final, registrando as chamadas.
+// Os demais métodos de ai.AIClient não são usados por ChatLongText.
+type summaryClient struct {
+	ai.AIClient
+	model string
+	calls [][]ai.Message
+}
+
+func (c *summaryClient) GetModel() string { return c.model }
+
+func (c *summaryClient) GetProvider() ai.Provider { return ai.ProviderReplay }
+
+func (c *summaryClient) Chat(messages []ai.Message, maxTokens int, temperature float64) (string, error) {
+	c.calls = append(c.calls, messages)
+	last := messages[len(messages)-1].Content
+	if strings.Contains(last, "Parte ") {
+		return fmt.Sprintf("## Resumo %d\n- ORA-00060 em destaque", len(c.calls)), nil
+	}
+	return "síntese final", nil
+}
+
+func buildLongText(sections, linesPerSection int) string {
+	var sb strings.Builder
+	for s := 1; s <= sections; s++ {
+		sb.WriteString(fmt.Sprintf("## Seção %d\n", s))
+		for l := 0; l < linesPerSection; l++ {
+			sb.WriteString(fmt.Sprintf("| sid %d | SELECT * FROM pedidos WHERE id = %d | 12.5s |\n", l, l))
+		}
+	}
+	return sb.String()
+}
+
+func TestEstimateTokensAndContextWindow(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	tests := []struct {
+		name         string
+		model        string
+		expectWindow int
+	}{
+		{name: "known model", model: "gpt-4o", expectWindow: 128000},
+		{name: "openrouter prefix", model: "anthropic/claude-3-opus-20240229", expectWindow: 200000},
+		{name: "unknown model uses default", model: "modelo-local", expectWindow: ai.DefaultContextWindow},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if window := ai.ContextWindow(tt.model); window != tt.expectWindow {
+				t.Errorf("expected window %d, got %d", tt.expectWindow, window)
+			}
+			if tokens := ai.EstimateTokens(tt.model, strings.Repeat("a", 3500)); tokens < 1000 || tokens > 1200 {
+				t.Errorf("expected about 1000-1200 tokens, got %d", tokens)
+			}
+		})
+	}
+
+	if tokens := ai.EstimateTokens("gpt-4o", ""); tokens != 0 {
+		t.Errorf("expected 0 tokens for empty text, got %d", tokens)
+	}
+}
+
+func TestSplitIntoChunks(t *testing.T) {
+	text := buildLongText(4, 40)
+	maxTokens := 1000
+
+	chunks := ai.SplitIntoChunks("gpt-4o", text, maxTokens)
+	if len(chunks) < 2 {
+		t.Fatalf("expected several chunks, got %d", len(chunks))
+	}
+
+	for i, chunk := range chunks {
+		if tokens := ai.EstimateTokens("gpt-4o", chunk); tokens > maxTokens {
+			t.Errorf("chunk %d has %d tokens, limit %d", i, tokens, maxTokens)
+		}
+		// Seções maiores que o limite repetem o cabeçalho em cada parte
+		if !strings.HasPrefix(chunk, "## Seção ") {
+			t.Errorf("chunk %d does not start with a section header: %q", i, chunk[:40])
+		}
+	}
+
+	if joined := strings.Join(chunks, "\n"); strings.Count(joined, "| sid ") != 160 {
+		t.Errorf("expected every row to be kept, got %d", strings.Count(joined, "| sid "))
+	}
+
+	small := "## A\nlinha\n## B\nlinha"
+	if chunks := ai.SplitIntoChunks("gpt-4o", small, maxTokens); len(chunks) != 1 || chunks[0] != small {
+		t.Errorf("expected small text in a single chunk, got %q", chunks)
+	}
+}
+
+func TestChatLongTextStrategy(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	tests := []struct {
+		name           string
+		text           string
+		expectStrategy string
+		expectMinCalls int
+	}{
+		{name: "fits in context", text: buildLongText(2, 5), expectStrategy: ai.StrategySingle, expectMinCalls: 1},
+		{name: "map-reduce when too large", text: buildLongText(6, 200), expectStrategy: ai.StrategyMapReduce, expectMinCalls: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &summaryClient{model: "modelo-local"}
+
+			var summarizedInput string
+			result, err := ai.ChatLongText(client, ai.LongTextRequest{
+				Text:    tt.text,
+				Subject: "oracle / running_queries",
+				Build: func(text string, summarized bool) ([]ai.Message, error) {
+					if summarized {
+						summarizedInput = text
+					}
+					return []ai.Message{{Role: "user", Content: "Analise:\n" + text}}, nil
+				},
+				MaxTokens:   2000,
+				Temperature: 0.7,
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if result.Strategy != tt.expectStrategy {
+				t.Errorf("expected strategy %s, got %s", tt.expectStrategy, result.Strategy)
+			}
+			if result.Metadata()["strategy"] != tt.expectStrategy {
+				t.Errorf("expected strategy in metadata, got %v", result.Metadata())
+			}
+			if result.Content != "síntese final" {
+				t.Errorf("expected final synthesis, got %q", result.Content)
+			}
+			if len(client.calls) < tt.expectMinCalls {
+				t.Errorf("expected at least %d calls, got %d", tt.expectMinCalls, len(client.calls))
+			}
+
+			if tt.expectStrategy == ai.StrategyMapReduce {
+				if result.Chunks != len(client.calls)-1 {
+					t.Errorf("expected %d chunks, got %d", len(client.calls)-1, result.Chunks)
+				}
+				if !strings.Contains(summarizedInput, "## Resumo 1") || !strings.Contains(summarizedInput, "ORA-00060") {
+					t.Errorf("expected chunk summaries in the final prompt, got %q", summarizedInput)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/snip/internal/ai"
+)
+
+func TestAIFallbackErrors(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	config := &ai.AIConfig{
+		Provider: ai.ProviderGroq,
+		APIKey:   "gsk-principal",
+		Profiles: map[string]*ai.AIProfile{
+			"fast":   {Provider: ai.ProviderGroq, Model: "llama-3.1-8b-instant", APIKey: "gsk-fast"},
+			"nokey":  {Provider: ai.ProviderOpenAI, Model: "gpt-4o-mini"},
+			"exotic": {Provider: "watson", APIKey: "k"},
+		},
+		Fallback: []string{"fast", "nokey", "gone", "exotic"},
+	}
+
+	problems := config.FallbackErrors()
+	if len(problems) != 3 || problems["fast"] != nil {
+		t.Fatalf("expected nokey, gone and exotic to be invalid, got %v", problems)
+	}
+	for _, name := range []string{"nokey", "gone", "exotic"} {
+		if problems[name] == nil {
+			t.Errorf("expected %s to be flagged", name)
+		}
+	}
+
+	// Principal que sempre falha, para que a cadeia de fallback seja usada
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error": {"message": "indisponível"}}`, http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+	config.Provider, config.Model, config.Endpoint = ai.ProviderAzure, "gpt-4o", server.URL
+	config.Fallback = []string{"nokey", "gone"}
+	if err := ai.SaveConfig(config); err != nil {
+		t.Fatal(err)
+	}
+
+	// Montar o cliente não avisa nada: comandos sem IA também passam por aqui
+	var client ai.AIClient
+	var err error
+	output := captureStderr(t, func() {
+		client, err = ai.NewAIClientFor(ai.FeatureDefault)
+	})
+	if err != nil || client == nil {
+		t.Fatalf("expected client despite invalid fallbacks, got %v", err)
+	}
+	if output != "" {
+		t.Errorf("expected no warning when building the client, got %q", output)
+	}
+
+	// O aviso aparece quando o principal falha, uma única vez
+	output = captureStderr(t, func() {
+		for i := 0; i < 2; i++ {
+			if _, err := client.Chat([]ai.Message{{Role: "user", Content: "oi"}}, 10, 0); err == nil {
+				t.Error("expected error from failing primary")
+			}
+		}
+	})
+	for _, name := range []string{"'nokey'", "'gone'"} {
+		if strings.Count(output, name) != 1 {
+			t.Errorf("expected one warning for %s, got %q", name, output)
+		}
+	}
+}
+
+// captureStderr retorna o que fn escreveu em os.Stderr
+func captureStderr(t *testing.T, fn func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stderr := os.Stderr
+	os.Stderr = w
+	fn()
+	os.Stderr = stderr
+	w.Close()
+
+	output, _ := io.ReadAll(r)
+	return string(output)
+}
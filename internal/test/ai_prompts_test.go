@@ -0,0 +1,51 @@
+package test
+
+import (
+	"strings"
+	"testing"
+	"text/template"
+
+	"github.com/snip/internal/ai"
+)
+
+func TestEmbeddedPromptsPerLanguage(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	prompts, err := ai.ListPrompts(ai.DefaultLanguage)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	names := make(map[string]bool)
+	for _, p := range prompts {
+		names[p.Name] = true
+	}
+	for _, name := range []string{
+		"dbhistorychat.context", "dbhistorychat.query", "dbhistorychat.interpret",
+		"dbhistorychat.error", "dbhistorychat.conversation",
+		"dbdynamic.query", "dbdynamic.interpret",
+	} {
+		if !names[name] {
+			t.Errorf("expected prompt %s to be listed", name)
+		}
+	}
+
+	// Todo prompt tem a própria versão em cada idioma, sem cair no padrão pt-BR
+	for _, language := range ai.GetLanguages() {
+		for _, p := range prompts {
+			source, origin, err := ai.LoadPromptSource(p.Name, language)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if origin != "embedded" {
+				t.Errorf("%s/%s: expected embedded template, got %s", language, p.Name, origin)
+			}
+			if _, err := template.New(p.Name).Parse(source); err != nil {
+				t.Errorf("%s/%s: %v", language, p.Name, err)
+			}
+			if language != ai.LanguagePortuguese && strings.Contains(source, "Você é") {
+				t.Errorf("%s/%s: template is not translated", language, p.Name)
+			}
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/snip/internal/ai"
+)
+
+func TestGeminiClientToolRoundTrip(t *testing.T) {
+	var gotPath, gotKey string
+	var gotBody map[string]interface{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotKey = r.Header.Get("x-goog-api-key")
+		body, _ := io.ReadAll(r.Body)
+		json.Unmarshal(body, &gotBody)
+
+		w.Write([]byte(`{
+			"candidates": [{"content": {"role": "model", "parts": [
+				{"text": "Vou consultar."},
+				{"functionCall": {"name": "run_query", "args": {"sql": "SELECT 1"}}}
+			]}}],
+			"usageMetadata": {"promptTokenCount": 42, "candidatesTokenCount": 7}
+		}`))
+	}))
+	defer server.Close()
+
+	client, err := ai.NewGeminiClient(&ai.AIConfig{APIKey: "chave", Model: "gemini-1.5-flash", Endpoint: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	messages := []ai.Message{
+		{Role: "system", Content: "Você é um DBA."},
+		{Role: "user", Content: "Quantas sessões?"},
+		{Role: "assistant", ToolCalls: []ai.ToolCall{{ID: "call_1", Type: "function", Function: ai.ToolCallFunction{Name: "run_query", Arguments: `{"sql":"SELECT 2"}`}}}},
+		{Role: "tool", ToolCallID: "call_1", Content: "2"},
+	}
+	tools := []ai.Tool{{Name: "run_query", Description: "Executa SQL", Parameters: map[string]interface{}{
+		"type":       "object",
+		"properties": map[string]interface{}{"sql": map[string]interface{}{"type": "string"}},
+	}}}
+
+	response, err := client.ChatWithTools(messages, tools, 500, 0.2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if gotPath != "/models/gemini-1.5-flash:generateContent" || gotKey != "chave" {
+		t.Errorf("unexpected request path %q or key %q", gotPath, gotKey)
+	}
+	if _, ok := gotBody["systemInstruction"]; !ok {
+		t.Error("expected systemInstruction in request")
+	}
+
+	contents, _ := gotBody["contents"].([]interface{})
+	if len(contents) != 3 {
+		t.Fatalf("expected 3 contents (user, model, user), got %d", len(contents))
+	}
+	last, _ := contents[2].(map[string]interface{})
+	parts, _ := last["parts"].([]interface{})
+	part, _ := parts[0].(map[string]interface{})
+	fnResponse, _ := part["functionResponse"].(map[string]interface{})
+	if fnResponse["name"] != "run_query" {
+		t.Errorf("expected functionResponse named after the tool call, got %v", part)
+	}
+
+	if response.Content != "Vou consultar." || len(response.ToolCalls) != 1 {
+		t.Fatalf("unexpected response: %+v", response)
+	}
+	if call := response.ToolCalls[0]; call.Function.Name != "run_query" || call.Function.Arguments != `{"sql": "SELECT 1"}` {
+		t.Errorf("unexpected tool call: %+v", call)
+	}
+	if usage := client.LastUsage(); usage.InputTokens != 42 || usage.OutputTokens != 7 {
+		t.Errorf("unexpected usage: %+v", usage)
+	}
+}
+
+func TestAzureOpenAIClientRequest(t *testing.T) {
+	var gotPath, gotVersion, gotKey string
+	var gotBody map[string]interface{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotVersion = r.URL.Query().Get("api-version")
+		gotKey = r.Header.Get("api-key")
+		body, _ := io.ReadAll(r.Body)
+		json.Unmarshal(body, &gotBody)
+
+		w.Write([]byte(`{
+			"choices": [{"message": {"role": "assistant", "content": "{\"ok\": true}"}}],
+			"usage": {"prompt_tokens": 10, "completion_tokens": 3}
+		}`))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name          string
+		config        ai.AIConfig
+		expectPath    string
+		expectVersion string
+		expectError   bool
+	}{
+		{
+			name:          "deployment and api version",
+			config:        ai.AIConfig{APIKey: "chave", Model: "gpt-4o", Endpoint: server.URL + "/", Deployment: "gpt4o-prod", APIVersion: "2024-10-21"},
+			expectPath:    "/openai/deployments/gpt4o-prod/chat/completions",
+			expectVersion: "2024-10-21",
+		},
+		{
+			name:          "deployment defaults to model",
+			config:        ai.AIConfig{APIKey: "chave", Model: "gpt-4o-mini", Endpoint: server.URL},
+			expectPath:    "/openai/deployments/gpt-4o-mini/chat/completions",
+			expectVersion: ai.DefaultAzureAPIVersion,
+		},
+		{
+			name:        "endpoint is required",
+			config:      ai.AIConfig{APIKey: "chave", Model: "gpt-4o"},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := ai.NewAzureOpenAIClient(&tt.config)
+			if tt.expectError {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			content, err := client.ChatJSONMode([]ai.Message{{Role: "user", Content: "status?"}}, 100, 0)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if gotPath != tt.expectPath || gotVersion != tt.expectVersion || gotKey != "chave" {
+				t.Errorf("unexpected request: path %q, version %q, key %q", gotPath, gotVersion, gotKey)
+			}
+			if format, _ := gotBody["response_format"].(map[string]interface{}); format["type"] != "json_object" {
+				t.Errorf("expected json_object response format, got %v", gotBody["response_format"])
+			}
+			if content != `{"ok": true}` {
+				t.Errorf("unexpected content %q", content)
+			}
+			if usage := client.LastUsage(); usage.InputTokens != 10 || usage.OutputTokens != 3 {
+				t.Errorf("unexpected usage: %+v", usage)
+			}
+		})
+	}
+}
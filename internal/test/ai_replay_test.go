@@ -0,0 +1,164 @@
+package test
+
+import (
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/snip/internal/ai"
+	"github.com/snip/internal/dbcharts"
+	"github.com/snip/internal/dbchat"
+	"github.com/snip/internal/dbtypes"
+)
+
+// useCassettes faz os clientes de IA responderem a partir de testdata/cassettes.
+// Para regravar, rode o fluxo com SNIP_AI_REPLAY=record e
+// SNIP_AI_CASSETTES apontando para este diretório.
+func useCassettes(t *testing.T) {
+	t.Helper()
+
+	dir, err := filepath.Abs(filepath.Join("testdata", "cassettes"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// HOME temporário: configuração padrão (prompts pt-BR), sem API key
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv(ai.ReplayModeEnv, ai.ReplayModeReplay)
+	t.Setenv(ai.ReplayDirEnv, dir)
+}
+
+func createChatTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	_, err = db.Exec(`
+		CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT NOT NULL, active INTEGER NOT NULL);
+		INSERT INTO users (name, active) VALUES ('bruno', 1), ('carla', 0), ('ana', 1);
+	`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+func TestDBChatGenerateQueryReplay(t *testing.T) {
+	tests := []struct {
+		name           string
+		message        string
+		expectQuery    string
+		expectResult   []string
+		expectResponse string
+	}{
+		{
+			name:           "query inside sql block with explanation",
+			message:        "quantos usuários existem?",
+			expectQuery:    "SELECT COUNT(*) AS total FROM users",
+			expectResult:   []string{"| total |", "| 3 |"},
+			expectResponse: "Existem 3 usuários cadastrados.",
+		},
+		{
+			name:           "multi-line query joined into one line",
+			message:        "liste os usuários ativos",
+			expectQuery:    "SELECT name FROM users WHERE active = 1 ORDER BY name",
+			expectResult:   []string{"| ana |", "| bruno |"},
+			expectResponse: "Os usuários ativos são **ana** e **bruno**.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useCassettes(t)
+
+			config := &dbtypes.ConnectionConfig{Type: dbtypes.DatabaseTypePostgreSQL, Host: "localhost", Database: "app"}
+			chat, err := dbchat.NewDBChat(dbtypes.DatabaseTypePostgreSQL, config, createChatTestDB(t))
+			if err != nil {
+				t.Fatalf("unexpected error creating chat: %v", err)
+			}
+
+			response, err := chat.SendMessage(tt.message)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if response != tt.expectResponse {
+				t.Errorf("expected response %q, got %q", tt.expectResponse, response)
+			}
+
+			messages := chat.GetSession().Messages
+			last := messages[len(messages)-1]
+			if last.Query != tt.expectQuery {
+				t.Errorf("expected query %q, got %q", tt.expectQuery, last.Query)
+			}
+			for _, expected := range tt.expectResult {
+				if !strings.Contains(last.Result, expected) {
+					t.Errorf("expected result to contain %q, got %q", expected, last.Result)
+				}
+			}
+		})
+	}
+}
+
+func TestChartExtractDataReplay(t *testing.T) {
+	analysis := "Conexões ativas por usuário: app = 12, batch = 3, report = 7"
+
+	tests := []struct {
+		name        string
+		analysis    string
+		expectRows  []string
+		expectError error
+	}{
+		{
+			name:       "fenced JSON with trailing comma",
+			analysis:   analysis,
+			expectRows: []string{"### 📋 Conexões por usuário", "| app | 12 |", "| batch | 3 |", "| report | 7 |"},
+		},
+		{
+			name:       "series length mismatch is fixed on retry",
+			analysis:   analysis + " (pico às 10h)",
+			expectRows: []string{"| app | 12 |", "| report | 7 |"},
+		},
+		{
+			name:        "missing cassette",
+			analysis:    "resultado sem cassete gravado",
+			expectError: ai.ErrCassetteNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useCassettes(t)
+
+			generator, err := dbcharts.NewChartGenerator()
+			if err != nil {
+				t.Fatalf("unexpected error creating generator: %v", err)
+			}
+
+			chart, err := generator.GenerateChartFromAnalysis(tt.analysis, dbcharts.ChartTypeTable)
+			if tt.expectError != nil {
+				if !errors.Is(err, tt.expectError) {
+					t.Fatalf("expected error %v, got %v", tt.expectError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			for _, row := range tt.expectRows {
+				if !strings.Contains(chart, row) {
+					t.Errorf("expected chart to contain %q, got:\n%s", row, chart)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,150 @@
+package test
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/snip/internal/ai"
+)
+
+// fakeUsageStore guarda os registros em memória; Totals soma os do período
+type fakeUsageStore struct {
+	records []*ai.UsageRecord
+	// base é o consumo de chamadas anteriores do mês
+	base ai.UsageSummary
+}
+
+func (s *fakeUsageStore) Record(record *ai.UsageRecord) error {
+	s.records = append(s.records, record)
+	return nil
+}
+
+func (s *fakeUsageStore) Totals(since time.Time) (*ai.UsageSummary, error) {
+	totals := s.base
+	for _, r := range s.records {
+		if !r.CreatedAt.Before(since) {
+			totals.Requests++
+			totals.InputTokens += r.InputTokens
+			totals.OutputTokens += r.OutputTokens
+			totals.CostUSD += r.CostUSD
+		}
+	}
+	return &totals, nil
+}
+
+// usageClient responde sempre o mesmo texto e informa um consumo fixo
+type usageClient struct {
+	ai.AIClient
+	model string
+	usage ai.Usage
+	calls int
+}
+
+func (c *usageClient) GetProvider() ai.Provider { return ai.ProviderOpenAI }
+
+func (c *usageClient) GetModel() string { return c.model }
+
+func (c *usageClient) LastUsage() ai.Usage { return c.usage }
+
+func (c *usageClient) Chat(messages []ai.Message, maxTokens int, temperature float64) (string, error) {
+	c.calls++
+	return "ok", nil
+}
+
+func withUsageStore(t *testing.T, store ai.UsageStore) {
+	t.Helper()
+	ai.SetUsageStore(store)
+	t.Cleanup(func() { ai.SetUsageStore(nil) })
+}
+
+func TestEstimateCost(t *testing.T) {
+	tests := []struct {
+		model    string
+		usage    ai.Usage
+		expected float64
+	}{
+		{model: "gpt-4o", usage: ai.Usage{InputTokens: 1_000_000, OutputTokens: 1_000_000}, expected: 12.50},
+		{model: "claude-3-5-haiku-20241022", usage: ai.Usage{InputTokens: 500_000}, expected: 0.40},
+		{model: "openai/gpt-4o-mini", usage: ai.Usage{OutputTokens: 2_000_000}, expected: 1.20},
+		{model: "modelo-local", usage: ai.Usage{InputTokens: 1_000_000, OutputTokens: 1_000_000}, expected: 0},
+		{model: "vendor/modelo-local", usage: ai.Usage{InputTokens: 1_000_000}, expected: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.model, func(t *testing.T) {
+			if cost := ai.EstimateCost(tt.model, tt.usage); math.Abs(cost-tt.expected) > 1e-9 {
+				t.Errorf("expected US$ %.4f, got %.4f", tt.expected, cost)
+			}
+		})
+	}
+}
+
+func TestMeteredClientRecordsUsage(t *testing.T) {
+	store := &fakeUsageStore{}
+	withUsageStore(t, store)
+
+	inner := &usageClient{model: "gpt-4o-mini", usage: ai.Usage{InputTokens: 1000, OutputTokens: 500}}
+	client := ai.NewMeteredClient(inner, ai.Feature("notes"), nil)
+	if _, err := client.Chat([]ai.Message{{Role: "user", Content: "oi"}}, 100, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(store.records) != 1 {
+		t.Fatalf("expected one usage record, got %d", len(store.records))
+	}
+	r := store.records[0]
+	expected := ai.EstimateCost("gpt-4o-mini", inner.usage)
+	if r.Provider != ai.ProviderOpenAI || r.Model != "gpt-4o-mini" || r.Feature != "notes" ||
+		r.InputTokens != 1000 || r.OutputTokens != 500 || r.CostUSD != expected || expected == 0 {
+		t.Errorf("unexpected record: %+v", r)
+	}
+}
+
+func TestMeteredClientBudget(t *testing.T) {
+	tests := []struct {
+		name        string
+		base        ai.UsageSummary
+		budget      *ai.BudgetConfig
+		expectBlock bool
+	}{
+		{name: "no budget", base: ai.UsageSummary{CostUSD: 100}},
+		{name: "under cost limit", base: ai.UsageSummary{CostUSD: 4.99}, budget: &ai.BudgetConfig{MonthlyLimitUSD: 5, Action: "block"}},
+		{name: "cost limit reached", base: ai.UsageSummary{CostUSD: 5}, budget: &ai.BudgetConfig{MonthlyLimitUSD: 5, Action: "block"}, expectBlock: true},
+		{name: "token limit reached", base: ai.UsageSummary{InputTokens: 900, OutputTokens: 100}, budget: &ai.BudgetConfig{MonthlyTokenLimit: 1000, Action: "block"}, expectBlock: true},
+		{name: "warn only", base: ai.UsageSummary{CostUSD: 50}, budget: &ai.BudgetConfig{MonthlyLimitUSD: 5, Action: "warn"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			withUsageStore(t, &fakeUsageStore{base: tt.base})
+
+			inner := &usageClient{model: "gpt-4o"}
+			client := ai.NewMeteredClient(inner, ai.FeatureDefault, tt.budget)
+			_, err := client.Chat([]ai.Message{{Role: "user", Content: "oi"}}, 100, 0)
+
+			if tt.expectBlock {
+				if !errors.Is(err, ai.ErrBudgetExceeded) || inner.calls != 0 {
+					t.Errorf("expected request to be refused before the provider call, got %v (%d calls)", err, inner.calls)
+				}
+				return
+			}
+			if err != nil || inner.calls != 1 {
+				t.Errorf("expected request to go through, got %v (%d calls)", err, inner.calls)
+			}
+		})
+	}
+
+	// O consumo do próprio mês conta: a segunda chamada passa do limite
+	store := &fakeUsageStore{}
+	withUsageStore(t, store)
+	inner := &usageClient{model: "gpt-4o", usage: ai.Usage{InputTokens: 1_000_000}}
+	client := ai.NewMeteredClient(inner, ai.FeatureDefault, &ai.BudgetConfig{MonthlyLimitUSD: 2, Action: "block"})
+	if _, err := client.Chat([]ai.Message{{Role: "user", Content: "1"}}, 100, 0); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Chat([]ai.Message{{Role: "user", Content: "2"}}, 100, 0); !errors.Is(err, ai.ErrBudgetExceeded) {
+		t.Errorf("expected month-to-date cost to block the second request, got %v", err)
+	}
+}
@@ -0,0 +1,218 @@
+package test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/snip/internal/bundle"
+	"github.com/snip/internal/checklist"
+	"github.com/snip/internal/database"
+	"github.com/snip/internal/handler"
+	"github.com/snip/internal/project"
+	"github.com/snip/internal/repository"
+	"github.com/snip/internal/task"
+)
+
+func sampleBundle() *bundle.Bundle {
+	return &bundle.Bundle{
+		Version: bundle.Version,
+		Project: bundle.Project{Name: "PostgreSQL major upgrade", Status: "active"},
+		Tasks: []bundle.Task{
+			{Ref: 10, Title: "Upgrade", Priority: "high", DueDate: "2025-01-10", Estimate: "2d"},
+			{Ref: 11, Parent: 10, Title: "pg_upgrade --check", DueDate: "2025-01-08", DependsOn: []int{12}},
+			{Ref: 12, Title: "Backup", Status: "completed", DueDate: "2025-01-05"},
+		},
+		Checklists: []bundle.Checklist{
+			{Task: 10, Title: "Pós-upgrade", Items: []bundle.Item{{Title: "ANALYZE"}, {Title: "Extensões", Completed: true}}},
+		},
+	}
+}
+
+func TestBundleValidate(t *testing.T) {
+	tests := []struct {
+		name        string
+		modify      func(b *bundle.Bundle)
+		expectError string
+	}{
+		{name: "valid bundle", modify: func(b *bundle.Bundle) {}},
+		{name: "unsupported version", modify: func(b *bundle.Bundle) { b.Version = 2 }, expectError: "versão 2"},
+		{name: "missing project name", modify: func(b *bundle.Bundle) { b.Project.Name = " " }, expectError: "project.name"},
+		{name: "invalid task status", modify: func(b *bundle.Bundle) { b.Tasks[0].Status = "doing" }, expectError: "status inválido: doing"},
+		{name: "duplicate ref", modify: func(b *bundle.Bundle) { b.Tasks[2].Ref = 10 }, expectError: "ref duplicado"},
+		{name: "unknown parent", modify: func(b *bundle.Bundle) { b.Tasks[1].Parent = 99 }, expectError: "parent 99"},
+		{name: "dependency cycle", modify: func(b *bundle.Bundle) { b.Tasks[2].DependsOn = []int{11} }, expectError: "dependência circular"},
+		{name: "invalid estimate", modify: func(b *bundle.Bundle) { b.Tasks[0].Estimate = "amanhã" }, expectError: "estimativa inválida"},
+		{name: "unknown checklist task", modify: func(b *bundle.Bundle) { b.Checklists[0].Task = 7 }, expectError: "task 7"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := sampleBundle()
+			tt.modify(b)
+			err := b.Validate()
+			if tt.expectError == "" {
+				if err != nil {
+					t.Errorf("expected no error, got %v", err)
+				}
+				return
+			}
+			var validationErr *bundle.ValidationError
+			if !errors.As(err, &validationErr) || !strings.Contains(err.Error(), tt.expectError) {
+				t.Errorf("expected validation error containing %q, got %v", tt.expectError, err)
+			}
+		})
+	}
+}
+
+func TestBundleShiftAndOrder(t *testing.T) {
+	b := sampleBundle()
+	if err := b.ShiftDueDates(date(2025, 3, 1)); err != nil {
+		t.Fatal(err)
+	}
+	// O prazo mais cedo (05/01) passa a ser 01/03 e os intervalos se mantêm
+	expected := map[int]string{10: "2025-03-06", 11: "2025-03-04", 12: "2025-03-01"}
+	for _, bt := range b.Tasks {
+		if bt.DueDate != expected[bt.Ref] {
+			t.Errorf("task %d: expected due %s, got %s", bt.Ref, expected[bt.Ref], bt.DueDate)
+		}
+	}
+
+	// A subtarefa vem antes do pai no arquivo, mas é criada depois dele
+	b.Tasks = []bundle.Task{b.Tasks[1], b.Tasks[0], b.Tasks[2]}
+	position := make(map[int]int)
+	for i, bt := range b.TaskOrder() {
+		position[bt.Ref] = i
+	}
+	if len(position) != 3 || position[10] > position[11] {
+		t.Errorf("expected parent before subtask, got %v", position)
+	}
+}
+
+func TestBundleRoundTrip(t *testing.T) {
+	for _, format := range []string{bundle.FormatYAML, bundle.FormatJSON} {
+		t.Run(format, func(t *testing.T) {
+			original := sampleBundle()
+			original.AsTemplate()
+			data, err := original.Marshal(format)
+			if err != nil {
+				t.Fatal(err)
+			}
+			decoded, err := bundle.Unmarshal("pacote."+format, data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !decoded.Template || len(decoded.Tasks) != 3 || decoded.Tasks[1].Parent != 10 || decoded.Tasks[1].DependsOn[0] != 12 {
+				t.Errorf("unexpected decoded bundle: %+v", decoded)
+			}
+			if decoded.Tasks[2].Status != "pending" || decoded.Checklists[0].Items[1].Completed {
+				t.Errorf("expected template to reset progress, got %+v", decoded)
+			}
+			if err := decoded.Validate(); err != nil {
+				t.Errorf("expected decoded bundle to be valid, got %v", err)
+			}
+		})
+	}
+
+	if _, err := sampleBundle().Marshal("xml"); err == nil {
+		t.Error("expected error for unsupported format")
+	}
+}
+
+func TestBundleExportImport(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	db, err := database.Connect()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	projectRepo, _ := repository.NewProjectRepository(db)
+	taskRepo, _ := repository.NewTaskRepository(db)
+	checklistRepo, _ := repository.NewChecklistRepository(db)
+	itemRepo, _ := repository.NewChecklistItemRepository(db)
+	h := handler.NewBundleHandler(projectRepo, taskRepo, checklistRepo, itemRepo)
+
+	p := project.NewProject("Upgrade", "")
+	if err := projectRepo.Create(p); err != nil {
+		t.Fatal(err)
+	}
+	parent := task.NewTask(p.ID, "Upgrade", "", "high")
+	backup := task.NewTask(p.ID, "Backup", "", "medium")
+	for _, tk := range []*task.Task{parent, backup} {
+		if err := taskRepo.Create(tk); err != nil {
+			t.Fatal(err)
+		}
+	}
+	check := task.NewTask(p.ID, "Check", "", "low")
+	check.ParentTaskID = parent.ID
+	if err := taskRepo.Create(check); err != nil {
+		t.Fatal(err)
+	}
+	if err := taskRepo.AddDependency(check.ID, backup.ID); err != nil {
+		t.Fatal(err)
+	}
+	c := checklist.NewChecklist("Pós-upgrade", "")
+	c.TaskID = &parent.ID
+	if err := checklistRepo.Create(c); err != nil {
+		t.Fatal(err)
+	}
+	if err := itemRepo.Create(checklist.NewChecklistItem(c.ID, "ANALYZE", "", 1)); err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(t.TempDir(), "upgrade.yaml")
+	if err := h.ExportProject(p.ID, bundle.FormatYAML, path, true); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Fatalf("expected bundle file: %v", err)
+	}
+
+	if err := h.ImportProject(path, "Upgrade cliente B", nil, false, true); err != nil {
+		t.Fatal(err)
+	}
+	if projects, _ := projectRepo.GetAll(""); len(projects) != 1 {
+		t.Fatalf("expected dry-run to create nothing, got %d projects", len(projects))
+	}
+
+	if err := h.ImportProject(path, "Upgrade cliente B", nil, false, false); err != nil {
+		t.Fatal(err)
+	}
+	projects, _ := projectRepo.GetAll("")
+	if len(projects) != 2 {
+		t.Fatalf("expected imported project, got %d projects", len(projects))
+	}
+	var imported *project.Project
+	for _, candidate := range projects {
+		if candidate.ID != p.ID {
+			imported = candidate
+		}
+	}
+	if imported.Name != "Upgrade cliente B" {
+		t.Errorf("expected renamed project, got %s", imported.Name)
+	}
+
+	tasks, _ := taskRepo.GetByProjectID(imported.ID, "")
+	byTitle := make(map[string]*task.Task)
+	for _, tk := range tasks {
+		byTitle[tk.Title] = tk
+	}
+	if len(tasks) != 3 || byTitle["Check"].ParentTaskID != byTitle["Upgrade"].ID {
+		t.Fatalf("expected remapped subtask, got %+v", tasks)
+	}
+	deps, _ := taskRepo.GetDependencies()
+	if got := deps[byTitle["Check"].ID]; len(got) != 1 || got[0] != byTitle["Backup"].ID {
+		t.Errorf("expected remapped dependency, got %v", got)
+	}
+	checklists, _ := checklistRepo.GetByTaskID(byTitle["Upgrade"].ID)
+	if len(checklists) != 1 {
+		t.Fatalf("expected imported checklist, got %d", len(checklists))
+	}
+	if items, _ := itemRepo.GetByChecklistID(checklists[0].ID); len(items) != 1 || items[0].Title != "ANALYZE" {
+		t.Errorf("unexpected checklist items: %+v", items)
+	}
+}
@@ -0,0 +1,291 @@
+package test
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/snip/internal/checklist"
+	"github.com/snip/internal/database"
+	"github.com/snip/internal/handler"
+	"github.com/snip/internal/project"
+	"github.com/snip/internal/repository"
+)
+
+func TestParseBulkChecklist(t *testing.T) {
+	tests := []struct {
+		name        string
+		csv         string
+		expected    int
+		completed   int
+		expectError bool
+	}{
+		{
+			name:      "bulk format",
+			csv:       "title,description,category,priority,status,notes\nBackups,Conferir jobs,Backup,high,done,ok\nDisco,Tablespaces,Storage,,failed,\n",
+			expected:  2,
+			completed: 1,
+		},
+		{
+			name:     "exported format with id",
+			csv:      "id,title,description,category,priority,status,notes\n4,Backups,,Backup,high,pending,\n,Novo,,Logs,low,pending,\n",
+			expected: 2,
+		},
+		{name: "missing category", csv: "title,description\nBackups,x\n", expectError: true},
+		{name: "invalid id", csv: "id,title,description,category\nquatro,Backups,,Backup\n", expectError: true},
+		{name: "header only", csv: "title,description,category\n", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := checklist.ParseBulkChecklist(strings.NewReader(tt.csv))
+			if tt.expectError {
+				if err == nil {
+					t.Error("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if result.TotalItems != tt.expected || result.Completed != tt.completed {
+				t.Errorf("expected %d items (%d completed), got %d (%d)", tt.expected, tt.completed, result.TotalItems, result.Completed)
+			}
+		})
+	}
+}
+
+func TestWriteBulkChecklistCSV(t *testing.T) {
+	items := []*checklist.ChecklistItem{
+		{ID: 7, Title: "Disco", Description: "Tablespaces", Category: "Storage", Priority: "high", Completed: true, Notes: "80% usado, ver tbs_data"},
+		{ID: 8, Title: "#2 Logs", Category: "Logs"},
+	}
+
+	var buf bytes.Buffer
+	if err := checklist.WriteBulkChecklistCSV(&buf, items); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := checklist.ParseBulkChecklist(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Items) != 2 {
+		t.Fatalf("expected 2 items after round trip, got %+v", result.Items)
+	}
+	first, second := result.Items[0], result.Items[1]
+	if first.ID != 7 || first.Status != "completed" || first.Notes != "80% usado, ver tbs_data" || first.Priority != "high" {
+		t.Errorf("unexpected first row: %+v", first)
+	}
+	if second.ID != 8 || second.Title != "#2 Logs" || second.Status != "pending" || second.Priority != "medium" {
+		t.Errorf("unexpected second row: %+v", second)
+	}
+}
+
+func TestChecklistBulkStatusRoundTrip(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	dir := t.TempDir()
+
+	db, err := database.Connect()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	checklistRepo, _ := repository.NewChecklistRepository(db)
+	itemRepo, _ := repository.NewChecklistItemRepository(db)
+	runRepo, _ := repository.NewChecklistRunRepository(db)
+	h := handler.NewChecklistHandler(checklistRepo, itemRepo, runRepo)
+
+	input := filepath.Join(dir, "weekly.csv")
+	if err := os.WriteFile(input, []byte("title,description,category,priority,status,notes\n"+
+		"Replicação,Lag,Replicação,high,failed,lag de 30s\n"+
+		"Disco,Tablespaces,Storage,medium,done,\n"+
+		"Backups,Jobs,Backup,low,pending,\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := h.ImportBulk(input, nil, 0, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	items, _ := itemRepo.GetByChecklistID(1)
+	if len(items) != 3 || items[0].Completed || items[0].Status != "failed" || !items[1].Completed {
+		t.Fatalf("expected failed status to be stored, got %+v", items)
+	}
+
+	exported := filepath.Join(dir, "export.csv")
+	if err := h.ExportChecklist(1, true, exported); err != nil {
+		t.Fatal(err)
+	}
+	file, err := os.Open(exported)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	result, err := checklist.ParseBulkChecklist(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	statuses := []string{result.Items[0].Status, result.Items[1].Status, result.Items[2].Status}
+	if statuses[0] != "failed" || statuses[1] != "done" || statuses[2] != "pending" || result.Failed != 1 {
+		t.Errorf("expected statuses failed,done,pending after round trip, got %v", statuses)
+	}
+
+	// Reimportar a exportação não altera nada
+	if err := h.ImportBulk(exported, nil, 1, ""); err != nil {
+		t.Fatal(err)
+	}
+	if again, _ := itemRepo.GetByChecklistID(1); *again[0] != *items[0] {
+		t.Errorf("expected failed item to be unchanged, got %+v", again[0])
+	}
+
+	// Marcado pelo toggle depois da importação, o item sai como concluído
+	if err := itemRepo.ToggleComplete(items[0].ID); err != nil {
+		t.Fatal(err)
+	}
+	toggled, _ := itemRepo.GetByChecklistID(1)
+	if b := toggled[0].ToBulk(); b.Status != "completed" {
+		t.Errorf("expected toggled item to export as completed, got %s", b.Status)
+	}
+}
+
+func TestChecklistBulkImportExport(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	dir := t.TempDir()
+
+	db, err := database.Connect()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	projectRepo, _ := repository.NewProjectRepository(db)
+	checklistRepo, _ := repository.NewChecklistRepository(db)
+	itemRepo, _ := repository.NewChecklistItemRepository(db)
+	runRepo, _ := repository.NewChecklistRunRepository(db)
+	h := handler.NewChecklistHandler(checklistRepo, itemRepo, runRepo)
+
+	p := project.NewProject("PG upgrade", "")
+	if err := projectRepo.Create(p); err != nil {
+		t.Fatal(err)
+	}
+
+	daily := filepath.Join(dir, "daily.csv")
+	if err := os.WriteFile(daily, []byte("title,description,category,priority,status,notes\n"+
+		"Conexões,Contar conexões,Monitoramento,high,pending,\n"+
+		"Disco,Tablespaces,Storage,medium,completed,ok\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := h.ImportBulk(daily, &p.ID, 0, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	checklists, _ := checklistRepo.GetByProjectID(p.ID)
+	if len(checklists) != 1 || checklists[0].Title != "daily" {
+		t.Fatalf("expected checklist named after the file in the project, got %+v", checklists)
+	}
+	id := checklists[0].ID
+	items, _ := itemRepo.GetByChecklistID(id)
+	if len(items) != 2 || items[0].Category != "Monitoramento" || items[0].Priority != "high" || !items[1].Completed || items[1].Notes != "ok" {
+		t.Fatalf("expected category, priority, status and notes to be stored, got %+v %+v", items[0], items[1])
+	}
+
+	exported := filepath.Join(dir, "export.csv")
+	if err := h.ExportChecklist(id, true, exported); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(exported)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Preenchido na planilha: o primeiro item concluído e uma linha nova sem id
+	edited := strings.Replace(string(data), "high,pending,", "high,completed,sem alertas", 1) + ",Backups,Jobs,Backup,high,pending,\n"
+	if err := os.WriteFile(exported, []byte(edited), 0644); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 2; i++ {
+		// Reimportar o mesmo arquivo não duplica a linha nova
+		if err := h.ImportBulk(exported, nil, id, ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+	items, _ = itemRepo.GetByChecklistID(id)
+	if len(items) != 3 || !items[0].Completed || items[0].Notes != "sem alertas" || items[2].Title != "Backups" || items[2].Order != 3 {
+		t.Fatalf("unexpected items after sync: %+v %+v %+v", items[0], items[1], items[2])
+	}
+
+	if err := os.WriteFile(exported, []byte("id,title,description,category\n99,Outro,,x\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := h.ImportBulk(exported, nil, id, ""); err == nil {
+		t.Error("expected error for item of another checklist")
+	}
+}
+
+// failingItemRepository falha na primeira atualização do item failOn
+type failingItemRepository struct {
+	repository.ChecklistItemRepository
+	failOn int
+	failed bool
+}
+
+func (r *failingItemRepository) Update(item *checklist.ChecklistItem) error {
+	if item.ID == r.failOn && !r.failed {
+		r.failed = true
+		return errors.New("disk I/O error")
+	}
+	return r.ChecklistItemRepository.Update(item)
+}
+
+func TestChecklistBulkSyncRollback(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	dir := t.TempDir()
+
+	db, err := database.Connect()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	checklistRepo, _ := repository.NewChecklistRepository(db)
+	itemRepo, _ := repository.NewChecklistItemRepository(db)
+	runRepo, _ := repository.NewChecklistRunRepository(db)
+
+	input := filepath.Join(dir, "daily.csv")
+	if err := os.WriteFile(input, []byte("title,description,category,priority,status,notes\n"+
+		"Conexões,,Monitoramento,high,pending,\n"+
+		"Disco,,Storage,medium,pending,\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := handler.NewChecklistHandler(checklistRepo, itemRepo, runRepo).ImportBulk(input, nil, 0, ""); err != nil {
+		t.Fatal(err)
+	}
+	original, _ := itemRepo.GetByChecklistID(1)
+
+	// A primeira linha é gravada, a nova é criada e a segunda falha: tudo é desfeito
+	if err := os.WriteFile(input, []byte("id,title,description,category,priority,status,notes\n"+
+		"1,Conexões,,Monitoramento,high,completed,ok\n"+
+		",Backups,,Backup,low,pending,\n"+
+		"2,Disco,,Storage,medium,failed,cheio\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	failing := &failingItemRepository{ChecklistItemRepository: itemRepo, failOn: 2}
+	h := handler.NewChecklistHandler(checklistRepo, failing, runRepo)
+	if err := h.ImportBulk(input, nil, 1, ""); err == nil {
+		t.Fatal("expected error from failing update")
+	}
+
+	items, _ := itemRepo.GetByChecklistID(1)
+	if len(items) != 2 {
+		t.Fatalf("expected created item to be removed, got %d items", len(items))
+	}
+	for i, item := range items {
+		if item.Completed != original[i].Completed || item.Status != original[i].Status || item.Notes != original[i].Notes {
+			t.Errorf("expected item #%d to be restored, got %+v", item.ID, item)
+		}
+	}
+}
@@ -0,0 +1,254 @@
+package test
+
+import (
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/snip/internal/checklist"
+	"github.com/snip/internal/database"
+	"github.com/snip/internal/handler"
+	"github.com/snip/internal/repository"
+)
+
+func TestParseRunResult(t *testing.T) {
+	tests := []struct {
+		value       string
+		expected    checklist.RunResult
+		expectError bool
+	}{
+		{value: "o", expected: checklist.ResultOK},
+		{value: " OK ", expected: checklist.ResultOK},
+		{value: "f", expected: checklist.ResultFailed},
+		{value: "falha", expected: checklist.ResultFailed},
+		{value: "s", expected: checklist.ResultSkipped},
+		{value: "skipped", expected: checklist.ResultSkipped},
+		{value: "", expectError: true},
+		{value: "talvez", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			result, err := checklist.ParseRunResult(tt.value)
+			if tt.expectError != (err != nil) {
+				t.Fatalf("expectError %v, got %v", tt.expectError, err)
+			}
+			if result != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestChecklistHistoryAndReorder(t *testing.T) {
+	items := []*checklist.ChecklistItem{
+		{ID: 1, Title: "Conexões", Order: 1},
+		{ID: 2, Title: "Disco", Order: 2},
+		{ID: 3, Title: "Backups", Order: 3},
+	}
+	runs := []*checklist.Run{
+		{ID: 1, Results: []*checklist.RunItem{
+			{ItemID: 1, Result: checklist.ResultOK},
+			{ItemID: 2, Result: checklist.ResultOK},
+			{ItemID: 9, Title: "Replicação", Result: checklist.ResultFailed},
+		}},
+		{ID: 2, Results: []*checklist.RunItem{
+			{ItemID: 1, Result: checklist.ResultOK},
+			{ItemID: 2, Result: checklist.ResultSkipped},
+		}},
+		{ID: 3, Results: []*checklist.RunItem{
+			{ItemID: 1, Result: checklist.ResultFailed},
+			{ItemID: 2, Result: checklist.ResultFailed},
+		}},
+	}
+
+	rows := checklist.History(items, runs)
+	if len(rows) != 4 || rows[3].ItemID != 9 || !rows[3].Removed || rows[3].Title != "Replicação" {
+		t.Fatalf("expected current items followed by the removed one, got %+v", rows)
+	}
+	if rows[2].Results[0] != "" || rows[0].Results[2] != checklist.ResultFailed {
+		t.Errorf("unexpected results: %+v", rows)
+	}
+	// Disco: ok, pulado, falha — o pulado não conta como passagem nem falha
+	if !rows[0].Regressed() || !rows[1].Regressed() || rows[2].Regressed() || rows[3].Regressed() {
+		t.Errorf("unexpected regressions: %+v", rows)
+	}
+	if runs[0].Count(checklist.ResultOK) != 2 || runs[2].Result(3) != "" {
+		t.Errorf("unexpected run counts")
+	}
+
+	ordered, err := checklist.Reorder(items, []int{3, 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ordered[0].ID != 3 || ordered[1].ID != 1 || ordered[2].ID != 2 || ordered[2].Order != 3 {
+		t.Errorf("expected order 3,1,2, got %d,%d,%d", ordered[0].ID, ordered[1].ID, ordered[2].ID)
+	}
+	if _, err := checklist.Reorder(items, []int{4}); err == nil {
+		t.Error("expected error for item of another checklist")
+	}
+	if _, err := checklist.Reorder(items, []int{1, 1}); err == nil {
+		t.Error("expected error for repeated item")
+	}
+}
+
+func TestChecklistRunRepository(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	db, err := database.Connect()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	checklistRepo, _ := repository.NewChecklistRepository(db)
+	itemRepo, _ := repository.NewChecklistItemRepository(db)
+	runRepo, _ := repository.NewChecklistRunRepository(db)
+
+	tpl := checklist.NewChecklist("Diário MySQL", "")
+	tpl.IsTemplate = true
+	if err := checklistRepo.Create(tpl); err != nil {
+		t.Fatal(err)
+	}
+	if found, err := checklistRepo.GetTemplateByTitle("diário mysql"); err != nil || found.ID != tpl.ID || !found.IsTemplate {
+		t.Fatalf("expected template by title, got %+v (%v)", found, err)
+	}
+	if _, err := checklistRepo.GetTemplateByTitle("Semanal"); !errors.Is(err, repository.ErrChecklistNotFound) {
+		t.Errorf("expected ErrChecklistNotFound, got %v", err)
+	}
+
+	var items []*checklist.ChecklistItem
+	for i, title := range []string{"Conexões", "Disco", "Backups"} {
+		item := checklist.NewChecklistItem(tpl.ID, title, "", i+1)
+		if err := itemRepo.Create(item); err != nil {
+			t.Fatal(err)
+		}
+		items = append(items, item)
+	}
+
+	ordered, _ := checklist.Reorder(items, []int{items[2].ID})
+	if err := itemRepo.UpdateOrder(ordered); err != nil {
+		t.Fatal(err)
+	}
+	if stored, _ := itemRepo.GetByChecklistID(tpl.ID); stored[0].Title != "Backups" || stored[2].Title != "Disco" {
+		t.Errorf("expected item_order to be honoured, got %s,%s,%s", stored[0].Title, stored[1].Title, stored[2].Title)
+	}
+
+	start := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+	for day := 0; day < 3; day++ {
+		run := &checklist.Run{ChecklistID: tpl.ID, StartedAt: start.AddDate(0, 0, day)}
+		if err := runRepo.Create(run); err != nil {
+			t.Fatal(err)
+		}
+		result := &checklist.RunItem{ItemID: items[0].ID, Title: "Conexões", Result: checklist.ResultOK, Notes: "ok", RecordedAt: run.StartedAt.Add(time.Minute)}
+		if err := runRepo.AddResult(run, result); err != nil {
+			t.Fatal(err)
+		}
+		if day < 2 {
+			if err := runRepo.Finish(run, run.StartedAt.Add(10*time.Minute)); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	runs, err := runRepo.GetByChecklistID(tpl.ID, 2)
+	if err != nil || len(runs) != 2 {
+		t.Fatalf("expected the last 2 runs, got %d (%v)", len(runs), err)
+	}
+	if !runs[0].StartedAt.Equal(start.AddDate(0, 0, 1)) || runs[0].FinishedAt == nil || runs[1].FinishedAt != nil {
+		t.Errorf("expected chronological order with the interrupted run last, got %+v %+v", runs[0], runs[1])
+	}
+	if len(runs[1].Results) != 1 || runs[1].Results[0].Notes != "ok" || runs[1].Results[0].Result != checklist.ResultOK {
+		t.Errorf("unexpected run results: %+v", runs[1].Results)
+	}
+
+	if err := checklistRepo.Delete(tpl.ID); err != nil {
+		t.Fatal(err)
+	}
+	if runs, _ := runRepo.GetByChecklistID(tpl.ID, 0); len(runs) != 0 {
+		t.Errorf("expected runs of deleted template to be removed, got %d", len(runs))
+	}
+	var orphans int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM checklist_run_items`).Scan(&orphans); err != nil || orphans != 0 {
+		t.Errorf("expected run items to be removed, got %d (%v)", orphans, err)
+	}
+}
+
+func TestChecklistHandlerRun(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	db, err := database.Connect()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	checklistRepo, _ := repository.NewChecklistRepository(db)
+	itemRepo, _ := repository.NewChecklistItemRepository(db)
+	runRepo, _ := repository.NewChecklistRunRepository(db)
+	h := handler.NewChecklistHandler(checklistRepo, itemRepo, runRepo)
+
+	if err := h.CreateChecklist("Deploy", "", nil, nil, false); err != nil {
+		t.Fatal(err)
+	}
+	if err := h.RunTemplate("1"); err == nil {
+		t.Error("expected error when running a single-use checklist")
+	}
+	if err := h.SetTemplate(1, true); err != nil {
+		t.Fatal(err)
+	}
+	if err := h.RunTemplate("Deploy"); err == nil {
+		t.Error("expected error when running a template without items")
+	}
+	for _, title := range []string{"Migrations", "Smoke test"} {
+		if err := h.AddChecklistItem(1, title, ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Resposta inválida é perguntada de novo; a entrada acaba antes do último item
+	withStdin(t, "x\nf\nmigração 42 falhou\n")
+	if err := h.RunTemplate("deploy"); err != nil {
+		t.Fatal(err)
+	}
+
+	runs, _ := runRepo.GetByChecklistID(1, 0)
+	if len(runs) != 1 || runs[0].FinishedAt != nil || len(runs[0].Results) != 1 {
+		t.Fatalf("expected one interrupted run with one result, got %+v", runs)
+	}
+	if r := runs[0].Results[0]; r.Result != checklist.ResultFailed || r.Notes != "migração 42 falhou" || r.Title != "Migrations" {
+		t.Errorf("unexpected result: %+v", r)
+	}
+
+	withStdin(t, "o\n\ns\n\n")
+	if err := h.RunTemplate("1"); err != nil {
+		t.Fatal(err)
+	}
+	if runs, _ := runRepo.GetByChecklistID(1, 0); len(runs) != 2 || runs[1].FinishedAt == nil {
+		t.Errorf("expected second run to be finished, got %+v", runs)
+	}
+	if err := h.ShowHistory("Deploy", 10); err != nil {
+		t.Error(err)
+	}
+}
+
+// withStdin troca os.Stdin pelo texto informado até o fim do teste
+func withStdin(t *testing.T, input string) {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.WriteString(input); err != nil {
+		t.Fatal(err)
+	}
+	w.Close()
+
+	stdin := os.Stdin
+	os.Stdin = r
+	t.Cleanup(func() {
+		os.Stdin = stdin
+		r.Close()
+	})
+}
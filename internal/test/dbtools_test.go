@@ -0,0 +1,94 @@
+package test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/snip/internal/database"
+	"github.com/snip/internal/dbtools"
+)
+
+func TestValidateReadOnly(t *testing.T) {
+	tests := []struct {
+		name        string
+		query       string
+		expected    string
+		expectedErr string
+	}{
+		{name: "select", query: "SELECT * FROM pedidos;", expected: "SELECT * FROM pedidos"},
+		{name: "comments removed", query: "-- pedidos recentes\nSELECT id /* pk */ FROM pedidos", expected: "SELECT id   FROM pedidos"},
+		{name: "read-only cte", query: "WITH t AS (SELECT 1 AS n) SELECT n FROM t", expected: "WITH t AS (SELECT 1 AS n) SELECT n FROM t"},
+		{name: "explain", query: "explain select 1", expected: "explain select 1"},
+		{name: "empty", query: " -- nada\n ; ", expectedErr: "query vazia"},
+		{name: "multiple statements", query: "SELECT 1; DROP TABLE pedidos", expectedErr: "apenas uma instrução"},
+		{name: "statement hidden after comment", query: "SELECT 1 /* ; */; DELETE FROM pedidos", expectedErr: "apenas uma instrução"},
+		{name: "line comment before dml", query: "-- SELECT\nDELETE FROM pedidos", expectedErr: "apenas queries de leitura"},
+		{name: "block comment before dml", query: "/* SELECT */ UPDATE pedidos SET total = 0", expectedErr: "apenas queries de leitura"},
+		{name: "cte wrapping delete", query: "WITH apagados AS (DELETE FROM pedidos RETURNING *) SELECT * FROM apagados", expectedErr: "contém DELETE"},
+		{name: "cte wrapping insert", query: "with x as (insert into log values (1) returning id) select id from x", expectedErr: "contém INSERT"},
+		{name: "select into", query: "SELECT * INTO copia FROM pedidos", expectedErr: "contém INTO"},
+		{name: "select for update", query: "SELECT * FROM pedidos FOR UPDATE", expectedErr: "contém UPDATE"},
+		{name: "terminate backend", query: "SELECT pg_terminate_backend(pid) FROM pg_stat_activity", expectedErr: "chama PG_TERMINATE_BACKEND"},
+		{name: "advisory lock", query: "select pg_advisory_lock (42)", expectedErr: "chama PG_ADVISORY_LOCK"},
+		{name: "sequence", query: "SELECT nextval('pedidos_id_seq')", expectedErr: "chama NEXTVAL"},
+		{name: "set config", query: "SELECT set_config('work_mem', '1GB', false)", expectedErr: "chama SET_CONFIG"},
+		{name: "oracle package", query: "SELECT DBMS_LOCK.SLEEP(10) FROM dual", expectedErr: "chama DBMS_LOCK"},
+		{name: "column named like a function", query: "SELECT sleep_minutes FROM turnos", expected: "SELECT sleep_minutes FROM turnos"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, err := dbtools.ValidateReadOnly(tt.query)
+			if tt.expectedErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expectedErr) {
+					t.Errorf("expected error containing %q, got %v", tt.expectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if query != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, query)
+			}
+		})
+	}
+}
+
+func TestToolsetRunReadOnlyQuery(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	db, err := database.Connect()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	if _, err := db.Exec("CREATE TABLE pedidos (id INTEGER PRIMARY KEY, total REAL); INSERT INTO pedidos (total) VALUES (10), (20)"); err != nil {
+		t.Fatal(err)
+	}
+
+	tools := dbtools.NewToolset(db, dbtools.DatabaseTypeSQLite, nil)
+	result, err := tools.RunReadOnlyQuery("SELECT COUNT(*) AS total FROM pedidos")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(result, "| total |") || !strings.Contains(result, "| 2 |") {
+		t.Errorf("unexpected result:\n%s", result)
+	}
+
+	for _, query := range []string{"DELETE FROM pedidos", "SELECT 1; DELETE FROM pedidos", "WITH x AS (DELETE FROM pedidos RETURNING id) SELECT id FROM x"} {
+		if _, err := tools.RunReadOnlyQuery(query); err == nil {
+			t.Errorf("expected %q to be rejected", query)
+		}
+	}
+
+	var count int
+	if err := db.QueryRow("SELECT COUNT(*) FROM pedidos").Scan(&count); err != nil || count != 2 {
+		t.Errorf("expected data to be untouched, got %d rows (%v)", count, err)
+	}
+
+	if _, err := tools.Execute("drop_table", nil); err == nil {
+		t.Errorf("expected unknown tool error, got %v", err)
+	}
+}
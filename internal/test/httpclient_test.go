@@ -0,0 +1,138 @@
+package test
+
+import (
+	"crypto/tls"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/snip/internal/httpclient"
+)
+
+func TestHTTPClientProxyAndOverrides(t *testing.T) {
+	t.Setenv("HTTPS_PROXY", "")
+	t.Setenv("HTTP_PROXY", "")
+	t.Setenv("NO_PROXY", "")
+
+	config := &httpclient.Config{
+		Settings: httpclient.Settings{
+			Proxy:   "http://proxy.empresa:3128",
+			NoProxy: ".interno.empresa.com,10.0.0.0/8",
+			Timeout: "45s",
+		},
+		Integrations: map[httpclient.Integration]*httpclient.Settings{
+			httpclient.IntegrationJira:       {Timeout: "2m", TLSMinVersion: "1.3"},
+			httpclient.IntegrationConfluence: {Proxy: httpclient.ProxyDirect},
+		},
+	}
+
+	tests := []struct {
+		name          string
+		integration   httpclient.Integration
+		url           string
+		expectProxy   string
+		expectTimeout time.Duration
+		expectTLS     uint16
+	}{
+		{name: "global proxy", integration: httpclient.IntegrationAI, url: "https://api.openai.com/v1", expectProxy: "http://proxy.empresa:3128", expectTimeout: 45 * time.Second, expectTLS: tls.VersionTLS12},
+		{name: "no_proxy domain", integration: httpclient.IntegrationAI, url: "https://jira.interno.empresa.com", expectTimeout: 45 * time.Second, expectTLS: tls.VersionTLS12},
+		{name: "no_proxy cidr", integration: httpclient.IntegrationAI, url: "http://10.1.2.3:8080", expectTimeout: 45 * time.Second, expectTLS: tls.VersionTLS12},
+		{name: "integration timeout and tls", integration: httpclient.IntegrationJira, url: "https://empresa.atlassian.net", expectProxy: "http://proxy.empresa:3128", expectTimeout: 2 * time.Minute, expectTLS: tls.VersionTLS13},
+		{name: "integration without proxy", integration: httpclient.IntegrationConfluence, url: "https://empresa.atlassian.net", expectTimeout: 45 * time.Second, expectTLS: tls.VersionTLS12},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := httpclient.NewWithConfig(config, tt.integration)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if client.Timeout != tt.expectTimeout {
+				t.Errorf("expected timeout %s, got %s", tt.expectTimeout, client.Timeout)
+			}
+
+			transport := client.Transport.(*http.Transport)
+			if transport.TLSClientConfig.MinVersion != tt.expectTLS {
+				t.Errorf("expected TLS min version %x, got %x", tt.expectTLS, transport.TLSClientConfig.MinVersion)
+			}
+
+			proxy := ""
+			if transport.Proxy != nil {
+				req, _ := http.NewRequest("GET", tt.url, nil)
+				proxyURL, err := transport.Proxy(req)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if proxyURL != nil {
+					proxy = proxyURL.String()
+				}
+			}
+			if proxy != tt.expectProxy {
+				t.Errorf("expected proxy %q, got %q", tt.expectProxy, proxy)
+			}
+		})
+	}
+}
+
+func TestHTTPClientInvalidSettings(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings httpclient.Settings
+	}{
+		{name: "invalid timeout", settings: httpclient.Settings{Timeout: "logo"}},
+		{name: "invalid tls version", settings: httpclient.Settings{TLSMinVersion: "1.4"}},
+		{name: "missing ca file", settings: httpclient.Settings{CAFiles: []string{"/nao/existe.pem"}}},
+		{name: "client cert without key", settings: httpclient.Settings{ClientCert: "/tmp/cert.pem"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := httpclient.NewWithConfig(&httpclient.Config{Settings: tt.settings}, httpclient.IntegrationAI); err == nil {
+				t.Fatal("expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestHTTPClientExtraCA(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	// Sem a CA do servidor a conexão deve falhar
+	client, err := httpclient.NewWithConfig(&httpclient.Config{}, httpclient.IntegrationJira)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp, err := client.Get(server.URL); err == nil {
+		resp.Body.Close()
+		t.Fatal("expected certificate error without the extra CA")
+	}
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := os.WriteFile(caFile, certPEM, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	config := &httpclient.Config{
+		Integrations: map[httpclient.Integration]*httpclient.Settings{
+			httpclient.IntegrationJira: {CAFiles: []string{caFile}},
+		},
+	}
+	client, err = httpclient.NewWithConfig(config, httpclient.IntegrationJira)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("expected success with the extra CA, got %v", err)
+	}
+	resp.Body.Close()
+}
@@ -36,6 +36,18 @@ func TestListNotes(t *testing.T) {
 			},
 			expectError: false,
 		},
+		{
+			name:    "successful verbose list with summaries",
+			isAsc:   false,
+			verbose: true,
+			tag:     nil,
+			setupMocks: func(noteRepo *mockNoteRepository, tagRepo *mockTagRepository) {
+				noteRepo.err = nil
+				noteRepo.notesWithTags = createTestNotes()
+				noteRepo.notesWithTags[0].Summary = "Summary of the first note"
+			},
+			expectError: false,
+		},
 		{
 			name:    "successful list notes with tag filter",
 			isAsc:   true,
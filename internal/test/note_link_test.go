@@ -0,0 +1,172 @@
+package test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/snip/internal/database"
+	"github.com/snip/internal/handler"
+	"github.com/snip/internal/note"
+	"github.com/snip/internal/project"
+	"github.com/snip/internal/repository"
+	"github.com/snip/internal/task"
+)
+
+func TestNoteLinkRepository(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	db, err := database.Connect()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	noteRepo, _ := repository.NewNoteRepository(db)
+	projectRepo, _ := repository.NewProjectRepository(db)
+	taskRepo, _ := repository.NewTaskRepository(db)
+	linkRepo, _ := repository.NewNoteLinkRepository(db)
+
+	p := project.NewProject("PG upgrade", "")
+	if err := projectRepo.Create(p); err != nil {
+		t.Fatal(err)
+	}
+	backup := task.NewTask(p.ID, "Backup", "", "medium")
+	if err := taskRepo.Create(backup); err != nil {
+		t.Fatal(err)
+	}
+	minutes := note.NewNote("Kickoff minutes", "")
+	design := note.NewNote("Design notes", "")
+	for _, n := range []*note.Note{minutes, design} {
+		if err := noteRepo.Create(n); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		kind     string
+		targetID int
+		expected error
+	}{
+		{name: "project", kind: note.LinkProject, targetID: p.ID},
+		{name: "task", kind: note.LinkTask, targetID: backup.ID},
+		{name: "link again is a no-op", kind: note.LinkTask, targetID: backup.ID},
+		{name: "missing task", kind: note.LinkTask, targetID: 99, expected: repository.ErrLinkTargetNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := linkRepo.Link(minutes.ID, tt.kind, tt.targetID)
+			if !errors.Is(err, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+	if err := linkRepo.Link(minutes.ID, "board", 1); err == nil {
+		t.Error("expected error for unknown link type")
+	}
+	if err := linkRepo.Link(design.ID, note.LinkTask, backup.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	links, err := linkRepo.GetLinks(minutes.ID)
+	if err != nil || len(links) != 2 || links[0].Kind != note.LinkProject || links[0].Title != "PG upgrade" || links[1].Title != "Backup" {
+		t.Fatalf("unexpected links: %+v (%v)", links, err)
+	}
+
+	// A ata tem vínculo direto e pela tarefa: aparece uma vez, como direta
+	byProject, err := linkRepo.GetNotesByProject(p.ID)
+	if err != nil || len(byProject) != 2 {
+		t.Fatalf("expected 2 notes in the project, got %+v (%v)", byProject, err)
+	}
+	via := make(map[int]int)
+	for _, n := range byProject {
+		via[n.ID] = n.TaskID
+	}
+	if via[minutes.ID] != 0 || via[design.ID] != backup.ID {
+		t.Errorf("expected direct link for minutes and task link for design, got %v", via)
+	}
+
+	if err := linkRepo.Unlink(minutes.ID, note.LinkTask, backup.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := linkRepo.Unlink(minutes.ID, note.LinkTask, backup.ID); !errors.Is(err, repository.ErrLinkNotFound) {
+		t.Errorf("expected ErrLinkNotFound, got %v", err)
+	}
+
+	if err := taskRepo.Delete(backup.ID); err != nil {
+		t.Fatal(err)
+	}
+	if notes, _ := linkRepo.GetNotesByTask(backup.ID); len(notes) != 0 {
+		t.Errorf("expected links of deleted task to be removed, got %+v", notes)
+	}
+	if err := noteRepo.Delete(minutes.ID); err != nil {
+		t.Fatal(err)
+	}
+	if notes, _ := linkRepo.GetNotesByProject(p.ID); len(notes) != 0 {
+		t.Errorf("expected links of deleted note to be removed, got %+v", notes)
+	}
+}
+
+func TestNoteHandlerLinks(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	db, err := database.Connect()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	noteRepo, _ := repository.NewNoteRepository(db)
+	tagRepo, _ := repository.NewTagRepository(db)
+	projectRepo, _ := repository.NewProjectRepository(db)
+	linkRepo, _ := repository.NewNoteLinkRepository(db)
+	h := handler.NewHandler(noteRepo, tagRepo, linkRepo)
+
+	p := project.NewProject("PG upgrade", "")
+	if err := projectRepo.Create(p); err != nil {
+		t.Fatal(err)
+	}
+
+	h.SetLinkContext(p.ID, 42)
+	if err := h.CreateNote("Minutes", stringPtr("content"), nil); !errors.Is(err, repository.ErrLinkTargetNotFound) {
+		t.Fatalf("expected missing task error, got %v", err)
+	}
+	if notes, _ := noteRepo.GetAll(false, 0); len(notes) != 0 {
+		t.Errorf("expected no note to be created for a missing task, got %d", len(notes))
+	}
+
+	h.SetLinkContext(p.ID, 0)
+	if err := h.CreateNote("Minutes", stringPtr("content"), nil); err != nil {
+		t.Fatal(err)
+	}
+	if notes, _ := linkRepo.GetNotesByProject(p.ID); len(notes) != 1 || notes[0].Title != "Minutes" {
+		t.Errorf("expected note linked to the project, got %+v", notes)
+	}
+
+	tests := []struct {
+		name        string
+		id          string
+		kind        string
+		targetID    string
+		expectError bool
+	}{
+		{name: "link to project", id: "1", kind: "project", targetID: "1"},
+		{name: "invalid note id", id: "abc", kind: "project", targetID: "1", expectError: true},
+		{name: "missing note", id: "9", kind: "project", targetID: "1", expectError: true},
+		{name: "unknown kind", id: "1", kind: "board", targetID: "1", expectError: true},
+		{name: "invalid target id", id: "1", kind: "task", targetID: "x", expectError: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := h.LinkNote(tt.id, tt.kind, tt.targetID)
+			if tt.expectError != (err != nil) {
+				t.Errorf("expectError %v, got %v", tt.expectError, err)
+			}
+		})
+	}
+
+	h.SetLinkContext(99, 0)
+	if err := h.ListNotes(false, false, nil); !errors.Is(err, repository.ErrLinkTargetNotFound) {
+		t.Errorf("expected missing project error when filtering, got %v", err)
+	}
+}
@@ -0,0 +1,114 @@
+package test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mattn/go-runewidth"
+	"github.com/snip/internal/dbcharts"
+	"github.com/snip/internal/task"
+)
+
+func TestSchedule(t *testing.T) {
+	now := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+	created := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	due := time.Date(2026, 3, 12, 0, 0, 0, 0, time.UTC)
+
+	newTask := func(id int, status string, estimate time.Duration) *task.Task {
+		tk := newEstimatedTask(id, status, estimate)
+		tk.CreatedAt = created
+		tk.UpdatedAt = created.Add(4 * time.Hour)
+		return tk
+	}
+	backup := newTask(1, "completed", 4*time.Hour)
+	upgrade := newTask(2, "pending", 16*time.Hour)
+	early := time.Date(2026, 3, 5, 0, 0, 0, 0, time.UTC)
+	upgrade.DueDate = &early
+	check := newTask(3, "pending", 0)
+	check.ParentTaskID = 2
+	notify := newTask(4, "pending", 0)
+	notify.DueDate = &due
+	notify.CreatedAt = now.Add(time.Hour)
+
+	rows, err := task.Schedule([]*task.Task{backup, upgrade, check, notify}, map[int][]int{2: {1}, 4: {2}}, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var ids []int
+	byID := make(map[int]task.ScheduledTask)
+	for _, row := range rows {
+		ids = append(ids, row.Task.ID)
+		byID[row.Task.ID] = row
+	}
+	if len(ids) != 4 || ids[1] != 2 || ids[2] != 3 || byID[3].Depth != 1 {
+		t.Errorf("expected tree order with subtask under its parent, got %v", ids)
+	}
+
+	if !byID[1].End.Equal(backup.UpdatedAt) {
+		t.Errorf("expected completed task to end at its last update, got %v", byID[1].End)
+	}
+	// Começa quando o backup terminou; 2 dias de 8h a partir dali já passaram, vai até now
+	if !byID[2].Start.Equal(backup.UpdatedAt) || !byID[2].End.Equal(now) || !byID[2].Critical || !byID[2].Late {
+		t.Errorf("expected overdue task to stretch until now on the critical path, got %+v", byID[2])
+	}
+	// Sem estimativa: começa na criação (depois da dependência) e vai até o fim do prazo
+	if !byID[4].Start.Equal(notify.CreatedAt) || byID[4].Estimated || !byID[4].End.Equal(due.AddDate(0, 0, 1)) || byID[4].Late {
+		t.Errorf("unexpected schedule for task without estimate: %+v", byID[4])
+	}
+	if !byID[3].End.Equal(now) || byID[3].Late {
+		t.Errorf("unexpected schedule for subtask: %+v", byID[3])
+	}
+
+	if _, err := task.Schedule([]*task.Task{backup, upgrade}, map[int][]int{1: {2}, 2: {1}}, now); err == nil {
+		t.Error("expected error for circular dependencies")
+	}
+}
+
+func TestGenerateGantt(t *testing.T) {
+	start := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	due := time.Date(2026, 3, 5, 0, 0, 0, 0, time.UTC)
+	data := dbcharts.GanttData{
+		Title: "Upgrade <PG 16>",
+		Today: start.AddDate(0, 0, 1),
+		Bars: []dbcharts.GanttBar{
+			{Label: "#1 Backup", Start: start, End: start.AddDate(0, 0, 1), Progress: 1},
+			{Label: "#2 pg_upgrade", Start: start.AddDate(0, 0, 1), End: start.AddDate(0, 0, 6), Due: &due, Critical: true, Late: true, DependsOn: []int{0}},
+			{Label: "#3 Validar extensões com um título bem comprido", Indent: 1, Start: start.AddDate(0, 0, 2), End: start.AddDate(0, 0, 3)},
+		},
+	}
+
+	ascii, err := dbcharts.GenerateGantt(data, dbcharts.ChartTypeASCII, 60)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, expected := range []string{"02/03", "#1 Backup", "◆", "⚠", "#2 pg_upgrade ← #1 Backup", "Legenda"} {
+		if !strings.Contains(ascii, expected) {
+			t.Errorf("expected ASCII chart to contain %q:\n%s", expected, ascii)
+		}
+	}
+	for _, line := range strings.Split(ascii, "\n") {
+		if strings.HasPrefix(line, "#") || strings.HasPrefix(line, "  #") {
+			if w := runewidth.StringWidth(line); w > 60 {
+				t.Errorf("expected bar lines to fit 60 columns, got %d: %q", w, line)
+			}
+		}
+	}
+
+	page, err := dbcharts.GenerateGantt(data, dbcharts.ChartTypeHTML, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(page, "<svg") || !strings.Contains(page, "Upgrade &lt;PG 16&gt;") || strings.Contains(page, "<script") {
+		t.Errorf("expected self-contained HTML with escaped title:\n%s", page)
+	}
+	if strings.Count(page, "marker-end") != 1 || strings.Count(page, "<polygon") != 1 {
+		t.Errorf("expected one dependency arrow and one due marker:\n%s", page)
+	}
+
+	data.Bars[0].DependsOn = []int{5}
+	if _, err := dbcharts.GenerateGantt(data, dbcharts.ChartTypeSVG, 0); err == nil {
+		t.Error("expected error for invalid dependency index")
+	}
+}
@@ -0,0 +1,117 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/snip/internal/database"
+	"github.com/snip/internal/note"
+	"github.com/snip/internal/repository"
+	"github.com/snip/internal/snippet"
+)
+
+const snippetAnswer = "Aqui está a função:\n\n```golang\nfunc Reverse(s string) string {\n\treturn s\n}\n```\n\nUso:\n\n```bash\ngo run main.go\n```\n"
+
+func TestExtractCode(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		language string
+		expected string
+	}{
+		{
+			name:     "blocks of the requested language",
+			content:  snippetAnswer,
+			language: "go",
+			expected: "func Reverse(s string) string {\n\treturn s\n}",
+		},
+		{
+			name:     "falls back to every block",
+			content:  snippetAnswer,
+			language: "python",
+			expected: "func Reverse(s string) string {\n\treturn s\n}\n\ngo run main.go",
+		},
+		{
+			name:     "blocks without language and tilde fences",
+			content:  "~~~\nSELECT 1;\n~~~\ntexto\n```\nSELECT 2;\n```",
+			language: "sql",
+			expected: "SELECT 1;\n\nSELECT 2;",
+		},
+		{
+			name:     "unterminated block is kept",
+			content:  "```py\nprint('oi')",
+			language: "python",
+			expected: "print('oi')",
+		},
+		{
+			name:     "no fences uses the whole answer",
+			content:  "  echo ok  \n",
+			language: "bash",
+			expected: "echo ok",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if code := snippet.ExtractCode(tt.content, tt.language); code != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, code)
+			}
+		})
+	}
+}
+
+func TestSnippetRepository(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	db, err := database.Connect()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	snippetRepo, _ := repository.NewSnippetRepository(db)
+	noteRepo, _ := repository.NewNoteRepository(db)
+
+	s := snippet.NewSnippet("reverse a string", "golang", snippetAnswer, "reverse a string")
+	n := note.NewNote(s.Title, snippetAnswer)
+	if err := snippetRepo.Create(n, s); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if s.NoteID == 0 || s.NoteID != n.ID || s.Language != "go" {
+		t.Fatalf("unexpected snippet: %+v", s)
+	}
+
+	plain := note.NewNote("plain note", "texto")
+	if err := noteRepo.Create(plain); err != nil {
+		t.Fatal(err)
+	}
+
+	if list, err := snippetRepo.List("go"); err != nil || len(list) != 1 {
+		t.Fatalf("expected one go snippet, got %d (%v)", len(list), err)
+	}
+	if list, _ := snippetRepo.List("python"); len(list) != 0 {
+		t.Errorf("expected no python snippets, got %d", len(list))
+	}
+
+	withTags, err := noteRepo.GetByID(n.ID)
+	if err != nil || withTags.Language != "go" {
+		t.Errorf("expected note to carry the snippet language, got %+v (%v)", withTags, err)
+	}
+	if _, err := snippetRepo.GetByNoteID(plain.ID); err != repository.ErrSnippetNotFound {
+		t.Errorf("expected ErrSnippetNotFound for a plain note, got %v", err)
+	}
+
+	// Editar a nota extrai o código de novo
+	if err := noteRepo.Update(n.ID, "```go\nfunc Novo() {}\n```", ""); err != nil {
+		t.Fatal(err)
+	}
+	if updated, _ := snippetRepo.GetByNoteID(n.ID); updated == nil || updated.Code != "func Novo() {}" {
+		t.Errorf("expected code to follow the note content, got %+v", updated)
+	}
+
+	if err := noteRepo.Delete(n.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := snippetRepo.GetByNoteID(n.ID); err != repository.ErrSnippetNotFound {
+		t.Errorf("expected snippet to be removed with the note, got %v", err)
+	}
+}
@@ -0,0 +1,206 @@
+package test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/snip/internal/ai"
+	"github.com/snip/internal/database"
+	"github.com/snip/internal/note"
+	"github.com/snip/internal/repository"
+	"github.com/snip/internal/study"
+)
+
+// flashcardClient responde ao prompt study.flashcards com um JSON fixo
+type flashcardClient struct {
+	ai.AIClient
+	response string
+	prompt   string
+}
+
+func (c *flashcardClient) ChatJSON(messages []ai.Message, schema interface{}, maxTokens int, temperature float64) error {
+	c.prompt = messages[len(messages)-1].Content
+	if err := json.Unmarshal([]byte(c.response), schema); err != nil {
+		return err
+	}
+	return ai.ValidateJSONSchema(schema)
+}
+
+func TestCardSchedule(t *testing.T) {
+	now := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		qualities   []int
+		interval    int
+		repetitions int
+		easeFactor  float64
+	}{
+		{name: "first good answer", qualities: []int{4}, interval: 1, repetitions: 1, easeFactor: 2.5},
+		{name: "second good answer", qualities: []int{4, 4}, interval: 6, repetitions: 2, easeFactor: 2.5},
+		{name: "third answer uses the ease factor", qualities: []int{5, 5, 5}, interval: 16, repetitions: 3, easeFactor: 2.8},
+		{name: "hard answer lowers the ease factor", qualities: []int{3}, interval: 1, repetitions: 1, easeFactor: 2.36},
+		{name: "failure resets repetitions", qualities: []int{5, 5, 1}, interval: 1, repetitions: 0, easeFactor: 2.16},
+		{name: "ease factor never goes below 1.3", qualities: []int{0, 0, 0, 0, 0}, interval: 1, repetitions: 0, easeFactor: study.MinEaseFactor},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			card := study.NewCard(1, "q", "a")
+			var review *study.Review
+			for _, q := range tt.qualities {
+				review = card.Schedule(q, now)
+			}
+
+			if card.Interval != tt.interval || card.Repetitions != tt.repetitions || card.EaseFactor != tt.easeFactor {
+				t.Errorf("expected interval=%d repetitions=%d ease=%.2f, got interval=%d repetitions=%d ease=%.2f",
+					tt.interval, tt.repetitions, tt.easeFactor, card.Interval, card.Repetitions, card.EaseFactor)
+			}
+			if !card.DueAt.Equal(now.AddDate(0, 0, tt.interval)) {
+				t.Errorf("expected due date %v, got %v", now.AddDate(0, 0, tt.interval), card.DueAt)
+			}
+			if review.Quality != tt.qualities[len(tt.qualities)-1] || review.Interval != card.Interval {
+				t.Errorf("unexpected review: %+v", review)
+			}
+		})
+	}
+}
+
+func TestGenerateFlashcards(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	tests := []struct {
+		name        string
+		response    string
+		maxCards    int
+		expected    int
+		expectError bool
+	}{
+		{
+			name:     "duplicates and empty cards are dropped",
+			response: `{"cards":[{"question":"Which view shows ASH samples?","answer":"V$ACTIVE_SESSION_HISTORY"},{"question":"which view shows ASH samples?","answer":"dup"},{"question":"  ","answer":"x"}]}`,
+			maxCards: 5,
+			expected: 1,
+		},
+		{
+			name:     "limited to max cards",
+			response: `{"cards":[{"question":"a","answer":"1"},{"question":"b","answer":"2"},{"question":"c","answer":"3"}]}`,
+			maxCards: 2,
+			expected: 2,
+		},
+		{
+			name:        "no cards is an error",
+			response:    `{"cards":[]}`,
+			maxCards:    5,
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &flashcardClient{response: tt.response}
+			cards, err := ai.GenerateFlashcards(client, "ASH", "V$ACTIVE_SESSION_HISTORY guarda amostras", tt.maxCards)
+			if tt.expectError {
+				if err == nil {
+					t.Error("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(cards) != tt.expected {
+				t.Errorf("expected %d cards, got %d", tt.expected, len(cards))
+			}
+			if !strings.Contains(client.prompt, "V$ACTIVE_SESSION_HISTORY") {
+				t.Error("expected the note content in the prompt")
+			}
+		})
+	}
+}
+
+func TestStudyRepository(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	db, err := database.Connect()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	studyRepo, _ := repository.NewStudyRepository(db)
+	noteRepo, _ := repository.NewNoteRepository(db)
+	tagRepo, _ := repository.NewTagRepository(db)
+
+	oracleNote := note.NewNote("ASH", "V$ACTIVE_SESSION_HISTORY")
+	pgNote := note.NewNote("Vacuum", "autovacuum")
+	for _, n := range []*note.Note{oracleNote, pgNote} {
+		if err := noteRepo.Create(n); err != nil {
+			t.Fatal(err)
+		}
+	}
+	oracleTag, err := tagRepo.GetOrCreate("oracle")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := noteRepo.AddTagToNote(oracleNote.ID, oracleTag.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	cards := []*study.Card{
+		study.NewCard(oracleNote.ID, "ASH view?", "V$ACTIVE_SESSION_HISTORY"),
+		study.NewCard(oracleNote.ID, "ASH interval?", "1s"),
+		study.NewCard(pgNote.ID, "Who runs vacuum?", "autovacuum"),
+	}
+	if err := studyRepo.CreateCards(cards); err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now().Add(time.Minute)
+	if due, _ := studyRepo.GetDue(now, 0, 0); len(due) != 3 {
+		t.Fatalf("expected 3 due cards, got %d", len(due))
+	}
+	due, err := studyRepo.GetDue(now, oracleTag.ID, 0)
+	if err != nil || len(due) != 2 || due[0].NoteTitle != "ASH" {
+		t.Fatalf("expected 2 oracle cards, got %d (%v)", len(due), err)
+	}
+	if limited, _ := studyRepo.GetDue(now, 0, 1); len(limited) != 1 {
+		t.Errorf("expected limit to apply, got %d", len(limited))
+	}
+
+	card := due[0]
+	review := card.Schedule(5, time.Now())
+	if err := studyRepo.SaveReview(card, review); err != nil {
+		t.Fatal(err)
+	}
+
+	stats, err := studyRepo.GetStats(now, oracleTag.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.Total != 2 || stats.Due != 1 || stats.New != 1 || stats.ReviewsToday != 1 {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+
+	// O cartão revisado só volta amanhã
+	if later, _ := studyRepo.GetDue(now.AddDate(0, 0, 2), oracleTag.ID, 0); len(later) != 2 {
+		t.Errorf("expected reviewed card to be due again later, got %d", len(later))
+	}
+
+	if count, _ := studyRepo.CountByNote(oracleNote.ID); count != 2 {
+		t.Errorf("expected 2 cards for the note, got %d", count)
+	}
+	if deleted, err := studyRepo.DeleteByNote(pgNote.ID); err != nil || deleted != 1 {
+		t.Errorf("expected 1 deleted card, got %d (%v)", deleted, err)
+	}
+
+	// Apagar a nota remove os cartões dela
+	if err := noteRepo.Delete(oracleNote.ID); err != nil {
+		t.Fatal(err)
+	}
+	if stats, _ := studyRepo.GetStats(now, 0); stats.Total != 0 {
+		t.Errorf("expected no cards left, got %d", stats.Total)
+	}
+}
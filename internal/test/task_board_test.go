@@ -0,0 +1,97 @@
+package test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mattn/go-runewidth"
+	"github.com/snip/internal/task"
+)
+
+func newBoardTask(id int, status, priority string) *task.Task {
+	return &task.Task{ID: id, ProjectID: 1, Title: "Tarefa com um título bem comprido", Status: status, Priority: priority}
+}
+
+func boardIDs(column *task.Column) []int {
+	var ids []int
+	for _, t := range column.Tasks {
+		ids = append(ids, t.ID)
+	}
+	return ids
+}
+
+func TestBoardColumnsAndMove(t *testing.T) {
+	tasks := []*task.Task{
+		newBoardTask(1, "pending", "low"),
+		newBoardTask(2, "pending", "high"),
+		newBoardTask(3, "in_progress", "medium"),
+		newBoardTask(4, "completed", "medium"),
+		newBoardTask(5, "unknown", "medium"),
+	}
+	board := task.NewBoard(tasks)
+
+	if ids := boardIDs(board.Columns[0]); len(ids) != 3 || ids[0] != 2 || ids[1] != 5 || ids[2] != 1 {
+		t.Errorf("expected pending column ordered by priority [2 5 1], got %v", ids)
+	}
+
+	tests := []struct {
+		name     string
+		id       int
+		delta    int
+		expected string
+		ok       bool
+	}{
+		{name: "pending to in progress", id: 2, delta: 1, expected: "in_progress", ok: true},
+		{name: "in progress to completed", id: 2, delta: 1, expected: "completed", ok: true},
+		{name: "past last column", id: 2, delta: 1, ok: false},
+		{name: "before first column", id: 1, delta: -1, ok: false},
+		{name: "unknown card", id: 99, delta: 1, ok: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, ok := board.Move(tt.id, tt.delta)
+			if ok != tt.ok || status != tt.expected {
+				t.Errorf("expected (%q, %v), got (%q, %v)", tt.expected, tt.ok, status, ok)
+			}
+		})
+	}
+
+	if c, _ := board.Locate(2); c != 2 || tasks[1].Status != "completed" {
+		t.Errorf("expected task 2 in completed column, got column %d status %s", c, tasks[1].Status)
+	}
+}
+
+func TestBoardRenderFitsWidth(t *testing.T) {
+	now := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+	late := now.AddDate(0, 0, -3)
+	soon := now.AddDate(0, 0, 2)
+
+	overdue := newBoardTask(1, "pending", "high")
+	overdue.DueDate = &late
+	upcoming := newBoardTask(2, "in_progress", "low")
+	upcoming.DueDate = &soon
+	board := task.NewBoard([]*task.Task{overdue, upcoming, newBoardTask(3, "completed", "medium")})
+
+	for _, width := range []int{120, 80, 40} {
+		out := board.Render(task.BoardStyle{Width: width, Now: now, Selected: 2})
+		for _, line := range strings.Split(strings.TrimRight(out, "\n"), "\n") {
+			if w := runewidth.StringWidth(line); w > width {
+				t.Errorf("width %d: line too wide (%d): %q", width, w, line)
+			}
+		}
+		if !strings.Contains(out, "atrasada 3d") || !strings.Contains(out, "em 2d") {
+			t.Errorf("width %d: expected due badges in board:\n%s", width, out)
+		}
+		if !strings.Contains(out, "╔") {
+			t.Errorf("width %d: expected selected card highlighted", width)
+		}
+	}
+
+	if out := board.Render(task.BoardStyle{Width: 80, Now: now}); strings.Contains(out, "\033[") {
+		t.Error("expected no ANSI codes without color")
+	}
+	if out := board.Render(task.BoardStyle{Width: 80, Now: now, Color: true}); !strings.Contains(out, "\033[31m▲ alta") {
+		t.Error("expected high priority in red")
+	}
+}
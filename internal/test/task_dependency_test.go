@@ -0,0 +1,168 @@
+package test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/snip/internal/database"
+	"github.com/snip/internal/repository"
+	"github.com/snip/internal/task"
+)
+
+func newEstimatedTask(id int, status string, estimate time.Duration) *task.Task {
+	return &task.Task{ID: id, ProjectID: 1, Title: "t", Status: status, EstimatedTime: estimate}
+}
+
+func TestFindCycle(t *testing.T) {
+	deps := map[int][]int{2: {1}, 3: {2}, 4: {1}}
+
+	tests := []struct {
+		name        string
+		taskID      int
+		dependsOnID int
+		expected    []int
+	}{
+		{name: "new independent dependency", taskID: 4, dependsOnID: 3, expected: nil},
+		{name: "self dependency", taskID: 1, dependsOnID: 1, expected: []int{1, 1}},
+		{name: "direct cycle", taskID: 1, dependsOnID: 2, expected: []int{1, 2, 1}},
+		{name: "indirect cycle", taskID: 1, dependsOnID: 3, expected: []int{1, 3, 2, 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cycle := task.FindCycle(deps, tt.taskID, tt.dependsOnID)
+			if !reflect.DeepEqual(cycle, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, cycle)
+			}
+		})
+	}
+}
+
+func TestCriticalPath(t *testing.T) {
+	start := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	due := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+
+	upgrade := newEstimatedTask(2, "pending", 8*time.Hour)
+	upgrade.DueDate = &due
+	tasks := []*task.Task{
+		newEstimatedTask(1, "completed", 3*time.Hour),
+		upgrade,
+		newEstimatedTask(3, "pending", 4*time.Hour),
+		newEstimatedTask(4, "pending", 10*time.Hour),
+		newEstimatedTask(5, "pending", 0),
+	}
+	// 2 → 1, 3 → 2, 4 → 1, 5 → 3 e uma dependência para fora do projeto
+	deps := map[int][]int{2: {1}, 3: {2}, 4: {1, 99}, 5: {3}}
+
+	result, err := task.CriticalPath(tasks, deps, start)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var ids []int
+	for _, step := range result.Steps {
+		ids = append(ids, step.Task.ID)
+	}
+	if !reflect.DeepEqual(ids, []int{1, 2, 3, 5}) {
+		t.Errorf("expected path [1 2 3 5], got %v", ids)
+	}
+	if result.Duration != 12*time.Hour || result.Unestimated != 1 {
+		t.Errorf("expected 12h with 1 unestimated task, got %s and %d", result.Duration, result.Unestimated)
+	}
+	if expected := start.AddDate(0, 0, 1).Add(4 * time.Hour); !result.End.Equal(expected) {
+		t.Errorf("expected end %v, got %v", expected, result.End)
+	}
+	if len(result.Late) != 1 || result.Late[0].Task.ID != 2 {
+		t.Errorf("expected task 2 to be late, got %+v", result.Late)
+	}
+
+	blocked := task.BlockedBy(3, deps, map[int]*task.Task{1: tasks[0], 2: upgrade})
+	if !reflect.DeepEqual(blocked, []int{2}) {
+		t.Errorf("expected task 3 blocked by [2], got %v", blocked)
+	}
+
+	deps[1] = []int{5}
+	if _, err := task.CriticalPath(tasks, deps, start); err == nil {
+		t.Error("expected error for circular dependencies")
+	}
+}
+
+func TestParseEstimate(t *testing.T) {
+	tests := []struct {
+		value       string
+		expected    time.Duration
+		expectError bool
+	}{
+		{value: "", expected: 0},
+		{value: "90m", expected: 90 * time.Minute},
+		{value: "2h30m", expected: 150 * time.Minute},
+		{value: "3d", expected: 3 * task.WorkDay},
+		{value: "1d4h", expected: task.WorkDay + 4*time.Hour},
+		{value: "amanhã", expectError: true},
+		{value: "-2h", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			d, err := task.ParseEstimate(tt.value)
+			if tt.expectError {
+				if err == nil {
+					t.Error("expected error, got nil")
+				}
+				return
+			}
+			if err != nil || d != tt.expected {
+				t.Errorf("expected %s, got %s (%v)", tt.expected, d, err)
+			}
+		})
+	}
+}
+
+func TestTaskDependencyRepository(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	db, err := database.Connect()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	taskRepo, _ := repository.NewTaskRepository(db)
+
+	backup := task.NewTask(1, "Backup", "", "high")
+	backup.EstimatedTime = 2 * time.Hour
+	upgrade := task.NewTask(1, "Upgrade", "", "high")
+	for _, tk := range []*task.Task{backup, upgrade} {
+		if err := taskRepo.Create(tk); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if got, _ := taskRepo.GetByID(backup.ID); got.EstimatedTime != 2*time.Hour {
+		t.Errorf("expected estimate to be stored, got %s", got.EstimatedTime)
+	}
+	if err := taskRepo.SetEstimate(upgrade.ID, 90*time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := taskRepo.GetByProjectID(1, ""); len(got) != 2 || got[0].EstimatedTime+got[1].EstimatedTime != 210*time.Minute {
+		t.Errorf("unexpected estimates: %+v", got)
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := taskRepo.AddDependency(upgrade.ID, backup.ID); err != nil {
+			t.Fatal(err)
+		}
+	}
+	deps, err := taskRepo.GetDependencies()
+	if err != nil || !reflect.DeepEqual(deps, map[int][]int{upgrade.ID: {backup.ID}}) {
+		t.Fatalf("unexpected dependencies: %v (%v)", deps, err)
+	}
+
+	if err := taskRepo.Delete(backup.ID); err != nil {
+		t.Fatal(err)
+	}
+	if deps, _ := taskRepo.GetDependencies(); len(deps) != 0 {
+		t.Errorf("expected dependencies of deleted task to be removed, got %v", deps)
+	}
+}
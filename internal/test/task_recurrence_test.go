@@ -0,0 +1,244 @@
+package test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/snip/internal/database"
+	"github.com/snip/internal/repository"
+	"github.com/snip/internal/task"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestParseRecurrence(t *testing.T) {
+	tests := []struct {
+		rule        string
+		expected    string
+		expectError bool
+	}{
+		{rule: "daily", expected: "FREQ=DAILY"},
+		{rule: "weekdays", expected: "FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR"},
+		{rule: "quarterly", expected: "FREQ=MONTHLY;INTERVAL=3"},
+		{rule: "RRULE:FREQ=WEEKLY;BYDAY=WE,MO", expected: "FREQ=WEEKLY;BYDAY=MO,WE"},
+		{rule: "freq=monthly;bymonthday=15", expected: "FREQ=MONTHLY;BYMONTHDAY=15"},
+		{rule: "FREQ=DAILY;INTERVAL=3", expected: "FREQ=DAILY;INTERVAL=3"},
+		{rule: "FREQ=YEARLY", expectError: true},
+		{rule: "INTERVAL=2", expectError: true},
+		{rule: "FREQ=DAILY;BYDAY=MO", expectError: true},
+		{rule: "FREQ=WEEKLY;BYDAY=XX", expectError: true},
+		{rule: "FREQ=MONTHLY;BYMONTHDAY=32", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.rule, func(t *testing.T) {
+			r, err := task.ParseRecurrence(tt.rule)
+			if tt.expectError {
+				if err == nil {
+					t.Error("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if r.String() != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, r.String())
+			}
+		})
+	}
+}
+
+func TestRecurrenceNext(t *testing.T) {
+	// 2026-03-02 é uma segunda-feira
+	tests := []struct {
+		name     string
+		rule     string
+		after    time.Time
+		expected time.Time
+	}{
+		{name: "every 3 days", rule: "FREQ=DAILY;INTERVAL=3", after: date(2026, 3, 2), expected: date(2026, 3, 5)},
+		{name: "weekly keeps weekday", rule: "weekly", after: date(2026, 3, 2), expected: date(2026, 3, 9)},
+		{name: "weekly byday same week", rule: "FREQ=WEEKLY;BYDAY=MO,WE", after: date(2026, 3, 2), expected: date(2026, 3, 4)},
+		{name: "weekly byday next week", rule: "FREQ=WEEKLY;BYDAY=MO,WE", after: date(2026, 3, 4), expected: date(2026, 3, 9)},
+		{name: "weekdays skip weekend", rule: "weekdays", after: date(2026, 3, 6), expected: date(2026, 3, 9)},
+		{name: "biweekly byday", rule: "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO", after: date(2026, 3, 2), expected: date(2026, 3, 16)},
+		{name: "monthly later this month", rule: "FREQ=MONTHLY;BYMONTHDAY=15", after: date(2026, 3, 2), expected: date(2026, 3, 15)},
+		{name: "monthly next month", rule: "FREQ=MONTHLY;BYMONTHDAY=15", after: date(2026, 3, 15), expected: date(2026, 4, 15)},
+		{name: "monthly clamps to last day", rule: "FREQ=MONTHLY;BYMONTHDAY=31", after: date(2026, 1, 31), expected: date(2026, 2, 28)},
+		{name: "quarterly", rule: "quarterly", after: date(2026, 1, 15), expected: date(2026, 4, 15)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := task.ParseRecurrence(tt.rule)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if next := r.Next(tt.after); !next.Equal(tt.expected) {
+				t.Errorf("expected %s, got %s", tt.expected.Format("2006-01-02"), next.Format("2006-01-02"))
+			}
+		})
+	}
+
+	// Regra mensal ancorada no dia 31 volta ao 31 depois de fevereiro
+	monthly, _ := task.ParseRecurrence("monthly")
+	monthly.Anchor(date(2026, 1, 31))
+	if monthly.String() != "FREQ=MONTHLY;BYMONTHDAY=31" {
+		t.Errorf("expected anchored rule, got %s", monthly.String())
+	}
+	feb := monthly.Next(date(2026, 1, 31))
+	if mar := monthly.Next(feb); !feb.Equal(date(2026, 2, 28)) || !mar.Equal(date(2026, 3, 31)) {
+		t.Errorf("expected 2026-02-28 and 2026-03-31, got %s and %s", feb.Format("2006-01-02"), mar.Format("2006-01-02"))
+	}
+
+	// First aceita o próprio dia quando ele casa com a regra
+	mondays, _ := task.ParseRecurrence("FREQ=WEEKLY;BYDAY=MO")
+	if first := mondays.First(date(2026, 3, 2)); !first.Equal(date(2026, 3, 2)) {
+		t.Errorf("expected 2026-03-02, got %s", first.Format("2006-01-02"))
+	}
+	if first := mondays.First(date(2026, 3, 3)); !first.Equal(date(2026, 3, 9)) {
+		t.Errorf("expected 2026-03-09, got %s", first.Format("2006-01-02"))
+	}
+
+	// Ocorrências perdidas são puladas até a data mínima
+	r, _ := task.ParseRecurrence("FREQ=WEEKLY;BYDAY=MO")
+	if next := r.NextOnOrAfter(date(2026, 1, 5), date(2026, 3, 3)); !next.Equal(date(2026, 3, 9)) {
+		t.Errorf("expected 2026-03-09, got %s", next.Format("2006-01-02"))
+	}
+}
+
+func TestTaskRepositoryRecurrence(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	db, err := database.Connect()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	taskRepo, _ := repository.NewTaskRepository(db)
+
+	review := task.NewTask(1, "Revisar índices", "", "medium")
+	review.Recurrence = "FREQ=WEEKLY;BYDAY=MO"
+	if err := taskRepo.Create(review); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := taskRepo.GetByID(review.ID); got.Recurrence != review.Recurrence {
+		t.Errorf("expected recurrence %q, got %q", review.Recurrence, got.Recurrence)
+	}
+
+	if err := taskRepo.SetRecurrence(review.ID, ""); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := taskRepo.GetByProjectID(1, ""); len(got) != 1 || got[0].Recurrence != "" {
+		t.Errorf("expected recurrence to be cleared, got %+v", got)
+	}
+}
+
+func TestTaskHandlerRecurrenceFirstDue(t *testing.T) {
+	now := time.Now()
+	today := date(now.Year(), now.Month(), now.Day())
+	tomorrow := today.AddDate(0, 0, 1)
+	byday := []string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+	// Um dia do mês diferente de hoje: o dia 1 do próximo mês ou, no dia 1, o dia 2
+	other, otherDue := 1, date(today.Year(), today.Month()+1, 1)
+	if today.Day() == 1 {
+		other, otherDue = 2, tomorrow
+	}
+
+	tests := []struct {
+		name     string
+		rule     string
+		expected time.Time
+		stored   string
+	}{
+		{name: "weekly starts today", rule: "weekly", expected: today, stored: "FREQ=WEEKLY"},
+		{name: "byday matching today", rule: "FREQ=WEEKLY;BYDAY=" + byday[today.Weekday()], expected: today},
+		{name: "byday not matching today", rule: "FREQ=WEEKLY;BYDAY=" + byday[tomorrow.Weekday()], expected: tomorrow},
+		{name: "monthly anchored on today", rule: "monthly", expected: today, stored: fmt.Sprintf("FREQ=MONTHLY;BYMONTHDAY=%d", today.Day())},
+		{
+			name:     "bymonthday other day",
+			rule:     fmt.Sprintf("FREQ=MONTHLY;BYMONTHDAY=%d", other),
+			expected: otherDue,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, taskRepo := createTestTaskHandler()
+			if err := h.CreateTask(1, 0, "Rotina", "", "", nil, 0, tt.rule); err != nil {
+				t.Fatal(err)
+			}
+			created, _ := taskRepo.GetByID(1)
+			if created.DueDate == nil || !created.DueDate.Equal(tt.expected) {
+				t.Fatalf("expected first due %s, got %v", tt.expected.Format("2006-01-02"), created.DueDate)
+			}
+			if tt.stored != "" && created.Recurrence != tt.stored {
+				t.Errorf("expected rule %s, got %s", tt.stored, created.Recurrence)
+			}
+		})
+	}
+}
+
+func TestTaskHandlerNextOccurrence(t *testing.T) {
+	// Prazo no futuro para que a próxima ocorrência não seja empurrada até hoje
+	year := time.Now().Year() + 1
+	due := date(year, 1, 31)
+
+	t.Run("toggle keeps monthly anchor", func(t *testing.T) {
+		h, taskRepo := createTestTaskHandler()
+		if err := h.CreateTask(1, 0, "Fechamento", "", "high", &due, 0, "monthly"); err != nil {
+			t.Fatal(err)
+		}
+
+		expected := []time.Time{date(year, 2, 28), date(year, 3, 31)}
+		id := 1
+		for _, next := range expected {
+			if err := h.ToggleTaskComplete(id, true); err != nil {
+				t.Fatal(err)
+			}
+			id++
+			created, err := taskRepo.GetByID(id)
+			if err != nil {
+				t.Fatalf("expected occurrence #%d to be created: %v", id, err)
+			}
+			if created.Status != "pending" || created.Priority != "high" || created.DueDate == nil || !created.DueDate.Equal(next) {
+				t.Fatalf("expected pending occurrence due %s, got %+v", next.Format("2006-01-02"), created)
+			}
+			if created.Recurrence != "FREQ=MONTHLY;BYMONTHDAY=31" {
+				t.Errorf("expected rule to be carried over, got %s", created.Recurrence)
+			}
+		}
+	})
+
+	t.Run("board move to completed", func(t *testing.T) {
+		h, taskRepo := createTestTaskHandler()
+		weekly := date(year, 3, 2)
+		if err := h.CreateTask(1, 0, "Revisar índices", "", "", &weekly, 0, "weekly"); err != nil {
+			t.Fatal(err)
+		}
+		// pending → in_progress → completed
+		for i := 0; i < 2; i++ {
+			if err := h.MoveCard(1, 1, 1); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if moved, _ := taskRepo.GetByID(1); moved.Status != "completed" {
+			t.Fatalf("expected card in completed column, got %s", moved.Status)
+		}
+		next, err := taskRepo.GetByID(2)
+		if err != nil {
+			t.Fatalf("expected next occurrence to be created: %v", err)
+		}
+		if next.Status != "pending" || next.DueDate == nil || !next.DueDate.Equal(date(year, 3, 9)) {
+			t.Errorf("expected pending occurrence due %d-03-09, got %+v", year, next)
+		}
+		if err := h.MoveCard(1, 1, 1); err == nil {
+			t.Error("expected error moving past the last column")
+		}
+	})
+}
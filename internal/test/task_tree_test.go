@@ -0,0 +1,113 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/snip/internal/database"
+	"github.com/snip/internal/repository"
+	"github.com/snip/internal/task"
+)
+
+func newSubtask(id, parentID int, status string) *task.Task {
+	return &task.Task{ID: id, ProjectID: 1, ParentTaskID: parentID, Title: "t", Status: status}
+}
+
+func TestTaskTreeProgress(t *testing.T) {
+	tasks := []*task.Task{
+		newSubtask(5, 0, "pending"),
+		newSubtask(1, 0, "pending"),
+		newSubtask(2, 1, "completed"),
+		newSubtask(3, 1, "pending"),
+		newSubtask(4, 3, "completed"),
+		newSubtask(6, 3, "in_progress"),
+		// Pai em outro projeto: vira raiz
+		newSubtask(7, 99, "completed"),
+	}
+
+	roots := task.BuildTree(tasks)
+	if len(roots) != 3 || roots[0].Task.ID != 1 || roots[1].Task.ID != 5 || roots[2].Task.ID != 7 {
+		t.Fatalf("unexpected roots: %+v", roots)
+	}
+
+	tests := []struct {
+		name  string
+		id    int
+		done  int
+		total int
+	}{
+		{name: "leaf", id: 2, done: 1, total: 1},
+		{name: "parent of leaves", id: 3, done: 1, total: 2},
+		{name: "rolls up nested children", id: 1, done: 2, total: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node := task.Find(roots, tt.id)
+			if node == nil {
+				t.Fatalf("task %d not found", tt.id)
+			}
+			if done, total := node.Progress(); done != tt.done || total != tt.total {
+				t.Errorf("expected %d/%d, got %d/%d", tt.done, tt.total, done, total)
+			}
+		})
+	}
+
+	if done, total := task.TreeProgress(roots); done != 3 || total != 5 {
+		t.Errorf("expected project progress 3/5, got %d/%d", done, total)
+	}
+	if open := task.OpenDescendants(tasks, 1); len(open) != 2 {
+		t.Errorf("expected 2 open descendants, got %d", len(open))
+	}
+}
+
+func TestTaskRepositoryMoveSubtree(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	db, err := database.Connect()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	taskRepo, _ := repository.NewTaskRepository(db)
+
+	prep := task.NewTask(1, "Preparação", "", "high")
+	if err := taskRepo.Create(prep); err != nil {
+		t.Fatal(err)
+	}
+	check := task.NewTask(1, "Checagem", "", "medium")
+	check.ParentTaskID = prep.ID
+	if err := taskRepo.Create(check); err != nil {
+		t.Fatal(err)
+	}
+	space := task.NewTask(1, "Espaço", "", "low")
+	space.ParentTaskID = check.ID
+	if err := taskRepo.Create(space); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, _ := taskRepo.GetByID(space.ID); got.ParentTaskID != check.ID {
+		t.Errorf("expected parent %d, got %d", check.ID, got.ParentTaskID)
+	}
+
+	if err := taskRepo.Move(check.ID, 0, 2); err != nil {
+		t.Fatal(err)
+	}
+	moved, _ := taskRepo.GetByProjectID(2, "")
+	if len(moved) != 2 {
+		t.Fatalf("expected subtree with 2 tasks in project 2, got %d", len(moved))
+	}
+	if got, _ := taskRepo.GetByID(check.ID); got.ParentTaskID != 0 {
+		t.Errorf("expected moved task to become a root, got parent %d", got.ParentTaskID)
+	}
+
+	// Ao apagar o pai as subtarefas sobem um nível
+	if err := taskRepo.Move(check.ID, prep.ID, 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := taskRepo.Delete(check.ID); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := taskRepo.GetByID(space.ID); got.ParentTaskID != prep.ID || got.ProjectID != 1 {
+		t.Errorf("expected subtask promoted to #%d in project 1, got parent %d project %d", prep.ID, got.ParentTaskID, got.ProjectID)
+	}
+}
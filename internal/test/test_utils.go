@@ -8,7 +8,10 @@ import (
 
 	"github.com/snip/internal/handler"
 	"github.com/snip/internal/note"
+	"github.com/snip/internal/project"
+	"github.com/snip/internal/repository"
 	"github.com/snip/internal/tag"
+	"github.com/snip/internal/task"
 )
 
 type mockNoteRepository struct {
@@ -154,6 +157,20 @@ func (m *mockNoteRepository) ExportNotes(exportDir string, since *time.Time, for
 	return nil
 }
 
+func (m *mockNoteRepository) UpdateSummary(id int, summary string) error {
+	if m.err != nil {
+		return m.err
+	}
+
+	for _, note := range m.notesWithTags {
+		if note.ID == id {
+			note.Summary = summary
+			return nil
+		}
+	}
+	return ErrNoteNotFound
+}
+
 func (m *mockNoteRepository) AddTagToNote(noteID, tagID int) error {
 	return nil
 }
@@ -228,11 +245,218 @@ func (m *mockTagRepository) Close() error {
 	return nil
 }
 
+// mockNoteLinkRepository não tem vínculos: os testes de notas rodam sem contexto
+type mockNoteLinkRepository struct{}
+
+func (m *mockNoteLinkRepository) CheckTarget(kind string, targetID int) error {
+	return nil
+}
+
+func (m *mockNoteLinkRepository) Link(noteID int, kind string, targetID int) error {
+	return nil
+}
+
+func (m *mockNoteLinkRepository) Unlink(noteID int, kind string, targetID int) error {
+	return nil
+}
+
+func (m *mockNoteLinkRepository) GetLinks(noteID int) ([]*note.Link, error) {
+	return nil, nil
+}
+
+func (m *mockNoteLinkRepository) GetNotesByProject(projectID int) ([]*note.LinkedNote, error) {
+	return nil, nil
+}
+
+func (m *mockNoteLinkRepository) GetNotesByTask(taskID int) ([]*note.LinkedNote, error) {
+	return nil, nil
+}
+
+func (m *mockNoteLinkRepository) Close() error {
+	return nil
+}
+
+// mockTaskRepository guarda as tarefas em memória; GetByID devolve cópias,
+// como o banco, para que o handler só altere o que grava
+type mockTaskRepository struct {
+	tasks  []*task.Task
+	deps   map[int][]int
+	nextID int
+}
+
+func (m *mockTaskRepository) find(id int) *task.Task {
+	for _, t := range m.tasks {
+		if t.ID == id {
+			return t
+		}
+	}
+	return nil
+}
+
+func (m *mockTaskRepository) Create(t *task.Task) error {
+	// IDs crescem sempre, como o AUTOINCREMENT, mesmo depois de Delete
+	m.nextID++
+	t.ID = m.nextID
+	stored := *t
+	m.tasks = append(m.tasks, &stored)
+	return nil
+}
+
+func (m *mockTaskRepository) GetByID(id int) (*task.Task, error) {
+	t := m.find(id)
+	if t == nil {
+		return nil, repository.ErrTaskNotFound
+	}
+	copied := *t
+	return &copied, nil
+}
+
+func (m *mockTaskRepository) GetByProjectID(projectID int, status string) ([]*task.Task, error) {
+	var tasks []*task.Task
+	for _, t := range m.tasks {
+		if t.ProjectID == projectID && (status == "" || t.Status == status) {
+			copied := *t
+			tasks = append(tasks, &copied)
+		}
+	}
+	return tasks, nil
+}
+
+func (m *mockTaskRepository) GetAll(status string) ([]*task.Task, error) {
+	var tasks []*task.Task
+	for _, t := range m.tasks {
+		if status == "" || t.Status == status {
+			copied := *t
+			tasks = append(tasks, &copied)
+		}
+	}
+	return tasks, nil
+}
+
+func (m *mockTaskRepository) Update(id int, title, description, status, priority string, dueDate *time.Time) error {
+	t := m.find(id)
+	if t == nil {
+		return repository.ErrTaskNotFound
+	}
+	t.Title, t.Description, t.Status, t.Priority, t.DueDate = title, description, status, priority, dueDate
+	return nil
+}
+
+func (m *mockTaskRepository) Delete(id int) error {
+	for i, t := range m.tasks {
+		if t.ID == id {
+			m.tasks = append(m.tasks[:i], m.tasks[i+1:]...)
+			return nil
+		}
+	}
+	return repository.ErrTaskNotFound
+}
+
+func (m *mockTaskRepository) ToggleComplete(id int) error {
+	t := m.find(id)
+	if t == nil {
+		return repository.ErrTaskNotFound
+	}
+	if t.Status == "completed" {
+		t.Status = "pending"
+	} else {
+		t.Status = "completed"
+	}
+	return nil
+}
+
+func (m *mockTaskRepository) SetEstimate(id int, estimate time.Duration) error {
+	if t := m.find(id); t != nil {
+		t.EstimatedTime = estimate
+	}
+	return nil
+}
+
+func (m *mockTaskRepository) SetRecurrence(id int, rule string) error {
+	if t := m.find(id); t != nil {
+		t.Recurrence = rule
+	}
+	return nil
+}
+
+func (m *mockTaskRepository) Move(id int, parentID int, projectID int) error {
+	if t := m.find(id); t != nil {
+		t.ParentTaskID, t.ProjectID = parentID, projectID
+	}
+	return nil
+}
+
+func (m *mockTaskRepository) AddDependency(taskID, dependsOnID int) error {
+	if m.deps == nil {
+		m.deps = make(map[int][]int)
+	}
+	m.deps[taskID] = append(m.deps[taskID], dependsOnID)
+	return nil
+}
+
+func (m *mockTaskRepository) RemoveDependency(taskID, dependsOnID int) error {
+	return nil
+}
+
+func (m *mockTaskRepository) GetDependencies() (map[int][]int, error) {
+	return m.deps, nil
+}
+
+func (m *mockTaskRepository) Close() error {
+	return nil
+}
+
+type mockProjectRepository struct {
+	projects []*project.Project
+	nextID   int
+}
+
+func (m *mockProjectRepository) Create(p *project.Project) error {
+	m.nextID++
+	p.ID = m.nextID
+	m.projects = append(m.projects, p)
+	return nil
+}
+
+func (m *mockProjectRepository) GetByID(id int) (*project.Project, error) {
+	for _, p := range m.projects {
+		if p.ID == id {
+			return p, nil
+		}
+	}
+	return nil, fmt.Errorf("project not found")
+}
+
+func (m *mockProjectRepository) GetAll(status string) ([]*project.Project, error) {
+	return m.projects, nil
+}
+
+func (m *mockProjectRepository) Update(id int, name, description, status string) error {
+	return nil
+}
+
+func (m *mockProjectRepository) Delete(id int) error {
+	return nil
+}
+
+func (m *mockProjectRepository) Close() error {
+	return nil
+}
+
+func createTestTaskHandler() (handler.TaskHandler, *mockTaskRepository) {
+	taskRepo := &mockTaskRepository{}
+	projectRepo := &mockProjectRepository{}
+	projectRepo.Create(project.NewProject("Rotinas", ""))
+
+	h := handler.NewTaskHandler(taskRepo, projectRepo, &mockNoteLinkRepository{})
+	return h, taskRepo
+}
+
 func createTestHandler() (handler.Handler, *mockNoteRepository, *mockTagRepository) {
 	mockNoteRepo := &mockNoteRepository{}
 	mockTagRepo := &mockTagRepository{}
 
-	h := handler.NewHandler(mockNoteRepo, mockTagRepo)
+	h := handler.NewHandler(mockNoteRepo, mockTagRepo, &mockNoteLinkRepository{})
 	return h, mockNoteRepo, mockTagRepo
 }
 
@@ -0,0 +1,137 @@
+package test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/snip/internal/database"
+	"github.com/snip/internal/repository"
+	"github.com/snip/internal/task"
+)
+
+func TestParseSince(t *testing.T) {
+	now := time.Date(2026, 3, 10, 15, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		value       string
+		expected    time.Time
+		expectError bool
+	}{
+		{value: "7d", expected: time.Date(2026, 3, 3, 0, 0, 0, 0, time.UTC)},
+		{value: "2w", expected: time.Date(2026, 2, 24, 0, 0, 0, 0, time.UTC)},
+		{value: "12h", expected: time.Date(2026, 3, 10, 3, 30, 0, 0, time.UTC)},
+		{value: "2026-01-01", expected: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{value: "", expectError: true},
+		{value: "7x", expectError: true},
+		{value: "ontem", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			since, err := task.ParseSince(tt.value, now)
+			if tt.expectError {
+				if err == nil {
+					t.Error("expected error, got nil")
+				}
+				return
+			}
+			if err != nil || !since.Equal(tt.expected) {
+				t.Errorf("expected %v, got %v (%v)", tt.expected, since, err)
+			}
+		})
+	}
+}
+
+func TestGroupTime(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	ended := now.Add(-time.Hour)
+	entries := []*task.TimeEntry{
+		{TaskID: 1, EndedAt: &ended, Duration: 90 * time.Minute, Tags: []string{"acme", "billable"}},
+		{TaskID: 2, EndedAt: &ended, Duration: 30 * time.Minute, Tags: []string{"acme"}},
+		// Cronômetro ativo conta até now
+		{TaskID: 2, StartedAt: now.Add(-2 * time.Hour)},
+	}
+
+	byTask := task.GroupTime(entries, now, func(e *task.TimeEntry) []string {
+		if e.TaskID == 1 {
+			return []string{"one"}
+		}
+		return []string{"two"}
+	})
+	if len(byTask) != 2 || byTask[0].Key != "two" || byTask[0].Duration != 150*time.Minute || byTask[0].Entries != 2 {
+		t.Errorf("unexpected totals by task: %+v", byTask)
+	}
+
+	byTag := task.GroupTime(entries, now, func(e *task.TimeEntry) []string { return e.Tags })
+	if len(byTag) != 2 || byTag[0].Key != "acme" || byTag[0].Duration != 2*time.Hour || byTag[1].Duration != 90*time.Minute {
+		t.Errorf("unexpected totals by tag: %+v", byTag)
+	}
+}
+
+func TestTimeEntryRepository(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	db, err := database.Connect()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	taskRepo, _ := repository.NewTaskRepository(db)
+	timeRepo, _ := repository.NewTimeEntryRepository(db)
+	tagRepo, _ := repository.NewTagRepository(db)
+
+	upgrade := task.NewTask(1, "Upgrade", "", "high")
+	if err := taskRepo.Create(upgrade); err != nil {
+		t.Fatal(err)
+	}
+	acme, err := tagRepo.GetOrCreate("acme")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Now().Add(-time.Hour)
+	timer := &task.TimeEntry{TaskID: upgrade.ID, StartedAt: start, Note: "homologação"}
+	if err := timeRepo.Create(timer, []int{acme.ID}); err != nil {
+		t.Fatal(err)
+	}
+	if err := timeRepo.Create(&task.TimeEntry{TaskID: upgrade.ID, StartedAt: time.Now()}, nil); !errors.Is(err, repository.ErrTimerRunning) {
+		t.Errorf("expected ErrTimerRunning, got %v", err)
+	}
+
+	active, err := timeRepo.GetActive()
+	if err != nil || active.ID != timer.ID || active.ProjectID != 1 || len(active.Tags) != 1 || active.Tags[0] != "acme" {
+		t.Fatalf("unexpected active timer: %+v (%v)", active, err)
+	}
+
+	stopped, err := timeRepo.Stop(timer.ID, start.Add(45*time.Minute), "homologação; ok")
+	if err != nil || stopped.Duration != 45*time.Minute {
+		t.Fatalf("unexpected stopped entry: %+v (%v)", stopped, err)
+	}
+	if _, err := timeRepo.GetActive(); !errors.Is(err, repository.ErrNoActiveTimer) {
+		t.Errorf("expected ErrNoActiveTimer, got %v", err)
+	}
+
+	old := time.Now().AddDate(0, 0, -30)
+	oldEnd := old.Add(2 * time.Hour)
+	if err := timeRepo.Create(&task.TimeEntry{TaskID: upgrade.ID, StartedAt: old, EndedAt: &oldEnd, Duration: 2 * time.Hour}, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	recent, err := timeRepo.GetSince(time.Now().AddDate(0, 0, -7))
+	if err != nil || len(recent) != 1 || recent[0].Note != "homologação; ok" {
+		t.Errorf("expected only the recent entry, got %+v (%v)", recent, err)
+	}
+	totals, err := timeRepo.TotalsByTask()
+	if err != nil || totals[upgrade.ID] != 2*time.Hour+45*time.Minute {
+		t.Errorf("expected 2h45m for the task, got %v (%v)", totals, err)
+	}
+
+	if err := taskRepo.Delete(upgrade.ID); err != nil {
+		t.Fatal(err)
+	}
+	if totals, _ := timeRepo.TotalsByTask(); len(totals) != 0 {
+		t.Errorf("expected entries of deleted task to be removed, got %v", totals)
+	}
+}